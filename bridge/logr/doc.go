@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logr provides a bridge from [github.com/go-logr/logr] to the
+// OpenTelemetry Logs API.
+//
+// The [LogSink] implements [logr.LogSink] and emits the log lines it is
+// passed to a [go.opentelemetry.io/otel/log.Logger]. Use [NewLogger] to
+// create a [logr.Logger] writing to it.
+//
+// Log lines are translated as follows:
+//
+//   - The message is used as the body.
+//   - The verbosity level of info lines is mapped to the severity, V(0)
+//     being mapped to [log.SeverityInfo] and every higher level to the
+//     severity below, down to [log.SeverityTrace1]. Error lines have the
+//     [log.SeverityError] severity.
+//   - The error of error lines is translated to the "exception.message" and
+//     "exception.type" attributes.
+//   - The key/value pairs, including the ones added with
+//     [logr.Logger.WithValues], are translated to log attributes.
+//   - The names added with [logr.Logger.WithName] are appended to the name
+//     of the Logger, separated by a "/", and are therefore part of the
+//     instrumentation scope of the log records.
+//
+// A [context.Context] passed as the value of a key/value pair is not
+// translated to an attribute, it is passed along with the log record
+// instead. The trace context it holds is therefore the one of the record.
+package logr // import "go.opentelemetry.io/otel/bridge/logr"
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logr_test
+
+import (
+	"context"
+	"errors"
+
+	otellogr "go.opentelemetry.io/otel/bridge/logr"
+	"go.opentelemetry.io/otel/log/noop"
+)
+
+func Example() {
+	// Use a working LoggerProvider implementation instead e.g. using
+	// go.opentelemetry.io/otel/sdk/log.
+	provider := noop.NewLoggerProvider()
+
+	logger := otellogr.NewLogger("my/pkg/name", otellogr.WithLoggerProvider(provider))
+
+	// The trace context of ctx is the one of the emitted log lines.
+	ctx := context.Background()
+	logger = logger.WithName("server").WithValues("ctx", ctx, "user", "alice")
+	logger.V(1).Info("handled", "status", 200)
+	logger.Error(errors.New("connection reset"), "write failed")
+}
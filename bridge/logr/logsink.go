@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logr // import "go.opentelemetry.io/otel/bridge/logr"
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+
+	"go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+// LogSink is a [logr.LogSink] emitting the log lines it is passed to a
+// [log.Logger].
+type LogSink struct {
+	cfg    config
+	name   string
+	logger log.Logger
+
+	// values are the attributes added with WithValues.
+	values []log.KeyValue
+	// ctx is the context added with WithValues, if any.
+	ctx context.Context
+}
+
+var _ logr.LogSink = (*LogSink)(nil)
+
+// NewLogSink returns a LogSink emitting the log lines it is passed to the
+// Logger named name.
+//
+// If [WithLoggerProvider] is not passed, the Logger is the one of the global
+// LoggerProvider.
+func NewLogSink(name string, options ...Option) *LogSink {
+	cfg := newConfig(options)
+	return &LogSink{
+		cfg:    cfg,
+		name:   name,
+		logger: cfg.logger(name),
+	}
+}
+
+// NewLogger returns a [logr.Logger] using a LogSink created with name and
+// options.
+func NewLogger(name string, options ...Option) logr.Logger {
+	return logr.New(NewLogSink(name, options...))
+}
+
+// Init does nothing, the LogSink does not use the runtime information.
+func (*LogSink) Init(logr.RuntimeInfo) {}
+
+// Enabled returns if the Logger emits info lines of the verbosity level.
+func (l *LogSink) Enabled(level int) bool {
+	var param log.EnabledParameters
+	param.SetSeverity(severity(level))
+	return l.logger.Enabled(l.context(), param)
+}
+
+// Info emits the info line of the verbosity level with the message msg and
+// the key/value pairs keysAndValues.
+func (l *LogSink) Info(level int, msg string, keysAndValues ...any) {
+	l.emit(severity(level), msg, nil, keysAndValues)
+}
+
+// Error emits the error line of the error err with the message msg and the
+// key/value pairs keysAndValues.
+func (l *LogSink) Error(err error, msg string, keysAndValues ...any) {
+	var kvs []log.KeyValue
+	if err != nil {
+		kvs = []log.KeyValue{
+			log.String(string(semconv.ExceptionMessageKey), err.Error()),
+			log.String(string(semconv.ExceptionTypeKey), typeStr(err)),
+		}
+	}
+	l.emit(log.SeverityError, msg, kvs, keysAndValues)
+}
+
+// emit emits the log line of the severity s with the message msg, the
+// attributes kvs and the key/value pairs keysAndValues.
+func (l *LogSink) emit(s log.Severity, msg string, kvs []log.KeyValue, keysAndValues []any) {
+	ctx, attrs := convertKeysAndValues(keysAndValues)
+	if ctx == nil {
+		ctx = l.context()
+	}
+
+	var record log.Record
+	record.SetBody(log.StringValue(msg))
+	record.SetSeverity(s)
+	record.AddAttributes(l.values...)
+	record.AddAttributes(kvs...)
+	record.AddAttributes(attrs...)
+	l.logger.Emit(ctx, record)
+}
+
+// context returns the context the log lines are emitted with by default.
+func (l *LogSink) context() context.Context {
+	if l.ctx != nil {
+		return l.ctx
+	}
+	return context.Background()
+}
+
+// WithName returns a copy of l emitting to the Logger named after the name
+// of l and name, separated by a "/".
+func (l *LogSink) WithName(name string) logr.LogSink {
+	c := *l
+	if c.name != "" {
+		c.name += "/"
+	}
+	c.name += name
+	c.logger = c.cfg.logger(c.name)
+	return &c
+}
+
+// WithValues returns a copy of l adding the key/value pairs keysAndValues to
+// the attributes of the log lines.
+func (l *LogSink) WithValues(keysAndValues ...any) logr.LogSink {
+	ctx, attrs := convertKeysAndValues(keysAndValues)
+
+	c := *l
+	if ctx != nil {
+		c.ctx = ctx
+	}
+	c.values = append(slices.Clip(c.values), attrs...)
+	return &c
+}
+
+// severity returns the severity of the verbosity level, clamped to the
+// severity range.
+func severity(level int) log.Severity {
+	s := int(log.SeverityInfo) - level
+	switch {
+	case s < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case s > int(log.SeverityInfo):
+		// logr does not pass negative levels, but LogSink may be used
+		// directly.
+		return log.SeverityInfo
+	}
+	return log.Severity(s) // nolint:gosec // Clamped to the severity range.
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logr
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// emitted returns the records emitted to the recorder rec.
+func emitted(rec *logtest.Recorder) []logtest.EmittedRecord {
+	var out []logtest.EmittedRecord
+	for _, s := range rec.Result() {
+		out = append(out, s.Records...)
+	}
+	return out
+}
+
+// attributes returns the attributes of the record r.
+func attributes(r log.Record) []log.KeyValue {
+	var kvs []log.KeyValue
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		kvs = append(kvs, kv)
+		return true
+	})
+	return kvs
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+type marshaler struct{}
+
+func (marshaler) MarshalLog() any { return map[string]int{"a": 1} }
+
+type ptrMarshaler struct{ v any }
+
+func (m *ptrMarshaler) MarshalLog() any { return m.v }
+
+type panicMarshaler struct{}
+
+func (panicMarshaler) MarshalLog() any { panic("boom") }
+
+func TestLogSinkInfo(t *testing.T) {
+	rec := logtest.NewRecorder()
+	NewLogger("test", WithLoggerProvider(rec)).V(1).Info("msg", "k", "v", 1, 2, "odd")
+
+	records := emitted(rec)
+	require.Len(t, records, 1)
+	want := logtest.RecordFactory{
+		Severity: log.SeverityDebug4,
+		Body:     log.StringValue("msg"),
+		Attributes: []log.KeyValue{
+			log.String("k", "v"),
+			log.Int("1", 2),
+			{Key: "odd"},
+		},
+	}.NewRecord()
+	logtest.AssertRecordEqual(t, want, records[0].Record)
+}
+
+func TestLogSinkError(t *testing.T) {
+	rec := logtest.NewRecorder()
+	l := NewLogger("test", WithLoggerProvider(rec))
+	l.Error(errors.New("failed"), "msg", "k", "v")
+	l.Error(nil, "msg")
+
+	records := emitted(rec)
+	require.Len(t, records, 2)
+	want := logtest.RecordFactory{
+		Severity: log.SeverityError,
+		Body:     log.StringValue("msg"),
+		Attributes: []log.KeyValue{
+			log.String("exception.message", "failed"),
+			log.String("exception.type", "*errors.errorString"),
+			log.String("k", "v"),
+		},
+	}.NewRecord()
+	logtest.AssertRecordEqual(t, want, records[0].Record)
+	assert.Empty(t, attributes(records[1].Record), "nil error translated")
+}
+
+func TestValue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		v    any
+		want log.Value
+	}{
+		{"Nil", nil, log.Value{}},
+		{"Bool", true, log.BoolValue(true)},
+		{"String", "v", log.StringValue("v")},
+		{"Bytes", []byte("v"), log.BytesValue([]byte("v"))},
+		{"Int", -1, log.IntValue(-1)},
+		{"Int8", int8(-1), log.Int64Value(-1)},
+		{"Int32", int32(-1), log.Int64Value(-1)},
+		{"Uint8", uint8(1), log.Int64Value(1)},
+		{"Uint64", uint64(1), log.Int64Value(1)},
+		{"Uint64Overflow", uint64(1 << 63), log.StringValue("9223372036854775808")},
+		{"Float32", float32(1.5), log.Float64Value(1.5)},
+		{"Float64", 1.5, log.Float64Value(1.5)},
+		{"Duration", time.Second, log.Int64Value(1e9)},
+		{"Time", now, log.Int64Value(now.UnixNano())},
+		{"Error", errors.New("v"), log.StringValue("v")},
+		{"Stringer", stringer{}, log.StringValue("stringer")},
+		{"Marshaler", marshaler{}, log.MapValue(log.Int("a", 1))},
+		{"NilError", (*os.PathError)(nil), log.StringValue("<nil>")},
+		{"NilStringer", (*url.URL)(nil), log.StringValue("<nil>")},
+		{"NilMarshaler", (*ptrMarshaler)(nil), log.StringValue("<nil>")},
+		{"PanicMarshaler", panicMarshaler{}, log.StringValue("<panic: boom>")},
+		{"Struct", struct{ A int }{1}, log.StringValue("{A:1}")},
+		{"Slice", []any{1, "a"}, log.SliceValue(log.IntValue(1), log.StringValue("a"))},
+		{"Array", [1]bool{true}, log.SliceValue(log.BoolValue(true))},
+		{"Map", map[int]bool{1: true}, log.MapValue(log.Bool("1", true))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, value(tt.v))
+		})
+	}
+}
+
+func TestLogSinkWithValues(t *testing.T) {
+	rec := logtest.NewRecorder()
+	l := NewLogger("test", WithLoggerProvider(rec)).WithValues("a", 1)
+
+	l.Info("msg", "b", 2)
+	// The loggers derived from l must not modify it.
+	l.WithValues("c", 3).Info("msg")
+	l.Info("msg")
+
+	records := emitted(rec)
+	require.Len(t, records, 3)
+	assert.Equal(t, []log.KeyValue{log.Int("a", 1), log.Int("b", 2)}, attributes(records[0].Record))
+	assert.Equal(t, []log.KeyValue{log.Int("a", 1), log.Int("c", 3)}, attributes(records[1].Record))
+	assert.Equal(t, []log.KeyValue{log.Int("a", 1)}, attributes(records[2].Record))
+}
+
+func TestLogSinkContext(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	rec := logtest.NewRecorder()
+	l := NewLogger("test", WithLoggerProvider(rec))
+	l.Info("msg", "ctx", ctx, "k", "v")
+	l.WithValues("ctx", ctx).Error(nil, "msg")
+	l.Info("msg")
+
+	records := emitted(rec)
+	require.Len(t, records, 3)
+	assert.Equal(t, sc, trace.SpanContextFromContext(records[0].Context()))
+	assert.Equal(t, []log.KeyValue{log.String("k", "v")}, attributes(records[0].Record), "context translated")
+	assert.Equal(t, sc, trace.SpanContextFromContext(records[1].Context()))
+	assert.False(t, trace.SpanContextFromContext(records[2].Context()).IsValid())
+}
+
+func TestLogSinkWithName(t *testing.T) {
+	rec := logtest.NewRecorder()
+	l := NewLogger("test", WithLoggerProvider(rec), WithVersion("v1"))
+	l.WithName("a").WithName("b").Info("msg")
+	NewLogger("", WithLoggerProvider(rec)).WithName("c").Info("msg")
+
+	var names []string
+	for _, s := range rec.Result() {
+		if len(s.Records) > 0 {
+			names = append(names, s.Name)
+			if s.Name == "test/a/b" {
+				assert.Equal(t, "v1", s.Version, "options not kept")
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"test/a/b", "c"}, names)
+}
+
+func TestLogSinkEnabled(t *testing.T) {
+	rec := logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, p log.EnabledParameters) bool {
+		s, _ := p.Severity()
+		return s >= log.SeverityDebug
+	}))
+	l := NewLogger("test", WithLoggerProvider(rec))
+
+	assert.True(t, l.Enabled())
+	assert.True(t, l.V(4).Enabled())
+	assert.False(t, l.V(5).Enabled())
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		level int
+		want  log.Severity
+	}{
+		{-1, log.SeverityInfo},
+		{0, log.SeverityInfo},
+		{1, log.SeverityDebug4},
+		{4, log.SeverityDebug},
+		{8, log.SeverityTrace1},
+		{100, log.SeverityTrace1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, severity(tt.level), tt.level)
+	}
+}
+
+func TestNewLogSinkConfig(t *testing.T) {
+	rec := logtest.NewRecorder()
+	NewLogger("test",
+		WithLoggerProvider(rec),
+		WithVersion("v1"),
+		WithSchemaURL("https://opentelemetry.io/schemas/1.26.0"),
+		WithAttributes(attribute.String("a", "b")),
+	).Info("msg")
+
+	result := rec.Result()
+	require.Len(t, result, 1)
+	assert.Equal(t, "test", result[0].Name)
+	assert.Equal(t, "v1", result[0].Version)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", result[0].SchemaURL)
+	assert.Equal(t, attribute.NewSet(attribute.String("a", "b")), result[0].Attributes)
+}
+
+var _ logr.Marshaler = marshaler{}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logr // import "go.opentelemetry.io/otel/bridge/logr"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// convertKeysAndValues returns the log attributes of the key/value pairs
+// keysAndValues, and the last context passed as a value, if any.
+//
+// Keys that are not strings are formatted. A key without a value has an
+// empty value.
+func convertKeysAndValues(keysAndValues []any) (context.Context, []log.KeyValue) {
+	var ctx context.Context
+	kvs := make([]log.KeyValue, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		if i+1 == len(keysAndValues) {
+			kvs = append(kvs, log.KeyValue{Key: key})
+			break
+		}
+
+		if c, ok := keysAndValues[i+1].(context.Context); ok {
+			ctx = c
+			continue
+		}
+		kvs = append(kvs, log.KeyValue{Key: key, Value: value(keysAndValues[i+1])})
+	}
+	return ctx, kvs
+}
+
+// value returns the log value of v.
+//
+// The values implementing [logr.Marshaler] are marshaled first. Durations are
+// returned as their number of nanoseconds and times as their Unix time in
+// nanoseconds. Unsigned integers overflowing an int64 are returned as
+// strings. Byte slices are returned as bytes, the other slices, arrays and
+// maps are returned as slices and maps of their converted values, and any
+// other value is returned as its string representation.
+func value(v any) log.Value {
+	if m, ok := v.(logr.Marshaler); ok {
+		v = invoke(m, m.MarshalLog)
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return log.Value{}
+	case bool:
+		return log.BoolValue(v)
+	case string:
+		return log.StringValue(v)
+	case []byte:
+		return log.BytesValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint:
+		return uint64Value(uint64(v))
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case uint64:
+		return uint64Value(v)
+	case uintptr:
+		return uint64Value(uint64(v))
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case time.Duration:
+		return log.Int64Value(v.Nanoseconds())
+	case time.Time:
+		return log.Int64Value(v.UnixNano())
+	case error:
+		return value(invoke(v, v.Error))
+	case fmt.Stringer:
+		return value(invoke(v, v.String))
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		vals := make([]log.Value, 0, rv.Len())
+		for i := range rv.Len() {
+			vals = append(vals, value(rv.Index(i).Interface()))
+		}
+		return log.SliceValue(vals...)
+	case reflect.Map:
+		kvs := make([]log.KeyValue, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			kvs = append(kvs, log.KeyValue{
+				Key:   fmt.Sprint(iter.Key().Interface()),
+				Value: value(iter.Value().Interface()),
+			})
+		}
+		return log.MapValue(kvs...)
+	}
+	return log.StringValue(fmt.Sprintf("%+v", v))
+}
+
+// invoke returns the result of the method f of v. As funcr does, a panic of
+// f is recovered and described in the returned value, which is "<nil>" if v
+// is a nil pointer.
+func invoke[T any](v any, f func() T) (ret any) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+				ret = "<nil>"
+				return
+			}
+			ret = fmt.Sprintf("<panic: %v>", r)
+		}
+	}()
+	return f()
+}
+
+// uint64Value returns the log value of u, as a string if it overflows an
+// int64.
+func uint64Value(u uint64) log.Value {
+	if u > math.MaxInt64 {
+		return log.StringValue(strconv.FormatUint(u, 10))
+	}
+	return log.Int64Value(int64(u)) // nolint:gosec // Overflow checked above.
+}
+
+// typeStr returns the fully qualified name of the type of i.
+func typeStr(i any) string {
+	t := reflect.TypeOf(i)
+	if t.PkgPath() == "" && t.Name() == "" {
+		// Likely a builtin type.
+		return t.String()
+	}
+	return fmt.Sprintf("%s.%s", t.PkgPath(), t.Name())
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prometheus provides a bridge from Prometheus to OpenTelemetry.
+//
+// The Prometheus Bridge allows Prometheus metrics to be exported using the
+// OpenTelemetry SDK. It gathers metrics from a [prometheus.Gatherer] when the
+// OpenTelemetry SDK collects, translates them to the OpenTelemetry data
+// model, and combines them with metrics from OpenTelemetry instrumentation.
+// This allows applications instrumented with the Prometheus client library to
+// send their metrics through any OpenTelemetry exporter (e.g. OTLP) without
+// being scraped.
+//
+// Metrics are translated as follows:
+//
+//   - Counters are translated to monotonic cumulative Sums.
+//   - Gauges and untyped metrics are translated to Gauges.
+//   - Classic histograms are translated to cumulative Histograms.
+//   - Native histograms are translated to cumulative ExponentialHistograms.
+//   - Summaries are translated to Summaries.
+//
+// Gauge histograms are not supported and are not produced.
+//
+// Exemplars with "trace_id" and "span_id" labels are translated to
+// OpenTelemetry exemplars with the respective trace and span IDs.
+//
+// Use [NewMetricProducer] to create a Producer and register it with a reader
+// using [go.opentelemetry.io/otel/sdk/metric.WithProducer].
+package prometheus // import "go.opentelemetry.io/otel/bridge/prometheus"
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus_test
+
+import (
+	"go.opentelemetry.io/otel/bridge/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func ExampleNewMetricProducer() {
+	// Create the Prometheus Metric bridge. By default, it gathers from the
+	// prometheus DefaultGatherer. Use prometheus.WithGatherer to gather from
+	// another registry.
+	bridge := prometheus.NewMetricProducer()
+	// Add the bridge as a producer to your reader.
+	// If using a push exporter, such as OTLP exporter,
+	// use metric.NewPeriodicReader with metric.WithProducer option.
+	reader := metric.NewManualReader(metric.WithProducer(bridge))
+	// Add the reader to your MeterProvider.
+	_ = metric.NewMeterProvider(metric.WithReader(reader))
+}
@@ -0,0 +1,458 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/bridge/prometheus"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/otel/bridge/prometheus"
+
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+var (
+	errUnsupportedType        = errors.New("unsupported Prometheus metric type")
+	errInvalidHistogram       = errors.New("invalid Prometheus histogram")
+	errInvalidNativeHistogram = errors.New("invalid Prometheus native histogram")
+)
+
+// MetricProducer implements the [go.opentelemetry.io/otel/sdk/metric.Producer]
+// to provide metrics from Prometheus to the OpenTelemetry SDK.
+type MetricProducer struct {
+	gatherers prometheus.Gatherers
+	start     time.Time
+}
+
+// NewMetricProducer returns a metric.Producer that gathers metrics from
+// Prometheus.
+//
+// Prometheus counters, histograms, and summaries that do not report a
+// created timestamp use the time the MetricProducer was created as their
+// start time.
+func NewMetricProducer(opts ...Option) *MetricProducer {
+	cfg := newConfig(opts...)
+	return &MetricProducer{
+		gatherers: cfg.gatherers,
+		start:     time.Now(),
+	}
+}
+
+var _ metric.Producer = (*MetricProducer)(nil)
+
+// Produce gathers metrics from the Prometheus Gatherers, translates them to
+// OpenTelemetry's data model, and returns them.
+//
+// If a Gatherer or the translation of a metric fails, the metrics that could
+// be gathered and translated are returned along with the error.
+func (p *MetricProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	now := time.Now()
+	families, gatherErr := p.gatherers.Gather()
+	otelMetrics, err := convertMetrics(families, p.start, now)
+	err = errors.Join(gatherErr, err)
+	if len(otelMetrics) == 0 {
+		return nil, err
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope: instrumentation.Scope{
+			Name:    scopeName,
+			Version: Version(),
+		},
+		Metrics: otelMetrics,
+	}}, err
+}
+
+// convertMetrics converts metric families from Prometheus to OpenTelemetry.
+func convertMetrics(families []*dto.MetricFamily, start, now time.Time) ([]metricdata.Metrics, error) {
+	otelMetrics := make([]metricdata.Metrics, 0, len(families))
+	var err error
+	for _, mf := range families {
+		if mf == nil || len(mf.GetMetric()) == 0 {
+			continue
+		}
+		agg, aggregationErr := convertAggregation(mf, start, now)
+		if aggregationErr != nil {
+			err = errors.Join(err, fmt.Errorf("error converting metric %v: %w", mf.GetName(), aggregationErr))
+			continue
+		}
+		otelMetrics = append(otelMetrics, metricdata.Metrics{
+			Name:        mf.GetName(),
+			Description: mf.GetHelp(),
+			Unit:        mf.GetUnit(),
+			Data:        agg,
+		})
+	}
+	if err != nil {
+		return otelMetrics, fmt.Errorf("error converting from Prometheus to OpenTelemetry: %w", err)
+	}
+	return otelMetrics, nil
+}
+
+// convertAggregation produces an aggregation based on the Prometheus metric
+// family type.
+func convertAggregation(mf *dto.MetricFamily, start, now time.Time) (metricdata.Aggregation, error) {
+	switch mf.GetType() {
+	case dto.MetricType_COUNTER:
+		return convertCounter(mf.GetMetric(), start, now), nil
+	case dto.MetricType_GAUGE:
+		return convertGauge(mf.GetMetric(), now, (*dto.Metric).GetGauge), nil
+	case dto.MetricType_UNTYPED:
+		return convertGauge(mf.GetMetric(), now, (*dto.Metric).GetUntyped), nil
+	case dto.MetricType_HISTOGRAM:
+		if isNativeHistogram(mf.GetMetric()[0].GetHistogram()) {
+			return convertNativeHistogram(mf.GetMetric(), start, now)
+		}
+		return convertHistogram(mf.GetMetric(), start, now)
+	case dto.MetricType_SUMMARY:
+		return convertSummary(mf.GetMetric(), start, now), nil
+	}
+	return nil, fmt.Errorf("%w: %v", errUnsupportedType, mf.GetType())
+}
+
+// valuer is a Prometheus metric value with a float64 value.
+type valuer interface {
+	GetValue() float64
+}
+
+// convertGauge converts Prometheus gauge or untyped metrics to an
+// OpenTelemetry gauge aggregation.
+func convertGauge[V valuer](metrics []*dto.Metric, now time.Time, value func(*dto.Metric) V) metricdata.Gauge[float64] {
+	points := make([]metricdata.DataPoint[float64], 0, len(metrics))
+	for _, m := range metrics {
+		points = append(points, metricdata.DataPoint[float64]{
+			Attributes: convertLabels(m.GetLabel()),
+			Time:       timestamp(m, now),
+			Value:      value(m).GetValue(),
+		})
+	}
+	return metricdata.Gauge[float64]{DataPoints: points}
+}
+
+// convertCounter converts Prometheus counter metrics to an OpenTelemetry
+// monotonic cumulative sum aggregation.
+func convertCounter(metrics []*dto.Metric, start, now time.Time) metricdata.Sum[float64] {
+	points := make([]metricdata.DataPoint[float64], 0, len(metrics))
+	for _, m := range metrics {
+		c := m.GetCounter()
+		t := timestamp(m, now)
+		points = append(points, metricdata.DataPoint[float64]{
+			Attributes: convertLabels(m.GetLabel()),
+			StartTime:  startTime(c.GetCreatedTimestamp(), start, t),
+			Time:       t,
+			Value:      c.GetValue(),
+			Exemplars:  convertExemplars([]*dto.Exemplar{c.GetExemplar()}),
+		})
+	}
+	return metricdata.Sum[float64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints:  points,
+	}
+}
+
+// convertHistogram converts Prometheus classic histogram metrics to an
+// OpenTelemetry cumulative histogram aggregation.
+func convertHistogram(metrics []*dto.Metric, start, now time.Time) (metricdata.Histogram[float64], error) {
+	points := make([]metricdata.HistogramDataPoint[float64], 0, len(metrics))
+	var err error
+	for _, m := range metrics {
+		h := m.GetHistogram()
+		count := histogramCount(h)
+
+		buckets := h.GetBucket()
+		bounds := make([]float64, 0, len(buckets))
+		bucketCounts := make([]uint64, 0, len(buckets)+1)
+		exemplars := make([]*dto.Exemplar, 0, len(buckets))
+		var cumulative uint64
+		var bucketErr error
+		for _, b := range buckets {
+			if math.IsInf(b.GetUpperBound(), 1) {
+				// The +Inf bucket is always represented by the total count.
+				exemplars = append(exemplars, b.GetExemplar())
+				continue
+			}
+			c := b.GetCumulativeCount()
+			if b.CumulativeCountFloat != nil {
+				c = floatCount(b.GetCumulativeCountFloat())
+			}
+			if c < cumulative {
+				bucketErr = fmt.Errorf("%w: cumulative bucket count decreases", errInvalidHistogram)
+				break
+			}
+			bounds = append(bounds, b.GetUpperBound())
+			bucketCounts = append(bucketCounts, c-cumulative)
+			exemplars = append(exemplars, b.GetExemplar())
+			cumulative = c
+		}
+		if bucketErr == nil && count < cumulative {
+			bucketErr = fmt.Errorf("%w: bucket counts exceed total count", errInvalidHistogram)
+		}
+		if bucketErr != nil {
+			err = errors.Join(err, bucketErr)
+			continue
+		}
+		bucketCounts = append(bucketCounts, count-cumulative)
+
+		t := timestamp(m, now)
+		points = append(points, metricdata.HistogramDataPoint[float64]{
+			Attributes:   convertLabels(m.GetLabel()),
+			StartTime:    startTime(h.GetCreatedTimestamp(), start, t),
+			Time:         t,
+			Count:        count,
+			Sum:          h.GetSampleSum(),
+			Bounds:       bounds,
+			BucketCounts: bucketCounts,
+			Exemplars:    convertExemplars(exemplars),
+		})
+	}
+	return metricdata.Histogram[float64]{
+		Temporality: metricdata.CumulativeTemporality,
+		DataPoints:  points,
+	}, err
+}
+
+// isNativeHistogram returns if h contains a Prometheus native histogram.
+func isNativeHistogram(h *dto.Histogram) bool {
+	return h.GetZeroThreshold() > 0 ||
+		h.GetZeroCount() > 0 ||
+		h.GetZeroCountFloat() > 0 ||
+		len(h.GetPositiveSpan()) > 0 ||
+		len(h.GetNegativeSpan()) > 0
+}
+
+// convertNativeHistogram converts Prometheus native histogram metrics to an
+// OpenTelemetry cumulative exponential histogram aggregation.
+func convertNativeHistogram(metrics []*dto.Metric, start, now time.Time) (metricdata.ExponentialHistogram[float64], error) {
+	points := make([]metricdata.ExponentialHistogramDataPoint[float64], 0, len(metrics))
+	var err error
+	for _, m := range metrics {
+		h := m.GetHistogram()
+		// Prometheus and OpenTelemetry use the same definition for the
+		// schema and the scale, but Prometheus supports a smaller range.
+		if s := h.GetSchema(); s < -4 || s > 8 {
+			err = errors.Join(err, fmt.Errorf("%w: unsupported schema %d", errInvalidNativeHistogram, s))
+			continue
+		}
+		pos, posErr := convertNativeBuckets(h.GetPositiveSpan(), h.GetPositiveDelta(), h.GetPositiveCount())
+		neg, negErr := convertNativeBuckets(h.GetNegativeSpan(), h.GetNegativeDelta(), h.GetNegativeCount())
+		if posErr != nil || negErr != nil {
+			err = errors.Join(err, posErr, negErr)
+			continue
+		}
+
+		zeroCount := h.GetZeroCount()
+		if h.ZeroCountFloat != nil {
+			zeroCount = floatCount(h.GetZeroCountFloat())
+		}
+
+		t := timestamp(m, now)
+		points = append(points, metricdata.ExponentialHistogramDataPoint[float64]{
+			Attributes:     convertLabels(m.GetLabel()),
+			StartTime:      startTime(h.GetCreatedTimestamp(), start, t),
+			Time:           t,
+			Count:          histogramCount(h),
+			Sum:            h.GetSampleSum(),
+			Scale:          h.GetSchema(),
+			ZeroCount:      zeroCount,
+			PositiveBucket: pos,
+			NegativeBucket: neg,
+			ZeroThreshold:  h.GetZeroThreshold(),
+			Exemplars:      convertExemplars(h.GetExemplars()),
+		})
+	}
+	return metricdata.ExponentialHistogram[float64]{
+		Temporality: metricdata.CumulativeTemporality,
+		DataPoints:  points,
+	}, err
+}
+
+// convertNativeBuckets converts the sparse buckets of a Prometheus native
+// histogram to a dense OpenTelemetry exponential bucket.
+//
+// Prometheus defines the bucket with index i to contain values in
+// (base^(i-1), base^i] where OpenTelemetry uses (base^i, base^(i+1)]. The
+// OpenTelemetry offset is therefore one less than the Prometheus index of the
+// first bucket.
+func convertNativeBuckets(spans []*dto.BucketSpan, deltas []int64, counts []float64) (metricdata.ExponentialBucket, error) {
+	var n int
+	for _, s := range spans {
+		n += int(s.GetLength())
+	}
+	if n == 0 {
+		return metricdata.ExponentialBucket{}, nil
+	}
+	// Integer histograms encode counts as deltas from the previous bucket,
+	// float histograms encode absolute counts.
+	useFloat := len(deltas) == 0
+	if (useFloat && len(counts) != n) || (!useFloat && len(deltas) != n) {
+		return metricdata.ExponentialBucket{}, fmt.Errorf("%w: spans define %d buckets", errInvalidNativeHistogram, n)
+	}
+
+	var (
+		bucketCounts []uint64
+		i            int
+		current      int64
+	)
+	for j, s := range spans {
+		if j > 0 {
+			// Offsets of all but the first span are relative to the end of
+			// the previous span.
+			if s.GetOffset() < 0 {
+				return metricdata.ExponentialBucket{}, fmt.Errorf("%w: negative span offset", errInvalidNativeHistogram)
+			}
+			for k := int32(0); k < s.GetOffset(); k++ {
+				bucketCounts = append(bucketCounts, 0)
+			}
+		}
+		for k := uint32(0); k < s.GetLength(); k++ {
+			if useFloat {
+				bucketCounts = append(bucketCounts, floatCount(counts[i]))
+			} else {
+				current += deltas[i]
+				if current < 0 {
+					return metricdata.ExponentialBucket{}, fmt.Errorf("%w: negative bucket count", errInvalidNativeHistogram)
+				}
+				bucketCounts = append(bucketCounts, uint64(current))
+			}
+			i++
+		}
+	}
+	return metricdata.ExponentialBucket{
+		Offset: spans[0].GetOffset() - 1,
+		Counts: bucketCounts,
+	}, nil
+}
+
+// convertSummary converts Prometheus summary metrics to an OpenTelemetry
+// summary aggregation.
+func convertSummary(metrics []*dto.Metric, start, now time.Time) metricdata.Summary {
+	points := make([]metricdata.SummaryDataPoint, 0, len(metrics))
+	for _, m := range metrics {
+		s := m.GetSummary()
+		quantiles := make([]metricdata.QuantileValue, 0, len(s.GetQuantile()))
+		for _, q := range s.GetQuantile() {
+			quantiles = append(quantiles, metricdata.QuantileValue{
+				Quantile: q.GetQuantile(),
+				Value:    q.GetValue(),
+			})
+		}
+		t := timestamp(m, now)
+		points = append(points, metricdata.SummaryDataPoint{
+			Attributes:     convertLabels(m.GetLabel()),
+			StartTime:      startTime(s.GetCreatedTimestamp(), start, t),
+			Time:           t,
+			Count:          s.GetSampleCount(),
+			Sum:            s.GetSampleSum(),
+			QuantileValues: quantiles,
+		})
+	}
+	return metricdata.Summary{DataPoints: points}
+}
+
+// convertLabels converts Prometheus label pairs to an OpenTelemetry attribute
+// set.
+func convertLabels(labels []*dto.LabelPair) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(labels))
+	for _, l := range labels {
+		kvs = append(kvs, attribute.String(l.GetName(), l.GetValue()))
+	}
+	return attribute.NewSet(kvs...)
+}
+
+// convertExemplars converts Prometheus exemplars to OpenTelemetry exemplars.
+// Nil exemplars are ignored.
+func convertExemplars(exemplars []*dto.Exemplar) []metricdata.Exemplar[float64] {
+	var out []metricdata.Exemplar[float64]
+	for _, e := range exemplars {
+		if e == nil {
+			continue
+		}
+		out = append(out, convertExemplar(e))
+	}
+	return out
+}
+
+// convertExemplar converts a Prometheus exemplar to an OpenTelemetry
+// exemplar. Valid trace_id and span_id labels are used as the trace and span
+// IDs of the exemplar, all other labels are used as filtered attributes.
+func convertExemplar(e *dto.Exemplar) metricdata.Exemplar[float64] {
+	exemplar := metricdata.Exemplar[float64]{Value: e.GetValue()}
+	if ts := e.GetTimestamp(); ts != nil {
+		exemplar.Time = ts.AsTime()
+	}
+	for _, l := range e.GetLabel() {
+		switch l.GetName() {
+		case traceIDLabel:
+			if id, err := trace.TraceIDFromHex(l.GetValue()); err == nil {
+				exemplar.TraceID = id[:]
+				continue
+			}
+		case spanIDLabel:
+			if id, err := trace.SpanIDFromHex(l.GetValue()); err == nil {
+				exemplar.SpanID = id[:]
+				continue
+			}
+		}
+		exemplar.FilteredAttributes = append(exemplar.FilteredAttributes, attribute.String(l.GetName(), l.GetValue()))
+	}
+	return exemplar
+}
+
+// histogramCount returns the total count of observations of h.
+func histogramCount(h *dto.Histogram) uint64 {
+	if h.SampleCountFloat != nil {
+		return floatCount(h.GetSampleCountFloat())
+	}
+	return h.GetSampleCount()
+}
+
+// floatCount returns the float count v rounded to an integer count.
+func floatCount(v float64) uint64 {
+	if v <= 0 || math.IsNaN(v) {
+		return 0
+	}
+	if v >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(math.Round(v))
+}
+
+// timestamp returns the time m was recorded, or now if it does not define a
+// timestamp.
+func timestamp(m *dto.Metric, now time.Time) time.Time {
+	if m.TimestampMs != nil {
+		return time.UnixMilli(m.GetTimestampMs())
+	}
+	return now
+}
+
+// startTime returns the created timestamp if it is defined and not after t.
+// Otherwise, start is returned.
+func startTime(created *timestamppb.Timestamp, start, t time.Time) time.Time {
+	if created != nil && created.IsValid() {
+		if ct := created.AsTime(); !ct.After(t) {
+			return ct
+		}
+	}
+	if start.After(t) {
+		return t
+	}
+	return start
+}
@@ -0,0 +1,529 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/bridge/prometheus"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+const (
+	traceIDStr = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanIDStr  = "00f067aa0ba902b7"
+)
+
+var (
+	traceID = []byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID  = []byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+)
+
+func TestProduce(t *testing.T) {
+	testCases := []struct {
+		name     string
+		testFn   func(*prometheus.Registry)
+		expected []metricdata.ScopeMetrics
+		wantErr  error
+	}{
+		{
+			name:   "no metrics registered",
+			testFn: func(*prometheus.Registry) {},
+		},
+		{
+			name: "counter",
+			testFn: func(reg *prometheus.Registry) {
+				metric := prometheus.NewCounterVec(prometheus.CounterOpts{
+					Name: "test_counter_metric",
+					Help: "A counter metric.",
+				}, []string{"foo"})
+				reg.MustRegister(metric)
+				metric.WithLabelValues("bar").Add(245.3)
+				metric.WithLabelValues("baz").Add(1)
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_counter_metric",
+						Description: "A counter metric.",
+						Data: metricdata.Sum[float64]{
+							Temporality: metricdata.CumulativeTemporality,
+							IsMonotonic: true,
+							DataPoints: []metricdata.DataPoint[float64]{
+								{
+									Attributes: attribute.NewSet(attribute.String("foo", "bar")),
+									Value:      245.3,
+								},
+								{
+									Attributes: attribute.NewSet(attribute.String("foo", "baz")),
+									Value:      1,
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "counter with exemplar",
+			testFn: func(reg *prometheus.Registry) {
+				metric := prometheus.NewCounter(prometheus.CounterOpts{
+					Name: "test_counter_metric",
+					Help: "A counter metric.",
+				})
+				reg.MustRegister(metric)
+				metric.(prometheus.ExemplarAdder).AddWithExemplar(
+					245.3, prometheus.Labels{
+						"trace_id": traceIDStr,
+						"span_id":  spanIDStr,
+						"other":    "label",
+					},
+				)
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_counter_metric",
+						Description: "A counter metric.",
+						Data: metricdata.Sum[float64]{
+							Temporality: metricdata.CumulativeTemporality,
+							IsMonotonic: true,
+							DataPoints: []metricdata.DataPoint[float64]{
+								{
+									Value: 245.3,
+									Exemplars: []metricdata.Exemplar[float64]{
+										{
+											Value:              245.3,
+											TraceID:            traceID,
+											SpanID:             spanID,
+											FilteredAttributes: []attribute.KeyValue{attribute.String("other", "label")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "gauge",
+			testFn: func(reg *prometheus.Registry) {
+				metric := prometheus.NewGauge(prometheus.GaugeOpts{
+					Name: "test_gauge_metric",
+					Help: "A gauge metric.",
+				})
+				reg.MustRegister(metric)
+				metric.Set(123.4)
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_gauge_metric",
+						Description: "A gauge metric.",
+						Data: metricdata.Gauge[float64]{
+							DataPoints: []metricdata.DataPoint[float64]{
+								{Value: 123.4},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "untyped",
+			testFn: func(reg *prometheus.Registry) {
+				reg.MustRegister(prometheus.NewUntypedFunc(prometheus.UntypedOpts{
+					Name: "test_untyped_metric",
+					Help: "An untyped metric.",
+				}, func() float64 { return 42 }))
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_untyped_metric",
+						Description: "An untyped metric.",
+						Data: metricdata.Gauge[float64]{
+							DataPoints: []metricdata.DataPoint[float64]{
+								{Value: 42},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "histogram",
+			testFn: func(reg *prometheus.Registry) {
+				metric := prometheus.NewHistogram(prometheus.HistogramOpts{
+					Name:    "test_histogram_metric",
+					Help:    "A histogram metric.",
+					Buckets: []float64{1, 5},
+				})
+				reg.MustRegister(metric)
+				metric.Observe(0.5)
+				metric.Observe(3)
+				metric.Observe(4)
+				metric.Observe(10)
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_histogram_metric",
+						Description: "A histogram metric.",
+						Data: metricdata.Histogram[float64]{
+							Temporality: metricdata.CumulativeTemporality,
+							DataPoints: []metricdata.HistogramDataPoint[float64]{
+								{
+									Count:        4,
+									Sum:          17.5,
+									Bounds:       []float64{1, 5},
+									BucketCounts: []uint64{1, 2, 1},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "native histogram",
+			testFn: func(reg *prometheus.Registry) {
+				metric := prometheus.NewHistogram(prometheus.HistogramOpts{
+					Name:                         "test_native_histogram_metric",
+					Help:                         "A native histogram metric.",
+					NativeHistogramBucketFactor:  2,
+					NativeHistogramZeroThreshold: 0.1,
+				})
+				reg.MustRegister(metric)
+				metric.Observe(0)
+				metric.Observe(-3)
+				metric.Observe(1)
+				metric.Observe(2)
+				metric.Observe(8)
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_native_histogram_metric",
+						Description: "A native histogram metric.",
+						Data: metricdata.ExponentialHistogram[float64]{
+							Temporality: metricdata.CumulativeTemporality,
+							DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{
+								{
+									Count:         5,
+									Sum:           8,
+									Scale:         0,
+									ZeroCount:     1,
+									ZeroThreshold: 0.1,
+									// 1 is in (0.5, 1], 2 is in (1, 2], and 8
+									// is in (4, 8].
+									PositiveBucket: metricdata.ExponentialBucket{
+										Offset: -1,
+										Counts: []uint64{1, 1, 0, 1},
+									},
+									// 3 is in (2, 4].
+									NegativeBucket: metricdata.ExponentialBucket{
+										Offset: 1,
+										Counts: []uint64{1},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "summary",
+			testFn: func(reg *prometheus.Registry) {
+				metric := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+					Name:       "test_summary_metric",
+					Help:       "A summary metric.",
+					Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01},
+				}, []string{"foo"})
+				reg.MustRegister(metric)
+				metric.WithLabelValues("bar").Observe(15)
+			},
+			expected: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{
+					Name:    scopeName,
+					Version: Version(),
+				},
+				Metrics: []metricdata.Metrics{
+					{
+						Name:        "test_summary_metric",
+						Description: "A summary metric.",
+						Data: metricdata.Summary{
+							DataPoints: []metricdata.SummaryDataPoint{
+								{
+									Attributes: attribute.NewSet(attribute.String("foo", "bar")),
+									Count:      1,
+									Sum:        15,
+									QuantileValues: []metricdata.QuantileValue{
+										{Quantile: 0.5, Value: 15},
+										{Quantile: 0.9, Value: 15},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := prometheus.NewRegistry()
+			tt.testFn(reg)
+			p := NewMetricProducer(WithGatherer(reg))
+			output, err := p.Produce(context.Background())
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			require.Equal(t, len(tt.expected), len(output))
+			for i := range output {
+				metricdatatest.AssertEqual(t, tt.expected[i], output[i], metricdatatest.IgnoreTimestamp())
+			}
+		})
+	}
+}
+
+func TestProducePartialSuccess(t *testing.T) {
+	errGather := errors.New("gather failure")
+	p := NewMetricProducer(WithGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{
+			{
+				Name:   proto.String("test_gauge_metric"),
+				Type:   dto.MetricType_GAUGE.Enum(),
+				Metric: []*dto.Metric{{Gauge: &dto.Gauge{Value: proto.Float64(1)}}},
+			},
+			{
+				Name: proto.String("test_gauge_histogram_metric"),
+				Type: dto.MetricType_GAUGE_HISTOGRAM.Enum(),
+				Metric: []*dto.Metric{{Histogram: &dto.Histogram{
+					SampleCount: proto.Uint64(1),
+				}}},
+			},
+		}, errGather
+	})))
+	output, err := p.Produce(context.Background())
+	assert.ErrorIs(t, err, errGather)
+	assert.ErrorIs(t, err, errUnsupportedType)
+	require.Len(t, output, 1)
+	require.Len(t, output[0].Metrics, 1)
+	assert.Equal(t, "test_gauge_metric", output[0].Metrics[0].Name)
+}
+
+func TestProduceTimestamps(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+	recorded := time.UnixMilli(2000000)
+
+	p := NewMetricProducer(WithGatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{{
+			Name: proto.String("test_counter_metric"),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{{Name: proto.String("n"), Value: proto.String("0")}},
+					Counter: &dto.Counter{
+						Value:            proto.Float64(1),
+						CreatedTimestamp: timestamppb.New(created),
+					},
+					TimestampMs: proto.Int64(recorded.UnixMilli()),
+				},
+				{
+					// Created after the recorded time.
+					Label: []*dto.LabelPair{{Name: proto.String("n"), Value: proto.String("1")}},
+					Counter: &dto.Counter{
+						Value:            proto.Float64(1),
+						CreatedTimestamp: timestamppb.New(recorded.Add(time.Second)),
+					},
+					TimestampMs: proto.Int64(recorded.UnixMilli()),
+				},
+				{
+					Label:   []*dto.LabelPair{{Name: proto.String("n"), Value: proto.String("2")}},
+					Counter: &dto.Counter{Value: proto.Float64(1)},
+				},
+			},
+		}}, nil
+	})))
+	output, err := p.Produce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	require.Len(t, output[0].Metrics, 1)
+
+	pts := output[0].Metrics[0].Data.(metricdata.Sum[float64]).DataPoints
+	require.Len(t, pts, 3)
+	assert.Equal(t, created, pts[0].StartTime)
+	assert.Equal(t, recorded, pts[0].Time)
+	assert.Equal(t, recorded, pts[1].StartTime)
+	assert.Equal(t, recorded, pts[1].Time)
+	assert.Equal(t, p.start, pts[2].StartTime)
+	assert.False(t, pts[2].Time.Before(p.start))
+}
+
+func TestConvertHistogramInvalid(t *testing.T) {
+	_, err := convertHistogram([]*dto.Metric{{
+		Histogram: &dto.Histogram{
+			SampleCount: proto.Uint64(1),
+			Bucket: []*dto.Bucket{
+				{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+			},
+		},
+	}}, time.Now(), time.Now())
+	assert.ErrorIs(t, err, errInvalidHistogram)
+
+	_, err = convertHistogram([]*dto.Metric{{
+		Histogram: &dto.Histogram{
+			SampleCount: proto.Uint64(3),
+			Bucket: []*dto.Bucket{
+				{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+				{UpperBound: proto.Float64(2), CumulativeCount: proto.Uint64(1)},
+			},
+		},
+	}}, time.Now(), time.Now())
+	assert.ErrorIs(t, err, errInvalidHistogram)
+}
+
+func TestConvertNativeBuckets(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spans   []*dto.BucketSpan
+		deltas  []int64
+		counts  []float64
+		want    metricdata.ExponentialBucket
+		wantErr error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "empty span",
+			spans: []*dto.BucketSpan{{Offset: proto.Int32(0), Length: proto.Uint32(0)}},
+		},
+		{
+			name: "deltas",
+			spans: []*dto.BucketSpan{
+				{Offset: proto.Int32(-2), Length: proto.Uint32(2)},
+				{Offset: proto.Int32(2), Length: proto.Uint32(1)},
+			},
+			deltas: []int64{3, -1, 2},
+			want: metricdata.ExponentialBucket{
+				Offset: -3,
+				Counts: []uint64{3, 2, 0, 0, 4},
+			},
+		},
+		{
+			name: "float counts",
+			spans: []*dto.BucketSpan{
+				{Offset: proto.Int32(5), Length: proto.Uint32(2)},
+				{Offset: proto.Int32(1), Length: proto.Uint32(1)},
+			},
+			counts: []float64{1, 2.4, math.NaN()},
+			want: metricdata.ExponentialBucket{
+				Offset: 4,
+				Counts: []uint64{1, 2, 0, 0},
+			},
+		},
+		{
+			name:    "missing deltas",
+			spans:   []*dto.BucketSpan{{Offset: proto.Int32(0), Length: proto.Uint32(2)}},
+			deltas:  []int64{1},
+			wantErr: errInvalidNativeHistogram,
+		},
+		{
+			name:    "negative count",
+			spans:   []*dto.BucketSpan{{Offset: proto.Int32(0), Length: proto.Uint32(2)}},
+			deltas:  []int64{1, -2},
+			wantErr: errInvalidNativeHistogram,
+		},
+		{
+			name: "negative span offset",
+			spans: []*dto.BucketSpan{
+				{Offset: proto.Int32(0), Length: proto.Uint32(1)},
+				{Offset: proto.Int32(-1), Length: proto.Uint32(1)},
+			},
+			deltas:  []int64{1, 0},
+			wantErr: errInvalidNativeHistogram,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertNativeBuckets(tt.spans, tt.deltas, tt.counts)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvertNativeHistogramInvalidSchema(t *testing.T) {
+	_, err := convertNativeHistogram([]*dto.Metric{{
+		Histogram: &dto.Histogram{
+			Schema:        proto.Int32(9),
+			ZeroThreshold: proto.Float64(1e-128),
+		},
+	}}, time.Now(), time.Now())
+	assert.ErrorIs(t, err, errInvalidNativeHistogram)
+}
+
+func TestProducerWithReader(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test_counter_metric",
+		Help: "A counter metric.",
+	})
+	reg.MustRegister(counter)
+	counter.Inc()
+
+	rdr := metric.NewManualReader(metric.WithProducer(NewMetricProducer(WithGatherer(reg))))
+	_ = metric.NewMeterProvider(metric.WithReader(rdr))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, rdr.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	assert.Equal(t, "test_counter_metric", rm.ScopeMetrics[0].Metrics[0].Name)
+}
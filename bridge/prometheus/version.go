@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/bridge/prometheus"
+
+// Version is the current release version of the prometheus bridge.
+func Version() string {
+	return "0.53.0"
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slog // import "go.opentelemetry.io/otel/bridge/slog"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// config contains options for the handler.
+type config struct {
+	provider  log.LoggerProvider
+	version   string
+	schemaURL string
+	attrs     []attribute.KeyValue
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(opts []Option) config {
+	cfg := config{}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+
+	if cfg.provider == nil {
+		cfg.provider = global.GetLoggerProvider()
+	}
+
+	return cfg
+}
+
+// logger returns the logger named name of the configured provider.
+func (c config) logger(name string) log.Logger {
+	var opts []log.LoggerOption
+	if c.version != "" {
+		opts = append(opts, log.WithInstrumentationVersion(c.version))
+	}
+	if c.schemaURL != "" {
+		opts = append(opts, log.WithSchemaURL(c.schemaURL))
+	}
+	if len(c.attrs) > 0 {
+		opts = append(opts, log.WithInstrumentationAttributes(c.attrs...))
+	}
+	return c.provider.Logger(name, opts...)
+}
+
+// Option sets handler option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithLoggerProvider configures the LoggerProvider the Handler gets its
+// Logger from. If this option is not used, the global LoggerProvider is used.
+func WithLoggerProvider(provider log.LoggerProvider) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.provider = provider
+		return cfg
+	})
+}
+
+// WithVersion configures the version of the instrumentation scope of the
+// Logger.
+func WithVersion(version string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.version = version
+		return cfg
+	})
+}
+
+// WithSchemaURL configures the schema URL of the instrumentation scope of the
+// Logger.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.schemaURL = schemaURL
+		return cfg
+	})
+}
+
+// WithAttributes configures the attributes of the instrumentation scope of
+// the Logger. This option can be used multiple times, the attributes are
+// combined.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.attrs = append(cfg.attrs, attrs...)
+		return cfg
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slog provides a bridge from [log/slog] to the OpenTelemetry Logs
+// API.
+//
+// The [Handler] implements [slog.Handler] and emits the records it handles
+// to a [go.opentelemetry.io/otel/log.Logger]. Use [NewLogger] to create a
+// [slog.Logger] writing to it, or [NewHandler] to combine it with other
+// handlers.
+//
+// Records are translated as follows:
+//
+//   - The time is used as the timestamp, unless it is zero.
+//   - The message is used as the body.
+//   - The level is mapped to the severity, [slog.LevelDebug],
+//     [slog.LevelInfo], [slog.LevelWarn] and [slog.LevelError] being mapped
+//     to [log.SeverityDebug], [log.SeverityInfo], [log.SeverityWarn] and
+//     [log.SeverityError], and the levels in between to the severities in
+//     between. The name of the level is used as the severity text.
+//   - The attributes are translated to log attributes. The values of
+//     [slog.LogValuer] are resolved first. The attributes of a group are
+//     translated to a [log.MapValue], groups without attributes are omitted
+//     and the attributes of groups without a key are inlined.
+//   - The attributes added with [Handler.WithGroup] are nested in a
+//     [log.MapValue] with the name of the group.
+//
+// The [context.Context] passed to [Handler.Handle] is passed along with the
+// record, the trace context it holds is therefore the one of the record.
+package slog // import "go.opentelemetry.io/otel/bridge/slog"
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slog_test
+
+import (
+	"context"
+	"log/slog"
+
+	otelslog "go.opentelemetry.io/otel/bridge/slog"
+	"go.opentelemetry.io/otel/log/noop"
+)
+
+func Example() {
+	// Use a working LoggerProvider implementation instead e.g. using
+	// go.opentelemetry.io/otel/sdk/log.
+	provider := noop.NewLoggerProvider()
+
+	logger := otelslog.NewLogger("my/pkg/name", otelslog.WithLoggerProvider(provider))
+
+	// The trace context of ctx is the one of the emitted record.
+	ctx := context.Background()
+	logger.With("user", "alice").WithGroup("request").InfoContext(ctx, "handled", slog.Int("status", 200))
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slog // import "go.opentelemetry.io/otel/bridge/slog"
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Handler is a [slog.Handler] emitting the records it handles to a
+// [log.Logger].
+type Handler struct {
+	logger log.Logger
+
+	// attrs are the attributes added before the first group.
+	attrs []log.KeyValue
+	// groups are the groups opened with WithGroup, outermost first.
+	groups []group
+}
+
+// group is a group opened with WithGroup and the attributes added to it.
+type group struct {
+	name  string
+	attrs []log.KeyValue
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler returns a Handler emitting the records it handles to the Logger
+// named name.
+//
+// If [WithLoggerProvider] is not passed, the Logger is the one of the global
+// LoggerProvider.
+func NewHandler(name string, options ...Option) *Handler {
+	return &Handler{logger: newConfig(options).logger(name)}
+}
+
+// NewLogger returns a [slog.Logger] using a Handler created with name and
+// options.
+func NewLogger(name string, options ...Option) *slog.Logger {
+	return slog.New(NewHandler(name, options...))
+}
+
+// Enabled returns if the Logger emits records of the level l.
+func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
+	var param log.EnabledParameters
+	param.SetSeverity(severity(l))
+	return h.logger.Enabled(ctx, param)
+}
+
+// Handle emits the record r with the context ctx.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var record log.Record
+	if !r.Time.IsZero() {
+		record.SetTimestamp(r.Time)
+	}
+	record.SetBody(log.StringValue(r.Message))
+	record.SetSeverity(severity(r.Level))
+	record.SetSeverityText(r.Level.String())
+
+	kvs := make([]log.KeyValue, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		kvs = appendAttr(kvs, a)
+		return true
+	})
+	// Nest the attributes in the groups, from the innermost to the
+	// outermost, omitting the empty ones.
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		g := h.groups[i]
+		kvs = append(slices.Clip(g.attrs), kvs...)
+		if len(kvs) > 0 {
+			kvs = []log.KeyValue{log.Map(g.name, kvs...)}
+		}
+	}
+	record.AddAttributes(append(slices.Clip(h.attrs), kvs...)...)
+
+	h.logger.Emit(ctx, record)
+	return nil
+}
+
+// WithAttrs returns a copy of h adding attrs to the attributes of the
+// records, in the group last opened with WithGroup if any.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var kvs []log.KeyValue
+	for _, a := range attrs {
+		kvs = appendAttr(kvs, a)
+	}
+	if len(kvs) == 0 {
+		return h
+	}
+
+	c := *h
+	if len(c.groups) == 0 {
+		c.attrs = append(slices.Clip(c.attrs), kvs...)
+		return &c
+	}
+	c.groups = slices.Clone(c.groups)
+	last := &c.groups[len(c.groups)-1]
+	last.attrs = append(slices.Clip(last.attrs), kvs...)
+	return &c
+}
+
+// WithGroup returns a copy of h nesting the attributes added afterwards,
+// including the ones of the records, in a group named name. If name is empty,
+// h is returned.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	c := *h
+	c.groups = append(slices.Clip(c.groups), group{name: name})
+	return &c
+}
+
+// severity returns the severity of the level l. The levels of slog and the
+// severities being spaced alike, the level is offset so that
+// [slog.LevelInfo] is [log.SeverityInfo] and clamped to the severity range.
+func severity(l slog.Level) log.Severity {
+	s := int(l) + int(log.SeverityInfo)
+	switch {
+	case s < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case s > int(log.SeverityFatal4):
+		return log.SeverityFatal4
+	}
+	return log.Severity(s) // nolint:gosec // Clamped to the severity range.
+}
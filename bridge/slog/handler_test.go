@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"os"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// emitted returns the records emitted to the recorder rec.
+func emitted(rec *logtest.Recorder) []logtest.EmittedRecord {
+	var out []logtest.EmittedRecord
+	for _, s := range rec.Result() {
+		out = append(out, s.Records...)
+	}
+	return out
+}
+
+// attributes returns the attributes of the record r.
+func attributes(r log.Record) []log.KeyValue {
+	var kvs []log.KeyValue
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		kvs = append(kvs, kv)
+		return true
+	})
+	return kvs
+}
+
+func TestSlogtest(t *testing.T) {
+	rec := logtest.NewRecorder()
+	slogtest.Run(t, func(*testing.T) slog.Handler {
+		rec.Reset()
+		return NewHandler("test", WithLoggerProvider(rec))
+	}, func(t *testing.T) map[string]any {
+		records := emitted(rec)
+		require.Len(t, records, 1)
+		r := records[0]
+
+		m := map[string]any{
+			slog.LevelKey:   r.SeverityText(),
+			slog.MessageKey: r.Body().AsString(),
+		}
+		if !r.Timestamp().IsZero() {
+			m[slog.TimeKey] = r.Timestamp()
+		}
+		for _, kv := range attributes(r.Record) {
+			m[kv.Key] = toAny(kv.Value)
+		}
+		return m
+	})
+}
+
+// toAny returns v as the values of the maps slogtest checks.
+func toAny(v log.Value) any {
+	switch v.Kind() {
+	case log.KindMap:
+		m := map[string]any{}
+		for _, kv := range v.AsMap() {
+			m[kv.Key] = toAny(kv.Value)
+		}
+		return m
+	case log.KindString:
+		return v.AsString()
+	}
+	return v.String()
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+type panicStringer struct{}
+
+func (panicStringer) String() string { panic("boom") }
+
+type valuer struct{}
+
+func (valuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("resolved", "value"))
+}
+
+func TestHandle(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want []log.KeyValue
+	}{
+		{"Bool", slog.Bool("k", true), []log.KeyValue{log.Bool("k", true)}},
+		{"Duration", slog.Duration("k", time.Second), []log.KeyValue{log.Int64("k", 1e9)}},
+		{"Float64", slog.Float64("k", 1.5), []log.KeyValue{log.Float64("k", 1.5)}},
+		{"Int", slog.Int("k", -1), []log.KeyValue{log.Int("k", -1)}},
+		{"String", slog.String("k", "v"), []log.KeyValue{log.String("k", "v")}},
+		{"Time", slog.Time("k", now), []log.KeyValue{log.Int64("k", now.UnixNano())}},
+		{"Uint64", slog.Uint64("k", 1), []log.KeyValue{log.Int64("k", 1)}},
+		{"Uint64Overflow", slog.Uint64("k", 1<<63), []log.KeyValue{log.String("k", "9223372036854775808")}},
+		{"Bytes", slog.Any("k", []byte("v")), []log.KeyValue{log.Bytes("k", []byte("v"))}},
+		{"Error", slog.Any("k", errors.New("v")), []log.KeyValue{log.String("k", "v")}},
+		{"Stringer", slog.Any("k", stringer{}), []log.KeyValue{log.String("k", "stringer")}},
+		{"NilError", slog.Any("k", (*os.PathError)(nil)), []log.KeyValue{log.String("k", "<nil>")}},
+		{"NilStringer", slog.Any("k", (*url.URL)(nil)), []log.KeyValue{log.String("k", "<nil>")}},
+		{"PanicStringer", slog.Any("k", panicStringer{}), []log.KeyValue{log.String("k", "!PANIC: boom")}},
+		{"Nil", slog.Any("k", nil), []log.KeyValue{{Key: "k"}}},
+		{"Struct", slog.Any("k", struct{ A int }{1}), []log.KeyValue{log.String("k", "{A:1}")}},
+		{
+			"Slice",
+			slog.Any("k", []any{1, "a", time.Second}),
+			[]log.KeyValue{log.Slice("k", log.Int64Value(1), log.StringValue("a"), log.Int64Value(1e9))},
+		},
+		{
+			"Map",
+			slog.Any("k", map[int]bool{1: true}),
+			[]log.KeyValue{log.Map("k", log.Bool("1", true))},
+		},
+		{
+			"LogValuer",
+			slog.Any("k", valuer{}),
+			[]log.KeyValue{log.Map("k", log.String("resolved", "value"))},
+		},
+		{
+			"Group",
+			slog.Group("g", slog.Int("a", 1), slog.Group("h", slog.Bool("b", true))),
+			[]log.KeyValue{log.Map("g", log.Int("a", 1), log.Map("h", log.Bool("b", true)))},
+		},
+		{"EmptyGroup", slog.Group("g"), nil},
+		{"InlineGroup", slog.Group("", slog.Int("a", 1)), []log.KeyValue{log.Int("a", 1)}},
+		{"Empty", slog.Attr{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := logtest.NewRecorder()
+			NewLogger("test", WithLoggerProvider(rec)).Info("msg", tt.attr)
+
+			records := emitted(rec)
+			require.Len(t, records, 1)
+			assert.Equal(t, tt.want, attributes(records[0].Record))
+		})
+	}
+}
+
+func TestHandleRecord(t *testing.T) {
+	rec := logtest.NewRecorder()
+	h := NewHandler("test", WithLoggerProvider(rec))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	now := time.Now()
+	r := slog.NewRecord(now, slog.LevelWarn+1, "msg", 0)
+	r.AddAttrs(slog.String("k", "v"))
+	require.NoError(t, h.Handle(ctx, r))
+
+	records := emitted(rec)
+	require.Len(t, records, 1)
+	want := logtest.RecordFactory{
+		Timestamp:    now,
+		Severity:     log.SeverityWarn2,
+		SeverityText: "WARN+1",
+		Body:         log.StringValue("msg"),
+		Attributes:   []log.KeyValue{log.String("k", "v")},
+	}.NewRecord()
+	logtest.AssertRecordEqual(t, want, records[0].Record)
+	assert.Equal(t, sc, trace.SpanContextFromContext(records[0].Context()), "trace context not passed")
+}
+
+func TestHandlerWith(t *testing.T) {
+	rec := logtest.NewRecorder()
+	h := NewHandler("test", WithLoggerProvider(rec)).
+		WithAttrs([]slog.Attr{slog.Int("a", 1)}).
+		WithGroup("g").
+		WithAttrs([]slog.Attr{slog.Int("b", 2)}).
+		WithGroup("h").
+		WithGroup("")
+	l := slog.New(h)
+
+	l.Info("msg", "c", 3)
+	l.Info("msg")
+	// The handlers derived from h must not modify it.
+	slog.New(h.WithAttrs([]slog.Attr{slog.Int("d", 4)})).Info("msg")
+
+	records := emitted(rec)
+	require.Len(t, records, 3)
+	assert.Equal(t, []log.KeyValue{
+		log.Int("a", 1),
+		log.Map("g", log.Int("b", 2), log.Map("h", log.Int("c", 3))),
+	}, attributes(records[0].Record))
+	assert.Equal(t, []log.KeyValue{
+		log.Int("a", 1),
+		log.Map("g", log.Int("b", 2)),
+	}, attributes(records[1].Record), "empty group not omitted")
+	assert.Equal(t, []log.KeyValue{
+		log.Int("a", 1),
+		log.Map("g", log.Int("b", 2), log.Map("h", log.Int("d", 4))),
+	}, attributes(records[2].Record))
+}
+
+func TestHandlerEnabled(t *testing.T) {
+	rec := logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, p log.EnabledParameters) bool {
+		s, _ := p.Severity()
+		return s >= log.SeverityWarn
+	}))
+	h := NewHandler("test", WithLoggerProvider(rec))
+
+	ctx := context.Background()
+	assert.False(t, h.Enabled(ctx, slog.LevelInfo))
+	assert.True(t, h.Enabled(ctx, slog.LevelWarn))
+	assert.True(t, h.Enabled(ctx, slog.LevelError))
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  log.Severity
+	}{
+		{slog.LevelDebug - 10, log.SeverityTrace1},
+		{slog.LevelDebug - 4, log.SeverityTrace1},
+		{slog.LevelDebug, log.SeverityDebug},
+		{slog.LevelInfo, log.SeverityInfo},
+		{slog.LevelInfo + 1, log.SeverityInfo2},
+		{slog.LevelWarn, log.SeverityWarn},
+		{slog.LevelError, log.SeverityError},
+		{slog.LevelError + 4, log.SeverityFatal},
+		{slog.LevelError + 7, log.SeverityFatal4},
+		{slog.LevelError + 10, log.SeverityFatal4},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, severity(tt.level), tt.level.String())
+	}
+}
+
+func TestNewHandlerConfig(t *testing.T) {
+	rec := logtest.NewRecorder()
+	NewLogger("test",
+		WithLoggerProvider(rec),
+		WithVersion("v1"),
+		WithSchemaURL("https://opentelemetry.io/schemas/1.26.0"),
+		WithAttributes(attribute.String("a", "b")),
+	).Info("msg")
+
+	result := rec.Result()
+	require.Len(t, result, 1)
+	assert.Equal(t, "test", result[0].Name)
+	assert.Equal(t, "v1", result[0].Version)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", result[0].SchemaURL)
+	assert.Equal(t, attribute.NewSet(attribute.String("a", "b")), result[0].Attributes)
+}
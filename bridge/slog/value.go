@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package slog // import "go.opentelemetry.io/otel/bridge/slog"
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// appendAttr appends the log attributes of a to kvs and returns the result.
+//
+// As handlers are required to, the attributes with an empty key and value
+// and the groups without attributes are omitted, and the attributes of the
+// groups with an empty key are inlined.
+func appendAttr(kvs []log.KeyValue, a slog.Attr) []log.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		var group []log.KeyValue
+		for _, ga := range a.Value.Group() {
+			group = appendAttr(group, ga)
+		}
+		if len(group) == 0 {
+			return kvs
+		}
+		if a.Key == "" {
+			return append(kvs, group...)
+		}
+		return append(kvs, log.Map(a.Key, group...))
+	}
+
+	return append(kvs, log.KeyValue{Key: a.Key, Value: value(a.Value)})
+}
+
+// value returns the log value of the resolved value v.
+//
+// Durations are returned as their number of nanoseconds and times as their
+// Unix time in nanoseconds. Unsigned integers overflowing an int64 are
+// returned as strings. Byte slices are returned as bytes, the other slices,
+// arrays and maps are returned as slices and maps of their converted values,
+// and any other value is returned as its string representation.
+func value(v slog.Value) log.Value {
+	switch v.Kind() {
+	case slog.KindBool:
+		return log.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return log.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindFloat64:
+		return log.Float64Value(v.Float64())
+	case slog.KindInt64:
+		return log.Int64Value(v.Int64())
+	case slog.KindString:
+		return log.StringValue(v.String())
+	case slog.KindTime:
+		return log.Int64Value(v.Time().UnixNano())
+	case slog.KindUint64:
+		return uint64Value(v.Uint64())
+	case slog.KindGroup:
+		var kvs []log.KeyValue
+		for _, a := range v.Group() {
+			kvs = appendAttr(kvs, a)
+		}
+		return log.MapValue(kvs...)
+	case slog.KindAny, slog.KindLogValuer:
+		return anyValue(v.Any())
+	}
+	return log.StringValue(v.String())
+}
+
+// uint64Value returns the log value of u, as a string if it overflows an
+// int64.
+func uint64Value(u uint64) log.Value {
+	if u > math.MaxInt64 {
+		return log.StringValue(strconv.FormatUint(u, 10))
+	}
+	return log.Int64Value(int64(u)) // nolint:gosec // Overflow checked above.
+}
+
+// anyValue returns the log value of a value of any type.
+func anyValue(a any) log.Value {
+	switch a := a.(type) {
+	case nil:
+		return log.Value{}
+	case []byte:
+		return log.BytesValue(a)
+	case error:
+		return stringValue(a, a.Error)
+	case fmt.Stringer:
+		return stringValue(a, a.String)
+	}
+
+	rv := reflect.ValueOf(a)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		vals := make([]log.Value, 0, rv.Len())
+		for i := range rv.Len() {
+			vals = append(vals, value(slog.AnyValue(rv.Index(i).Interface()).Resolve()))
+		}
+		return log.SliceValue(vals...)
+	case reflect.Map:
+		kvs := make([]log.KeyValue, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			kvs = append(kvs, log.KeyValue{
+				Key:   fmt.Sprint(iter.Key().Interface()),
+				Value: value(slog.AnyValue(iter.Value().Interface()).Resolve()),
+			})
+		}
+		return log.MapValue(kvs...)
+	}
+	return log.StringValue(fmt.Sprintf("%+v", a))
+}
+
+// stringValue returns the log value of the string returned by the method f of
+// a. As slog.TextHandler does, "<nil>" is returned if f panics for a nil
+// pointer a, and the panic is described otherwise.
+func stringValue(a any, f func() string) (v log.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rv := reflect.ValueOf(a); rv.Kind() == reflect.Pointer && rv.IsNil() {
+				v = log.StringValue("<nil>")
+				return
+			}
+			v = log.StringValue(fmt.Sprintf("!PANIC: %v", r))
+		}
+	}()
+	return log.StringValue(f())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpcshared // import "go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared"
+
+import "sync"
+
+// RetryBudget defines a token bucket limiting the retries of export requests,
+// in the same way as the retry throttling of gRPC.
+//
+// The bucket starts with MaxTokens tokens. Every attempt failing with a
+// retryable error takes a token from the bucket, and every successful
+// attempt puts TokenRatio tokens back into it, up to MaxTokens. Failed
+// export requests are only retried while the bucket holds more than half of
+// MaxTokens, so retries stop when most attempts fail and resume as attempts
+// succeed again.
+type RetryBudget struct {
+	// MaxTokens is the number of tokens of a full bucket.
+	MaxTokens int
+	// TokenRatio is the number of tokens a successful attempt puts back
+	// into the bucket.
+	TokenRatio float64
+}
+
+// retryBudget is the token bucket of a RetryBudget. A nil *retryBudget
+// allows all retries.
+type retryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	ratio  float64
+}
+
+func newRetryBudget(b RetryBudget) *retryBudget {
+	if b.MaxTokens <= 0 {
+		return nil
+	}
+	return &retryBudget{
+		tokens: float64(b.MaxTokens),
+		max:    float64(b.MaxTokens),
+		ratio:  b.TokenRatio,
+	}
+}
+
+// failure records an attempt that failed with a retryable error and returns
+// if it can be retried.
+func (b *retryBudget) failure() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = max(b.tokens-1, 0)
+	return b.tokens > b.max/2
+}
+
+// success records a successful attempt.
+func (b *retryBudget) success() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, b.max)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpcshared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudget(t *testing.T) {
+	b := newRetryBudget(RetryBudget{MaxTokens: 4, TokenRatio: 0.5})
+
+	// 4 tokens: retries are allowed while more than 2 are left.
+	assert.True(t, b.failure(), "3 tokens")
+	assert.False(t, b.failure(), "2 tokens")
+	assert.False(t, b.failure(), "1 token")
+	assert.False(t, b.failure(), "0 token")
+	assert.False(t, b.failure(), "tokens must not be negative")
+
+	for i := 0; i < 8; i++ {
+		b.success()
+	}
+	assert.True(t, b.failure(), "retries must resume after successes")
+
+	for i := 0; i < 100; i++ {
+		b.success()
+	}
+	assert.Equal(t, 4.0, b.tokens, "tokens must not exceed MaxTokens")
+}
+
+func TestRetryBudgetDisabled(t *testing.T) {
+	b := newRetryBudget(RetryBudget{})
+	assert.Nil(t, b)
+
+	b.success()
+	for i := 0; i < 10; i++ {
+		assert.True(t, b.failure())
+	}
+}
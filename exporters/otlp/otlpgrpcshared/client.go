@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpcshared // import "go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared"
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared/internal/retry"
+)
+
+// Client is a gRPC connection to an OTLP endpoint, with a retry policy and
+// an export budget, shared by all the exporters built from it.
+//
+// A Client is safe for concurrent use.
+type Client struct {
+	requestFunc retry.RequestFunc
+
+	// inFlight holds a value for every export request being sent. It is nil
+	// if the number of concurrent export requests is not limited.
+	inFlight chan struct{}
+	budget   *retryBudget
+
+	// ourConn keeps track of where conn was created: true if created here in
+	// New, or false if passed with an option. This is important on Close as
+	// conn should only be closed if we created it. Otherwise, it is up to the
+	// processes that passed conn to close it.
+	ourConn bool
+	conn    *grpc.ClientConn
+}
+
+// New returns a new Client configured with options.
+//
+// The gRPC connection is established lazily, by the first export request
+// sent with it.
+func New(options ...Option) (*Client, error) {
+	cfg := newConfig(options)
+
+	c := &Client{
+		budget: newRetryBudget(cfg.retryBudget),
+		conn:   cfg.conn,
+	}
+	c.requestFunc = cfg.retry.RequestFunc(c.evaluate)
+
+	if cfg.maxInFlight > 0 {
+		c.inFlight = make(chan struct{}, cfg.maxInFlight)
+	}
+
+	if c.conn == nil {
+		conn, err := grpc.NewClient(cfg.endpoint, newGRPCDialOptions(cfg)...)
+		if err != nil {
+			return nil, err
+		}
+		c.ourConn = true
+		c.conn = conn
+	}
+
+	return c, nil
+}
+
+func newGRPCDialOptions(cfg config) []grpc.DialOption {
+	var dialOpts []grpc.DialOption
+	// Prioritize GRPCCredentials over Insecure (passing both is an error).
+	if cfg.credentials != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(cfg.credentials))
+	} else if cfg.insecure {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		// Default to using the host's root CA.
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(
+			credentials.NewTLS(nil),
+		))
+	}
+	return append(dialOpts, cfg.dialOptions...)
+}
+
+// Conn returns the gRPC connection of c.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Do sends an export request by calling fn, retrying it according to the
+// retry policy and budget of c.
+//
+// Every call of fn waits until the number of export requests being sent with
+// c is lower than the limit set with WithMaxInFlight, or until ctx is done.
+func (c *Client) Do(ctx context.Context, fn func(context.Context) error) error {
+	return c.requestFunc(ctx, func(ctx context.Context) error {
+		if err := c.acquire(ctx); err != nil {
+			return err
+		}
+		err := fn(ctx)
+		c.release()
+
+		if err == nil {
+			c.budget.success()
+		}
+		return err
+	})
+}
+
+// acquire waits for an export request to be allowed to be sent.
+func (c *Client) acquire(ctx context.Context) error {
+	if c.inFlight == nil {
+		return nil
+	}
+
+	select {
+	case c.inFlight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release records the end of an export request acquired with acquire.
+func (c *Client) release() {
+	if c.inFlight != nil {
+		<-c.inFlight
+	}
+}
+
+// Close closes the gRPC connection of c if it was created by New. It must be
+// called after all the exporters built from c are shut down.
+//
+// Any gRPC connection passed using WithGRPCConn will not be closed. It is the
+// caller's responsibility to handle cleanup of that resource.
+func (c *Client) Close() error {
+	if !c.ourConn {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// evaluate returns if err identifies a request that can be retried and a
+// duration to wait for if an explicit throttle time is included in err. A
+// request is not retried once the retry budget of c is exhausted.
+func (c *Client) evaluate(err error) (bool, time.Duration) {
+	ok, d := retryable(err)
+	if ok && !c.budget.failure() {
+		return false, 0
+	}
+	return ok, d
+}
+
+// retryable returns if err identifies a request that can be retried and a
+// duration to wait for if an explicit throttle time is included in err.
+func retryable(err error) (bool, time.Duration) {
+	s := status.Convert(err)
+	switch s.Code() {
+	case codes.Canceled,
+		codes.DeadlineExceeded,
+		codes.Aborted,
+		codes.OutOfRange,
+		codes.Unavailable,
+		codes.DataLoss:
+		// Additionally, handle RetryInfo.
+		_, d := throttleDelay(s)
+		return true, d
+	case codes.ResourceExhausted:
+		// Retry only if the server signals that the recovery from resource exhaustion is possible.
+		return throttleDelay(s)
+	}
+
+	// Not a retry-able error.
+	return false, 0
+}
+
+// throttleDelay returns if the status is RetryInfo
+// and the duration to wait for if an explicit throttle time is included.
+func throttleDelay(s *status.Status) (bool, time.Duration) {
+	for _, detail := range s.Details() {
+		if t, ok := detail.(*errdetails.RetryInfo); ok {
+			return true, t.RetryDelay.AsDuration()
+		}
+	}
+	return false, 0
+}
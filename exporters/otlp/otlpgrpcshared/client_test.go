@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpcshared
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var fastRetry = RetryConfig{
+	Enabled:         true,
+	InitialInterval: time.Nanosecond,
+	MaxInterval:     time.Nanosecond,
+	MaxElapsedTime:  time.Minute,
+}
+
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	c, err := New(append([]Option{WithInsecure()}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestNew(t *testing.T) {
+	c := newTestClient(t, WithEndpoint("collector:4317"))
+	require.NotNil(t, c.Conn())
+	assert.Equal(t, "collector:4317", c.Conn().Target())
+	assert.True(t, c.ourConn)
+}
+
+func TestNewDefaultEndpoint(t *testing.T) {
+	c := newTestClient(t)
+	assert.Equal(t, defaultEndpoint, c.Conn().Target())
+}
+
+func TestWithGRPCConn(t *testing.T) {
+	conn, err := grpc.NewClient("passthrough:///collector:4317", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	c, err := New(WithGRPCConn(conn), WithEndpoint("ignored:4317"))
+	require.NoError(t, err)
+	assert.Same(t, conn, c.Conn())
+
+	require.NoError(t, c.Close())
+	// The passed connection must not be closed by the Client.
+	assert.NoError(t, conn.Close())
+}
+
+func TestDoRetry(t *testing.T) {
+	c := newTestClient(t, WithRetry(fastRetry))
+
+	var calls int
+	err := c.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDoNotRetryable(t *testing.T) {
+	c := newTestClient(t, WithRetry(fastRetry))
+
+	var calls int
+	err := c.Do(context.Background(), func(context.Context) error {
+		calls++
+		return status.Error(codes.InvalidArgument, "invalid")
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestDoRetryBudget(t *testing.T) {
+	c := newTestClient(
+		t,
+		WithRetry(fastRetry),
+		WithRetryBudget(RetryBudget{MaxTokens: 6, TokenRatio: 1}),
+	)
+
+	unavailable := func(context.Context) error {
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	var calls int
+	err := c.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return unavailable(ctx)
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	// Tokens: 6 -> 5 (retry) -> 4 (retry) -> 3 (budget exhausted).
+	assert.Equal(t, 3, calls)
+
+	// The budget is shared by all the export requests.
+	calls = 0
+	err = c.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return unavailable(ctx)
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls, "exhausted budget must not retry")
+
+	// Successful requests refill the budget.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.Do(context.Background(), func(context.Context) error { return nil }))
+	}
+	calls = 0
+	_ = c.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return unavailable(ctx)
+	})
+	assert.Greater(t, calls, 1, "refilled budget must retry")
+}
+
+func TestDoMaxInFlight(t *testing.T) {
+	const maxInFlight = 2
+	c := newTestClient(t, WithMaxInFlight(maxInFlight))
+
+	var (
+		running, peak atomic.Int32
+		release       = make(chan struct{})
+		wg            sync.WaitGroup
+	)
+	for i := 0; i < 2*maxInFlight; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = c.Do(context.Background(), func(context.Context) error {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				<-release
+				running.Add(-1)
+				return nil
+			})
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		return running.Load() == maxInFlight
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(maxInFlight), peak.Load())
+}
+
+func TestDoMaxInFlightContextDone(t *testing.T) {
+	c := newTestClient(t, WithMaxInFlight(1), WithRetry(RetryConfig{Enabled: false}))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = c.Do(context.Background(), func(context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var called bool
+	err := c.Do(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+
+	close(release)
+	<-done
+}
+
+func TestRetryable(t *testing.T) {
+	retryableCodes := map[codes.Code]bool{
+		codes.OK:                 false,
+		codes.Canceled:           true,
+		codes.Unknown:            false,
+		codes.InvalidArgument:    false,
+		codes.DeadlineExceeded:   true,
+		codes.NotFound:           false,
+		codes.AlreadyExists:      false,
+		codes.PermissionDenied:   false,
+		codes.ResourceExhausted:  false,
+		codes.FailedPrecondition: false,
+		codes.Aborted:            true,
+		codes.OutOfRange:         true,
+		codes.Unimplemented:      false,
+		codes.Internal:           false,
+		codes.Unavailable:        true,
+		codes.DataLoss:           true,
+		codes.Unauthenticated:    false,
+	}
+
+	for c, want := range retryableCodes {
+		got, _ := retryable(status.Error(c, ""))
+		assert.Equalf(t, want, got, "evaluate(%s)", c)
+	}
+
+	got, _ := retryable(errors.New("not a status"))
+	assert.False(t, got)
+}
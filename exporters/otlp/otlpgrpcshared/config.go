@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpcshared // import "go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared"
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared/internal/retry"
+)
+
+// defaultEndpoint is the endpoint of a local OTLP gRPC receiver.
+const defaultEndpoint = "localhost:4317"
+
+// Option applies an option to the Client.
+type Option interface {
+	applyOption(config) config
+}
+
+type fnOpt func(config) config
+
+func (f fnOpt) applyOption(c config) config { return f(c) }
+
+type config struct {
+	endpoint    string
+	insecure    bool
+	credentials credentials.TransportCredentials
+	dialOptions []grpc.DialOption
+	conn        *grpc.ClientConn
+
+	retry       retry.Config
+	maxInFlight int
+	retryBudget RetryBudget
+}
+
+func newConfig(options []Option) config {
+	c := config{
+		endpoint: defaultEndpoint,
+		retry:    retry.DefaultConfig,
+	}
+	for _, opt := range options {
+		c = opt.applyOption(c)
+	}
+	return c
+}
+
+// WithEndpoint sets the target endpoint the Client connects to. This
+// endpoint is specified as a host and optional port, no path or scheme should
+// be included (see WithInsecure for the scheme).
+//
+// By default, if this option is not passed, "localhost:4317" is used.
+//
+// This option has no effect if WithGRPCConn is used.
+func WithEndpoint(endpoint string) Option {
+	return fnOpt(func(c config) config {
+		c.endpoint = endpoint
+		return c
+	})
+}
+
+// WithInsecure disables client transport security for the Client's gRPC
+// connection, just like grpc.WithInsecure()
+// (https://pkg.go.dev/google.golang.org/grpc#WithInsecure) does.
+//
+// By default, if this option is not passed, client security will be used.
+//
+// This option has no effect if WithGRPCConn is used.
+func WithInsecure() Option {
+	return fnOpt(func(c config) config {
+		c.insecure = true
+		return c
+	})
+}
+
+// WithTLSCredentials sets the gRPC connection to use creds.
+//
+// If both this option and WithInsecure are used, this option takes
+// precedence.
+//
+// This option has no effect if WithGRPCConn is used.
+func WithTLSCredentials(creds credentials.TransportCredentials) Option {
+	return fnOpt(func(c config) config {
+		c.credentials = creds
+		return c
+	})
+}
+
+// WithDialOption sets explicit grpc.DialOptions to use when establishing a
+// gRPC connection. The options here are appended to the internal grpc.DialOptions
+// used so they will take precedence over any other internal grpc.DialOptions
+// they might conflict with.
+//
+// This option has no effect if WithGRPCConn is used.
+func WithDialOption(opts ...grpc.DialOption) Option {
+	return fnOpt(func(c config) config {
+		c.dialOptions = opts
+		return c
+	})
+}
+
+// WithGRPCConn sets conn as the gRPC ClientConn used for all communication.
+//
+// This option takes precedence over any other option that relates to
+// establishing a gRPC connection to a target endpoint. Any other option of
+// those types passed will be ignored.
+//
+// It is the callers responsibility to close the passed conn. The Client
+// Close method will not close this conn.
+func WithGRPCConn(conn *grpc.ClientConn) Option {
+	return fnOpt(func(c config) config {
+		c.conn = conn
+		return c
+	})
+}
+
+// RetryConfig defines configuration for retrying the export requests that
+// failed.
+type RetryConfig retry.Config
+
+// WithRetry sets the retry policy of the export requests of all the
+// exporters built from the Client.
+//
+// This configuration does not define any network retry policy. That is
+// entirely handled by the gRPC ClientConn.
+//
+// If unset, the default retry policy will be used. It will retry the export
+// 5 seconds after receiving a retryable error and increase exponentially
+// after each error for no more than a total time of 1 minute.
+func WithRetry(rc RetryConfig) Option {
+	return fnOpt(func(c config) config {
+		c.retry = retry.Config(rc)
+		return c
+	})
+}
+
+// WithMaxInFlight sets the maximum number of export requests the exporters
+// built from the Client send concurrently. An export request that would
+// exceed this number waits for another one to complete.
+//
+// By default, if this option is not passed or n is not greater than zero,
+// the number of concurrent export requests is not limited.
+func WithMaxInFlight(n int) Option {
+	return fnOpt(func(c config) config {
+		c.maxInFlight = n
+		return c
+	})
+}
+
+// WithRetryBudget sets the budget limiting the retries of all the export
+// requests of the exporters built from the Client.
+//
+// By default, if this option is not passed or the MaxTokens of b is not
+// greater than zero, retries are only limited by the retry policy.
+func WithRetryBudget(b RetryBudget) Option {
+	return fnOpt(func(c config) config {
+		c.retryBudget = b
+		return c
+	})
+}
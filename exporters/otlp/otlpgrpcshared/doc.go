@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlpgrpcshared provides an OTLP gRPC client shared by the
+otlptracegrpc, otlpmetricgrpc and otlploggrpc exporters.
+
+The exporters built from the same [Client] send their export requests over a
+single [grpc.ClientConn] and retry the failed ones with a single retry
+policy, instead of each dialing its own connection and running its own retry
+loop. The number of concurrent export requests of all these exporters can be
+limited with [WithMaxInFlight], and their retries with [WithRetryBudget], so
+an unavailable endpoint is not flooded by the reconnects and retries of every
+exporter.
+
+The Client should be created using [New] and passed to the exporters with
+their WithSharedClient option. It is closed with [Client.Close] once all the
+exporters built from it are shut down.
+*/
+package otlpgrpcshared // import "go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared"
@@ -0,0 +1,446 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/retry/retry_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		ctx      context.Context
+		delay    time.Duration
+		expected error
+	}{
+		{
+			ctx:   context.Background(),
+			delay: time.Duration(0),
+		},
+		{
+			ctx:   context.Background(),
+			delay: time.Duration(1),
+		},
+		{
+			ctx:   context.Background(),
+			delay: time.Duration(-1),
+		},
+		{
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			// Ensure the timer and context do not end simultaneously.
+			delay:    1 * time.Hour,
+			expected: context.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		err := wait(test.ctx, test.delay)
+		if test.expected == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, test.expected)
+		}
+	}
+}
+
+func TestNonRetryableError(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return false, 0 }
+
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: 1 * time.Nanosecond,
+		MaxInterval:     1 * time.Nanosecond,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+	ctx := context.Background()
+	assert.NoError(t, reqFunc(ctx, func(context.Context) error {
+		return nil
+	}))
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}), assert.AnError)
+}
+
+func TestThrottledRetry(t *testing.T) {
+	// Ensure the throttle delay is used by making longer than backoff delay.
+	throttleDelay, backoffDelay := time.Second, time.Nanosecond
+
+	ev := func(error) (bool, time.Duration) {
+		// Retry everything with a throttle delay.
+		return true, throttleDelay
+	}
+
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: backoffDelay,
+		MaxInterval:     backoffDelay,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+
+	origWait := waitFunc
+	var done bool
+	waitFunc = func(_ context.Context, delay time.Duration) error {
+		assert.Equal(t, throttleDelay, delay, "retry not throttled")
+		// Try twice to ensure call is attempted again after delay.
+		if done {
+			return assert.AnError
+		}
+		done = true
+		return nil
+	}
+	defer func() { waitFunc = origWait }()
+
+	ctx := context.Background()
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return errors.New("not this error")
+	}), assert.AnError)
+}
+
+func TestBackoffRetry(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+
+	delay := time.Nanosecond
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: delay,
+		MaxInterval:     delay,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+
+	origWait := waitFunc
+	var done bool
+	waitFunc = func(_ context.Context, d time.Duration) error {
+		delta := math.Ceil(float64(delay) * backoff.DefaultRandomizationFactor)
+		assert.InDelta(t, delay, d, delta, "retry not backoffed")
+		// Try twice to ensure call is attempted again after delay.
+		if done {
+			return assert.AnError
+		}
+		done = true
+		return nil
+	}
+	t.Cleanup(func() { waitFunc = origWait })
+
+	ctx := context.Background()
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return errors.New("not this error")
+	}), assert.AnError)
+}
+
+func TestBackoffRetryCanceledContext(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+
+	delay := time.Millisecond
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: delay,
+		MaxInterval:     delay,
+		// Never stop retrying.
+		MaxElapsedTime: 10 * time.Millisecond,
+	}.RequestFunc(ev)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count := 0
+	cancel()
+	err := reqFunc(ctx, func(context.Context) error {
+		count++
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), assert.AnError.Error())
+	assert.Equal(t, 1, count)
+}
+
+func TestThrottledRetryGreaterThanMaxElapsedTime(t *testing.T) {
+	// Ensure the throttle delay is used by making longer than backoff delay.
+	tDelay, bDelay := time.Hour, time.Nanosecond
+	ev := func(error) (bool, time.Duration) { return true, tDelay }
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: bDelay,
+		MaxInterval:     bDelay,
+		MaxElapsedTime:  tDelay - (time.Nanosecond),
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	assert.Contains(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}).Error(), "max retry time would elapse: ")
+}
+
+func TestMaxElapsedTime(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	delay := time.Nanosecond
+	reqFunc := Config{
+		Enabled: true,
+		// InitialInterval > MaxElapsedTime means immediate return.
+		InitialInterval: 2 * delay,
+		MaxElapsedTime:  delay,
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	assert.Contains(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}).Error(), "max retry time elapsed: ")
+}
+
+func TestRetryNotEnabled(t *testing.T) {
+	ev := func(error) (bool, time.Duration) {
+		t.Error("evaluated retry when not enabled")
+		return false, 0
+	}
+
+	reqFunc := Config{}.RequestFunc(ev)
+	ctx := context.Background()
+	assert.NoError(t, reqFunc(ctx, func(context.Context) error {
+		return nil
+	}))
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}), assert.AnError)
+}
+
+func TestRetryConcurrentSafe(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Config{
+		Enabled: true,
+	}.RequestFunc(ev)
+
+	var wg sync.WaitGroup
+	ctx := context.Background()
+
+	for i := 1; i < 5; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var done bool
+			assert.NoError(t, reqFunc(ctx, func(context.Context) error {
+				if !done {
+					done = true
+					return assert.AnError
+				}
+
+				return nil
+			}))
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestJitter(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+
+	var delays []time.Duration
+	origWait := waitFunc
+	waitFunc = func(_ context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+	t.Cleanup(func() { waitFunc = origWait })
+
+	run := func(j Jitter) {
+		delays = nil
+		reqFunc := Config{
+			Enabled:         true,
+			InitialInterval: time.Second,
+			MaxInterval:     time.Second,
+			Jitter:          j,
+		}.RequestFunc(ev)
+
+		var calls int
+		_ = reqFunc(context.Background(), func(context.Context) error {
+			calls++
+			if calls > 20 {
+				return nil
+			}
+			return assert.AnError
+		})
+	}
+
+	run(NoJitter)
+	for _, d := range delays {
+		assert.Equal(t, time.Second, d)
+	}
+
+	run(ProportionalJitter)
+	for _, d := range delays {
+		assert.GreaterOrEqual(t, d, time.Second/2)
+		assert.LessOrEqual(t, d, 3*time.Second/2)
+	}
+
+	run(FullJitter)
+	for _, d := range delays {
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, time.Second)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	errRetry := errors.New("retry")
+	errDrop := errors.New("drop")
+	ev := func(err error) (bool, time.Duration) {
+		return errors.Is(err, assert.AnError), time.Nanosecond
+	}
+
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		Classify: func(err error) Classification {
+			switch {
+			case errors.Is(err, errRetry):
+				return Retryable
+			case errors.Is(err, errDrop):
+				return NonRetryable
+			}
+			return DefaultClassification
+		},
+	}.RequestFunc(ev)
+
+	request := func(errs ...error) (int, error) {
+		var calls int
+		err := reqFunc(context.Background(), func(context.Context) error {
+			calls++
+			if calls > len(errs) {
+				return nil
+			}
+			return errs[calls-1]
+		})
+		return calls, err
+	}
+
+	calls, err := request(errRetry, errRetry)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls, "Retryable errors must be retried")
+
+	calls, err = request(errDrop)
+	assert.ErrorIs(t, err, errDrop)
+	assert.Equal(t, 1, calls, "NonRetryable errors must not be retried")
+
+	calls, err = request(assert.AnError)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls, "default classification must retry")
+
+	calls, err = request(errors.New("other"))
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "default classification must not retry")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	current := time.Now()
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	errPermanent := errors.New("permanent")
+	ev := func(err error) (bool, time.Duration) {
+		return errors.Is(err, assert.AnError), 0
+	}
+	reqFunc := Config{
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenDuration:     time.Minute,
+		},
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	var calls int
+	request := func(err error) error {
+		return reqFunc(ctx, func(context.Context) error {
+			calls++
+			return err
+		})
+	}
+
+	// Non-retryable errors do not open the circuit.
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, request(errPermanent), errPermanent)
+	}
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+	assert.NoError(t, request(nil), "success must reset the failures")
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+
+	calls = 0
+	assert.ErrorIs(t, request(nil), ErrCircuitOpen)
+	assert.Equal(t, 0, calls, "open circuit must not send requests")
+
+	// Half-open: the failed probe opens the circuit again.
+	current = current.Add(time.Minute)
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, request(nil), ErrCircuitOpen)
+	assert.Equal(t, 1, calls)
+
+	// Half-open: the successful probe closes the circuit.
+	current = current.Add(time.Minute)
+	assert.NoError(t, request(nil))
+	assert.NoError(t, request(nil))
+	assert.Equal(t, 3, calls)
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	assert.Equal(t, DefaultOpenDuration, b.openDuration)
+
+	current := time.Now()
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	require.True(t, b.allow())
+	b.done(true)
+	assert.False(t, b.allow())
+
+	current = current.Add(DefaultOpenDuration)
+	assert.True(t, b.allow(), "probe")
+	assert.False(t, b.allow(), "concurrent request while probing")
+	b.done(false)
+	assert.True(t, b.allow())
+}
+
+func TestCircuitBreakerRetry(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		CircuitBreaker:  CircuitBreakerConfig{FailureThreshold: 3},
+	}.RequestFunc(ev)
+
+	var calls int
+	err := reqFunc(context.Background(), func(context.Context) error {
+		calls++
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 3, calls, "retries must stop once the circuit opens")
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	assert.Nil(t, newCircuitBreaker(CircuitBreakerConfig{}))
+}
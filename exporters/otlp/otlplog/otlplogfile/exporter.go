@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlplogfile // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/filewriter"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/otlpjson"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/transform"
+	"go.opentelemetry.io/otel/sdk/log"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+)
+
+// Exporter is a OpenTelemetry log Exporter. It writes log records as
+// OTLP/JSON lines.
+// Exporter must be created with [New].
+type Exporter struct {
+	path string
+
+	// mu protects the writer and closer. They are unset on Shutdown.
+	mu     sync.Mutex
+	writer *filewriter.Writer
+	closer io.Closer
+}
+
+// Compile-time check Exporter implements [log.Exporter].
+var _ log.Exporter = (*Exporter)(nil)
+
+// New returns a new [Exporter].
+//
+// If WithFile is used, the file is opened and an error is returned if it
+// cannot be.
+//
+// It is recommended to use it with a [BatchProcessor]
+// or other processor exporting records asynchronously.
+func New(options ...Option) (*Exporter, error) {
+	cfg := newConfig(options)
+	w, closer, err := cfg.open()
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{path: cfg.path, writer: w, closer: closer}, nil
+}
+
+// Export transforms log records and writes them as a single OTLP/JSON line.
+func (e *Exporter) Export(ctx context.Context, records []log.Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	otlp := transform.ResourceLogs(records)
+	if otlp == nil {
+		return nil
+	}
+	b, err := otlpjson.Marshal(&collogpb.ExportLogsServiceRequest{
+		ResourceLogs: otlp,
+	})
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.WriteLine(b)
+}
+
+// Shutdown syncs the written log records and closes the file opened by the
+// Exporter. Writers set with WithWriter are not closed. Calls to Export or
+// ForceFlush will perform no operation after this is called.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.writer == nil {
+		return nil
+	}
+	err := e.writer.Sync()
+	if e.closer != nil {
+		err = errors.Join(err, e.closer.Close())
+	}
+	e.writer, e.closer = nil, nil
+	return errors.Join(err, ctx.Err())
+}
+
+// ForceFlush commits the written log records to stable storage if the
+// underlying file or writer supports it.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.Sync()
+}
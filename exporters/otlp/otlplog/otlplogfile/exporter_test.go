@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlplogfile
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/otlpjson"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+)
+
+// emit emits a log record for each of the bodies with a LoggerProvider using
+// exp.
+func emit(t *testing.T, exp *Exporter, bodies ...string) {
+	t.Helper()
+
+	ctx := context.Background()
+	res := resource.NewSchemaless(attribute.String("service.name", "test"))
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)),
+	)
+	logger := provider.Logger("logger")
+	for _, body := range bodies {
+		var r log.Record
+		r.SetBody(log.StringValue(body))
+		r.SetSeverity(log.SeverityInfo)
+		logger.Emit(ctx, r)
+	}
+	require.NoError(t, provider.Shutdown(ctx))
+}
+
+// requests decodes the OTLP/JSON lines of s.
+func requests(t *testing.T, s string) []*collogpb.ExportLogsServiceRequest {
+	t.Helper()
+
+	var out []*collogpb.ExportLogsServiceRequest
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		req := &collogpb.ExportLogsServiceRequest{}
+		require.NoError(t, otlpjson.Unmarshal([]byte(line), req))
+		out = append(out, req)
+	}
+	return out
+}
+
+func TestExporterWriter(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := New(WithWriter(&buf))
+	require.NoError(t, err)
+	emit(t, exp, "first", "second")
+
+	got := requests(t, buf.String())
+	require.Len(t, got, 2)
+	for i, want := range []string{"first", "second"} {
+		require.Len(t, got[i].ResourceLogs, 1)
+		rl := got[i].ResourceLogs[0]
+		assert.Equal(t, "service.name", rl.Resource.Attributes[0].Key)
+		require.Len(t, rl.ScopeLogs, 1)
+		require.Len(t, rl.ScopeLogs[0].LogRecords, 1)
+		assert.Equal(t, want, rl.ScopeLogs[0].LogRecords[0].Body.GetStringValue())
+	}
+}
+
+func TestExporterFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+	exp, err := New(WithFile(path), WithRotation(1, 1))
+	require.NoError(t, err)
+	emit(t, exp, "first", "second")
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "second")
+
+	b, err = os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "first")
+}
+
+func TestExporterFileError(t *testing.T) {
+	_, err := New(WithFile(filepath.Join(t.TempDir(), "missing", "logs.jsonl")))
+	assert.ErrorContains(t, err, "failed to open file")
+}
+
+func TestExporterShutdown(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := New(WithWriter(&buf))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Shutdown(ctx))
+	// Calls after Shutdown perform no operation.
+	assert.NoError(t, exp.Shutdown(ctx))
+	assert.NoError(t, exp.ForceFlush(ctx))
+	assert.NoError(t, exp.Export(ctx, make([]sdklog.Record, 1)))
+	assert.Empty(t, buf.String())
+}
+
+func TestExporterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := New(WithWriter(&buf))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Export(context.Background(), nil))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,143 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/filewriter/file.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filewriter // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/filewriter"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var errClosed = errors.New("file is closed")
+
+// File is an append-only file that is rotated once it reaches a maximum size.
+// It is safe for concurrent use.
+type File struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// OpenFile opens the file at path for appending, creating it if it does not
+// exist.
+//
+// If maxSize is greater than zero, the file is rotated before a write would
+// make it larger than maxSize bytes: the file is renamed to path.1, the
+// previous path.1 to path.2, and so on, and a new file is created at path. At
+// most maxBackups rotated files are kept, older ones are removed.
+func OpenFile(path string, maxSize int64, maxBackups int) (*File, error) {
+	f := &File{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to stat file: %w", err), file.Close())
+	}
+	f.f, f.size = file, info.Size()
+	return nil
+}
+
+// Write appends p to the file. The file is rotated before p is written if
+// writing p would make the file exceed its maximum size. The content of p is
+// never split across files.
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.f == nil {
+		return 0, errClosed
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.f.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, shifts the backups, and opens a new file.
+//
+// If the file cannot be rotated, the file at path is reopened so the
+// following writes are still appended to it.
+func (f *File) rotate() error {
+	err := f.f.Close()
+	f.f = nil
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to close file: %w", err), f.open())
+	}
+	if err := f.shift(); err != nil {
+		return errors.Join(err, f.open())
+	}
+	return f.open()
+}
+
+// shift renames the file at path to path.1, the previous path.1 to path.2,
+// and so on, or removes it if no backup is kept.
+func (f *File) shift() error {
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil {
+			return fmt.Errorf("failed to remove file: %w", err)
+		}
+		return nil
+	}
+
+	for i := f.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(f.backup(i), f.backup(i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate file: %w", err)
+		}
+	}
+	if err := os.Rename(f.path, f.backup(1)); err != nil {
+		return fmt.Errorf("failed to rotate file: %w", err)
+	}
+	return nil
+}
+
+// backup returns the path of the n-th most recent rotated file.
+func (f *File) backup(n int) string {
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
+
+// Sync commits the content of the file to stable storage.
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.f == nil {
+		return errClosed
+	}
+	return f.f.Sync()
+}
+
+// Close closes the file. Calls to Write after Close return an error.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.f == nil {
+		return errClosed
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
@@ -0,0 +1,107 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/filewriter/writer_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filewriter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingWriter counts the calls to Write.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+	syncs  int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func (w *countingWriter) Sync() error {
+	w.syncs++
+	return nil
+}
+
+func TestWriterWriteLine(t *testing.T) {
+	var buf countingWriter
+	w := NewWriter(&buf)
+
+	require.NoError(t, w.WriteLine([]byte(`{"a":1}`)))
+	require.NoError(t, w.WriteLine([]byte(`{"b":2}`)))
+	assert.Equal(t, "{\"a\":1}\n{\"b\":2}\n", buf.String())
+	assert.Equal(t, 2, buf.writes, "lines not written with a single write")
+
+	require.NoError(t, w.Sync())
+	assert.Equal(t, 1, buf.syncs)
+}
+
+func TestWriterConcurrentSafe(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	const goroutines, lines = 10, 100
+	line := strings.Repeat("x", 64)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < lines; j++ {
+				assert.NoError(t, w.WriteLine([]byte(line)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, got, goroutines*lines)
+	for _, l := range got {
+		assert.Equal(t, line, l)
+	}
+}
+
+type errWriter struct{}
+
+var errWrite = errors.New("write error")
+
+func (errWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+func TestWriterError(t *testing.T) {
+	w := NewWriter(errWriter{})
+	assert.ErrorIs(t, w.WriteLine([]byte("line")), errWrite)
+	// Writers without Sync are not synced.
+	assert.NoError(t, w.Sync())
+}
+
+func TestWriterSyncPipe(t *testing.T) {
+	r, pw, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = r.Close()
+		_ = pw.Close()
+	})
+
+	w := NewWriter(pw)
+	require.NoError(t, w.WriteLine([]byte("line")))
+	// Pipes cannot be synced, like os.Stdout when redirected.
+	assert.NoError(t, w.Sync())
+
+	require.NoError(t, pw.Close())
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "line\n", string(got))
+}
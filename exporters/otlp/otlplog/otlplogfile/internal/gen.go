@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal"
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/file.go.tmpl "--data={}" --out=filewriter/file.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/file_test.go.tmpl "--data={}" --out=filewriter/file_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/writer.go.tmpl "--data={}" --out=filewriter/writer.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/writer_test.go.tmpl "--data={}" --out=filewriter/writer_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpjson/otlpjson.go.tmpl "--data={}" --out=otlpjson/otlpjson.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpjson/otlpjson_test.go.tmpl "--data={}" --out=otlpjson/otlpjson_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/attr_test.go.tmpl "--data={}" --out=transform/attr_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log.go.tmpl "--data={}" --out=transform/log.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log_attr_test.go.tmpl "--data={}" --out=transform/log_attr_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log_test.go.tmpl "--data={}" --out=transform/log_test.go
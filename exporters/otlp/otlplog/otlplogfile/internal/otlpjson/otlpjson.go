@@ -0,0 +1,130 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/otlpjson/otlpjson.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlpjson provides the OTLP/JSON encoding of OTLP messages.
+//
+// OTLP/JSON is the Protobuf JSON mapping of OTLP messages with the following
+// deviations required by the OTLP specification:
+//
+//   - Trace and span IDs are hex-encoded instead of base64-encoded.
+//   - Enum values are encoded as integers instead of names.
+//
+// See https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+package otlpjson // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/otlpjson"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentType is the Content-Type of OTLP/JSON payloads.
+const ContentType = "application/json"
+
+// idKeys are the JSON keys of the OTLP fields holding trace or span IDs.
+var idKeys = map[string]struct{}{
+	"traceId":      {},
+	"spanId":       {},
+	"parentSpanId": {},
+}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseEnumNumbers: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Marshal returns the OTLP/JSON encoding of m.
+func Marshal(m proto.Message) ([]byte, error) {
+	b, err := marshalOptions.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return convertIDs(b, base64ToHex)
+}
+
+// Unmarshal parses the OTLP/JSON encoded data and stores the result in m.
+// Fields unknown to m are ignored.
+func Unmarshal(b []byte, m proto.Message) error {
+	b, err := convertIDs(b, hexToBase64)
+	if err != nil {
+		return err
+	}
+	return unmarshalOptions.Unmarshal(b, m)
+}
+
+// convertIDs returns the JSON b with the values of all trace and span IDs
+// converted with fn.
+func convertIDs(b []byte, fn func(string) (string, error)) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// Keep the exact representation of numbers.
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := walkIDs(v, fn); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	// Drop the newline added by the Encoder.
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// walkIDs converts the trace and span IDs of v in place using fn.
+func walkIDs(v interface{}, fn func(string) (string, error)) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if s, ok := val.(string); ok {
+				if _, isID := idKeys[key]; isID {
+					id, err := fn(s)
+					if err != nil {
+						return fmt.Errorf("invalid %s %q: %w", key, s, err)
+					}
+					v[key] = id
+				}
+				continue
+			}
+			if err := walkIDs(val, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			if err := walkIDs(val, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func base64ToHex(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hexToBase64(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
@@ -5,6 +5,7 @@ package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/o
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,9 +18,15 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/split"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/zstd"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
 )
@@ -30,6 +37,18 @@ type client struct {
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
 
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize int
+
+	partialSuccess *internal.PartialSuccessHandler
+
+	// callOpts are the options of every export call.
+	callOpts []grpc.CallOption
+
+	// queue is the disk queue of the export requests that failed with
+	// transient errors. It is nil if no disk queue is configured.
+	queue *diskqueue.Queue
+
 	// ourConn keeps track of where conn was created: true if created here in
 	// NewClient, or false if passed with an option. This is important on
 	// Shutdown as conn should only be closed if we created it. Otherwise,
@@ -45,15 +64,43 @@ var newGRPCClientFn = grpc.NewClient
 // newClient creates a new gRPC log client.
 func newClient(cfg config) (*client, error) {
 	c := &client{
-		exportTimeout: cfg.timeout.Value,
-		requestFunc:   cfg.retryCfg.Value.RequestFunc(retryable),
-		conn:          cfg.gRPCConn.Value,
+		exportTimeout:  cfg.timeout.Value,
+		requestFunc:    cfg.retryCfg.Value.RequestFunc(retryable),
+		maxRequestSize: cfg.maxRequestSize.Value,
+		partialSuccess: internal.NewLogPartialSuccessHandler(
+			otel.GetMeterProvider(),
+			"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc",
+			Version(),
+			cfg.partialSuccessHandler.Value,
+		),
+		conn: cfg.gRPCConn.Value,
+	}
+
+	if s := cfg.sharedClient.Value; s != nil {
+		c.conn = s.Conn()
+		c.requestFunc = s.Do
 	}
 
 	if len(cfg.headers.Value) > 0 {
 		c.metadata = metadata.New(cfg.headers.Value)
 	}
 
+	if a := cfg.authenticator.Value; a != nil {
+		// The transport security of a connection passed with an option is
+		// decided by the creator of the connection.
+		secure := !cfg.insecure.Value && c.conn == nil
+		creds := auth.PerRPCCredentials(a, secure)
+		c.callOpts = append(c.callOpts, grpc.PerRPCCredentials(creds))
+	}
+
+	if cfg.diskQueue.Value.Dir != "" {
+		q, err := diskqueue.New(cfg.diskQueue.Value, c.replay)
+		if err != nil {
+			return nil, err
+		}
+		c.queue = q
+	}
+
 	if c.conn == nil {
 		// If the caller did not provide a ClientConn when the client was
 		// created, create one using the configuration they did provide.
@@ -61,6 +108,7 @@ func newClient(cfg config) (*client, error) {
 
 		conn, err := newGRPCClientFn(cfg.endpoint.Value, dialOpts...)
 		if err != nil {
+			c.queue.Close()
 			return nil, err
 		}
 		// Keep track that we own the lifecycle of this conn and need to close
@@ -71,6 +119,9 @@ func newClient(cfg config) (*client, error) {
 
 	c.lsc = collogpb.NewLogsServiceClient(c.conn)
 
+	// Send the requests queued before a restart.
+	c.queue.Flush()
+
 	return c, nil
 }
 
@@ -96,8 +147,11 @@ func newGRPCDialOptions(cfg config) []grpc.DialOption {
 		))
 	}
 	// Compression
-	if cfg.compression.Value == GzipCompression {
+	switch cfg.compression.Value {
+	case GzipCompression:
 		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(zstd.Name)))
 	}
 	// Reconnection period
 	if cfg.reconnectionPeriod.Value != 0 {
@@ -120,6 +174,26 @@ func newGRPCDialOptions(cfg config) []grpc.DialOption {
 // ensures this is not called after the Exporter is shutdown. Only thing
 // to do here is send data.
 func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error {
+	batches, err := split.Logs(rl, c.maxRequestSize, nil)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	var errs []error
+	for _, batch := range batches {
+		if err := c.uploadLogs(ctx, batch, c.requestFunc); err != nil {
+			c.enqueue(batch, err)
+			errs = append(errs, err)
+			continue
+		}
+		// The endpoint is available, send the queued requests.
+		c.queue.Flush()
+	}
+	return errors.Join(errs...)
+}
+
+// uploadLogs sends rl in a single export request, using requestFunc.
+func (c *client) uploadLogs(ctx context.Context, rl []*logpb.ResourceLogs, requestFunc retry.RequestFunc) error {
 	select {
 	case <-ctx.Done():
 		// Do not upload if the context is already expired.
@@ -130,17 +204,14 @@ func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(ctx context.Context) error {
+	return requestFunc(ctx, func(ctx context.Context) error {
 		resp, err := c.lsc.Export(ctx, &collogpb.ExportLogsServiceRequest{
 			ResourceLogs: rl,
-		})
+		}, c.callOpts...)
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedLogRecords()
-			if n != 0 || msg != "" {
-				err := fmt.Errorf("OTLP partial success: %s (%d log records rejected)", msg, n)
-				otel.Handle(err)
-			}
+			c.partialSuccess.Handle(ctx, n, msg)
 		}
 		// nil is converted to OK.
 		if status.Code(err) == codes.OK {
@@ -151,6 +222,46 @@ func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error
 	})
 }
 
+// enqueue adds the export request of rl, which failed with err, to the disk
+// queue if the error is transient.
+func (c *client) enqueue(rl []*logpb.ResourceLogs, err error) {
+	if c.queue == nil || !transient(err) {
+		return
+	}
+
+	data, err := proto.Marshal(&collogpb.ExportLogsServiceRequest{
+		ResourceLogs: rl,
+	})
+	if err == nil {
+		err = c.queue.Push(data)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// replay sends the export request data read from the disk queue in a single
+// attempt. The request is dropped if it fails with a permanent error.
+func (c *client) replay(ctx context.Context, data []byte) error {
+	var req collogpb.ExportLogsServiceRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		otel.Handle(fmt.Errorf("dropping invalid queued export request: %w", err))
+		return nil
+	}
+
+	err := c.uploadLogs(ctx, req.ResourceLogs, once)
+	if err != nil && !transient(err) {
+		otel.Handle(err)
+		return nil
+	}
+	return err
+}
+
+// once sends a request in a single attempt.
+func once(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
 // Shutdown shuts down the client, freeing all resources.
 //
 // Any active connections to a remote endpoint are closed if they were created
@@ -162,6 +273,9 @@ func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error
 // ensures this is called only once. The only thing that needs to be done
 // here is to release any computational resources the client holds.
 func (c *client) Shutdown(ctx context.Context) error {
+	// Stop sending the queued requests, they are kept for the next start.
+	c.queue.Close()
+
 	c.metadata = nil
 	c.requestFunc = nil
 	c.lsc = nil
@@ -226,6 +340,18 @@ func retryable(err error) (bool, time.Duration) {
 	return retryableGRPCStatus(s)
 }
 
+// transient returns if err, returned by an export request, may not be
+// returned when sending the request again later: the request failed with a
+// retry-able status, or was not sent.
+func transient(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	ok, _ = retryableGRPCStatus(s)
+	return ok
+}
+
 func retryableGRPCStatus(s *status.Status) (bool, time.Duration) {
 	switch s.Code() {
 	case codes.Canceled,
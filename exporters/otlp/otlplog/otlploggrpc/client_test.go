@@ -5,8 +5,10 @@ package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/o
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared"
 	"go.opentelemetry.io/otel/sdk/log"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
@@ -276,6 +279,10 @@ func TestNewClient(t *testing.T) {
 	userConn, err := grpc.NewClient("test 2", grpc.WithTransportCredentials(insecure.NewCredentials()))
 	require.NoError(t, err)
 
+	// The shared client of several exporters.
+	shared, err := otlpgrpcshared.New(otlpgrpcshared.WithGRPCConn(userConn))
+	require.NoError(t, err)
+
 	testCases := []struct {
 		name string
 		cfg  config
@@ -314,6 +321,17 @@ func TestNewClient(t *testing.T) {
 				lsc:     collogpb.NewLogsServiceClient(userConn),
 			},
 		},
+		{
+			name: "with shared client",
+			cfg: config{
+				sharedClient: newSetting[SharedClient](shared),
+			},
+			cli: &client{
+				ourConn: false,
+				conn:    userConn,
+				lsc:     collogpb.NewLogsServiceClient(userConn),
+			},
+		},
 		{
 			// It is not possible to compare grpc dial options directly, so we just check that the client is created
 			// and no panic occurs.
@@ -561,6 +579,44 @@ func TestClient(t *testing.T) {
 		want := fmt.Sprintf("%s (%d log records rejected)", msg, n)
 		assert.ErrorContains(t, errs[0], want)
 	})
+
+	t.Run("PartialSuccessHandler", func(t *testing.T) {
+		rCh := make(chan exportResult, 1)
+		rCh <- exportResult{
+			Response: &collogpb.ExportLogsServiceResponse{
+				PartialSuccess: &collogpb.ExportLogsPartialSuccess{
+					RejectedLogRecords: 3,
+					ErrorMessage:       "bad data",
+				},
+			},
+		}
+
+		coll, err := newGRPCCollector("", rCh)
+		require.NoError(t, err)
+		t.Cleanup(coll.srv.Stop)
+
+		var got []PartialSuccessError
+		cfg := newConfig([]Option{
+			WithEndpoint(coll.listener.Addr().String()),
+			WithInsecure(),
+			WithPartialSuccessHandler(func(err PartialSuccessError) {
+				got = append(got, err)
+			}),
+		})
+		client, err := newClient(cfg)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+		require.NoError(t, client.Shutdown(ctx))
+
+		want := []PartialSuccessError{{
+			ErrorMessage:  "bad data",
+			RejectedItems: 3,
+			RejectedKind:  "log records",
+		}}
+		assert.Equal(t, want, got)
+	})
 }
 
 func TestConfig(t *testing.T) {
@@ -597,4 +653,116 @@ func TestConfig(t *testing.T) {
 		require.Contains(t, got, additionalKey)
 		assert.Equal(t, []string{headers[key]}, got[key])
 	})
+
+	t.Run("WithAuthenticator", func(t *testing.T) {
+		a := AuthenticatorFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"Authorization": "Bearer token"}, nil
+		})
+		exp, coll := factoryFunc(nil, WithAuthenticator(a))
+		t.Cleanup(coll.srv.Stop)
+
+		ctx := context.Background()
+		require.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		// Ensure everything is flushed.
+		require.NoError(t, exp.Shutdown(ctx))
+
+		coll.headersMu.Lock()
+		defer coll.headersMu.Unlock()
+		assert.Equal(t, []string{"Bearer token"}, coll.headers.Get("authorization"))
+	})
+
+	t.Run("WithAuthenticatorGRPCConn", func(t *testing.T) {
+		coll, err := newGRPCCollector("", nil)
+		require.NoError(t, err)
+		t.Cleanup(coll.srv.Stop)
+
+		conn, err := grpc.NewClient(coll.listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+		// The insecure connection is used without WithInsecure.
+		a := AuthenticatorFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"Authorization": "Bearer token"}, nil
+		})
+		ctx := context.Background()
+		exp, err := New(ctx, WithGRPCConn(conn), WithAuthenticator(a))
+		require.NoError(t, err)
+		require.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		coll.headersMu.Lock()
+		defer coll.headersMu.Unlock()
+		assert.Equal(t, []string{"Bearer token"}, coll.headers.Get("authorization"))
+	})
+
+	t.Run("WithAuthenticatorError", func(t *testing.T) {
+		a := AuthenticatorFunc(func(context.Context) (map[string]string, error) {
+			return nil, errors.New("token unavailable")
+		})
+		exp, coll := factoryFunc(nil, WithAuthenticator(a))
+		t.Cleanup(coll.srv.Stop)
+
+		ctx := context.Background()
+		err := exp.Export(ctx, make([]log.Record, 1))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.ErrorContains(t, err, "token unavailable")
+		require.NoError(t, exp.Shutdown(ctx))
+	})
+
+	t.Run("WithMaxRequestSize", func(t *testing.T) {
+		const maxSize = 200
+		exp, coll := factoryFunc(nil, WithMaxRequestSize(maxSize))
+		t.Cleanup(coll.srv.Stop)
+
+		ctx := context.Background()
+		require.NoError(t, exp.Export(ctx, make([]log.Record, 100)))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		got := coll.Collect().Dump()
+		assert.Greater(t, len(got), 1, "request not split")
+		var n int
+		for _, rl := range got {
+			req := &collogpb.ExportLogsServiceRequest{
+				ResourceLogs: []*lpb.ResourceLogs{rl},
+			}
+			assert.LessOrEqual(t, proto.Size(req), maxSize)
+			for _, sl := range rl.ScopeLogs {
+				n += len(sl.LogRecords)
+			}
+		}
+		assert.Equal(t, 100, n)
+	})
+
+	t.Run("WithDiskQueue", func(t *testing.T) {
+		rCh := make(chan exportResult, 4)
+		rCh <- exportResult{Err: status.Error(codes.Unavailable, "unavailable")}
+		rCh <- exportResult{Err: status.Error(codes.InvalidArgument, "invalid")}
+		rCh <- exportResult{}
+		rCh <- exportResult{}
+		dir := t.TempDir()
+		exp, coll := factoryFunc(
+			rCh,
+			WithRetry(RetryConfig{Enabled: false}),
+			WithDiskQueue(DiskQueueConfig{Dir: dir}),
+		)
+		t.Cleanup(coll.srv.Stop)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		// The request failing with a transient error is queued, the one
+		// failing with a permanent error is dropped.
+		assert.Error(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Error(t, exp.Export(ctx, make([]log.Record, 1)))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		// The queued request is sent once an export succeeds.
+		assert.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Eventually(t, func() bool {
+			entries, err := os.ReadDir(dir)
+			return err == nil && len(entries) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Len(t, coll.Collect().Dump(), 4)
+	})
 }
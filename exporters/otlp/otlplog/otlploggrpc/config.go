@@ -4,8 +4,8 @@
 package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/url"
@@ -18,7 +18,11 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/tlsreload"
 	"go.opentelemetry.io/otel/internal/global"
 )
 
@@ -91,6 +95,14 @@ type config struct {
 	compression setting[Compression]
 	timeout     setting[time.Duration]
 	retryCfg    setting[retry.Config]
+	diskQueue   setting[diskqueue.Config]
+
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize setting[int]
+	// partialSuccessHandler handles partial success responses.
+	partialSuccessHandler setting[func(internal.PartialSuccess)]
+	// authenticator provides the authentication headers of requests.
+	authenticator setting[auth.Authenticator]
 
 	// gRPC configurations
 	gRPCCredentials    setting[credentials.TransportCredentials]
@@ -98,6 +110,7 @@ type config struct {
 	reconnectionPeriod setting[time.Duration]
 	dialOptions        setting[[]grpc.DialOption]
 	gRPCConn           setting[*grpc.ClientConn]
+	sharedClient       setting[SharedClient]
 }
 
 func newConfig(options []Option) config {
@@ -135,6 +148,66 @@ func newConfig(options []Option) config {
 	return c
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the export requests
+// sent by the Exporter. Log records that would encode to a larger request are
+// split into multiple requests, keeping log records grouped by their resource
+// and instrumentation scope. Log records larger than size on their own are
+// dropped and reported to the global error handler.
+//
+// The size is measured using the protobuf encoding of the request, before any
+// compression is applied. The gRPC server rejects messages larger than its
+// maximum receive message size, which is 4 MiB by default.
+//
+// If unset or not positive, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return fnOpt(func(c config) config {
+		c.maxRequestSize = newSetting(size)
+		return c
+	})
+}
+
+// WithPartialSuccessHandler sets the function called with the partial success
+// responses of the OTLP server. It is called synchronously during the export,
+// so it should not block.
+//
+// If unset, partial successes are passed to the global error handler.
+//
+// Regardless of this option, the number of rejected log records is counted with
+// the otel.sdk.exporter.log.rejected counter of the global MeterProvider.
+func WithPartialSuccessHandler(h func(PartialSuccessError)) Option {
+	return fnOpt(func(c config) config {
+		c.partialSuccessHandler = newSetting(h)
+		return c
+	})
+}
+
+// WithAuthenticator sets the Authenticator providing the authentication
+// headers of the export requests, for example an Authenticator returned by
+// NewOAuth2Authenticator or NewTokenFileAuthenticator.
+//
+// The headers are sent as the metadata of every gRPC call, including retries.
+// Over the connections created by the Exporter, they are only sent if the
+// connection is secure, unless the Exporter is configured with WithInsecure.
+// Over a connection passed with WithGRPCConn or WithSharedClient, they are
+// sent whatever the transport security of that connection is.
+//
+// An error of the Authenticator fails the export request, it is not retried.
+func WithAuthenticator(a Authenticator) Option {
+	return fnOpt(func(c config) config {
+		c.authenticator = newSetting(a)
+		return c
+	})
+}
+
+// PartialSuccessError is the error describing a partial success response of the
+// OTLP server, which accepted an export request but rejected log records of it
+// or returned a warning. RejectedItems is the number of rejected log records
+// and ErrorMessage the message of the server.
+//
+// It is passed to the function set with WithPartialSuccessHandler, or otherwise
+// to the global error handler. Use errors.As to identify it there.
+type PartialSuccessError = internal.PartialSuccess
+
 // RetryConfig defines configuration for retrying the export of log data
 // that failed.
 //
@@ -142,6 +215,10 @@ func newConfig(options []Option) config {
 // entirely handled by the gRPC ClientConn.
 type RetryConfig retry.Config
 
+// DiskQueueConfig configures the disk queue of the export requests that failed
+// with transient errors. See WithDiskQueue.
+type DiskQueueConfig diskqueue.Config
+
 // WithInsecure disables client transport security for the Exporter's gRPC
 // connection, just like grpc.WithInsecure()
 // (https://pkg.go.dev/google.golang.org/grpc#WithInsecure) does.
@@ -196,6 +273,9 @@ func WithEndpoint(endpoint string) Option {
 //
 // If an invalid URL is provided, the default value will be kept.
 //
+// A URL with the unix scheme, such as "unix:///var/run/otel.sock", connects
+// to the Unix domain socket at its path without transport security.
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "localhost:4317" will be used.
 //
@@ -207,7 +287,11 @@ func WithEndpointURL(rawURL string) Option {
 		return fnOpt(func(c config) config { return c })
 	}
 	return fnOpt(func(c config) config {
-		c.endpoint = newSetting(u.Host)
+		if u.Scheme == "unix" {
+			c.endpoint = newSetting(unixEndpoint(u))
+		} else {
+			c.endpoint = newSetting(u.Host)
+		}
 		c.insecure = insecureFromScheme(c.insecure, u.Scheme)
 		return c
 	})
@@ -232,15 +316,17 @@ const (
 	NoCompression Compression = iota
 	// GzipCompression represents that gzip compression should be used.
 	GzipCompression
+	// ZstdCompression represents that zstd compression should be used.
+	ZstdCompression
 )
 
 // WithCompressor sets the compressor the gRPC client uses.
-// Supported compressor values: "gzip".
+// Supported compressor values: "gzip", "zstd".
 //
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", or "zstd". If both are set,
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -331,6 +417,34 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})
 }
 
+// SharedClient is a gRPC connection, with a retry policy, shared by several
+// exporters. The Client of go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared
+// implements it.
+type SharedClient interface {
+	// Conn returns the gRPC connection the export requests are sent with.
+	Conn() *grpc.ClientConn
+	// Do sends an export request by calling fn, retrying it according to the
+	// retry policy of the SharedClient.
+	Do(ctx context.Context, fn func(context.Context) error) error
+}
+
+// WithSharedClient sets c as the gRPC connection and retry policy of the
+// exporter. All the exporters built from c share its connection, retry
+// policy, and export budget.
+//
+// This option takes precedence over WithGRPCConn, WithRetry, and any other
+// option that relates to establishing or persisting a gRPC connection to a
+// target endpoint. Any other option of those types passed will be ignored.
+//
+// It is the callers responsibility to close c once all the exporters built
+// from it are shut down. The Exporter Shutdown method will not close it.
+func WithSharedClient(c SharedClient) Option {
+	return fnOpt(func(cfg config) config {
+		cfg.sharedClient = newSetting(c)
+		return cfg
+	})
+}
+
 // WithTimeout sets the max amount of time an Exporter will attempt an export.
 //
 // This takes precedence over any retry settings defined by WithRetry. Once
@@ -372,12 +486,35 @@ func WithRetry(rc RetryConfig) Option {
 	})
 }
 
+// WithDiskQueue persists the export requests that failed with transient
+// errors, after any retry, to the directory dq.Dir, created if it does not
+// exist. The persisted requests are sent again in the background, oldest
+// first, once an export request succeeds, and when an exporter is created
+// with the same directory, for instance after a restart.
+//
+// The total size of the persisted requests is bounded by dq.MaxBytes, or by
+// 64 MiB if it is not positive: the oldest requests are dropped to make room
+// for new ones. The directory must not be used by another exporter.
+//
+// Persisted requests can be sent more than once if the process stops while
+// sending them.
+//
+// By default, the export requests that failed are dropped.
+func WithDiskQueue(dq DiskQueueConfig) Option {
+	return fnOpt(func(c config) config {
+		c.diskQueue = newSetting(diskqueue.Config(dq))
+		return c
+	})
+}
+
 // convCompression returns the parsed compression encoded in s. NoCompression
 // and an errors are returned if s is unknown.
 func convCompression(s string) (Compression, error) {
 	switch s {
 	case "gzip":
 		return GzipCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
 	case "none", "":
 		return NoCompression, nil
 	}
@@ -391,9 +528,21 @@ func convEndpoint(s string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if u.Scheme == "unix" {
+		return unixEndpoint(u), nil
+	}
 	return u.Host, nil
 }
 
+// unixEndpoint returns the endpoint of the Unix domain socket of u, a URL with
+// the unix scheme, in the "unix:path" form.
+func unixEndpoint(u *url.URL) string {
+	if u.Opaque != "" {
+		return "unix:" + u.Opaque
+	}
+	return "unix:" + u.Path
+}
+
 // convInsecure converts s from string to bool without case sensitivity.
 // If s is not valid returns error.
 func convInsecure(s string) (bool, error) {
@@ -474,7 +623,8 @@ func convDuration(s string) (time.Duration, error) {
 
 // loadEnvTLS returns a resolver that loads a *tls.Config from files defined by
 // the OTLP TLS environment variables. This will load both the rootCAs and
-// certificates used for mTLS.
+// certificates used for mTLS. The files are read again when they change, so
+// rotated certificates are used for new connections.
 //
 // If the filepath defined is invalid or does not contain valid TLS files, an
 // error is passed to the OTel ErrorHandler and no TLS configuration is
@@ -486,33 +636,34 @@ func loadEnvTLS[T *tls.Config]() resolver[T] {
 			return s
 		}
 
-		var rootCAs *x509.CertPool
-		var err error
+		var caFile string
 		for _, key := range envTLSCert {
 			if v := os.Getenv(key); v != "" {
-				rootCAs, err = loadCertPool(v)
+				caFile = v
 				break
 			}
 		}
 
-		var certs []tls.Certificate
+		var certFile, keyFile string
 		for _, pair := range envTLSClient {
 			cert := os.Getenv(pair.Certificate)
 			key := os.Getenv(pair.Key)
 			if cert != "" && key != "" {
-				var e error
-				certs, e = loadCertificates(cert, key)
-				err = errors.Join(err, e)
+				certFile, keyFile = cert, key
 				break
 			}
 		}
 
+		if caFile == "" && certFile == "" {
+			return s
+		}
+		cfg, err := tlsreload.NewConfig(caFile, certFile, keyFile, readFile)
 		if err != nil {
 			err = fmt.Errorf("failed to load TLS: %w", err)
 			otel.Handle(err)
-		} else if rootCAs != nil || certs != nil {
+		} else {
 			s.Set = true
-			s.Value = &tls.Config{RootCAs: rootCAs, Certificates: certs}
+			s.Value = cfg
 		}
 		return s
 	}
@@ -521,38 +672,6 @@ func loadEnvTLS[T *tls.Config]() resolver[T] {
 // readFile is used for testing.
 var readFile = os.ReadFile
 
-// loadCertPool loads and returns the *x509.CertPool found at path if it exists
-// and is valid. Otherwise, nil and an error is returned.
-func loadCertPool(path string) (*x509.CertPool, error) {
-	b, err := readFile(path)
-	if err != nil {
-		return nil, err
-	}
-	cp := x509.NewCertPool()
-	if ok := cp.AppendCertsFromPEM(b); !ok {
-		return nil, errors.New("certificate not added")
-	}
-	return cp, nil
-}
-
-// loadCertificates loads and returns the tls.Certificate found at path if it
-// exists and is valid. Otherwise, nil and an error is returned.
-func loadCertificates(certPath, keyPath string) ([]tls.Certificate, error) {
-	cert, err := readFile(certPath)
-	if err != nil {
-		return nil, err
-	}
-	key, err := readFile(keyPath)
-	if err != nil {
-		return nil, err
-	}
-	crt, err := tls.X509KeyPair(cert, key)
-	if err != nil {
-		return nil, err
-	}
-	return []tls.Certificate{crt}, nil
-}
-
 // insecureFromScheme return setting if the connection should
 // use client transport security or not.
 // Empty scheme doesn't force insecure setting.
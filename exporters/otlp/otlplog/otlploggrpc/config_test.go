@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/retry"
 )
 
@@ -99,6 +100,18 @@ func TestNewConfig(t *testing.T) {
 				retryCfg: newSetting(defaultRetryCfg),
 			},
 		},
+		{
+			name: "ZstdCompressor",
+			options: []Option{
+				WithCompressor("zstd"),
+			},
+			want: config{
+				endpoint:    newSetting(defaultEndpoint),
+				compression: newSetting(ZstdCompression),
+				timeout:     newSetting(defaultTimeout),
+				retryCfg:    newSetting(defaultRetryCfg),
+			},
+		},
 		{
 			name: "Options",
 			options: []Option{
@@ -112,7 +125,9 @@ func TestNewConfig(t *testing.T) {
 				WithServiceConfig("{}"),
 				WithDialOption(dialOptions...),
 				WithGRPCConn(&grpc.ClientConn{}),
+				WithSharedClient(&otlpgrpcshared.Client{}),
 				WithTimeout(2 * time.Second),
+				WithMaxRequestSize(4 << 20),
 				WithRetry(RetryConfig(rc)),
 			},
 			want: config{
@@ -121,11 +136,13 @@ func TestNewConfig(t *testing.T) {
 				headers:            newSetting(headers),
 				compression:        newSetting(GzipCompression),
 				timeout:            newSetting(2 * time.Second),
+				maxRequestSize:     newSetting(4 << 20),
 				retryCfg:           newSetting(rc),
 				gRPCCredentials:    newSetting(credentials.NewTLS(tlsCfg)),
 				serviceConfig:      newSetting("{}"),
 				reconnectionPeriod: newSetting(time.Second),
 				gRPCConn:           newSetting(&grpc.ClientConn{}),
+				sharedClient:       newSetting[SharedClient](&otlpgrpcshared.Client{}),
 				dialOptions:        newSetting(dialOptions),
 			},
 		},
@@ -12,7 +12,8 @@ The environment variables described below can be used for configuration.
 OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_LOGS_ENDPOINT (default: "https://localhost:4317") -
 target to which the exporter sends telemetry.
 The target syntax is defined in https://github.com/grpc/grpc/blob/master/doc/naming.md.
-The value must contain a scheme ("http" or "https") and host.
+The value must contain a scheme ("http" or "https") and host,
+or be a "unix" scheme URL of a Unix domain socket, such as "unix:///var/run/otel.sock".
 The value may additionally contain a port, and a path.
 The value should not contain a query string or fragment.
 OTEL_EXPORTER_OTLP_LOGS_ENDPOINT takes precedence over OTEL_EXPORTER_OTLP_ENDPOINT.
@@ -39,7 +40,7 @@ The configuration can be overridden by [WithTimeout] option.
 
 OTEL_EXPORTER_OTLP_COMPRESSION, OTEL_EXPORTER_OTLP_LOGS_COMPRESSION (default: none) -
 the gRPC compressor the exporter uses.
-Supported value: "gzip".
+Supported values: "gzip", "zstd".
 OTEL_EXPORTER_OTLP_LOGS_COMPRESSION takes precedence over OTEL_EXPORTER_OTLP_COMPRESSION.
 The configuration can be overridden by [WithCompressor], [WithGRPCConn] options.
 
@@ -58,6 +59,9 @@ the filepath to the client's private key to use in mTLS communication in PEM for
 OTEL_EXPORTER_OTLP_LOGS_CLIENT_KEY takes precedence over OTEL_EXPORTER_OTLP_CLIENT_KEY.
 The configuration can be overridden by [WithTLSCredentials], [WithGRPCConn] option.
 
+The certificate files are read again when they change, so rotated certificates
+are used for new connections without recreating the exporter.
+
 [W3C Baggage HTTP Header Content Format]: https://www.w3.org/TR/baggage/#header-content
 */
 package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
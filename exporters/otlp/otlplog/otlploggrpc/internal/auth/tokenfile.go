@@ -0,0 +1,61 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/tokenfile.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/auth"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewTokenFile returns an Authenticator that sends the content of the file at
+// path as a bearer token in the Authorization header.
+//
+// The file is read again whenever its modification time or size changes, so
+// the token can be rotated by an external process, such as the kubelet
+// refreshing a projected service account token. Leading and trailing white
+// space of the file content is ignored.
+func NewTokenFile(path string) Authenticator {
+	return &tokenFile{path: path}
+}
+
+type tokenFile struct {
+	path string
+
+	mu sync.Mutex
+	// modTime and size identify the version of the file authorization was
+	// read from.
+	modTime       time.Time
+	size          int64
+	authorization string
+}
+
+func (f *tokenFile) Headers(context.Context) (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("token file: %w", err)
+	}
+	if f.authorization == "" || !info.ModTime().Equal(f.modTime) || info.Size() != f.size {
+		b, err := os.ReadFile(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("token file: %w", err)
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return nil, fmt.Errorf("token file: %s is empty", f.path)
+		}
+		f.authorization = "Bearer " + token
+		f.modTime, f.size = info.ModTime(), info.Size()
+	}
+	return map[string]string{"Authorization": f.authorization}, nil
+}
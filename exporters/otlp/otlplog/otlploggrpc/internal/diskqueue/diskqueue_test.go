@@ -0,0 +1,149 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/diskqueue/diskqueue_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diskqueue
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a SendFunc recording the requests it sends.
+type recorder struct {
+	mu   sync.Mutex
+	sent []string
+	err  error
+}
+
+func (r *recorder) send(_ context.Context, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, string(data))
+	return nil
+}
+
+func (r *recorder) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func (r *recorder) requests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sent...)
+}
+
+func newQueue(t *testing.T, cfg Config, send SendFunc) *Queue {
+	t.Helper()
+	q, err := New(cfg, send)
+	require.NoError(t, err)
+	t.Cleanup(q.Close)
+	return q
+}
+
+func TestQueueFlush(t *testing.T) {
+	r := &recorder{err: assert.AnError}
+	q := newQueue(t, Config{Dir: t.TempDir()}, r.send)
+
+	require.NoError(t, q.Push([]byte("a")))
+	require.NoError(t, q.Push([]byte("b")))
+	assert.Equal(t, 2, q.Len())
+
+	// Failed requests are kept.
+	q.Flush()
+	assert.Never(t, func() bool { return q.Len() != 2 }, 50*time.Millisecond, time.Millisecond)
+
+	r.setErr(nil)
+	q.Flush()
+	assert.Eventually(t, func() bool { return q.Len() == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"a", "b"}, r.requests())
+}
+
+func TestQueueRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := New(Config{Dir: dir}, (&recorder{err: assert.AnError}).send)
+	require.NoError(t, err)
+	require.NoError(t, q.Push([]byte("a")))
+	require.NoError(t, q.Push([]byte("b")))
+	q.Close()
+
+	// Partially written request.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00000000000000000002.pb.tmp"), []byte("c"), 0o600))
+
+	r := &recorder{}
+	q = newQueue(t, Config{Dir: dir}, r.send)
+	assert.Equal(t, 2, q.Len())
+	q.Flush()
+	assert.Eventually(t, func() bool { return q.Len() == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"a", "b"}, r.requests(), "requests of the previous queue must be sent")
+
+	require.NoError(t, q.Push([]byte("d")))
+	q.Flush()
+	assert.Eventually(t, func() bool { return len(r.requests()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, "d", r.requests()[2], "sequence must continue after restart")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestQueueMaxBytes(t *testing.T) {
+	r := &recorder{err: assert.AnError}
+	q := newQueue(t, Config{Dir: t.TempDir(), MaxBytes: 4}, r.send)
+
+	require.NoError(t, q.Push([]byte("aa")))
+	require.NoError(t, q.Push([]byte("bb")))
+	assert.ErrorContains(t, q.Push([]byte("c")), "1 oldest requests dropped")
+	assert.Equal(t, 2, q.Len())
+	assert.Error(t, q.Push([]byte("eeeee")), "request larger than the queue")
+
+	r.setErr(nil)
+	q.Flush()
+	assert.Eventually(t, func() bool { return q.Len() == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"bb", "c"}, r.requests())
+}
+
+func TestQueueDefaultMaxBytes(t *testing.T) {
+	q := newQueue(t, Config{Dir: t.TempDir()}, (&recorder{}).send)
+	assert.Equal(t, DefaultMaxBytes, q.maxBytes)
+}
+
+func TestQueueClose(t *testing.T) {
+	sending := make(chan struct{})
+	q, err := New(Config{Dir: t.TempDir()}, func(ctx context.Context, _ []byte) error {
+		close(sending)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, q.Push([]byte("a")))
+	q.Flush()
+	<-sending
+
+	// Close cancels the request being sent, which is kept.
+	q.Close()
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestQueueNil(t *testing.T) {
+	var q *Queue
+	assert.NotPanics(t, q.Flush)
+	assert.NotPanics(t, q.Close)
+}
@@ -3,9 +3,33 @@
 
 package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal"
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/auth.go.tmpl "--data={}" --out=auth/auth.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/grpc.go.tmpl "--data={}" --out=auth/grpc.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/grpc_test.go.tmpl "--data={}" --out=auth/grpc_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/oauth2.go.tmpl "--data={}" --out=auth/oauth2.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/oauth2_test.go.tmpl "--data={}" --out=auth/oauth2_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/tokenfile.go.tmpl "--data={}" --out=auth/tokenfile.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/tokenfile_test.go.tmpl "--data={}" --out=auth/tokenfile_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/diskqueue/diskqueue.go.tmpl "--data={}" --out=diskqueue/diskqueue.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/diskqueue/diskqueue_test.go.tmpl "--data={}" --out=diskqueue/diskqueue_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/partialsuccess.go.tmpl "--data={}" --out=partialsuccess.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/partialsuccess_test.go.tmpl "--data={}" --out=partialsuccess_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry.go.tmpl "--data={}" --out=retry/retry.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry_test.go.tmpl "--data={}" --out=retry/retry_test.go
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/split.go.tmpl "--data={}" --out=split/split.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/logs.go.tmpl "--data={}" --out=split/logs.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/logs_test.go.tmpl "--data={}" --out=split/logs_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/tlsreload/tlsreload.go.tmpl "--data={}" --out=tlsreload/tlsreload.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/tlsreload/tlsreload_test.go.tmpl "--data={}" --out=tlsreload/tlsreload_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/zstd/zstd.go.tmpl "--data={}" --out=zstd/zstd.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/zstd/zstd_test.go.tmpl "--data={}" --out=zstd/zstd_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/attr_test.go.tmpl "--data={}" --out=transform/attr_test.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log.go.tmpl "--data={}" --out=transform/log.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log_attr_test.go.tmpl "--data={}" --out=transform/log_attr_test.go
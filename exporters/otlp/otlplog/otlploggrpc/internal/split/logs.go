@@ -0,0 +1,44 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/logs.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/split"
+
+import (
+	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+var logs = hierarchy[*logpb.ResourceLogs, *logpb.ScopeLogs, *logpb.LogRecord]{
+	kind:   "log records",
+	scopes: (*logpb.ResourceLogs).GetScopeLogs,
+	items:  (*logpb.ScopeLogs).GetLogRecords,
+	emptyResource: func(r *logpb.ResourceLogs) *logpb.ResourceLogs {
+		return &logpb.ResourceLogs{Resource: r.Resource, SchemaUrl: r.SchemaUrl}
+	},
+	emptyScope: func(s *logpb.ScopeLogs) *logpb.ScopeLogs {
+		return &logpb.ScopeLogs{Scope: s.Scope, SchemaUrl: s.SchemaUrl}
+	},
+	appendScope: func(r *logpb.ResourceLogs, s *logpb.ScopeLogs) {
+		r.ScopeLogs = append(r.ScopeLogs, s)
+	},
+	appendItem: func(s *logpb.ScopeLogs, l *logpb.LogRecord) {
+		s.LogRecords = append(s.LogRecords, l)
+	},
+}
+
+// Logs splits rl into batches that each encode to an ExportLogsServiceRequest
+// of no more than maxSize bytes. Log records are kept grouped by their
+// resource and instrumentation scope.
+//
+// If size is not nil, it returns the encoded size of the request for a batch,
+// otherwise the size of its protobuf encoding is used.
+//
+// Log records that are larger than maxSize on their own are dropped and an
+// error reporting them is returned alongside the batches.
+//
+// If maxSize is not positive, rl is returned as the only batch.
+func Logs(rl []*logpb.ResourceLogs, maxSize int, size func([]*logpb.ResourceLogs) int) ([][]*logpb.ResourceLogs, error) {
+	return logs.split(rl, maxSize, size)
+}
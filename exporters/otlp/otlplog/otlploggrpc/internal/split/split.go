@@ -0,0 +1,300 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/split.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package split splits OTLP export requests into multiple requests that each
+// encode to no more than a maximum number of bytes.
+//
+// Sizes are measured using the protobuf encoding of the request, or the
+// encoding measured by a size function, before any compression is applied.
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/split"
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// hierarchy describes the resource, scope, and item levels of the data of an
+// OTLP signal.
+type hierarchy[R, S, I proto.Message] struct {
+	// kind is the plural name of the units items are counted in, used in
+	// error messages.
+	kind string
+
+	scopes func(R) []S
+	items  func(S) []I
+
+	// emptyResource returns a copy of r that holds no scopes.
+	emptyResource func(r R) R
+	// emptyScope returns a copy of s that holds no items.
+	emptyScope func(s S) S
+
+	appendScope func(R, S)
+	appendItem  func(S, I)
+
+	// units, if not nil, returns the number of units item i is counted in.
+	// Otherwise, every item is a single unit.
+	units func(i I) int
+	// splitItem, if not nil, splits item i, which holds more than one unit,
+	// into items that each either have an encoded size for which fits
+	// returns true or hold a single unit.
+	splitItem func(i I, fits func(size int) bool) []I
+}
+
+// split splits resources into batches that each encode to an export request
+// of no more than maxSize bytes. The resource and scope grouping of items is
+// preserved.
+//
+// If size is not nil, it returns the encoded size of the export request for a
+// batch, otherwise the size of its protobuf encoding is used.
+//
+// Items that do not fit into a request on their own are split into items
+// holding fewer units if the hierarchy supports it. The units that do not fit
+// into a request on their own are dropped and an error reporting them is
+// returned alongside the batches.
+//
+// If maxSize is not positive or the request for resources is small enough,
+// resources is returned as the only batch.
+func (h hierarchy[R, S, I]) split(resources []R, maxSize int, size func([]R) int) ([][]R, error) {
+	if maxSize <= 0 {
+		return [][]R{resources}, nil
+	}
+
+	var (
+		batches [][]R
+		dropped int
+	)
+	if size == nil {
+		if requestSize(resources) <= maxSize {
+			return [][]R{resources}, nil
+		}
+		batches, dropped = h.pack(resources, maxSize, false)
+	} else {
+		batches, dropped = h.resplit(resources, maxSize, size)
+	}
+
+	var err error
+	if dropped > 0 {
+		err = fmt.Errorf(
+			"%d %s dropped: larger than the maximum request size of %d bytes",
+			dropped, h.kind, maxSize,
+		)
+	}
+	return batches, err
+}
+
+// resplit splits resources into batches that each have an export request of
+// size, as returned by size, no more than maxSize. It returns the batches and
+// the number of units dropped.
+//
+// As the sizes of other encodings are not derived from the protobuf encoding
+// of items, the batches are packed using a protobuf size limit scaled to the
+// ratio of the two sizes, and the batches still too large are split again.
+func (h hierarchy[R, S, I]) resplit(resources []R, maxSize int, size func([]R) int) ([][]R, int) {
+	n := size(resources)
+	if n <= maxSize {
+		return [][]R{resources}, 0
+	}
+	if units := h.count(resources); units <= 1 {
+		return nil, units
+	}
+
+	pbSize := requestSize(resources)
+	limit := pbSize * maxSize / n
+	// Items that do not fit the limit are not dropped, their size is checked
+	// on their own.
+	batches, _ := h.pack(resources, limit, true)
+
+	var (
+		out     [][]R
+		dropped int
+	)
+	for _, b := range batches {
+		bs, d := h.resplit(b, maxSize, size)
+		out = append(out, bs...)
+		dropped += d
+	}
+	return out, dropped
+}
+
+// pack packs the items of resources into batches that each encode to a
+// protobuf export request of no more than maxSize bytes. It returns the
+// batches and the number of units dropped.
+//
+// If isolate is true, the units that do not fit into a request on their own
+// are packed in a batch of their own instead of being dropped.
+func (h hierarchy[R, S, I]) pack(resources []R, maxSize int, isolate bool) ([][]R, int) {
+	p := packer[R, S, I]{hierarchy: h, maxSize: maxSize, isolate: isolate}
+	for _, r := range resources {
+		rShell := proto.Size(h.emptyResource(r))
+		for _, s := range h.scopes(r) {
+			sShell := proto.Size(h.emptyScope(s))
+			for _, i := range h.items(s) {
+				p.add(r, rShell, s, sShell, i)
+			}
+			p.closeScope()
+		}
+		p.closeResource()
+	}
+	p.flush()
+	return p.batches, p.dropped
+}
+
+// count returns the number of units of the items of resources.
+func (h hierarchy[R, S, I]) count(resources []R) int {
+	var n int
+	for _, r := range resources {
+		for _, s := range h.scopes(r) {
+			for _, i := range h.items(s) {
+				n += h.unitsOf(i)
+			}
+		}
+	}
+	return n
+}
+
+// unitsOf returns the number of units item i is counted in.
+func (h hierarchy[R, S, I]) unitsOf(i I) int {
+	if h.units == nil {
+		return 1
+	}
+	return h.units(i)
+}
+
+// packer greedily packs items into batches.
+type packer[R, S, I proto.Message] struct {
+	hierarchy[R, S, I]
+
+	maxSize int
+	isolate bool
+	dropped int
+	batches [][]R
+
+	// batch is the batch being packed and batchSize the encoded size of its
+	// request, not including the open resource.
+	batch     []R
+	batchSize int
+
+	// r is the open resource of batch and rSize its encoded size, not
+	// including the open scope.
+	r     R
+	rOpen bool
+	rSize int
+
+	// s is the open scope of r and sSize its encoded size.
+	s     S
+	sOpen bool
+	sSize int
+}
+
+// add adds item i, belonging to scope s of resource r, to the batch being
+// packed. The encoded sizes of r and s without any children are rShell and
+// sShell.
+func (p *packer[R, S, I]) add(r R, rShell int, s S, sShell int, i I) {
+	iSize := fieldSize(proto.Size(i))
+	if p.sizeWith(rShell, sShell, iSize) > p.maxSize {
+		p.flush()
+		if p.sizeWith(rShell, sShell, iSize) > p.maxSize {
+			p.oversized(r, rShell, s, sShell, i, iSize)
+			return
+		}
+	}
+	p.put(r, rShell, s, sShell, i, iSize)
+}
+
+// put adds item i of encoded size iSize to the batch being packed, without
+// checking its size.
+func (p *packer[R, S, I]) put(r R, rShell int, s S, sShell int, i I, iSize int) {
+	if !p.rOpen {
+		p.r, p.rOpen, p.rSize = p.emptyResource(r), true, rShell
+		p.batch = append(p.batch, p.r)
+	}
+	if !p.sOpen {
+		p.s, p.sOpen, p.sSize = p.emptyScope(s), true, sShell
+		p.appendScope(p.r, p.s)
+	}
+	p.appendItem(p.s, i)
+	p.sSize += iSize
+}
+
+// oversized splits item i, which does not fit into an empty batch, and adds
+// the resulting items to the batch being packed. If it cannot be split, it is
+// dropped, or packed in a batch of its own if isolating.
+func (p *packer[R, S, I]) oversized(r R, rShell int, s S, sShell int, i I, iSize int) {
+	n := p.unitsOf(i)
+	if p.splitItem == nil || n <= 1 {
+		if p.isolate {
+			p.put(r, rShell, s, sShell, i, iSize)
+			p.flush()
+			return
+		}
+		p.dropped += n
+		return
+	}
+
+	// The batch is empty, the items that fit are added to a batch of their
+	// own.
+	fits := func(size int) bool {
+		return p.sizeWith(rShell, sShell, fieldSize(size)) <= p.maxSize
+	}
+	for _, part := range p.splitItem(i, fits) {
+		p.add(r, rShell, s, sShell, part)
+	}
+}
+
+// sizeWith returns the encoded size of the batch request if an item of
+// encoded size iSize were added to it.
+func (p *packer[R, S, I]) sizeWith(rShell, sShell, iSize int) int {
+	rSize, sSize := rShell, sShell
+	if p.rOpen {
+		rSize = p.rSize
+	}
+	if p.sOpen {
+		sSize = p.sSize
+	}
+	return p.batchSize + fieldSize(rSize+fieldSize(sSize+iSize))
+}
+
+func (p *packer[R, S, I]) closeScope() {
+	if p.sOpen {
+		p.rSize += fieldSize(p.sSize)
+		p.sOpen = false
+	}
+}
+
+func (p *packer[R, S, I]) closeResource() {
+	p.closeScope()
+	if p.rOpen {
+		p.batchSize += fieldSize(p.rSize)
+		p.rOpen = false
+	}
+}
+
+// flush closes the batch being packed and starts a new one.
+func (p *packer[R, S, I]) flush() {
+	p.closeResource()
+	if len(p.batch) > 0 {
+		p.batches = append(p.batches, p.batch)
+	}
+	p.batch, p.batchSize = nil, 0
+}
+
+// requestSize returns the encoded size of an export request for resources.
+func requestSize[R proto.Message](resources []R) int {
+	var n int
+	for _, r := range resources {
+		n += fieldSize(proto.Size(r))
+	}
+	return n
+}
+
+// fieldSize returns the encoded size of an embedded message field holding a
+// message of encoded size n. All fields that hold the OTLP resource, scope,
+// and item messages have a field number that encodes as a single byte tag.
+func fieldSize(n int) int {
+	return 1 + protowire.SizeBytes(n)
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
@@ -18,17 +19,28 @@ import (
 	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/diskqueue"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/otlpjson"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/split"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/tlsreload"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/transport"
 )
 
+const contentTypeProto = "application/x-protobuf"
+
 type client struct {
 	uploadLogs func(context.Context, []*logpb.ResourceLogs) error
+	shutdown   func()
 }
 
 func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error {
@@ -38,32 +50,39 @@ func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error
 	return nil
 }
 
+// Shutdown releases the resources of the client.
+func (c *client) Shutdown() {
+	if c.shutdown != nil {
+		c.shutdown()
+	}
+}
+
 func newNoopClient() *client {
 	return &client{}
 }
 
 // newHTTPClient creates a new HTTP log client.
 func newHTTPClient(cfg config) (*client, error) {
-	hc := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.timeout.Value,
-	}
-
+	baseTransport := ourTransport
 	if cfg.tlsCfg.Value != nil || cfg.proxy.Value != nil {
-		clonedTransport := ourTransport.Clone()
-		hc.Transport = clonedTransport
+		baseTransport = ourTransport.Clone()
 
 		if cfg.tlsCfg.Value != nil {
-			clonedTransport.TLSClientConfig = cfg.tlsCfg.Value
+			baseTransport.TLSClientConfig = tlsreload.ForServer(cfg.tlsCfg.Value, transport.Host(cfg.endpoint.Value))
 		}
 		if cfg.proxy.Value != nil {
-			clonedTransport.Proxy = cfg.proxy.Value
+			baseTransport.Proxy = cfg.proxy.Value
 		}
 	}
 
+	hc := &http.Client{
+		Transport: transport.New(baseTransport, cfg.endpoint.Value, cfg.h2c.Value && cfg.insecure.Value),
+		Timeout:   cfg.timeout.Value,
+	}
+
 	u := &url.URL{
 		Scheme: "https",
-		Host:   cfg.endpoint.Value,
+		Host:   transport.Host(cfg.endpoint.Value),
 		Path:   cfg.path.Value,
 	}
 	if cfg.insecure.Value {
@@ -83,23 +102,59 @@ func newHTTPClient(cfg config) (*client, error) {
 			req.Header.Set(k, v)
 		}
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	contentType := contentTypeProto
+	if cfg.encoding.Value == JSONEncoding {
+		contentType = otlpjson.ContentType
+	}
+	req.Header.Set("Content-Type", contentType)
 
 	c := &httpClient{
-		compression: cfg.compression.Value,
-		req:         req,
-		requestFunc: cfg.retryCfg.Value.RequestFunc(evaluate),
-		client:      hc,
+		compression:    cfg.compression.Value,
+		encoding:       cfg.encoding.Value,
+		req:            req,
+		requestFunc:    cfg.retryCfg.Value.RequestFunc(evaluate),
+		client:         hc,
+		maxRequestSize: cfg.maxRequestSize.Value,
+		partialSuccess: internal.NewLogPartialSuccessHandler(
+			otel.GetMeterProvider(),
+			"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp",
+			Version(),
+			cfg.partialSuccessHandler.Value,
+		),
+		authenticator: cfg.authenticator.Value,
 	}
-	return &client{uploadLogs: c.uploadLogs}, nil
+
+	if cfg.diskQueue.Value.Dir != "" {
+		q, err := diskqueue.New(cfg.diskQueue.Value, c.replay)
+		if err != nil {
+			return nil, err
+		}
+		c.queue = q
+		// Send the requests queued before a restart.
+		q.Flush()
+	}
+	return &client{uploadLogs: c.uploadLogs, shutdown: c.queue.Close}, nil
 }
 
 type httpClient struct {
 	// req is cloned for every upload the client makes.
 	req         *http.Request
 	compression Compression
+	encoding    Encoding
 	requestFunc retry.RequestFunc
 	client      *http.Client
+
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize int
+
+	partialSuccess *internal.PartialSuccessHandler
+
+	// authenticator provides the authentication headers of requests.
+	authenticator auth.Authenticator
+
+	// queue is the disk queue of the export requests that failed with
+	// transient errors. It is nil if no disk queue is configured.
+	queue *diskqueue.Queue
 }
 
 // Keep it in sync with golang's DefaultTransport from net/http! We
@@ -120,11 +175,31 @@ var ourTransport = &http.Transport{
 }
 
 func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs) error {
+	batches, err := split.Logs(data, c.maxRequestSize, c.requestSize())
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	var errs []error
+	for _, batch := range batches {
+		if err := c.uploadRequest(ctx, batch, c.requestFunc); err != nil {
+			c.enqueue(batch, err)
+			errs = append(errs, err)
+			continue
+		}
+		// The endpoint is available, send the queued requests.
+		c.queue.Flush()
+	}
+	return errors.Join(errs...)
+}
+
+// uploadRequest sends data in a single export request, using requestFunc.
+func (c *httpClient) uploadRequest(ctx context.Context, data []*logpb.ResourceLogs, requestFunc retry.RequestFunc) error {
 	// The Exporter synchronizes access to client methods. This is not called
 	// after the Exporter is shutdown. Only thing to do here is send data.
 
 	pbRequest := &collogpb.ExportLogsServiceRequest{ResourceLogs: data}
-	body, err := proto.Marshal(pbRequest)
+	body, err := c.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -133,7 +208,7 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 		return err
 	}
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	return requestFunc(ctx, func(iCtx context.Context) error {
 		select {
 		case <-iCtx.Done():
 			return iCtx.Err()
@@ -141,6 +216,9 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 		}
 
 		request.reset(iCtx)
+		if err := auth.SetHeaders(iCtx, c.authenticator, request.Header); err != nil {
+			return err
+		}
 		resp, err := c.client.Do(request.Request)
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) && urlErr.Temporary() {
@@ -164,20 +242,15 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 				return nil
 			}
 
-			if resp.Header.Get("Content-Type") == "application/x-protobuf" {
-				var respProto collogpb.ExportLogsServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
-					return err
-				}
-
-				if respProto.PartialSuccess != nil {
-					msg := respProto.PartialSuccess.GetErrorMessage()
-					n := respProto.PartialSuccess.GetRejectedLogRecords()
-					if n != 0 || msg != "" {
-						err := fmt.Errorf("OTLP partial success: %s (%d log records rejected)", msg, n)
-						otel.Handle(err)
-					}
-				}
+			var respProto collogpb.ExportLogsServiceResponse
+			ok, err := unmarshalResponse(resp.Header.Get("Content-Type"), respData.Bytes(), &respProto)
+			if err != nil {
+				return err
+			}
+			if ok && respProto.PartialSuccess != nil {
+				msg := respProto.PartialSuccess.GetErrorMessage()
+				n := respProto.PartialSuccess.GetRejectedLogRecords()
+				c.partialSuccess.Handle(iCtx, n, msg)
 			}
 			return nil
 		case sc == http.StatusTooManyRequests,
@@ -185,7 +258,8 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 			sc == http.StatusServiceUnavailable,
 			sc == http.StatusGatewayTimeout:
 			// Retry-able failure.
-			rErr = newResponseError(resp.Header, nil)
+			statusErr := &retry.HTTPStatusError{StatusCode: sc, Status: resp.Status}
+			rErr = newResponseError(resp.Header, statusErr)
 
 			// server may return a message with the response
 			// body, so we read it to include in the error
@@ -201,11 +275,10 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 			// if it is not empty
 			if respStr := strings.TrimSpace(respData.String()); respStr != "" {
 				// Include response for context.
-				e := errors.New(respStr)
-				rErr = newResponseError(resp.Header, e)
+				statusErr.Err = errors.New(respStr)
 			}
 		default:
-			rErr = fmt.Errorf("failed to send logs to %s: %s", request.URL, resp.Status)
+			rErr = fmt.Errorf("failed to send logs to %s: %w", request.URL, &retry.HTTPStatusError{StatusCode: sc, Status: resp.Status})
 		}
 
 		if err := resp.Body.Close(); err != nil {
@@ -215,6 +288,83 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 	})
 }
 
+// enqueue adds the export request of data, which failed with err, to the disk
+// queue if the error is transient.
+func (c *httpClient) enqueue(data []*logpb.ResourceLogs, err error) {
+	if c.queue == nil || !transient(err) {
+		return
+	}
+
+	b, err := proto.Marshal(&collogpb.ExportLogsServiceRequest{ResourceLogs: data})
+	if err == nil {
+		err = c.queue.Push(b)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// replay sends the export request data read from the disk queue in a single
+// attempt. The request is dropped if it fails with a permanent error.
+func (c *httpClient) replay(ctx context.Context, data []byte) error {
+	var req collogpb.ExportLogsServiceRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		otel.Handle(fmt.Errorf("dropping invalid queued export request: %w", err))
+		return nil
+	}
+
+	err := c.uploadRequest(ctx, req.ResourceLogs, once)
+	if err != nil && !transient(err) {
+		otel.Handle(err)
+		return nil
+	}
+	return err
+}
+
+// once sends a request in a single attempt.
+func once(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// requestSize returns the function measuring the size of the export request
+// of a batch, or nil if requests are encoded with protobuf, which the split
+// package measures itself.
+func (c *httpClient) requestSize() func([]*logpb.ResourceLogs) int {
+	if c.encoding != JSONEncoding {
+		return nil
+	}
+	return func(rl []*logpb.ResourceLogs) int {
+		b, err := otlpjson.Marshal(&collogpb.ExportLogsServiceRequest{ResourceLogs: rl})
+		if err != nil {
+			// The error is returned when the batch is sent.
+			return 0
+		}
+		return len(b)
+	}
+}
+
+// marshal returns the encoding of m used by the client.
+func (c *httpClient) marshal(m proto.Message) ([]byte, error) {
+	if c.encoding == JSONEncoding {
+		return otlpjson.Marshal(m)
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalResponse parses the response body b with the encoding of
+// contentType into m. It returns false if the encoding is unknown, in which
+// case m is not modified.
+func unmarshalResponse(contentType string, b []byte, m proto.Message) (bool, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case contentTypeProto:
+		return true, proto.Unmarshal(b, m)
+	case otlpjson.ContentType:
+		return true, otlpjson.Unmarshal(b, m)
+	}
+	return false, nil
+}
+
 var gzPool = sync.Pool{
 	New: func() interface{} {
 		w := gzip.NewWriter(io.Discard)
@@ -222,6 +372,15 @@ var gzPool = sync.Pool{
 	},
 }
 
+var zstdPool = sync.Pool{
+	New: func() interface{} {
+		// Encode synchronously, the encoder is used for a single payload at
+		// a time. The options are valid, no error is returned.
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	},
+}
+
 func (c *httpClient) newRequest(ctx context.Context, body []byte) (request, error) {
 	r := c.req.Clone(ctx)
 	req := request{Request: r}
@@ -250,6 +409,15 @@ func (c *httpClient) newRequest(ctx context.Context, body []byte) (request, erro
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+	case ZstdCompression:
+		// Ensure the content length is not used.
+		r.ContentLength = -1
+		r.Header.Set("Content-Encoding", "zstd")
+
+		enc := zstdPool.Get().(*zstd.Encoder)
+		defer zstdPool.Put(enc)
+
+		req.bodyReader = bodyReader(enc.EncodeAll(body, nil))
 	}
 
 	return req, nil
@@ -340,3 +508,18 @@ func evaluate(err error) (bool, time.Duration) {
 
 	return true, time.Duration(rErr.throttle)
 }
+
+// transient returns if err, returned by an export request, may not be
+// returned when sending the request again later: the request failed with a
+// retry-able status, failed to reach the endpoint, or was not sent.
+func transient(err error) bool {
+	var (
+		rErr   *retryableError
+		urlErr *url.Error
+	)
+	return errors.As(err, &rErr) ||
+		errors.As(err, &urlErr) ||
+		errors.Is(err, retry.ErrCircuitOpen) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
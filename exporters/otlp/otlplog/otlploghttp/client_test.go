@@ -21,12 +21,14 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -37,6 +39,7 @@ import (
 	lpb "go.opentelemetry.io/proto/otlp/logs/v1"
 	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/otlpjson"
 	"go.opentelemetry.io/otel/sdk/log"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
@@ -310,12 +313,17 @@ func (c *httpCollector) Headers() map[string][]string {
 }
 
 func (c *httpCollector) handler(w http.ResponseWriter, r *http.Request) {
-	c.respond(w, c.record(r))
+	c.respond(w, r.Header.Get("Content-Type"), c.record(r))
 }
 
 func (c *httpCollector) record(r *http.Request) exportResult {
-	// Currently only supports protobuf.
-	if v := r.Header.Get("Content-Type"); v != "application/x-protobuf" {
+	var unmarshal func([]byte, proto.Message) error
+	switch v := r.Header.Get("Content-Type"); v {
+	case "application/x-protobuf":
+		unmarshal = proto.Unmarshal
+	case otlpjson.ContentType:
+		unmarshal = otlpjson.Unmarshal
+	default:
 		err := fmt.Errorf("content-type not supported: %s", v)
 		return exportResult{Err: err}
 	}
@@ -325,7 +333,7 @@ func (c *httpCollector) record(r *http.Request) exportResult {
 		return exportResult{Err: err}
 	}
 	pbRequest := &collogpb.ExportLogsServiceRequest{}
-	err = proto.Unmarshal(body, pbRequest)
+	err = unmarshal(body, pbRequest)
 	if err != nil {
 		return exportResult{
 			Err: &httpResponseError{
@@ -362,6 +370,16 @@ func (c *httpCollector) readBody(r *http.Request) (body []byte, err error) {
 				Status: http.StatusInternalServerError,
 			}
 		}
+	case "zstd":
+		var dec *zstd.Decoder
+		dec, err = zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, &httpResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = dec.IOReadCloser()
 	default:
 		reader = r.Body
 	}
@@ -385,7 +403,7 @@ func (c *httpCollector) readBody(r *http.Request) (body []byte, err error) {
 	return body, err
 }
 
-func (c *httpCollector) respond(w http.ResponseWriter, resp exportResult) {
+func (c *httpCollector) respond(w http.ResponseWriter, contentType string, resp exportResult) {
 	if resp.Err != nil {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -412,6 +430,21 @@ func (c *httpCollector) respond(w http.ResponseWriter, resp exportResult) {
 		return
 	}
 
+	if contentType == otlpjson.ContentType {
+		// Respond with the encoding of the request.
+		if resp.Response == nil {
+			resp.Response = &collogpb.ExportLogsServiceResponse{}
+		}
+		r, err := otlpjson.Marshal(resp.Response)
+		if err != nil {
+			panic(err)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(r)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/x-protobuf")
 	w.WriteHeader(http.StatusOK)
 	if resp.Response == nil {
@@ -564,6 +597,49 @@ func TestClient(t *testing.T) {
 	})
 }
 
+func TestClientJSONEncoding(t *testing.T) {
+	const n, msg = 2, "bad data"
+	rCh := make(chan exportResult, 2)
+	rCh <- exportResult{}
+	rCh <- exportResult{
+		Response: &collogpb.ExportLogsServiceResponse{
+			PartialSuccess: &collogpb.ExportLogsPartialSuccess{
+				RejectedLogRecords: n,
+				ErrorMessage:       msg,
+			},
+		},
+	}
+	coll, err := newHTTPCollector("", rCh)
+	require.NoError(t, err)
+
+	opts := []Option{WithEndpoint(coll.Addr().String()), WithInsecure(), WithEncoding(JSONEncoding)}
+	client, err := newHTTPClient(newConfig(opts))
+	require.NoError(t, err)
+
+	defer func(orig otel.ErrorHandler) {
+		otel.SetErrorHandler(orig)
+	}(otel.GetErrorHandler())
+
+	errs := []error{}
+	eh := otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) })
+	otel.SetErrorHandler(eh)
+
+	ctx := context.Background()
+	require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+	got := coll.Collect().Dump()
+	require.Len(t, got, 1, "upload of one ResourceLogs")
+	diff := cmp.Diff(got[0], resourceLogs[0], cmp.Comparer(proto.Equal))
+	if diff != "" {
+		t.Fatalf("unexpected ResourceLogs:\n%s", diff)
+	}
+	assert.Equal(t, []string{otlpjson.ContentType}, coll.Headers()["Content-Type"])
+
+	require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+	require.Len(t, errs, 1)
+	want := fmt.Sprintf("%s (%d log records rejected)", msg, n)
+	assert.ErrorContains(t, errs[0], want)
+}
+
 func TestClientWithHTTPCollectorRespondingPlainText(t *testing.T) {
 	ctx := context.Background()
 	coll, err := newHTTPCollector("", nil, withHTTPCollectorRespondingPlainText())
@@ -662,6 +738,15 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, coll.Collect().Dump(), 1)
 	})
 
+	t.Run("WithCompressionZstd", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithCompression(ZstdCompression))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
 	t.Run("WithRetry", func(t *testing.T) {
 		emptyErr := errors.New("")
 		rCh := make(chan exportResult, 5)
@@ -724,6 +809,48 @@ func TestConfig(t *testing.T) {
 		assert.ErrorIs(t, err, *retryErr)
 	})
 
+	t.Run("WithDiskQueue", func(t *testing.T) {
+		rCh := make(chan exportResult, 4)
+		rCh <- exportResult{Err: &httpResponseError{
+			Status: http.StatusServiceUnavailable,
+			Err:    errors.New("unavailable"),
+		}}
+		rCh <- exportResult{Err: &httpResponseError{
+			Status: http.StatusBadRequest,
+			Err:    errors.New("invalid"),
+		}}
+		rCh <- exportResult{}
+		rCh <- exportResult{}
+		dir := t.TempDir()
+		exp, coll := factoryFunc(
+			"",
+			rCh,
+			WithRetry(RetryConfig{Enabled: false}),
+			WithDiskQueue(DiskQueueConfig{Dir: dir}),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		// The request failing with a transient error is queued, the one
+		// failing with a permanent error is dropped.
+		assert.Error(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Error(t, exp.Export(ctx, make([]log.Record, 1)))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		// The queued request is sent once an export succeeds.
+		assert.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Eventually(t, func() bool {
+			entries, err := os.ReadDir(dir)
+			return err == nil && len(entries) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Empty(t, rCh, "queued request not sent")
+	})
+
 	t.Run("WithURLPath", func(t *testing.T) {
 		path := "/prefix/v2/logs"
 		ePt := fmt.Sprintf("http://localhost:0%s", path)
@@ -5,7 +5,6 @@ package otlploghttp // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/o
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,7 +15,11 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/tlsreload"
 	"go.opentelemetry.io/otel/internal/global"
 )
 
@@ -51,6 +54,11 @@ var (
 		"OTEL_EXPORTER_OTLP_COMPRESSION",
 	}
 
+	envProtocol = []string{
+		"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL",
+		"OTEL_EXPORTER_OTLP_PROTOCOL",
+	}
+
 	envTimeout = []string{
 		"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT",
 		"OTEL_EXPORTER_OTLP_TIMEOUT",
@@ -91,9 +99,20 @@ type config struct {
 	tlsCfg      setting[*tls.Config]
 	headers     setting[map[string]string]
 	compression setting[Compression]
+	encoding    setting[Encoding]
 	timeout     setting[time.Duration]
 	proxy       setting[HTTPTransportProxyFunc]
 	retryCfg    setting[retry.Config]
+	diskQueue   setting[diskqueue.Config]
+
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize setting[int]
+	// partialSuccessHandler handles partial success responses.
+	partialSuccessHandler setting[func(internal.PartialSuccess)]
+	// authenticator provides the authentication headers of requests.
+	authenticator setting[auth.Authenticator]
+	// h2c is whether HTTP/2 is used over cleartext connections.
+	h2c setting[bool]
 }
 
 func newConfig(options []Option) config {
@@ -123,6 +142,9 @@ func newConfig(options []Option) config {
 	c.compression = c.compression.Resolve(
 		getenv[Compression](envCompression, convCompression),
 	)
+	c.encoding = c.encoding.Resolve(
+		getenv[Encoding](envProtocol, convEncoding),
+	)
 	c.timeout = c.timeout.Resolve(
 		getenv[time.Duration](envTimeout, convDuration),
 		fallback[time.Duration](defaultTimeout),
@@ -172,6 +194,10 @@ func WithEndpoint(endpoint string) Option {
 //
 // If an invalid URL is provided, the default value will be kept.
 //
+// A URL with the unix scheme, such as "unix:///var/run/otel.sock", sends the
+// requests to the Unix domain socket at its path without transport security.
+// Its path is not used as the URL path of the requests (see WithURLPath).
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "localhost:4318" will be used.
 func WithEndpointURL(rawURL string) Option {
@@ -181,8 +207,13 @@ func WithEndpointURL(rawURL string) Option {
 		return fnOpt(func(c config) config { return c })
 	}
 	return fnOpt(func(c config) config {
-		c.endpoint = newSetting(u.Host)
-		c.path = newSetting(u.Path)
+		if u.Scheme == "unix" {
+			// The path is the one of the socket, the URL path is kept.
+			c.endpoint = newSetting(unixEndpoint(u))
+		} else {
+			c.endpoint = newSetting(u.Host)
+			c.path = newSetting(u.Path)
+		}
 		if u.Scheme != "https" {
 			c.insecure = newSetting(true)
 		} else {
@@ -200,6 +231,8 @@ const (
 	NoCompression Compression = iota
 	// GzipCompression represents that gzip compression should be used.
 	GzipCompression
+	// ZstdCompression represents that zstd compression should be used.
+	ZstdCompression
 )
 
 // WithCompression sets the compression strategy the Exporter will use to
@@ -208,7 +241,7 @@ const (
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", or "zstd". If both are set,
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -220,6 +253,37 @@ func WithCompression(compression Compression) Option {
 	})
 }
 
+// Encoding describes the encoding used for exported payloads.
+type Encoding int
+
+const (
+	// ProtobufEncoding represents that payloads should be encoded as binary
+	// Protobuf (OTLP/HTTP binary Protobuf).
+	ProtobufEncoding Encoding = iota
+	// JSONEncoding represents that payloads should be encoded as JSON
+	// (OTLP/HTTP JSON), using the Protobuf JSON mapping with hex-encoded trace
+	// and span IDs defined by the OTLP specification.
+	JSONEncoding
+)
+
+// WithEncoding sets the encoding the Exporter will use to encode the HTTP
+// body.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_LOGS_PROTOCOL
+// environment variable is set, and this option is not passed, that variable
+// value will be used. That value can be either "http/protobuf" or
+// "http/json". If both are set, OTEL_EXPORTER_OTLP_LOGS_PROTOCOL will take
+// precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, binary Protobuf will be used.
+func WithEncoding(encoding Encoding) Option {
+	return fnOpt(func(c config) config {
+		c.encoding = newSetting(encoding)
+		return c
+	})
+}
+
 // WithURLPath sets the URL path the Exporter will send requests to.
 //
 // If the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_LOGS_ENDPOINT
@@ -311,10 +375,71 @@ func WithTimeout(duration time.Duration) Option {
 	})
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the export requests
+// sent by the Exporter. Log records that would encode to a larger request are
+// split into multiple requests, keeping log records grouped by their resource
+// and instrumentation scope. Log records larger than size on their own are
+// dropped and reported to the global error handler.
+//
+// The size is measured using the encoding of the request, protobuf or JSON,
+// before any compression is applied. Use this to stay below the request body
+// limit of the collector or of a proxy in front of it.
+//
+// If unset or not positive, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return fnOpt(func(c config) config {
+		c.maxRequestSize = newSetting(size)
+		return c
+	})
+}
+
+// WithPartialSuccessHandler sets the function called with the partial success
+// responses of the OTLP server. It is called synchronously during the export,
+// so it should not block.
+//
+// If unset, partial successes are passed to the global error handler.
+//
+// Regardless of this option, the number of rejected log records is counted with
+// the otel.sdk.exporter.log.rejected counter of the global MeterProvider.
+func WithPartialSuccessHandler(h func(PartialSuccessError)) Option {
+	return fnOpt(func(c config) config {
+		c.partialSuccessHandler = newSetting(h)
+		return c
+	})
+}
+
+// WithAuthenticator sets the Authenticator providing the authentication
+// headers of the export requests, for example an Authenticator returned by
+// NewOAuth2Authenticator or NewTokenFileAuthenticator.
+//
+// The headers are set on every HTTP request, including retries, and take
+// precedence over the headers set with WithHeaders.
+//
+// An error of the Authenticator fails the export request, it is not retried.
+func WithAuthenticator(a Authenticator) Option {
+	return fnOpt(func(c config) config {
+		c.authenticator = newSetting(a)
+		return c
+	})
+}
+
+// PartialSuccessError is the error describing a partial success response of the
+// OTLP server, which accepted an export request but rejected log records of it
+// or returned a warning. RejectedItems is the number of rejected log records
+// and ErrorMessage the message of the server.
+//
+// It is passed to the function set with WithPartialSuccessHandler, or otherwise
+// to the global error handler. Use errors.As to identify it there.
+type PartialSuccessError = internal.PartialSuccess
+
 // RetryConfig defines configuration for retrying the export of log data that
 // failed.
 type RetryConfig retry.Config
 
+// DiskQueueConfig configures the disk queue of the export requests that failed
+// with transient errors. See WithDiskQueue.
+type DiskQueueConfig diskqueue.Config
+
 // WithRetry sets the retry policy for transient retryable errors that are
 // returned by the target endpoint.
 //
@@ -332,6 +457,27 @@ func WithRetry(rc RetryConfig) Option {
 	})
 }
 
+// WithDiskQueue persists the export requests that failed with transient
+// errors, after any retry, to the directory dq.Dir, created if it does not
+// exist. The persisted requests are sent again in the background, oldest
+// first, once an export request succeeds, and when an exporter is created
+// with the same directory, for instance after a restart.
+//
+// The total size of the persisted requests is bounded by dq.MaxBytes, or by
+// 64 MiB if it is not positive: the oldest requests are dropped to make room
+// for new ones. The directory must not be used by another exporter.
+//
+// Persisted requests can be sent more than once if the process stops while
+// sending them.
+//
+// By default, the export requests that failed are dropped.
+func WithDiskQueue(dq DiskQueueConfig) Option {
+	return fnOpt(func(c config) config {
+		c.diskQueue = newSetting(diskqueue.Config(dq))
+		return c
+	})
+}
+
 // HTTPTransportProxyFunc is a function that resolves which URL to use as proxy
 // for a given request. This type is compatible with http.Transport.Proxy and
 // can be used to set a custom proxy function to the OTLP HTTP client.
@@ -347,6 +493,20 @@ func WithProxy(pf HTTPTransportProxyFunc) Option {
 	})
 }
 
+// WithH2C sets the Exporter to send requests using HTTP/2 over cleartext
+// connections (h2c) with prior knowledge, instead of HTTP/1.1. The endpoint
+// needs to support it, as the OTLP/HTTP receiver of the OpenTelemetry
+// Collector does.
+//
+// This option only applies to insecure connections (see WithInsecure), and
+// proxies are not used with it.
+func WithH2C() Option {
+	return fnOpt(func(c config) config {
+		c.h2c = newSetting(true)
+		return c
+	})
+}
+
 // setting is a configuration setting value.
 type setting[T any] struct {
 	Value T
@@ -379,7 +539,8 @@ func (s setting[T]) Resolve(fn ...resolver[T]) setting[T] {
 
 // loadEnvTLS returns a resolver that loads a *tls.Config from files defined by
 // the OTLP TLS environment variables. This will load both the rootCAs and
-// certificates used for mTLS.
+// certificates used for mTLS. The files are read again when they change, so
+// rotated certificates are used for new connections.
 //
 // If the filepath defined is invalid or does not contain valid TLS files, an
 // error is passed to the OTel ErrorHandler and no TLS configuration is
@@ -391,33 +552,34 @@ func loadEnvTLS[T *tls.Config]() resolver[T] {
 			return s
 		}
 
-		var rootCAs *x509.CertPool
-		var err error
+		var caFile string
 		for _, key := range envTLSCert {
 			if v := os.Getenv(key); v != "" {
-				rootCAs, err = loadCertPool(v)
+				caFile = v
 				break
 			}
 		}
 
-		var certs []tls.Certificate
+		var certFile, keyFile string
 		for _, pair := range envTLSClient {
 			cert := os.Getenv(pair.Certificate)
 			key := os.Getenv(pair.Key)
 			if cert != "" && key != "" {
-				var e error
-				certs, e = loadCertificates(cert, key)
-				err = errors.Join(err, e)
+				certFile, keyFile = cert, key
 				break
 			}
 		}
 
+		if caFile == "" && certFile == "" {
+			return s
+		}
+		cfg, err := tlsreload.NewConfig(caFile, certFile, keyFile, readFile)
 		if err != nil {
 			err = fmt.Errorf("failed to load TLS: %w", err)
 			otel.Handle(err)
-		} else if rootCAs != nil || certs != nil {
+		} else {
 			s.Set = true
-			s.Value = &tls.Config{RootCAs: rootCAs, Certificates: certs}
+			s.Value = cfg
 		}
 		return s
 	}
@@ -426,38 +588,6 @@ func loadEnvTLS[T *tls.Config]() resolver[T] {
 // readFile is used for testing.
 var readFile = os.ReadFile
 
-// loadCertPool loads and returns the *x509.CertPool found at path if it exists
-// and is valid. Otherwise, nil and an error is returned.
-func loadCertPool(path string) (*x509.CertPool, error) {
-	b, err := readFile(path)
-	if err != nil {
-		return nil, err
-	}
-	cp := x509.NewCertPool()
-	if ok := cp.AppendCertsFromPEM(b); !ok {
-		return nil, errors.New("certificate not added")
-	}
-	return cp, nil
-}
-
-// loadCertificates loads and returns the tls.Certificate found at path if it
-// exists and is valid. Otherwise, nil and an error is returned.
-func loadCertificates(certPath, keyPath string) ([]tls.Certificate, error) {
-	cert, err := readFile(certPath)
-	if err != nil {
-		return nil, err
-	}
-	key, err := readFile(keyPath)
-	if err != nil {
-		return nil, err
-	}
-	crt, err := tls.X509KeyPair(cert, key)
-	if err != nil {
-		return nil, err
-	}
-	return []tls.Certificate{crt}, nil
-}
-
 // getenv returns a resolver that will apply an environment variable value
 // associated with the first set key to a setting value. The conv function is
 // used to convert between the environment variable value and the setting type.
@@ -496,9 +626,21 @@ func convEndpoint(s string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if u.Scheme == "unix" {
+		return unixEndpoint(u), nil
+	}
 	return u.Host, nil
 }
 
+// unixEndpoint returns the endpoint of the Unix domain socket of u, a URL with
+// the unix scheme, in the "unix:path" form.
+func unixEndpoint(u *url.URL) string {
+	if u.Opaque != "" {
+		return "unix:" + u.Opaque
+	}
+	return "unix:" + u.Path
+}
+
 // convPathExact converts s from a URL string to the exact path if s is a valid
 // URL. Otherwise, "" and an error are returned.
 //
@@ -508,6 +650,10 @@ func convPathExact(s string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if u.Scheme == "unix" {
+		// The path is the one of the socket.
+		return defaultPath, nil
+	}
 	if u.Path == "" {
 		return "/", nil
 	}
@@ -521,6 +667,10 @@ func convPath(s string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if u.Scheme == "unix" {
+		// The path is the one of the socket.
+		return defaultPath, nil
+	}
 	return u.Path + "/v1/logs", nil
 }
 
@@ -573,12 +723,27 @@ func convCompression(s string) (Compression, error) {
 	switch s {
 	case "gzip":
 		return GzipCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
 	case "none", "":
 		return NoCompression, nil
 	}
 	return NoCompression, fmt.Errorf("unknown compression: %s", s)
 }
 
+// convEncoding returns the encoding of the OTLP/HTTP protocol s.
+// ProtobufEncoding and an error are returned if s is not an OTLP/HTTP
+// protocol.
+func convEncoding(s string) (Encoding, error) {
+	switch s {
+	case "http/protobuf":
+		return ProtobufEncoding, nil
+	case "http/json":
+		return JSONEncoding, nil
+	}
+	return ProtobufEncoding, fmt.Errorf("unsupported protocol: %s", s)
+}
+
 // convDuration converts s into a duration of milliseconds. If s does not
 // contain an integer, 0 and an error are returned.
 func convDuration(s string) (time.Duration, error) {
@@ -98,6 +98,19 @@ func TestNewConfig(t *testing.T) {
 				retryCfg: newSetting(defaultRetryCfg),
 			},
 		},
+		{
+			name: "ZstdCompression",
+			options: []Option{
+				WithCompression(ZstdCompression),
+			},
+			want: config{
+				endpoint:    newSetting(defaultEndpoint),
+				path:        newSetting(defaultPath),
+				compression: newSetting(ZstdCompression),
+				timeout:     newSetting(defaultTimeout),
+				retryCfg:    newSetting(defaultRetryCfg),
+			},
+		},
 		{
 			name: "Options",
 			options: []Option{
@@ -106,20 +119,24 @@ func TestNewConfig(t *testing.T) {
 				WithInsecure(),
 				WithTLSClientConfig(tlsCfg),
 				WithCompression(GzipCompression),
+				WithEncoding(JSONEncoding),
 				WithHeaders(headers),
 				WithTimeout(time.Second),
+				WithMaxRequestSize(4 << 20),
 				WithRetry(RetryConfig(rc)),
 				// Do not test WithProxy. Requires func comparison.
 			},
 			want: config{
-				endpoint:    newSetting("test"),
-				path:        newSetting("/path"),
-				insecure:    newSetting(true),
-				tlsCfg:      newSetting(tlsCfg),
-				headers:     newSetting(headers),
-				compression: newSetting(GzipCompression),
-				timeout:     newSetting(time.Second),
-				retryCfg:    newSetting(rc),
+				endpoint:       newSetting("test"),
+				path:           newSetting("/path"),
+				insecure:       newSetting(true),
+				tlsCfg:         newSetting(tlsCfg),
+				headers:        newSetting(headers),
+				compression:    newSetting(GzipCompression),
+				encoding:       newSetting(JSONEncoding),
+				timeout:        newSetting(time.Second),
+				maxRequestSize: newSetting(4 << 20),
+				retryCfg:       newSetting(rc),
 			},
 		},
 		{
@@ -135,6 +152,34 @@ func TestNewConfig(t *testing.T) {
 				retryCfg: newSetting(defaultRetryCfg),
 			},
 		},
+		{
+			name: "WithEndpointURLUnix",
+			options: []Option{
+				WithEndpointURL("unix:///var/run/otel.sock"),
+				WithH2C(),
+			},
+			want: config{
+				endpoint: newSetting("unix:/var/run/otel.sock"),
+				path:     newSetting(defaultPath),
+				insecure: newSetting(true),
+				timeout:  newSetting(defaultTimeout),
+				retryCfg: newSetting(defaultRetryCfg),
+				h2c:      newSetting(true),
+			},
+		},
+		{
+			name: "LogEndpointEnvironmentVariablesUnix",
+			envars: map[string]string{
+				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			want: config{
+				endpoint: newSetting("unix:/var/run/otel.sock"),
+				path:     newSetting(defaultPath),
+				insecure: newSetting(true),
+				timeout:  newSetting(defaultTimeout),
+				retryCfg: newSetting(defaultRetryCfg),
+			},
+		},
 		{
 			name: "EndpointPrecedence",
 			options: []Option{
@@ -173,6 +218,7 @@ func TestNewConfig(t *testing.T) {
 				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT":           "https://env.endpoint:8080/prefix",
 				"OTEL_EXPORTER_OTLP_LOGS_HEADERS":            "a=A",
 				"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION":        "gzip",
+				"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL":           "http/json",
 				"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT":            "15000",
 				"OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE":        "cert_path",
 				"OTEL_EXPORTER_OTLP_LOGS_CLIENT_CERTIFICATE": "cert_path",
@@ -185,6 +231,7 @@ func TestNewConfig(t *testing.T) {
 				tlsCfg:      newSetting(tlsCfg),
 				headers:     newSetting(headers),
 				compression: newSetting(GzipCompression),
+				encoding:    newSetting(JSONEncoding),
 				timeout:     newSetting(15 * time.Second),
 				retryCfg:    newSetting(defaultRetryCfg),
 			},
@@ -208,6 +255,7 @@ func TestNewConfig(t *testing.T) {
 				"OTEL_EXPORTER_OTLP_ENDPOINT":           "http://env.endpoint:8080/prefix",
 				"OTEL_EXPORTER_OTLP_HEADERS":            "a=A",
 				"OTEL_EXPORTER_OTLP_COMPRESSION":        "none",
+				"OTEL_EXPORTER_OTLP_PROTOCOL":           "http/protobuf",
 				"OTEL_EXPORTER_OTLP_TIMEOUT":            "15000",
 				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
 				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
@@ -220,6 +268,7 @@ func TestNewConfig(t *testing.T) {
 				tlsCfg:      newSetting(tlsCfg),
 				headers:     newSetting(headers),
 				compression: newSetting(NoCompression),
+				encoding:    newSetting(ProtobufEncoding),
 				timeout:     newSetting(15 * time.Second),
 				retryCfg:    newSetting(defaultRetryCfg),
 			},
@@ -314,6 +363,7 @@ func TestNewConfig(t *testing.T) {
 				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT":           "%invalid",
 				"OTEL_EXPORTER_OTLP_LOGS_HEADERS":            "a,%ZZ=valid,key=%ZZ",
 				"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION":        "xz",
+				"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL":           "grpc",
 				"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT":            "100 seconds",
 				"OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE":        "invalid_cert",
 				"OTEL_EXPORTER_OTLP_LOGS_CLIENT_CERTIFICATE": "invalid_cert",
@@ -335,6 +385,7 @@ func TestNewConfig(t *testing.T) {
 				`invalid header key: %ZZ`,
 				`invalid header value: %ZZ`,
 				`invalid OTEL_EXPORTER_OTLP_LOGS_COMPRESSION value xz: unknown compression: xz`,
+				`invalid OTEL_EXPORTER_OTLP_LOGS_PROTOCOL value grpc: unsupported protocol: grpc`,
 				`invalid OTEL_EXPORTER_OTLP_LOGS_TIMEOUT value 100 seconds: strconv.Atoi: parsing "100 seconds": invalid syntax`,
 			},
 		},
@@ -11,7 +11,8 @@ The environment variables described below can be used for configuration.
 
 OTEL_EXPORTER_OTLP_ENDPOINT (default: "https://localhost:4318") -
 target base URL ("/v1/logs" is appended) to which the exporter sends telemetry.
-The value must contain a scheme ("http" or "https") and host.
+The value must contain a scheme ("http" or "https") and host,
+or be a "unix" scheme URL of a Unix domain socket, such as "unix:///var/run/otel.sock".
 The value may additionally contain a port and a path.
 The value should not contain a query string or fragment.
 The configuration can be overridden by OTEL_EXPORTER_OTLP_LOGS_ENDPOINT
@@ -19,7 +20,8 @@ environment variable and by [WithEndpoint], [WithEndpointURL], [WithInsecure] op
 
 OTEL_EXPORTER_OTLP_LOGS_ENDPOINT (default: "https://localhost:4318/v1/logs") -
 target URL to which the exporter sends telemetry.
-The value must contain a scheme ("http" or "https") and host.
+The value must contain a scheme ("http" or "https") and host,
+or be a "unix" scheme URL of a Unix domain socket, such as "unix:///var/run/otel.sock".
 The value may additionally contain a port and a path.
 The value should not contain a query string or fragment.
 The configuration can be overridden by [WithEndpoint], [WithEndpointURL], [WithInsecure], and [WithURLPath] options.
@@ -39,10 +41,16 @@ The configuration can be overridden by [WithTimeout] option.
 
 OTEL_EXPORTER_OTLP_COMPRESSION, OTEL_EXPORTER_OTLP_LOGS_COMPRESSION (default: none) -
 the compression strategy the exporter uses to compress the HTTP body.
-Supported value: "gzip".
+Supported values: "gzip", "zstd".
 OTEL_EXPORTER_OTLP_LOGS_COMPRESSION takes precedence over OTEL_EXPORTER_OTLP_COMPRESSION.
 The configuration can be overridden by [WithCompression] option.
 
+OTEL_EXPORTER_OTLP_PROTOCOL, OTEL_EXPORTER_OTLP_LOGS_PROTOCOL (default: "http/protobuf") -
+the encoding the exporter uses to encode the HTTP body.
+Supported values: "http/protobuf", "http/json".
+OTEL_EXPORTER_OTLP_LOGS_PROTOCOL takes precedence over OTEL_EXPORTER_OTLP_PROTOCOL.
+The configuration can be overridden by [WithEncoding] option.
+
 OTEL_EXPORTER_OTLP_CERTIFICATE, OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE (default: none) -
 the filepath to the trusted certificate to use when verifying a server's TLS credentials.
 OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE takes precedence over OTEL_EXPORTER_OTLP_CERTIFICATE.
@@ -58,6 +66,9 @@ the filepath to the client's private key to use in mTLS communication in PEM for
 OTEL_EXPORTER_OTLP_LOGS_CLIENT_KEY takes precedence over OTEL_EXPORTER_OTLP_CLIENT_KEY.
 The configuration can be overridden by [WithTLSClientConfig] option.
 
+The certificate files are read again when they change, so rotated certificates
+are used for new connections without recreating the exporter.
+
 [W3C Baggage HTTP Header Content Format]: https://www.w3.org/TR/baggage/#header-content
 */
 package otlploghttp // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
@@ -63,7 +63,8 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 		return nil
 	}
 
-	e.client.Store(newNoopClient())
+	// Stop sending the queued requests, they are kept for the next start.
+	e.client.Swap(newNoopClient()).Shutdown()
 	return nil
 }
 
@@ -0,0 +1,128 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/partialsuccess_test.go
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func requireErrorString(t *testing.T, expect string, err error) {
+	t.Helper()
+	require.Error(t, err)
+	require.ErrorIs(t, err, PartialSuccess{})
+
+	const pfx = "OTLP partial success: "
+
+	msg := err.Error()
+	require.True(t, strings.HasPrefix(msg, pfx))
+	require.Equal(t, expect, msg[len(pfx):])
+}
+
+func TestPartialSuccessFormat(t *testing.T) {
+	requireErrorString(t, "empty message (0 metric data points rejected)", MetricPartialSuccessError(0, ""))
+	requireErrorString(t, "help help (0 metric data points rejected)", MetricPartialSuccessError(0, "help help"))
+	requireErrorString(t, "what happened (10 metric data points rejected)", MetricPartialSuccessError(10, "what happened"))
+	requireErrorString(t, "what happened (15 spans rejected)", TracePartialSuccessError(15, "what happened"))
+	requireErrorString(t, "what happened (5 log records rejected)", LogPartialSuccessError(5, "what happened"))
+}
+
+// meterProvider records the Int64Counter instruments created by its Meters.
+type meterProvider struct {
+	noop.MeterProvider
+
+	scope    string
+	counters map[string]*counter
+}
+
+func (mp *meterProvider) Meter(name string, _ ...metric.MeterOption) metric.Meter {
+	mp.scope = name
+	return meter{mp: mp}
+}
+
+type meter struct {
+	noop.Meter
+
+	mp *meterProvider
+}
+
+func (m meter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	c := &counter{}
+	if m.mp.counters == nil {
+		m.mp.counters = make(map[string]*counter)
+	}
+	m.mp.counters[name] = c
+	return c, nil
+}
+
+type counter struct {
+	noop.Int64Counter
+
+	sum int64
+}
+
+func (c *counter) Add(_ context.Context, incr int64, _ ...metric.AddOption) {
+	c.sum += incr
+}
+
+func TestPartialSuccessHandler(t *testing.T) {
+	ctx := context.Background()
+
+	var got []PartialSuccess
+	mp := &meterProvider{}
+	h := NewTracePartialSuccessHandler(mp, "scope", "v0.1.0", func(ps PartialSuccess) {
+		got = append(got, ps)
+	})
+	assert.Equal(t, "scope", mp.scope)
+
+	h.Handle(ctx, 0, "")
+	h.Handle(ctx, 2, "rejected")
+	h.Handle(ctx, 0, "warning")
+	h.Handle(ctx, 3, "")
+
+	want := []PartialSuccess{
+		{ErrorMessage: "rejected", RejectedItems: 2, RejectedKind: "spans"},
+		{ErrorMessage: "warning", RejectedItems: 0, RejectedKind: "spans"},
+		{ErrorMessage: "", RejectedItems: 3, RejectedKind: "spans"},
+	}
+	assert.Equal(t, want, got)
+	require.Contains(t, mp.counters, "otel.sdk.exporter.span.rejected")
+	assert.Equal(t, int64(5), mp.counters["otel.sdk.exporter.span.rejected"].sum)
+}
+
+func TestPartialSuccessHandlerDefault(t *testing.T) {
+	var got []error
+	eh := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		got = append(got, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(eh) })
+
+	mp := &meterProvider{}
+	h := NewMetricPartialSuccessHandler(mp, "scope", "v0.1.0", nil)
+	h.Handle(context.Background(), 4, "rejected")
+
+	require.Len(t, got, 1)
+	var ps PartialSuccess
+	require.True(t, errors.As(got[0], &ps))
+	assert.Equal(t, int64(4), ps.RejectedItems)
+	assert.Equal(t, "metric data points", ps.RejectedKind)
+	assert.Equal(t, int64(4), mp.counters["otel.sdk.exporter.metric_data_point.rejected"].sum)
+
+	h = NewLogPartialSuccessHandler(mp, "scope", "v0.1.0", nil)
+	h.Handle(context.Background(), 1, "")
+	assert.Equal(t, int64(1), mp.counters["otel.sdk.exporter.log.rejected"].sum)
+}
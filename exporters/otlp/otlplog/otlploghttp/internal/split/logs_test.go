@@ -0,0 +1,69 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/logs_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+func newLogRecord(body string) *logpb.LogRecord {
+	return &logpb.LogRecord{
+		TimeUnixNano: 1,
+		Body: &cpb.AnyValue{
+			Value: &cpb.AnyValue_StringValue{StringValue: body},
+		},
+	}
+}
+
+func TestLogs(t *testing.T) {
+	var records []*logpb.LogRecord
+	for i := 0; i < 50; i++ {
+		records = append(records, newLogRecord(strings.Repeat("x", i)))
+	}
+	records = append(records, newLogRecord(strings.Repeat("x", 500)))
+	rl := []*logpb.ResourceLogs{
+		{
+			Resource: &rpb.Resource{DroppedAttributesCount: 1},
+			ScopeLogs: []*logpb.ScopeLogs{
+				{Scope: &cpb.InstrumentationScope{Name: "a"}, LogRecords: records[:25]},
+				{Scope: &cpb.InstrumentationScope{Name: "b"}, LogRecords: records[25:]},
+			},
+		},
+	}
+
+	const maxSize = 250
+	batches, err := Logs(rl, maxSize, nil)
+	assert.EqualError(t, err, "1 log records dropped: larger than the maximum request size of 250 bytes")
+	require.Greater(t, len(batches), 1)
+
+	var got []*logpb.LogRecord
+	for _, batch := range batches {
+		req := &collogpb.ExportLogsServiceRequest{ResourceLogs: batch}
+		assert.LessOrEqual(t, proto.Size(req), maxSize)
+		for _, r := range batch {
+			assert.Same(t, rl[0].Resource, r.Resource)
+			for _, sl := range r.ScopeLogs {
+				got = append(got, sl.LogRecords...)
+			}
+		}
+	}
+	assert.Equal(t, records[:50], got)
+
+	batches, err = Logs(rl, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]*logpb.ResourceLogs{rl}, batches)
+}
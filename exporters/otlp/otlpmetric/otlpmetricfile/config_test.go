@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewConfig(t *testing.T) {
+	var buf bytes.Buffer
+	deltaSelector := func(metric.InstrumentKind) metricdata.Temporality {
+		return metricdata.DeltaTemporality
+	}
+	dropSelector := func(metric.InstrumentKind) metric.Aggregation {
+		return metric.AggregationDrop{}
+	}
+
+	testCases := []struct {
+		name    string
+		options []Option
+		check   func(*testing.T, config)
+	}{
+		{
+			name: "Defaults",
+			check: func(t *testing.T, c config) {
+				assert.Equal(t, os.Stdout, c.writer)
+				assert.Empty(t, c.path)
+				assert.Zero(t, c.maxSize)
+				assert.Zero(t, c.maxBackups)
+				assert.Equal(t, metricdata.CumulativeTemporality, c.temporalitySelector(metric.InstrumentKindCounter))
+				assert.Equal(t, metric.AggregationSum{}, c.aggregationSelector(metric.InstrumentKindCounter))
+			},
+		},
+		{
+			name:    "WithWriter",
+			options: []Option{WithFile("metrics.jsonl"), WithWriter(&buf)},
+			check: func(t *testing.T, c config) {
+				assert.Equal(t, &buf, c.writer)
+				assert.Empty(t, c.path)
+			},
+		},
+		{
+			name:    "WithFile",
+			options: []Option{WithFile("metrics.jsonl"), WithRotation(1024, 3)},
+			check: func(t *testing.T, c config) {
+				assert.Equal(t, "metrics.jsonl", c.path)
+				assert.Equal(t, int64(1024), c.maxSize)
+				assert.Equal(t, 3, c.maxBackups)
+			},
+		},
+		{
+			name:    "WithTemporalitySelector",
+			options: []Option{WithTemporalitySelector(deltaSelector)},
+			check: func(t *testing.T, c config) {
+				assert.Equal(t, metricdata.DeltaTemporality, c.temporalitySelector(metric.InstrumentKindCounter))
+			},
+		},
+		{
+			name:    "WithAggregationSelector",
+			options: []Option{WithAggregationSelector(dropSelector)},
+			check: func(t *testing.T, c config) {
+				assert.Equal(t, metric.AggregationDrop{}, c.aggregationSelector(metric.InstrumentKindCounter))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, newConfig(tc.options))
+		})
+	}
+}
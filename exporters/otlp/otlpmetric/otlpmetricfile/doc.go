@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlpmetricfile provides an OTLP metric exporter writing metrics as
+OTLP/JSON Lines to a file or an [io.Writer].
+
+Each export is written as a single line holding an
+ExportMetricsServiceRequest encoded as OTLP/JSON, the format of the OTLP file
+exporter specification. The written files can be read by tools ingesting this
+format, like the otlpjsonfile receiver of the OpenTelemetry Collector.
+
+By default the lines are written to [os.Stdout]. Use [WithFile] to write
+them to a file, optionally rotated with [WithRotation], or [WithWriter] to
+write them to any [io.Writer].
+
+The Exporter should be created using [New] and used with a
+[metric.PeriodicReader].
+
+[metric.PeriodicReader]: https://pkg.go.dev/go.opentelemetry.io/otel/sdk/metric#PeriodicReader
+*/
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile_test
+
+import (
+	"context"
+	"log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func Example() {
+	ctx := context.Background()
+	exp, err := otlpmetricfile.New(
+		otlpmetricfile.WithFile("/var/log/otel/metrics.jsonl"),
+		// Keep at most 5 rotated files of 100 MiB.
+		otlpmetricfile.WithRotation(100<<20, 5),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	meterProvider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exp)))
+	defer func() {
+		if err := meterProvider.Shutdown(ctx); err != nil {
+			log.Println(err)
+		}
+	}()
+	otel.SetMeterProvider(meterProvider)
+
+	// From here, the meterProvider can be used by instrumentation to collect
+	// telemetry.
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/filewriter"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/otlpjson"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/transform"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+var errShutdown = errors.New("file exporter is shutdown")
+
+// Exporter is an OpenTelemetry metric Exporter writing OTLP/JSON lines.
+type Exporter struct {
+	path                string
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+
+	// mu protects the writer and closer. They are unset on Shutdown.
+	mu     sync.Mutex
+	writer *filewriter.Writer
+	closer io.Closer
+}
+
+// Compile-time check Exporter implements [metric.Exporter].
+var _ metric.Exporter = (*Exporter)(nil)
+
+// New returns an OpenTelemetry metric Exporter. The Exporter can be used with
+// a PeriodicReader to write OpenTelemetry metric data as OTLP/JSON lines.
+//
+// If WithFile is used, the file is opened and an error is returned if it
+// cannot be.
+func New(opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts)
+	w, closer, err := cfg.open()
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		path:                cfg.path,
+		temporalitySelector: cfg.temporalitySelector,
+		aggregationSelector: cfg.aggregationSelector,
+		writer:              w,
+		closer:              closer,
+	}, nil
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (e *Exporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return e.temporalitySelector(k)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return e.aggregationSelector(k)
+}
+
+// Export transforms metric data and writes it as a single OTLP/JSON line.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	defer global.Debug("OTLP file exporter export", "Data", rm)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	otlpRm, err := transform.ResourceMetrics(rm)
+	// Best effort write of transformable metrics.
+	b, mErr := otlpjson.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{otlpRm},
+	})
+	if mErr != nil {
+		return errors.Join(err, mErr)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer == nil {
+		return errShutdown
+	}
+	if wErr := e.writer.WriteLine(b); wErr != nil {
+		if err == nil {
+			return fmt.Errorf("failed to write metrics: %w", wErr)
+		}
+		// Merge the two errors.
+		return fmt.Errorf("failed to write incomplete metrics (%w): %w", err, wErr)
+	}
+	return err
+}
+
+// ForceFlush commits the written metric data to stable storage if the
+// underlying file or writer supports it.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+//
+// This method is safe to call concurrently.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer == nil {
+		return errShutdown
+	}
+	return e.writer.Sync()
+}
+
+// Shutdown flushes the written metric data and closes the file opened by the
+// Exporter. Writers set with WithWriter are not closed.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+//
+// This method is safe to call concurrently.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.writer == nil {
+		return errShutdown
+	}
+	err := e.writer.Sync()
+	if e.closer != nil {
+		err = errors.Join(err, e.closer.Close())
+	}
+	e.writer, e.closer = nil, nil
+	return errors.Join(err, ctx.Err())
+}
+
+// MarshalLog returns logging data about the Exporter.
+func (e *Exporter) MarshalLog() interface{} {
+	return struct {
+		Type string
+		Path string
+	}{
+		Type: "OTLP file",
+		Path: e.path,
+	}
+}
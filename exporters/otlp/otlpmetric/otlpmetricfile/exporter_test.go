@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/otlpjson"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+)
+
+// requests decodes the OTLP/JSON lines of s.
+func requests(t *testing.T, s string) []*colmetricpb.ExportMetricsServiceRequest {
+	t.Helper()
+
+	var out []*colmetricpb.ExportMetricsServiceRequest
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		req := &colmetricpb.ExportMetricsServiceRequest{}
+		require.NoError(t, otlpjson.Unmarshal([]byte(line), req))
+		out = append(out, req)
+	}
+	return out
+}
+
+func TestExporterWriter(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := New(WithWriter(&buf))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	res := resource.NewSchemaless(attribute.String("service.name", "test"))
+	provider := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(metric.NewPeriodicReader(exp)),
+	)
+	counter, err := provider.Meter("meter").Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(ctx, 2, otelmetric.WithAttributes(attribute.String("method", "GET")))
+
+	require.NoError(t, provider.ForceFlush(ctx))
+	// The final export on shutdown writes a second line.
+	require.NoError(t, provider.Shutdown(ctx))
+
+	got := requests(t, buf.String())
+	require.Len(t, got, 2)
+	for _, req := range got {
+		require.Len(t, req.ResourceMetrics, 1)
+		rm := req.ResourceMetrics[0]
+		assert.Equal(t, "service.name", rm.Resource.Attributes[0].Key)
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		m := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "requests", m.Name)
+		assert.Equal(t, int64(2), m.GetSum().DataPoints[0].GetAsInt())
+	}
+}
+
+func TestExporterFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	exp, err := New(WithFile(path))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rm := &metricdata.ResourceMetrics{Resource: resource.Empty()}
+	require.NoError(t, exp.Export(ctx, rm))
+	require.NoError(t, exp.ForceFlush(ctx))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, requests(t, string(b)), 1)
+}
+
+func TestExporterFileError(t *testing.T) {
+	_, err := New(WithFile(filepath.Join(t.TempDir(), "missing", "metrics.jsonl")))
+	assert.ErrorContains(t, err, "failed to open file")
+}
+
+func TestExporterShutdown(t *testing.T) {
+	exp, err := New(WithWriter(&bytes.Buffer{}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.ErrorIs(t, exp.Shutdown(ctx), errShutdown)
+	assert.ErrorIs(t, exp.ForceFlush(ctx), errShutdown)
+	assert.ErrorIs(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), errShutdown)
+}
+
+func TestExporterCanceledContext(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := New(WithWriter(&buf))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), context.Canceled)
+	assert.ErrorIs(t, exp.ForceFlush(ctx), context.Canceled)
+	assert.Empty(t, buf.String())
+}
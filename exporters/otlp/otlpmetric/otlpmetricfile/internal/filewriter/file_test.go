@@ -0,0 +1,118 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/filewriter/file_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filewriter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestFileAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otlp.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0o600))
+
+	f, err := OpenFile(path, 0, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("line\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "existing\nline\n", readFile(t, path))
+
+	_, err = f.Write([]byte("line\n"))
+	assert.ErrorIs(t, err, errClosed)
+	assert.ErrorIs(t, f.Sync(), errClosed)
+	assert.ErrorIs(t, f.Close(), errClosed)
+}
+
+func TestFileRotate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "otlp.jsonl")
+
+	f, err := OpenFile(path, 10, 2)
+	require.NoError(t, err)
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		_, err = f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "line 4\n", readFile(t, path))
+	assert.Equal(t, "line 3\n", readFile(t, path+".1"))
+	assert.Equal(t, "line 2\n", readFile(t, path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestFileRotateWithoutBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "otlp.jsonl")
+
+	f, err := OpenFile(path, 10, 0)
+	require.NoError(t, err)
+	for _, line := range []string{"line 1\n", "line 2\n"} {
+		_, err = f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "line 2\n", readFile(t, path))
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestFileRotateError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "otlp.jsonl")
+	// The file cannot be renamed to a non-empty directory.
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "dir"), 0o700))
+
+	f, err := OpenFile(path, 10, 1)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("line 1\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("line 2\n"))
+	assert.ErrorContains(t, err, "failed to rotate file")
+
+	// The file is still written once it can be rotated.
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = f.Write([]byte("line 3\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "line 3\n", readFile(t, path))
+	assert.Equal(t, "line 1\n", readFile(t, path+".1"))
+}
+
+func TestFileOversizedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otlp.jsonl")
+
+	f, err := OpenFile(path, 4, 1)
+	require.NoError(t, err)
+	// Writes larger than the maximum size are not split.
+	_, err = f.Write([]byte("oversized\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "oversized\n", readFile(t, path))
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestOpenFileError(t *testing.T) {
+	_, err := OpenFile(filepath.Join(t.TempDir(), "missing", "otlp.jsonl"), 0, 0)
+	assert.ErrorContains(t, err, "failed to open file")
+}
@@ -0,0 +1,61 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/filewriter/writer.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filewriter provides the writers used by the OTLP file exporters to
+// write OTLP/JSON Lines.
+package filewriter // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/filewriter"
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// Writer writes lines to an io.Writer. It is safe for concurrent use.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter returns a Writer writing lines to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteLine writes b followed by a newline to the underlying io.Writer. The
+// line is written with a single call to the Write method of the io.Writer so
+// lines written concurrently are not interleaved.
+func (w *Writer) WriteLine(b []byte) error {
+	line := make([]byte, len(b)+1)
+	copy(line, b)
+	line[len(b)] = '\n'
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.w.Write(line)
+	return err
+}
+
+// Sync commits the lines written to stable storage if the underlying
+// io.Writer supports it. Files that are not regular files, like pipes and
+// terminals, are not synced.
+func (w *Writer) Sync() error {
+	s, ok := w.w.(interface{ Sync() error })
+	if !ok {
+		return nil
+	}
+	if f, ok := w.w.(*os.File); ok {
+		// Syncing a pipe, a terminal, or a device returns an error on most
+		// platforms, and os.Stdout is often one of them.
+		if info, err := f.Stat(); err == nil && !info.Mode().IsRegular() {
+			return nil
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return s.Sync()
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal"
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/file.go.tmpl "--data={}" --out=filewriter/file.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/file_test.go.tmpl "--data={}" --out=filewriter/file_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/writer.go.tmpl "--data={}" --out=filewriter/writer.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/writer_test.go.tmpl "--data={}" --out=filewriter/writer_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpjson/otlpjson.go.tmpl "--data={}" --out=otlpjson/otlpjson.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpjson/otlpjson_test.go.tmpl "--data={}" --out=otlpjson/otlpjson_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/transform/attribute.go.tmpl "--data={}" --out=transform/attribute.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/transform/attribute_test.go.tmpl "--data={}" --out=transform/attribute_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/transform/error.go.tmpl "--data={}" --out=transform/error.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/transform/error_test.go.tmpl "--data={}" --out=transform/error_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/transform/metricdata.go.tmpl "--data={}" --out=transform/metricdata.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/transform/metricdata_test.go.tmpl "--data={}" --out=transform/metricdata_test.go
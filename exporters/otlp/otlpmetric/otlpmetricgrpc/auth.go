@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth"
+)
+
+// Authenticator provides the authentication headers of export requests. Its
+// Headers method is called before every request, including retries, so it can
+// return short-lived credentials. It must be safe for concurrent use.
+type Authenticator = auth.Authenticator
+
+// AuthenticatorFunc is a function implementing Authenticator.
+type AuthenticatorFunc func(ctx context.Context) (map[string]string, error)
+
+// Headers returns f(ctx).
+func (f AuthenticatorFunc) Headers(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+// OAuth2Config configures an Authenticator that obtains access tokens using
+// the OAuth 2.0 client credentials grant.
+type OAuth2Config auth.ClientCredentialsConfig
+
+// NewOAuth2Authenticator returns an Authenticator that sends the access tokens
+// it obtains with cfg as bearer tokens in the Authorization header. A token is
+// requested on first use and cached until shortly before it expires.
+func NewOAuth2Authenticator(cfg OAuth2Config) Authenticator {
+	return auth.NewClientCredentials(auth.ClientCredentialsConfig(cfg))
+}
+
+// NewTokenFileAuthenticator returns an Authenticator that sends the content of
+// the file at path as a bearer token in the Authorization header. The file is
+// read again whenever it changes, so the token can be rotated by an external
+// process.
+func NewTokenFileAuthenticator(path string) Authenticator {
+	return auth.NewTokenFile(path)
+}
@@ -5,6 +5,8 @@ package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpme
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -12,11 +14,15 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/split"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
@@ -26,6 +32,18 @@ type client struct {
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
 
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize int
+
+	partialSuccess *internal.PartialSuccessHandler
+
+	// callOpts are the options of every export call.
+	callOpts []grpc.CallOption
+
+	// queue is the disk queue of the export requests that failed with
+	// transient errors. It is nil if no disk queue is configured.
+	queue *diskqueue.Queue
+
 	// ourConn keeps track of where conn was created: true if created here in
 	// NewClient, or false if passed with an option. This is important on
 	// Shutdown as the conn should only be closed if we created it. Otherwise,
@@ -38,15 +56,43 @@ type client struct {
 // newClient creates a new gRPC metric client.
 func newClient(_ context.Context, cfg oconf.Config) (*client, error) {
 	c := &client{
-		exportTimeout: cfg.Metrics.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
-		conn:          cfg.GRPCConn,
+		exportTimeout:  cfg.Metrics.Timeout,
+		requestFunc:    cfg.RetryConfig.RequestFunc(retryable),
+		maxRequestSize: cfg.Metrics.MaxRequestSize,
+		partialSuccess: internal.NewMetricPartialSuccessHandler(
+			otel.GetMeterProvider(),
+			"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc",
+			Version(),
+			cfg.Metrics.PartialSuccessHandler,
+		),
+		conn: cfg.GRPCConn,
+	}
+
+	if s := cfg.SharedClient; s != nil {
+		c.conn = s.Conn()
+		c.requestFunc = s.Do
 	}
 
 	if len(cfg.Metrics.Headers) > 0 {
 		c.metadata = metadata.New(cfg.Metrics.Headers)
 	}
 
+	if a := cfg.Metrics.Authenticator; a != nil {
+		// The transport security of a connection passed with an option is
+		// decided by the creator of the connection.
+		secure := !cfg.Metrics.Insecure && c.conn == nil
+		creds := auth.PerRPCCredentials(a, secure)
+		c.callOpts = append(c.callOpts, grpc.PerRPCCredentials(creds))
+	}
+
+	if cfg.DiskQueue.Dir != "" {
+		q, err := diskqueue.New(cfg.DiskQueue, c.replay)
+		if err != nil {
+			return nil, err
+		}
+		c.queue = q
+	}
+
 	if c.conn == nil {
 		// If the caller did not provide a ClientConn when the client was
 		// created, create one using the configuration they did provide.
@@ -56,6 +102,7 @@ func newClient(_ context.Context, cfg oconf.Config) (*client, error) {
 
 		conn, err := grpc.NewClient(cfg.Metrics.Endpoint, dialOpts...)
 		if err != nil {
+			c.queue.Close()
 			return nil, err
 		}
 		// Keep track that we own the lifecycle of this conn and need to close
@@ -66,6 +113,9 @@ func newClient(_ context.Context, cfg oconf.Config) (*client, error) {
 
 	c.msc = colmetricpb.NewMetricsServiceClient(c.conn)
 
+	// Send the requests queued before a restart.
+	c.queue.Flush()
+
 	return c, nil
 }
 
@@ -80,6 +130,9 @@ func (c *client) Shutdown(ctx context.Context) error {
 	// ensures this is called only once. The only thing that needs to be done
 	// here is to release any computational resources the client holds.
 
+	// Stop sending the queued requests, they are kept for the next start.
+	c.queue.Close()
+
 	c.metadata = nil
 	c.requestFunc = nil
 	c.msc = nil
@@ -101,6 +154,27 @@ func (c *client) Shutdown(ctx context.Context) error {
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
 func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
+	parts, err := split.Metrics(protoMetrics, c.maxRequestSize, nil)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	var errs []error
+	for _, part := range parts {
+		if err := c.uploadMetrics(ctx, part, c.requestFunc); err != nil {
+			c.enqueue(part, err)
+			errs = append(errs, err)
+			continue
+		}
+		// The endpoint is available, send the queued requests.
+		c.queue.Flush()
+	}
+	return errors.Join(errs...)
+}
+
+// uploadMetrics sends protoMetrics in a single export request, using
+// requestFunc.
+func (c *client) uploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics, requestFunc retry.RequestFunc) error {
 	// The otlpmetric.Exporter synchronizes access to client methods, and
 	// ensures this is not called after the Exporter is shutdown. Only thing
 	// to do here is send data.
@@ -115,17 +189,14 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	return requestFunc(ctx, func(iCtx context.Context) error {
 		resp, err := c.msc.Export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
 			ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
-		})
+		}, c.callOpts...)
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedDataPoints()
-			if n != 0 || msg != "" {
-				err := internal.MetricPartialSuccessError(n, msg)
-				otel.Handle(err)
-			}
+			c.partialSuccess.Handle(iCtx, n, msg)
 		}
 		// nil is converted to OK.
 		if status.Code(err) == codes.OK {
@@ -136,6 +207,53 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	})
 }
 
+// enqueue adds the export request of protoMetrics, which failed with err, to
+// the disk queue if the error is transient.
+func (c *client) enqueue(protoMetrics *metricpb.ResourceMetrics, err error) {
+	if c.queue == nil || !transient(err) {
+		return
+	}
+
+	data, err := proto.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
+	})
+	if err == nil {
+		err = c.queue.Push(data)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// replay sends the export request data read from the disk queue in a single
+// attempt. The request is dropped if it fails with a permanent error.
+func (c *client) replay(ctx context.Context, data []byte) error {
+	var req colmetricpb.ExportMetricsServiceRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		otel.Handle(fmt.Errorf("dropping invalid queued export request: %w", err))
+		return nil
+	}
+
+	var errs []error
+	for _, rm := range req.ResourceMetrics {
+		if err := c.uploadMetrics(ctx, rm, once); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && !transient(err) {
+		otel.Handle(err)
+		return nil
+	}
+	return err
+}
+
+// once sends a request in a single attempt.
+func once(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
 // exportContext returns a copy of parent with an appropriate deadline and
 // cancellation function based on the clients configured export timeout.
 //
@@ -166,6 +284,18 @@ func (c *client) exportContext(parent context.Context) (context.Context, context
 	return ctx, cancel
 }
 
+// transient returns if err, returned by an export request, may not be
+// returned when sending the request again later: the request failed with a
+// retry-able status, or was not sent.
+func transient(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	ok, _ = retryableGRPCStatus(s)
+	return ok
+}
+
 // retryable returns if err identifies a request that can be retried and a
 // duration to wait for if an explicit throttle time is included in err.
 func retryable(err error) (bool, time.Duration) {
@@ -5,6 +5,7 @@ package otlpmetricgrpc
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -174,6 +176,24 @@ func TestClient(t *testing.T) {
 	t.Run("Integration", otest.RunClientTests(factory))
 }
 
+// sharedClient is a SharedClient retrying the export requests that fail up
+// to three times.
+type sharedClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c sharedClient) Conn() *grpc.ClientConn { return c.conn }
+
+func (c sharedClient) Do(ctx context.Context, fn func(context.Context) error) error {
+	var err error
+	for i := 0; i < 3; i++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func TestConfig(t *testing.T) {
 	factoryFunc := func(rCh <-chan otest.ExportResult, o ...Option) (metric.Exporter, *otest.GRPCCollector) {
 		coll, err := otest.NewGRPCCollector("", rCh)
@@ -223,6 +243,38 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, []string{headers[key]}, got[key])
 	})
 
+	t.Run("WithAuthenticatorGRPCConn", func(t *testing.T) {
+		coll, err := otest.NewGRPCCollector("", nil)
+		require.NoError(t, err)
+		t.Cleanup(coll.Shutdown)
+
+		conn, err := grpc.NewClient(coll.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+		// The insecure connection is used without WithInsecure.
+		a := AuthenticatorFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"Authorization": "Bearer token"}, nil
+		})
+		ctx := context.Background()
+		exp, err := New(ctx, WithGRPCConn(conn), WithAuthenticator(a))
+		require.NoError(t, err)
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		assert.Equal(t, []string{"Bearer token"}, coll.Headers()["authorization"])
+	})
+
+	t.Run("WithCompressorZstd", func(t *testing.T) {
+		exp, coll := factoryFunc(nil, WithCompressor("zstd"))
+		t.Cleanup(coll.Shutdown)
+
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
 	t.Run("WithTimeout", func(t *testing.T) {
 		// Do not send on rCh so the Collector never responds to the client.
 		rCh := make(chan otest.ExportResult)
@@ -239,6 +291,66 @@ func TestConfig(t *testing.T) {
 		assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
 	})
 
+	t.Run("WithSharedClient", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 2)
+		rCh <- otest.ExportResult{Err: status.Error(codes.Unavailable, "unavailable")}
+		rCh <- otest.ExportResult{}
+		coll, err := otest.NewGRPCCollector("", rCh)
+		require.NoError(t, err)
+		t.Cleanup(coll.Shutdown)
+
+		conn, err := grpc.NewClient(
+			coll.Addr().String(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		shared := sharedClient{conn: conn}
+
+		ctx := context.Background()
+		exp, err := New(ctx, WithSharedClient(shared), WithRetry(RetryConfig{Enabled: false}))
+		require.NoError(t, err)
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		require.NoError(t, exp.Shutdown(ctx))
+		// The failed attempt is retried by the shared client.
+		assert.Len(t, coll.Collect().Dump(), 2)
+
+		// The shared connection is not closed by the exporter shutdown.
+		assert.NoError(t, conn.Close())
+	})
+
+	t.Run("WithDiskQueue", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 4)
+		rCh <- otest.ExportResult{Err: status.Error(codes.Unavailable, "unavailable")}
+		rCh <- otest.ExportResult{Err: status.Error(codes.InvalidArgument, "invalid")}
+		rCh <- otest.ExportResult{}
+		rCh <- otest.ExportResult{}
+		dir := t.TempDir()
+		exp, coll := factoryFunc(
+			rCh,
+			WithRetry(RetryConfig{Enabled: false}),
+			WithDiskQueue(DiskQueueConfig{Dir: dir}),
+		)
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		// The request failing with a transient error is queued, the one
+		// failing with a permanent error is dropped.
+		assert.Error(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Error(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		// The queued request is sent once an export succeeds.
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Eventually(t, func() bool {
+			entries, err := os.ReadDir(dir)
+			return err == nil && len(entries) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Len(t, coll.Collect().Dump(), 4)
+	})
+
 	t.Run("WithCustomUserAgent", func(t *testing.T) {
 		key := "user-agent"
 		customerUserAgent := "custom-user-agent"
@@ -4,6 +4,7 @@
 package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,8 +12,11 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/zstd"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -29,6 +33,15 @@ func asGRPCOptions(opts []Option) []oconf.GRPCOption {
 	return converted
 }
 
+// PartialSuccessError is the error describing a partial success response of the
+// OTLP server, which accepted an export request but rejected metric data points
+// of it or returned a warning. RejectedItems is the number of rejected metric
+// data points and ErrorMessage the message of the server.
+//
+// It is passed to the function set with WithPartialSuccessHandler, or otherwise
+// to the global error handler. Use errors.As to identify it there.
+type PartialSuccessError = internal.PartialSuccess
+
 // RetryConfig defines configuration for retrying the export of metric data
 // that failed.
 //
@@ -36,6 +49,10 @@ func asGRPCOptions(opts []Option) []oconf.GRPCOption {
 // entirely handled by the gRPC ClientConn.
 type RetryConfig retry.Config
 
+// DiskQueueConfig configures the disk queue of the export requests that failed
+// with transient errors. See WithDiskQueue.
+type DiskQueueConfig diskqueue.Config
+
 type wrappedOption struct {
 	oconf.GRPCOption
 }
@@ -94,6 +111,9 @@ func WithEndpoint(endpoint string) Option {
 //
 // If an invalid URL is provided, the default value will be kept.
 //
+// A URL with the unix scheme, such as "unix:///var/run/otel.sock", connects
+// to the Unix domain socket at its path without transport security.
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "localhost:4317" will be used.
 //
@@ -114,8 +134,11 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) oconf.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return oconf.GzipCompression
+	case zstd.Name:
+		return oconf.ZstdCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -123,12 +146,12 @@ func compressorToCompression(compressor string) oconf.Compression {
 }
 
 // WithCompressor sets the compressor the gRPC client uses.
-// Supported compressor values: "gzip".
+// Supported compressor values: "gzip", "zstd".
 //
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", or "zstd". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -213,6 +236,36 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})}
 }
 
+// SharedClient is a gRPC connection, with a retry policy, shared by several
+// exporters. The Client of go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared
+// implements it.
+type SharedClient interface {
+	// Conn returns the gRPC connection the export requests are sent with.
+	Conn() *grpc.ClientConn
+	// Do sends an export request by calling fn, retrying it according to the
+	// retry policy of the SharedClient.
+	Do(ctx context.Context, fn func(context.Context) error) error
+}
+
+// WithSharedClient sets c as the gRPC connection and retry policy of the
+// exporter. All the exporters built from c share its connection, retry
+// policy, and export budget.
+//
+// This option takes precedence over WithGRPCConn, WithRetry, and any other
+// option that relates to establishing or persisting a gRPC connection to a
+// target endpoint. Any other option of those types passed will be ignored.
+//
+// It is the callers responsibility to close c once all the exporters built
+// from it are shut down. The Exporter Shutdown method will not close it.
+func WithSharedClient(c SharedClient) Option {
+	return wrappedOption{oconf.NewGRPCOption(func(cfg oconf.Config) oconf.Config {
+		if c != nil {
+			cfg.SharedClient = c
+		}
+		return cfg
+	})}
+}
+
 // WithTimeout sets the max amount of time an Exporter will attempt an export.
 //
 // This takes precedence over any retry settings defined by WithRetry. Once
@@ -231,6 +284,50 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{oconf.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the export requests
+// sent by the exporter. A batch of metrics that would encode to a larger
+// request is split into multiple requests, keeping metrics grouped by their
+// resource and instrumentation scope. The data points of a metric too large
+// for a request are split across multiple requests. Data points larger than
+// size on their own are dropped and reported to the global error handler.
+//
+// The size is measured using the protobuf encoding of the request, before any
+// compression is applied. The gRPC server rejects messages larger than its
+// maximum receive message size, which is 4 MiB by default.
+//
+// If unset or not positive, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(size)}
+}
+
+// WithPartialSuccessHandler sets the function called with the partial success
+// responses of the OTLP server. It is called synchronously during the export,
+// so it should not block.
+//
+// If unset, partial successes are passed to the global error handler.
+//
+// Regardless of this option, the number of rejected metric data points is
+// counted with the otel.sdk.exporter.metric_data_point.rejected counter of the
+// global MeterProvider.
+func WithPartialSuccessHandler(h func(PartialSuccessError)) Option {
+	return wrappedOption{oconf.WithPartialSuccessHandler(h)}
+}
+
+// WithAuthenticator sets the Authenticator providing the authentication
+// headers of the export requests, for example an Authenticator returned by
+// NewOAuth2Authenticator or NewTokenFileAuthenticator.
+//
+// The headers are sent as the metadata of every gRPC call, including retries.
+// Over the connections created by the Exporter, they are only sent if the
+// connection is secure, unless the Exporter is configured with WithInsecure.
+// Over a connection passed with WithGRPCConn or WithSharedClient, they are
+// sent whatever the transport security of that connection is.
+//
+// An error of the Authenticator fails the export request, it is not retried.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{oconf.WithAuthenticator(a)}
+}
+
 // WithRetry sets the retry policy for transient retryable errors that are
 // returned by the target endpoint.
 //
@@ -248,6 +345,24 @@ func WithRetry(settings RetryConfig) Option {
 	return wrappedOption{oconf.WithRetry(retry.Config(settings))}
 }
 
+// WithDiskQueue persists the export requests that failed with transient
+// errors, after any retry, to the directory dq.Dir, created if it does not
+// exist. The persisted requests are sent again in the background, oldest
+// first, once an export request succeeds, and when an exporter is created
+// with the same directory, for instance after a restart.
+//
+// The total size of the persisted requests is bounded by dq.MaxBytes, or by
+// 64 MiB if it is not positive: the oldest requests are dropped to make room
+// for new ones. The directory must not be used by another exporter.
+//
+// Persisted requests can be sent more than once if the process stops while
+// sending them.
+//
+// By default, the export requests that failed are dropped.
+func WithDiskQueue(dq DiskQueueConfig) Option {
+	return wrappedOption{oconf.WithDiskQueue(diskqueue.Config(dq))}
+}
+
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
 // determine the Temporality of an instrument based on its kind. If this option
 // is not used, the client will use the DefaultTemporalitySelector from the
@@ -0,0 +1,46 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/grpc.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth"
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// PerRPCCredentials returns gRPC per-RPC credentials adding the headers
+// provided by a to every call. If secure is true, the credentials are only
+// sent over connections with transport security.
+//
+// Errors of a are returned with the Unauthenticated code so the call is
+// not retried.
+func PerRPCCredentials(a Authenticator, secure bool) credentials.PerRPCCredentials {
+	return perRPCCredentials{auth: a, secure: secure}
+}
+
+type perRPCCredentials struct {
+	auth   Authenticator
+	secure bool
+}
+
+var _ credentials.PerRPCCredentials = perRPCCredentials{}
+
+// GetRequestMetadata returns the headers of the authenticator.
+func (c perRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	h, err := c.auth.Headers(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return h, nil
+}
+
+// RequireTransportSecurity reports whether transport security is required.
+func (c perRPCCredentials) RequireTransportSecurity() bool {
+	return c.secure
+}
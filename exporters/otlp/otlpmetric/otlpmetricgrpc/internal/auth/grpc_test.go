@@ -0,0 +1,44 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/grpc_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type authFunc func(context.Context) (map[string]string, error)
+
+func (f authFunc) Headers(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	want := map[string]string{"authorization": "Bearer token"}
+	creds := PerRPCCredentials(authFunc(func(context.Context) (map[string]string, error) {
+		return want, nil
+	}), true)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	got, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	creds = PerRPCCredentials(authFunc(func(context.Context) (map[string]string, error) {
+		return nil, errors.New("no token")
+	}), false)
+	assert.False(t, creds.RequireTransportSecurity())
+
+	_, err = creds.GetRequestMetadata(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
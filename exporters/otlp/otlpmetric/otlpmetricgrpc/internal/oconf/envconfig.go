@@ -8,7 +8,6 @@ package oconf // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlp
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"net/url"
 	"os"
 	"path"
@@ -16,6 +15,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/envconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/tlsreload"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -49,10 +49,14 @@ func ApplyHTTPEnvConfigs(cfg Config) Config {
 func getOptionsFromEnv() []GenericOption {
 	opts := []GenericOption{}
 
-	tlsConf := &tls.Config{}
+	var tlsFiles tlsFiles
 	DefaultEnvOptionsReader.Apply(
 		envconfig.WithURL("ENDPOINT", func(u *url.URL) {
 			opts = append(opts, withEndpointScheme(u))
+			if u.Scheme == "unix" {
+				opts = append(opts, WithEndpoint(unixEndpoint(u)))
+				return
+			}
 			opts = append(opts, newSplitOption(func(cfg Config) Config {
 				cfg.Metrics.Endpoint = u.Host
 				// For OTLP/HTTP endpoint URLs without a per-signal
@@ -64,6 +68,10 @@ func getOptionsFromEnv() []GenericOption {
 		}),
 		envconfig.WithURL("METRICS_ENDPOINT", func(u *url.URL) {
 			opts = append(opts, withEndpointScheme(u))
+			if u.Scheme == "unix" {
+				opts = append(opts, WithEndpoint(unixEndpoint(u)))
+				return
+			}
 			opts = append(opts, newSplitOption(func(cfg Config) Config {
 				cfg.Metrics.Endpoint = u.Host
 				// For endpoint URLs for OTLP/HTTP per-signal variables, the
@@ -78,17 +86,19 @@ func getOptionsFromEnv() []GenericOption {
 				return cfg
 			}, withEndpointForGRPC(u)))
 		}),
-		envconfig.WithCertPool("CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithCertPool("METRICS_CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithClientCert("CLIENT_CERTIFICATE", "CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
-		envconfig.WithClientCert("METRICS_CLIENT_CERTIFICATE", "METRICS_CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
+		envconfig.WithString("CERTIFICATE", func(v string) { tlsFiles.ca = v }),
+		envconfig.WithString("METRICS_CERTIFICATE", func(v string) { tlsFiles.ca = v }),
+		withClientCertFiles("CLIENT_CERTIFICATE", "CLIENT_KEY", &tlsFiles),
+		withClientCertFiles("METRICS_CLIENT_CERTIFICATE", "METRICS_CLIENT_KEY", &tlsFiles),
 		envconfig.WithBool("INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
 		envconfig.WithBool("METRICS_INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
-		withTLSConfig(tlsConf, func(c *tls.Config) { opts = append(opts, WithTLSClientConfig(c)) }),
+		withTLSConfig(&tlsFiles, func(c *tls.Config) { opts = append(opts, withTLSReloadConfig(c)) }),
 		envconfig.WithHeaders("HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		envconfig.WithHeaders("METRICS_HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		WithEnvCompression("COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
 		WithEnvCompression("METRICS_COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
+		WithEnvProtocol("PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshaler(m)) }),
+		WithEnvProtocol("METRICS_PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshaler(m)) }),
 		envconfig.WithDuration("TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 		envconfig.WithDuration("METRICS_TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 		withEnvTemporalityPreference("METRICS_TEMPORALITY_PREFERENCE", func(t metric.TemporalitySelector) { opts = append(opts, WithTemporalitySelector(t)) }),
@@ -112,8 +122,11 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	return func(e *envconfig.EnvOptionsReader) {
 		if v, ok := e.GetEnvValue(n); ok {
 			cp := NoCompression
-			if v == "gzip" {
+			switch v {
+			case "gzip":
 				cp = GzipCompression
+			case "zstd":
+				cp = ZstdCompression
 			}
 
 			fn(cp)
@@ -121,6 +134,21 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	}
 }
 
+// WithEnvProtocol retrieves the specified config and passes it to ConfigFn as
+// the Marshaler of an OTLP/HTTP protocol. Other protocols are ignored.
+func WithEnvProtocol(n string, fn func(Marshaler)) func(e *envconfig.EnvOptionsReader) {
+	return func(e *envconfig.EnvOptionsReader) {
+		if v, ok := e.GetEnvValue(n); ok {
+			switch v {
+			case "http/protobuf":
+				fn(MarshalProto)
+			case "http/json":
+				fn(MarshalJSON)
+			}
+		}
+	}
+}
+
 func withEndpointScheme(u *url.URL) GenericOption {
 	switch strings.ToLower(u.Scheme) {
 	case "http", "unix":
@@ -138,14 +166,53 @@ func withInsecure(b bool) GenericOption {
 	return WithSecure()
 }
 
-func withTLSConfig(c *tls.Config, fn func(*tls.Config)) func(e *envconfig.EnvOptionsReader) {
+// tlsFiles are the paths of the TLS files set with environment variables.
+type tlsFiles struct {
+	ca, cert, key string
+}
+
+// withClientCertFiles sets the client certificate and key paths of f to the
+// values of the environment variables nc and nk if both are set.
+func withClientCertFiles(nc, nk string, f *tlsFiles) func(e *envconfig.EnvOptionsReader) {
 	return func(e *envconfig.EnvOptionsReader) {
-		if c.RootCAs != nil || len(c.Certificates) > 0 {
-			fn(c)
+		vc, okc := e.GetEnvValue(nc)
+		vk, okk := e.GetEnvValue(nk)
+		if okc && okk {
+			f.cert, f.key = vc, vk
 		}
 	}
 }
 
+// withTLSConfig passes the TLS configuration loaded from the files of f to
+// fn. The configuration reloads the files when they change, so rotated
+// certificates are used for new connections.
+func withTLSConfig(f *tlsFiles, fn func(*tls.Config)) func(e *envconfig.EnvOptionsReader) {
+	return func(e *envconfig.EnvOptionsReader) {
+		if f.ca == "" && f.cert == "" {
+			return
+		}
+		c, err := tlsreload.NewConfig(f.ca, f.cert, f.key, e.ReadFile)
+		if err != nil {
+			global.Error(err, "load tls config")
+			return
+		}
+		fn(c)
+	}
+}
+
+// withTLSReloadConfig is WithTLSClientConfig for a config returned by
+// tlsreload.NewConfig. The gRPC credentials verify the server certificate of
+// every connection against its authority.
+func withTLSReloadConfig(c *tls.Config) GenericOption {
+	return newSplitOption(func(cfg Config) Config {
+		cfg.Metrics.TLSCfg = c.Clone()
+		return cfg
+	}, func(cfg Config) Config {
+		cfg.Metrics.GRPCCredentials = tlsreload.Credentials(c)
+		return cfg
+	})
+}
+
 func withEnvTemporalityPreference(n string, fn func(metric.TemporalitySelector)) func(e *envconfig.EnvOptionsReader) {
 	return func(e *envconfig.EnvOptionsReader) {
 		if s, ok := e.GetEnvValue(n); ok {
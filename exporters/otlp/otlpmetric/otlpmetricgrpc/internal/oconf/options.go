@@ -7,6 +7,7 @@
 package oconf // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/oconf"
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
@@ -21,6 +22,9 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -53,9 +57,22 @@ type (
 		TLSCfg      *tls.Config
 		Headers     map[string]string
 		Compression Compression
+		Marshaler   Marshaler
 		Timeout     time.Duration
 		URLPath     string
 
+		// MaxRequestSize is the maximum encoded size of an export request.
+		// Larger requests are split. It is unlimited if not positive.
+		MaxRequestSize int
+
+		// PartialSuccessHandler is called with the partial success responses
+		// of the server. If nil, they are passed to the global error handler.
+		PartialSuccessHandler func(internal.PartialSuccess)
+
+		// Authenticator provides the authentication headers added to every
+		// export request. It is not used if nil.
+		Authenticator auth.Authenticator
+
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
 
@@ -63,6 +80,10 @@ type (
 		AggregationSelector metric.AggregationSelector
 
 		Proxy HTTPTransportProxyFunc
+
+		// H2C sends OTLP/HTTP requests using HTTP/2 over cleartext
+		// connections. It is only used with insecure connections.
+		H2C bool
 	}
 
 	Config struct {
@@ -71,11 +92,26 @@ type (
 
 		RetryConfig retry.Config
 
+		// DiskQueue, if its Dir is not empty, persists the export requests
+		// that failed with transient errors to be sent again later.
+		DiskQueue diskqueue.Config
+
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
 		ServiceConfig      string
 		DialOptions        []grpc.DialOption
 		GRPCConn           *grpc.ClientConn
+
+		// SharedClient, if not nil, provides the gRPC connection and the
+		// retry of export requests in place of GRPCConn and RetryConfig.
+		SharedClient SharedClient
+	}
+
+	// SharedClient is a gRPC connection, with a retry policy, shared by
+	// several exporters.
+	SharedClient interface {
+		Conn() *grpc.ClientConn
+		Do(context.Context, func(context.Context) error) error
 	}
 )
 
@@ -150,8 +186,13 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Metrics.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Metrics.Compression == GzipCompression {
+	switch cfg.Metrics.Compression {
+	case GzipCompression:
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		// The zstd compressor is registered by the internal zstd package of
+		// the gRPC exporter.
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor("zstd")))
 	}
 	if cfg.ReconnectionPeriod != 0 {
 		p := grpc.ConnectParams{
@@ -285,8 +326,14 @@ func WithEndpointURL(v string) GenericOption {
 			return cfg
 		}
 
-		cfg.Metrics.Endpoint = u.Host
-		cfg.Metrics.URLPath = u.Path
+		if u.Scheme == "unix" {
+			// The path is the one of the socket, the default URL path is
+			// kept.
+			cfg.Metrics.Endpoint = unixEndpoint(u)
+		} else {
+			cfg.Metrics.Endpoint = u.Host
+			cfg.Metrics.URLPath = u.Path
+		}
 		if u.Scheme != "https" {
 			cfg.Metrics.Insecure = true
 		}
@@ -295,6 +342,16 @@ func WithEndpointURL(v string) GenericOption {
 	})
 }
 
+// unixEndpoint returns the endpoint of the Unix domain socket of u, a URL with
+// the unix scheme, in the "unix:path" form supported by gRPC and the HTTP
+// exporter.
+func unixEndpoint(u *url.URL) string {
+	if u.Opaque != "" {
+		return "unix:" + u.Opaque
+	}
+	return "unix:" + u.Path
+}
+
 func WithCompression(compression Compression) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.Compression = compression
@@ -302,6 +359,13 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithMarshaler(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.Marshaler = m
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.URLPath = urlPath
@@ -316,6 +380,13 @@ func WithRetry(rc retry.Config) GenericOption {
 	})
 }
 
+func WithDiskQueue(dq diskqueue.Config) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.DiskQueue = dq
+		return cfg
+	})
+}
+
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg Config) Config {
 		cfg.Metrics.TLSCfg = tlsCfg.Clone()
@@ -354,6 +425,27 @@ func WithTimeout(duration time.Duration) GenericOption {
 	})
 }
 
+func WithMaxRequestSize(size int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.MaxRequestSize = size
+		return cfg
+	})
+}
+
+func WithPartialSuccessHandler(h func(internal.PartialSuccess)) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.PartialSuccessHandler = h
+		return cfg
+	})
+}
+
+func WithAuthenticator(a auth.Authenticator) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.Authenticator = a
+		return cfg
+	})
+}
+
 func WithTemporalitySelector(selector metric.TemporalitySelector) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.TemporalitySelector = selector
@@ -368,6 +460,13 @@ func WithAggregationSelector(selector metric.AggregationSelector) GenericOption
 	})
 }
 
+func WithH2C() GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.H2C = true
+		return cfg
+	})
+}
+
 func WithProxy(pf HTTPTransportProxyFunc) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.Proxy = pf
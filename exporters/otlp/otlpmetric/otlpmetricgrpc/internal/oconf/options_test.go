@@ -115,6 +115,17 @@ func TestConfigs(t *testing.T) {
 				assert.False(t, c.Metrics.Insecure)
 			},
 		},
+		{
+			name: "Test With Unix Endpoint URL",
+			opts: []GenericOption{
+				WithEndpointURL("unix:///var/run/otel.sock"),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, "unix:/var/run/otel.sock", c.Metrics.Endpoint)
+				assert.Equal(t, "/v1/metrics", c.Metrics.URLPath)
+				assert.True(t, c.Metrics.Insecure)
+			},
+		},
 		{
 			name: "Test With Invalid Endpoint URL",
 			opts: []GenericOption{
@@ -164,6 +175,19 @@ func TestConfigs(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Test Environment Unix Endpoint",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.True(t, c.Metrics.Insecure)
+				assert.Equal(t, "unix:/var/run/otel.sock", c.Metrics.Endpoint)
+				if !grpcOption {
+					assert.Equal(t, "/v1/metrics", c.Metrics.URLPath)
+				}
+			},
+		},
 		{
 			name: "Test Environment Signal Specific Endpoint",
 			env: map[string]string{
@@ -407,6 +431,75 @@ func TestConfigs(t *testing.T) {
 				assert.Equal(t, NoCompression, c.Metrics.Compression)
 			},
 		},
+		{
+			name: "Test With Zstd Compression",
+			opts: []GenericOption{
+				WithCompression(ZstdCompression),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, ZstdCompression, c.Metrics.Compression)
+			},
+		},
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, ZstdCompression, c.Metrics.Compression)
+			},
+		},
+
+		// Marshaler Tests
+		{
+			name: "Test With Marshaler",
+			opts: []GenericOption{
+				WithMarshaler(MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/json",
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Unsupported Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []GenericOption{
+				WithMarshaler(MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
 
 		// Timeout Tests
 		{
@@ -451,6 +544,23 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// Max Request Size Tests
+		{
+			name: "Test Default Max Request Size",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, 0, c.Metrics.MaxRequestSize)
+			},
+		},
+		{
+			name: "Test With Max Request Size",
+			opts: []GenericOption{
+				WithMaxRequestSize(4 << 20),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, 4<<20, c.Metrics.MaxRequestSize)
+			},
+		},
+
 		// Temporality Selector Tests
 		{
 			name: "WithTemporalitySelector",
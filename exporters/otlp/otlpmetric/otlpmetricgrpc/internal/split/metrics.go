@@ -0,0 +1,160 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/metrics.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/split"
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+var metrics = hierarchy[*mpb.ResourceMetrics, *mpb.ScopeMetrics, *mpb.Metric]{
+	kind:   "metric data points",
+	scopes: (*mpb.ResourceMetrics).GetScopeMetrics,
+	items:  (*mpb.ScopeMetrics).GetMetrics,
+	emptyResource: func(r *mpb.ResourceMetrics) *mpb.ResourceMetrics {
+		return &mpb.ResourceMetrics{Resource: r.Resource, SchemaUrl: r.SchemaUrl}
+	},
+	emptyScope: func(s *mpb.ScopeMetrics) *mpb.ScopeMetrics {
+		return &mpb.ScopeMetrics{Scope: s.Scope, SchemaUrl: s.SchemaUrl}
+	},
+	appendScope: func(r *mpb.ResourceMetrics, s *mpb.ScopeMetrics) {
+		r.ScopeMetrics = append(r.ScopeMetrics, s)
+	},
+	appendItem: func(s *mpb.ScopeMetrics, m *mpb.Metric) {
+		s.Metrics = append(s.Metrics, m)
+	},
+	units:     dataPoints,
+	splitItem: splitMetric,
+}
+
+// Metrics splits rm into parts that each encode to an
+// ExportMetricsServiceRequest of no more than maxSize bytes. Every part holds
+// the resource of rm and metrics are kept grouped by their instrumentation
+// scope. The data points of a metric too large to fit into a part are split
+// across copies of the metric in multiple parts.
+//
+// If size is not nil, it returns the encoded size of the request for a part,
+// otherwise the size of its protobuf encoding is used.
+//
+// Data points that are larger than maxSize on their own are dropped and an
+// error reporting them is returned alongside the parts.
+//
+// If maxSize is not positive, rm is returned as the only part.
+func Metrics(rm *mpb.ResourceMetrics, maxSize int, size func(*mpb.ResourceMetrics) int) ([]*mpb.ResourceMetrics, error) {
+	var batchSize func([]*mpb.ResourceMetrics) int
+	if size != nil {
+		// The batches split from a single resource hold a single part.
+		batchSize = func(b []*mpb.ResourceMetrics) int { return size(b[0]) }
+	}
+	batches, err := metrics.split([]*mpb.ResourceMetrics{rm}, maxSize, batchSize)
+	parts := make([]*mpb.ResourceMetrics, 0, len(batches))
+	for _, b := range batches {
+		parts = append(parts, b...)
+	}
+	return parts, err
+}
+
+// dataPoints returns the number of data points of m.
+func dataPoints(m *mpb.Metric) int {
+	switch d := m.Data.(type) {
+	case *mpb.Metric_Gauge:
+		return len(d.Gauge.GetDataPoints())
+	case *mpb.Metric_Sum:
+		return len(d.Sum.GetDataPoints())
+	case *mpb.Metric_Histogram:
+		return len(d.Histogram.GetDataPoints())
+	case *mpb.Metric_ExponentialHistogram:
+		return len(d.ExponentialHistogram.GetDataPoints())
+	case *mpb.Metric_Summary:
+		return len(d.Summary.GetDataPoints())
+	}
+	return 0
+}
+
+// splitMetric splits the data points of m across copies of m that each have
+// an encoded size for which fits returns true, or hold a single data point.
+func splitMetric(m *mpb.Metric, fits func(int) bool) []*mpb.Metric {
+	switch d := m.Data.(type) {
+	case *mpb.Metric_Gauge:
+		return splitPoints(m, d.Gauge.GetDataPoints(), fits, func(c *mpb.Metric, dp []*mpb.NumberDataPoint) proto.Message {
+			g := &mpb.Gauge{DataPoints: dp}
+			c.Data = &mpb.Metric_Gauge{Gauge: g}
+			return g
+		})
+	case *mpb.Metric_Sum:
+		return splitPoints(m, d.Sum.GetDataPoints(), fits, func(c *mpb.Metric, dp []*mpb.NumberDataPoint) proto.Message {
+			s := &mpb.Sum{
+				DataPoints:             dp,
+				AggregationTemporality: d.Sum.AggregationTemporality,
+				IsMonotonic:            d.Sum.IsMonotonic,
+			}
+			c.Data = &mpb.Metric_Sum{Sum: s}
+			return s
+		})
+	case *mpb.Metric_Histogram:
+		return splitPoints(m, d.Histogram.GetDataPoints(), fits, func(c *mpb.Metric, dp []*mpb.HistogramDataPoint) proto.Message {
+			h := &mpb.Histogram{
+				DataPoints:             dp,
+				AggregationTemporality: d.Histogram.AggregationTemporality,
+			}
+			c.Data = &mpb.Metric_Histogram{Histogram: h}
+			return h
+		})
+	case *mpb.Metric_ExponentialHistogram:
+		return splitPoints(m, d.ExponentialHistogram.GetDataPoints(), fits, func(c *mpb.Metric, dp []*mpb.ExponentialHistogramDataPoint) proto.Message {
+			h := &mpb.ExponentialHistogram{
+				DataPoints:             dp,
+				AggregationTemporality: d.ExponentialHistogram.AggregationTemporality,
+			}
+			c.Data = &mpb.Metric_ExponentialHistogram{ExponentialHistogram: h}
+			return h
+		})
+	case *mpb.Metric_Summary:
+		return splitPoints(m, d.Summary.GetDataPoints(), fits, func(c *mpb.Metric, dp []*mpb.SummaryDataPoint) proto.Message {
+			s := &mpb.Summary{DataPoints: dp}
+			c.Data = &mpb.Metric_Summary{Summary: s}
+			return s
+		})
+	}
+	return []*mpb.Metric{m}
+}
+
+// splitPoints splits the data points dp of m across copies of m. The data of
+// a copy c is set to hold the data points passed to setData, which returns
+// the data message set.
+func splitPoints[P proto.Message](m *mpb.Metric, dp []P, fits func(int) bool, setData func(c *mpb.Metric, dp []P) proto.Message) []*mpb.Metric {
+	newCopy := func() *mpb.Metric {
+		return &mpb.Metric{
+			Name:        m.Name,
+			Description: m.Description,
+			Unit:        m.Unit,
+			Metadata:    m.Metadata,
+		}
+	}
+	// The encoded size of a copy holding data points of encoded size n is
+	// shell+fieldSize(data+n), data being the size of its data message
+	// without data points and shell the size of the copy without data.
+	shell := proto.Size(newCopy())
+	data := proto.Size(setData(newCopy(), nil))
+
+	var parts []*mpb.Metric
+	start, size := 0, 0
+	for i, p := range dp {
+		pSize := fieldSize(proto.Size(p))
+		if i > start && !fits(shell+fieldSize(data+size+pSize)) {
+			c := newCopy()
+			setData(c, dp[start:i:i])
+			parts = append(parts, c)
+			start, size = i, 0
+		}
+		size += pSize
+	}
+	c := newCopy()
+	setData(c, dp[start:])
+	return append(parts, c)
+}
@@ -0,0 +1,63 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/tlsreload/grpc_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlsreload
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials(t *testing.T) {
+	ca := newTestCert(t, nil)
+	good := newTestCert(t, ca)
+	wrong := newTestCert(t, ca, net.IPv4(10, 9, 9, 9))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeFile(t, caFile, ca.certPEM)
+	cfg, err := NewConfig(caFile, "", "", os.ReadFile)
+	require.NoError(t, err)
+	creds := Credentials(cfg).Clone()
+
+	handshake := func(c *testCert) error {
+		pair, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+		require.NoError(t, err)
+		ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			Certificates: []tls.Certificate{pair},
+			NextProtos:   []string{"h2"},
+		})
+		require.NoError(t, err)
+		defer ln.Close()
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}()
+
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+		tlsConn, _, err := creds.ClientHandshake(context.Background(), ln.Addr().String(), conn)
+		if err == nil {
+			_ = tlsConn.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, handshake(good))
+	// The certificate is not valid for the IP address of the authority.
+	assert.ErrorContains(t, handshake(wrong), "not 127.0.0.1")
+}
@@ -0,0 +1,236 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/tlsreload/tlsreload_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+)
+
+// testCert is a certificate and its key.
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert returns a certificate for localhost, or for ips if not empty,
+// signed by parent, or a self-signed CA certificate if parent is nil.
+func newTestCert(t *testing.T, parent *testCert, ips ...net.IP) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject: pkix.Name{
+			Organization: []string{"otel-go"},
+		},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	if len(ips) > 0 {
+		tmpl.DNSNames, tmpl.IPAddresses = nil, ips
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFile(t *testing.T, path string, b []byte) {
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+}
+
+// newTLSServer returns a server using the certificate c.
+func newTLSServer(t *testing.T, c *testCert) *httptest.Server {
+	pair, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// get sends a request to rawURL with cfg bound to its host.
+func get(t *testing.T, cfg *tls.Config, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return getUnbound(ForServer(cfg, u.Host), rawURL)
+}
+
+func getUnbound(cfg *tls.Config, rawURL string) error {
+	tr := &http.Transport{
+		TLSClientConfig:   cfg,
+		DisableKeepAlives: true,
+	}
+	client := &http.Client{Transport: tr}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestRootCAsReload(t *testing.T) {
+	var handled []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		handled = append(handled, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	ca1 := newTestCert(t, nil)
+	ca2 := newTestCert(t, nil)
+	srv1 := newTLSServer(t, newTestCert(t, ca1))
+	srv2 := newTLSServer(t, newTestCert(t, ca2))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeFile(t, caFile, ca1.certPEM)
+
+	cfg, err := NewConfig(caFile, "", "", os.ReadFile)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.RootCAs)
+
+	require.NoError(t, get(t, cfg, srv1.URL))
+	assert.Error(t, get(t, cfg, srv2.URL), "server of unknown CA accepted")
+
+	writeFile(t, caFile, ca2.certPEM)
+	require.NoError(t, get(t, cfg, srv2.URL))
+	assert.Error(t, get(t, cfg, srv1.URL), "server of removed CA accepted")
+
+	// Invalid content is ignored.
+	writeFile(t, caFile, []byte("invalid"))
+	require.NoError(t, get(t, cfg, srv2.URL))
+	require.Len(t, handled, 1)
+	assert.ErrorContains(t, handled[0], "certificate not added")
+}
+
+func TestServerName(t *testing.T) {
+	ca := newTestCert(t, nil)
+	// The server listens on 127.0.0.1 with a certificate for another IP
+	// address.
+	srv := newTLSServer(t, newTestCert(t, ca, net.IPv4(10, 9, 9, 9)))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeFile(t, caFile, ca.certPEM)
+	cfg, err := NewConfig(caFile, "", "", os.ReadFile)
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, get(t, cfg, srv.URL), "not 127.0.0.1")
+	assert.ErrorContains(t, getUnbound(cfg, srv.URL), "no server name")
+
+	// The configured server name is verified.
+	cfg.ServerName = "10.9.9.9"
+	assert.NoError(t, get(t, cfg, srv.URL))
+	cfg.ServerName = "10.0.0.1"
+	assert.ErrorContains(t, get(t, cfg, srv.URL), "not 10.0.0.1")
+}
+
+func TestClientCertReload(t *testing.T) {
+	var handled []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		handled = append(handled, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	ca := newTestCert(t, nil)
+	cert1 := newTestCert(t, ca)
+	cert2 := newTestCert(t, ca)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeFile(t, certFile, cert1.certPEM)
+	writeFile(t, keyFile, cert1.keyPEM)
+
+	cfg, err := NewConfig("", certFile, keyFile, os.ReadFile)
+	require.NoError(t, err)
+	require.Len(t, cfg.Certificates, 1)
+	require.NotNil(t, cfg.GetClientCertificate)
+
+	got, err := cfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, cert1.cert.Raw, got.Certificate[0])
+
+	writeFile(t, certFile, cert2.certPEM)
+	// The key has not been updated yet, the previous certificate is used.
+	got, err = cfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, cert1.cert.Raw, got.Certificate[0])
+	assert.Len(t, handled, 1)
+
+	writeFile(t, keyFile, cert2.keyPEM)
+	got, err = cfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, cert2.cert.Raw, got.Certificate[0])
+}
+
+func TestNewConfigErrors(t *testing.T) {
+	files := map[string][]byte{"invalid": []byte("invalid")}
+	readFile := func(name string) ([]byte, error) {
+		if b, ok := files[name]; ok {
+			return b, nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	_, err := NewConfig("invalid", "invalid", "invalid", readFile)
+	assert.ErrorContains(t, err, "certificate not added")
+	assert.ErrorContains(t, err, "tls: failed to find any PEM data in certificate input")
+
+	_, err = NewConfig("missing", "", "", readFile)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	cfg, err := NewConfig("", "", "", readFile)
+	require.NoError(t, err)
+	assert.Nil(t, cfg.RootCAs)
+	assert.Nil(t, cfg.Certificates)
+}
@@ -0,0 +1,104 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/zstd/zstd.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package zstd provides a gRPC compressor using the zstd compression
+// algorithm.
+//
+// Importing this package registers the compressor with gRPC under the Name
+// "zstd", unless a compressor is already registered with that name, in which
+// case that compressor is used.
+package zstd // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/zstd"
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name the compressor is registered with.
+const Name = "zstd"
+
+func init() {
+	// Do not override the zstd compressor registered by another package.
+	if encoding.GetCompressor(Name) == nil {
+		encoding.RegisterCompressor(&compressor{})
+	}
+}
+
+type compressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+// Compress returns a writer that compresses data written to it with zstd and
+// writes the result to w.
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		// Encode synchronously, the messages sent are small enough that
+		// spawning encoding goroutines for each of them is not worth it.
+		enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		enc.Reset(w)
+	}
+	return &writer{Encoder: enc, pool: &c.encoders}, nil
+}
+
+// Decompress returns a reader that decompresses the zstd data read from r.
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else if err := dec.Reset(r); err != nil {
+		c.decoders.Put(dec)
+		return nil, err
+	}
+	return &reader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+// Name returns the name of the compressor.
+func (c *compressor) Name() string {
+	return Name
+}
+
+// writer returns its Encoder to the pool once closed.
+type writer struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *writer) Close() error {
+	defer w.pool.Put(w.Encoder)
+	return w.Encoder.Close()
+}
+
+// reader returns its Decoder to the pool once fully read.
+type reader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
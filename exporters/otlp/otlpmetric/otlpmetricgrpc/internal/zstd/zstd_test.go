@@ -0,0 +1,58 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/zstd/zstd_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zstd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestRegistered(t *testing.T) {
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c, "compressor not registered")
+	assert.Equal(t, Name, c.Name())
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c)
+
+	msg := []byte(strings.Repeat("OpenTelemetry ", 100))
+	// Run multiple times to reuse pooled encoders and decoders.
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(msg)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Less(t, buf.Len(), len(msg), "data not compressed")
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, msg, got)
+	}
+}
+
+func TestDecompressInvalid(t *testing.T) {
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c)
+
+	r, err := c.Decompress(strings.NewReader("not zstd"))
+	if err == nil {
+		_, err = io.ReadAll(r)
+	}
+	assert.Error(t, err)
+}
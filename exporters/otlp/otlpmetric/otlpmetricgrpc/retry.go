@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+
+import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry"
+
+// RetryJitter is a strategy randomizing the intervals between retries.
+type RetryJitter = retry.Jitter
+
+const (
+	// ProportionalJitter randomizes every retry interval by up to half of
+	// its value, in both directions. It is the default.
+	ProportionalJitter = retry.ProportionalJitter
+	// FullJitter picks every retry interval at random between zero and its
+	// value.
+	FullJitter = retry.FullJitter
+	// NoJitter does not randomize the retry intervals.
+	NoJitter = retry.NoJitter
+)
+
+// RetryClassification is the classification of the error of a failed export
+// request, returned by the Classify function of RetryConfig.
+type RetryClassification = retry.Classification
+
+const (
+	// ClassifyDefault defers to the default classification of the exporter.
+	ClassifyDefault = retry.DefaultClassification
+	// ClassifyRetryable classifies the error as transient: the request is
+	// retried.
+	ClassifyRetryable = retry.Retryable
+	// ClassifyNonRetryable classifies the error as permanent: the request is
+	// not retried.
+	ClassifyNonRetryable = retry.NonRetryable
+)
+
+// CircuitBreakerConfig configures the circuit breaker of RetryConfig. The
+// circuit opens after FailureThreshold consecutive export requests failed
+// with retry-able errors. While it is open, export requests fail with
+// ErrCircuitOpen without being sent. Once OpenDuration has elapsed, a single
+// request is sent to probe the endpoint: the circuit closes if it succeeds.
+type CircuitBreakerConfig = retry.CircuitBreakerConfig
+
+// ErrCircuitOpen is returned when an export request is not sent because the
+// circuit breaker is open.
+var ErrCircuitOpen = retry.ErrCircuitOpen
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
@@ -18,22 +19,44 @@ import (
 	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/oconf"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/otlpjson"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/split"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/tlsreload"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/transport"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
 
+const contentTypeProto = "application/x-protobuf"
+
 type client struct {
 	// req is cloned for every upload the client makes.
 	req         *http.Request
 	compression Compression
+	encoding    Encoding
 	requestFunc retry.RequestFunc
 	httpClient  *http.Client
+
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize int
+
+	partialSuccess *internal.PartialSuccessHandler
+
+	// authenticator provides the authentication headers of requests.
+	authenticator auth.Authenticator
+
+	// queue is the disk queue of the export requests that failed with
+	// transient errors. It is nil if no disk queue is configured.
+	queue *diskqueue.Queue
 }
 
 // Keep it in sync with golang's DefaultTransport from net/http! We
@@ -55,26 +78,26 @@ var ourTransport = &http.Transport{
 
 // newClient creates a new HTTP metric client.
 func newClient(cfg oconf.Config) (*client, error) {
-	httpClient := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.Metrics.Timeout,
-	}
-
+	baseTransport := ourTransport
 	if cfg.Metrics.TLSCfg != nil || cfg.Metrics.Proxy != nil {
-		clonedTransport := ourTransport.Clone()
-		httpClient.Transport = clonedTransport
+		baseTransport = ourTransport.Clone()
 
 		if cfg.Metrics.TLSCfg != nil {
-			clonedTransport.TLSClientConfig = cfg.Metrics.TLSCfg
+			baseTransport.TLSClientConfig = tlsreload.ForServer(cfg.Metrics.TLSCfg, transport.Host(cfg.Metrics.Endpoint))
 		}
 		if cfg.Metrics.Proxy != nil {
-			clonedTransport.Proxy = cfg.Metrics.Proxy
+			baseTransport.Proxy = cfg.Metrics.Proxy
 		}
 	}
 
+	httpClient := &http.Client{
+		Transport: transport.New(baseTransport, cfg.Metrics.Endpoint, cfg.Metrics.H2C && cfg.Metrics.Insecure),
+		Timeout:   cfg.Metrics.Timeout,
+	}
+
 	u := &url.URL{
 		Scheme: "https",
-		Host:   cfg.Metrics.Endpoint,
+		Host:   transport.Host(cfg.Metrics.Endpoint),
 		Path:   cfg.Metrics.URLPath,
 	}
 	if cfg.Metrics.Insecure {
@@ -94,14 +117,38 @@ func newClient(cfg oconf.Config) (*client, error) {
 			req.Header.Set(k, v)
 		}
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	contentType := contentTypeProto
+	if cfg.Metrics.Marshaler == oconf.MarshalJSON {
+		contentType = otlpjson.ContentType
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	c := &client{
+		compression:    Compression(cfg.Metrics.Compression),
+		encoding:       Encoding(cfg.Metrics.Marshaler),
+		req:            req,
+		requestFunc:    cfg.RetryConfig.RequestFunc(evaluate),
+		httpClient:     httpClient,
+		maxRequestSize: cfg.Metrics.MaxRequestSize,
+		partialSuccess: internal.NewMetricPartialSuccessHandler(
+			otel.GetMeterProvider(),
+			"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp",
+			Version(),
+			cfg.Metrics.PartialSuccessHandler,
+		),
+		authenticator: cfg.Metrics.Authenticator,
+	}
 
-	return &client{
-		compression: Compression(cfg.Metrics.Compression),
-		req:         req,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
-		httpClient:  httpClient,
-	}, nil
+	if cfg.DiskQueue.Dir != "" {
+		q, err := diskqueue.New(cfg.DiskQueue, c.replay)
+		if err != nil {
+			return nil, err
+		}
+		c.queue = q
+		// Send the requests queued before a restart.
+		q.Flush()
+	}
+	return c, nil
 }
 
 // Shutdown shuts down the client, freeing all resources.
@@ -110,6 +157,9 @@ func (c *client) Shutdown(ctx context.Context) error {
 	// ensures this is called only once. The only thing that needs to be done
 	// here is to release any computational resources the client holds.
 
+	// Stop sending the queued requests, they are kept for the next start.
+	c.queue.Close()
+
 	c.requestFunc = nil
 	c.httpClient = nil
 	return ctx.Err()
@@ -120,6 +170,27 @@ func (c *client) Shutdown(ctx context.Context) error {
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
 func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
+	parts, err := split.Metrics(protoMetrics, c.maxRequestSize, c.requestSize())
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	var errs []error
+	for _, part := range parts {
+		if err := c.uploadMetrics(ctx, part, c.requestFunc); err != nil {
+			c.enqueue(part, err)
+			errs = append(errs, err)
+			continue
+		}
+		// The endpoint is available, send the queued requests.
+		c.queue.Flush()
+	}
+	return errors.Join(errs...)
+}
+
+// uploadMetrics sends protoMetrics in a single export request, using
+// requestFunc.
+func (c *client) uploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics, requestFunc retry.RequestFunc) error {
 	// The otlpmetric.Exporter synchronizes access to client methods, and
 	// ensures this is not called after the Exporter is shutdown. Only thing
 	// to do here is send data.
@@ -127,7 +198,7 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	pbRequest := &colmetricpb.ExportMetricsServiceRequest{
 		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 	}
-	body, err := proto.Marshal(pbRequest)
+	body, err := c.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -136,7 +207,7 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		return err
 	}
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	return requestFunc(ctx, func(iCtx context.Context) error {
 		select {
 		case <-iCtx.Done():
 			return iCtx.Err()
@@ -144,6 +215,9 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 
 		request.reset(iCtx)
+		if err := auth.SetHeaders(iCtx, c.authenticator, request.Header); err != nil {
+			return err
+		}
 		resp, err := c.httpClient.Do(request.Request)
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) && urlErr.Temporary() {
@@ -167,20 +241,15 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 				return nil
 			}
 
-			if resp.Header.Get("Content-Type") == "application/x-protobuf" {
-				var respProto colmetricpb.ExportMetricsServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
-					return err
-				}
-
-				if respProto.PartialSuccess != nil {
-					msg := respProto.PartialSuccess.GetErrorMessage()
-					n := respProto.PartialSuccess.GetRejectedDataPoints()
-					if n != 0 || msg != "" {
-						err := internal.MetricPartialSuccessError(n, msg)
-						otel.Handle(err)
-					}
-				}
+			var respProto colmetricpb.ExportMetricsServiceResponse
+			ok, err := unmarshalResponse(resp.Header.Get("Content-Type"), respData.Bytes(), &respProto)
+			if err != nil {
+				return err
+			}
+			if ok && respProto.PartialSuccess != nil {
+				msg := respProto.PartialSuccess.GetErrorMessage()
+				n := respProto.PartialSuccess.GetRejectedDataPoints()
+				c.partialSuccess.Handle(iCtx, n, msg)
 			}
 			return nil
 		case sc == http.StatusTooManyRequests,
@@ -188,7 +257,8 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 			sc == http.StatusServiceUnavailable,
 			sc == http.StatusGatewayTimeout:
 			// Retry-able failure.
-			rErr = newResponseError(resp.Header, nil)
+			statusErr := &retry.HTTPStatusError{StatusCode: sc, Status: resp.Status}
+			rErr = newResponseError(resp.Header, statusErr)
 
 			// server may return a message with the response
 			// body, so we read it to include in the error
@@ -204,11 +274,10 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 			// if it is not empty
 			if respStr := strings.TrimSpace(respData.String()); respStr != "" {
 				// Include response for context.
-				e := errors.New(respStr)
-				rErr = newResponseError(resp.Header, e)
+				statusErr.Err = errors.New(respStr)
 			}
 		default:
-			rErr = fmt.Errorf("failed to send metrics to %s: %s", request.URL, resp.Status)
+			rErr = fmt.Errorf("failed to send metrics to %s: %w", request.URL, &retry.HTTPStatusError{StatusCode: sc, Status: resp.Status})
 		}
 
 		if err := resp.Body.Close(); err != nil {
@@ -218,6 +287,94 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	})
 }
 
+// enqueue adds the export request of protoMetrics, which failed with err, to
+// the disk queue if the error is transient.
+func (c *client) enqueue(protoMetrics *metricpb.ResourceMetrics, err error) {
+	if c.queue == nil || !transient(err) {
+		return
+	}
+
+	data, err := proto.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
+	})
+	if err == nil {
+		err = c.queue.Push(data)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// replay sends the export request data read from the disk queue in a single
+// attempt. The request is dropped if it fails with a permanent error.
+func (c *client) replay(ctx context.Context, data []byte) error {
+	var req colmetricpb.ExportMetricsServiceRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		otel.Handle(fmt.Errorf("dropping invalid queued export request: %w", err))
+		return nil
+	}
+
+	var errs []error
+	for _, rm := range req.ResourceMetrics {
+		if err := c.uploadMetrics(ctx, rm, once); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && !transient(err) {
+		otel.Handle(err)
+		return nil
+	}
+	return err
+}
+
+// once sends a request in a single attempt.
+func once(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// requestSize returns the function measuring the size of the export request
+// of a part, or nil if requests are encoded with protobuf, which the split
+// package measures itself.
+func (c *client) requestSize() func(*metricpb.ResourceMetrics) int {
+	if c.encoding != JSONEncoding {
+		return nil
+	}
+	return func(rm *metricpb.ResourceMetrics) int {
+		b, err := otlpjson.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*metricpb.ResourceMetrics{rm},
+		})
+		if err != nil {
+			// The error is returned when the part is sent.
+			return 0
+		}
+		return len(b)
+	}
+}
+
+// marshal returns the encoding of m used by the client.
+func (c *client) marshal(m proto.Message) ([]byte, error) {
+	if c.encoding == JSONEncoding {
+		return otlpjson.Marshal(m)
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalResponse parses the response body b with the encoding of
+// contentType into m. It returns false if the encoding is unknown, in which
+// case m is not modified.
+func unmarshalResponse(contentType string, b []byte, m proto.Message) (bool, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case contentTypeProto:
+		return true, proto.Unmarshal(b, m)
+	case otlpjson.ContentType:
+		return true, otlpjson.Unmarshal(b, m)
+	}
+	return false, nil
+}
+
 var gzPool = sync.Pool{
 	New: func() interface{} {
 		w := gzip.NewWriter(io.Discard)
@@ -225,6 +382,15 @@ var gzPool = sync.Pool{
 	},
 }
 
+var zstdPool = sync.Pool{
+	New: func() interface{} {
+		// Encode synchronously, the encoder is used for a single payload at
+		// a time. The options are valid, no error is returned.
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	},
+}
+
 func (c *client) newRequest(ctx context.Context, body []byte) (request, error) {
 	r := c.req.Clone(ctx)
 	req := request{Request: r}
@@ -253,6 +419,15 @@ func (c *client) newRequest(ctx context.Context, body []byte) (request, error) {
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+	case ZstdCompression:
+		// Ensure the content length is not used.
+		r.ContentLength = -1
+		r.Header.Set("Content-Encoding", "zstd")
+
+		enc := zstdPool.Get().(*zstd.Encoder)
+		defer zstdPool.Put(enc)
+
+		req.bodyReader = bodyReader(enc.EncodeAll(body, nil))
 	}
 
 	return req, nil
@@ -343,3 +518,18 @@ func evaluate(err error) (bool, time.Duration) {
 
 	return true, time.Duration(rErr.throttle)
 }
+
+// transient returns if err, returned by an export request, may not be
+// returned when sending the request again later: the request failed with a
+// retry-able status, failed to reach the endpoint, or was not sent.
+func transient(err error) bool {
+	var (
+		rErr   *retryableError
+		urlErr *url.Error
+	)
+	return errors.As(err, &rErr) ||
+		errors.As(err, &urlErr) ||
+		errors.Is(err, retry.ErrCircuitOpen) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -8,19 +8,27 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/otest"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/otlpjson"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
 
@@ -73,6 +81,127 @@ func TestClientWithHTTPCollectorRespondingPlainText(t *testing.T) {
 	require.Len(t, got, 1, "upload of one ResourceMetrics")
 }
 
+func TestClientJSONEncoding(t *testing.T) {
+	var (
+		contentType string
+		got         colmetricpb.ExportMetricsServiceRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, err := io.ReadAll(r.Body)
+		if err == nil {
+			err = otlpjson.Unmarshal(body, &got)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resp, _ := otlpjson.Marshal(&colmetricpb.ExportMetricsServiceResponse{
+			PartialSuccess: &colmetricpb.ExportMetricsPartialSuccess{
+				RejectedDataPoints: 1,
+				ErrorMessage:       "partially successful",
+			},
+		})
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	var errs []error
+	eh := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) {
+		errs = append(errs, e)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(eh) })
+
+	opts := []Option{WithEndpointURL(srv.URL), WithEncoding(JSONEncoding)}
+	cfg := oconf.NewHTTPConfig(asHTTPOptions(opts)...)
+	client, err := newClient(cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rm := &mpb.ResourceMetrics{SchemaUrl: "https://example.com/schema"}
+	require.NoError(t, client.UploadMetrics(ctx, rm))
+	require.NoError(t, client.Shutdown(ctx))
+
+	assert.Equal(t, otlpjson.ContentType, contentType)
+	require.Len(t, got.ResourceMetrics, 1)
+	assert.Equal(t, rm.SchemaUrl, got.ResourceMetrics[0].SchemaUrl)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "partially successful")
+}
+
+func TestClientMaxRequestSize(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		sizes []int
+		names []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		var req colmetricpb.ExportMetricsServiceRequest
+		if err == nil {
+			err = proto.Unmarshal(body, &req)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		sizes = append(sizes, len(body))
+		for _, rm := range req.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					names = append(names, m.Name)
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var errs []error
+	eh := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) {
+		errs = append(errs, e)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(eh) })
+
+	const maxSize = 200
+	opts := []Option{WithEndpointURL(srv.URL), WithMaxRequestSize(maxSize)}
+	cfg := oconf.NewHTTPConfig(asHTTPOptions(opts)...)
+	client, err := newClient(cfg)
+	require.NoError(t, err)
+
+	var want []string
+	sm := &mpb.ScopeMetrics{}
+	for i := 0; i < 20; i++ {
+		want = append(want, fmt.Sprintf("metric-%d", i))
+		sm.Metrics = append(sm.Metrics, &mpb.Metric{Name: want[i]})
+	}
+	sm.Metrics = append(sm.Metrics, &mpb.Metric{
+		Name: strings.Repeat("x", maxSize),
+		Data: &mpb.Metric_Gauge{
+			Gauge: &mpb.Gauge{DataPoints: []*mpb.NumberDataPoint{{}}},
+		},
+	})
+	rm := &mpb.ResourceMetrics{ScopeMetrics: []*mpb.ScopeMetrics{sm}}
+
+	ctx := context.Background()
+	require.NoError(t, client.UploadMetrics(ctx, rm))
+	require.NoError(t, client.Shutdown(ctx))
+
+	assert.Equal(t, want, names)
+	assert.Greater(t, len(sizes), 1, "request not split")
+	for _, size := range sizes {
+		assert.LessOrEqual(t, size, maxSize)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "1 metric data points dropped")
+}
+
 func TestNewWithInvalidEndpoint(t *testing.T) {
 	ctx := context.Background()
 	exp, err := New(ctx, WithEndpoint("host:invalid-port"))
@@ -154,6 +283,15 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, coll.Collect().Dump(), 1)
 	})
 
+	t.Run("WithCompressionZstd", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithCompression(ZstdCompression))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
 	t.Run("WithRetry", func(t *testing.T) {
 		emptyErr := errors.New("")
 		rCh := make(chan otest.ExportResult, 5)
@@ -216,6 +354,48 @@ func TestConfig(t *testing.T) {
 		assert.ErrorIs(t, err, *retryErr)
 	})
 
+	t.Run("WithDiskQueue", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 4)
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Status: http.StatusServiceUnavailable,
+			Err:    errors.New("unavailable"),
+		}}
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Status: http.StatusBadRequest,
+			Err:    errors.New("invalid"),
+		}}
+		rCh <- otest.ExportResult{}
+		rCh <- otest.ExportResult{}
+		dir := t.TempDir()
+		exp, coll := factoryFunc(
+			"",
+			rCh,
+			WithRetry(RetryConfig{Enabled: false}),
+			WithDiskQueue(DiskQueueConfig{Dir: dir}),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		// The request failing with a transient error is queued, the one
+		// failing with a permanent error is dropped.
+		assert.Error(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Error(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		// The queued request is sent once an export succeeds.
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Eventually(t, func() bool {
+			entries, err := os.ReadDir(dir)
+			return err == nil && len(entries) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Empty(t, rCh, "queued request not sent")
+	})
+
 	t.Run("WithURLPath", func(t *testing.T) {
 		path := "/prefix/v2/metrics"
 		ePt := fmt.Sprintf("http://localhost:0%s", path)
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"time"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/retry"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -30,6 +32,22 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(oconf.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(oconf.ZstdCompression)
+)
+
+// Encoding describes the encoding used for payloads sent to the collector.
+type Encoding oconf.Marshaler
+
+const (
+	// ProtobufEncoding tells the driver to send payloads encoded as binary
+	// Protobuf (OTLP/HTTP binary Protobuf).
+	ProtobufEncoding = Encoding(oconf.MarshalProto)
+	// JSONEncoding tells the driver to send payloads encoded as JSON
+	// (OTLP/HTTP JSON), using the Protobuf JSON mapping with hex-encoded
+	// trace and span IDs defined by the OTLP specification.
+	JSONEncoding = Encoding(oconf.MarshalJSON)
 )
 
 // Option applies an option to the Exporter.
@@ -45,10 +63,23 @@ func asHTTPOptions(opts []Option) []oconf.HTTPOption {
 	return converted
 }
 
+// PartialSuccessError is the error describing a partial success response of the
+// OTLP server, which accepted an export request but rejected metric data points
+// of it or returned a warning. RejectedItems is the number of rejected metric
+// data points and ErrorMessage the message of the server.
+//
+// It is passed to the function set with WithPartialSuccessHandler, or otherwise
+// to the global error handler. Use errors.As to identify it there.
+type PartialSuccessError = internal.PartialSuccess
+
 // RetryConfig defines configuration for retrying the export of metric data
 // that failed.
 type RetryConfig retry.Config
 
+// DiskQueueConfig configures the disk queue of the export requests that failed
+// with transient errors. See WithDiskQueue.
+type DiskQueueConfig diskqueue.Config
+
 type wrappedOption struct {
 	oconf.HTTPOption
 }
@@ -86,6 +117,10 @@ func WithEndpoint(endpoint string) Option {
 //
 // If an invalid URL is provided, the default value will be kept.
 //
+// A URL with the unix scheme, such as "unix:///var/run/otel.sock", sends the
+// requests to the Unix domain socket at its path without transport security.
+// Its path is not used as the URL path of the requests (see WithURLPath).
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "localhost:4318" will be used.
 //
@@ -100,7 +135,7 @@ func WithEndpointURL(u string) Option {
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", or "zstd". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -109,6 +144,19 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{oconf.WithCompression(oconf.Compression(compression))}
 }
 
+// WithEncoding sets the encoding the Exporter will use for the HTTP body.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_METRICS_PROTOCOL
+// environment variable is set to "http/protobuf" or "http/json", and this
+// option is not passed, that variable value will be used. If both are set,
+// OTEL_EXPORTER_OTLP_METRICS_PROTOCOL will take precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufEncoding will be used.
+func WithEncoding(encoding Encoding) Option {
+	return wrappedOption{oconf.WithMarshaler(oconf.Marshaler(encoding))}
+}
+
 // WithURLPath sets the URL path the Exporter will send requests to.
 //
 // If the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
@@ -185,6 +233,47 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{oconf.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the export requests
+// sent by the exporter. A batch of metrics that would encode to a larger
+// request is split into multiple requests, keeping metrics grouped by their
+// resource and instrumentation scope. The data points of a metric too large
+// for a request are split across multiple requests. Data points larger than
+// size on their own are dropped and reported to the global error handler.
+//
+// The size is measured using the encoding of the request, protobuf or JSON,
+// before any compression is applied. Use this to stay below the request body limit of the
+// collector or of a proxy in front of it.
+//
+// If unset or not positive, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(size)}
+}
+
+// WithPartialSuccessHandler sets the function called with the partial success
+// responses of the OTLP server. It is called synchronously during the export,
+// so it should not block.
+//
+// If unset, partial successes are passed to the global error handler.
+//
+// Regardless of this option, the number of rejected metric data points is
+// counted with the otel.sdk.exporter.metric_data_point.rejected counter of the
+// global MeterProvider.
+func WithPartialSuccessHandler(h func(PartialSuccessError)) Option {
+	return wrappedOption{oconf.WithPartialSuccessHandler(h)}
+}
+
+// WithAuthenticator sets the Authenticator providing the authentication
+// headers of the export requests, for example an Authenticator returned by
+// NewOAuth2Authenticator or NewTokenFileAuthenticator.
+//
+// The headers are set on every HTTP request, including retries, and take
+// precedence over the headers set with WithHeaders.
+//
+// An error of the Authenticator fails the export request, it is not retried.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{oconf.WithAuthenticator(a)}
+}
+
 // WithRetry sets the retry policy for transient retryable errors that are
 // returned by the target endpoint.
 //
@@ -199,6 +288,24 @@ func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{oconf.WithRetry(retry.Config(rc))}
 }
 
+// WithDiskQueue persists the export requests that failed with transient
+// errors, after any retry, to the directory dq.Dir, created if it does not
+// exist. The persisted requests are sent again in the background, oldest
+// first, once an export request succeeds, and when an exporter is created
+// with the same directory, for instance after a restart.
+//
+// The total size of the persisted requests is bounded by dq.MaxBytes, or by
+// 64 MiB if it is not positive: the oldest requests are dropped to make room
+// for new ones. The directory must not be used by another exporter.
+//
+// Persisted requests can be sent more than once if the process stops while
+// sending them.
+//
+// By default, the export requests that failed are dropped.
+func WithDiskQueue(dq DiskQueueConfig) Option {
+	return wrappedOption{oconf.WithDiskQueue(diskqueue.Config(dq))}
+}
+
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
 // determine the Temporality of an instrument based on its kind. If this option
 // is not used, the client will use the DefaultTemporalitySelector from the
@@ -222,3 +329,14 @@ func WithAggregationSelector(selector metric.AggregationSelector) Option {
 func WithProxy(pf HTTPTransportProxyFunc) Option {
 	return wrappedOption{oconf.WithProxy(oconf.HTTPTransportProxyFunc(pf))}
 }
+
+// WithH2C sets the Exporter to send requests using HTTP/2 over cleartext
+// connections (h2c) with prior knowledge, instead of HTTP/1.1. The endpoint
+// needs to support it, as the OTLP/HTTP receiver of the OpenTelemetry
+// Collector does.
+//
+// This option only applies to insecure connections (see WithInsecure), and
+// proxies are not used with it.
+func WithH2C() Option {
+	return wrappedOption{oconf.WithH2C()}
+}
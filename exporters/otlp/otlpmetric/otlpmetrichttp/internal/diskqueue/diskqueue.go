@@ -0,0 +1,285 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/diskqueue/diskqueue.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diskqueue provides a bounded queue of serialized export requests
+// persisted to a directory, so that they are not lost when an endpoint is
+// unavailable or the process restarts.
+package diskqueue // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/diskqueue"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+// DefaultMaxBytes is the default maximum total size of the requests of a
+// Queue.
+const DefaultMaxBytes int64 = 64 << 20
+
+const (
+	// fileExt is the extension of the files holding the queued requests.
+	fileExt = ".pb"
+	// tmpExt is the extension of the files being written.
+	tmpExt = ".tmp"
+)
+
+// Config configures a Queue.
+type Config struct {
+	// Dir is the directory the requests are written to. It is created if it
+	// does not exist. It must not be used by another Queue.
+	Dir string
+	// MaxBytes is the maximum total size of the queued requests. The oldest
+	// requests are dropped to make room for new ones. DefaultMaxBytes is
+	// used if it is not positive.
+	MaxBytes int64
+}
+
+// SendFunc sends a request read from a Queue. The request is removed from the
+// queue if it returns nil, otherwise it is kept to be sent again later.
+type SendFunc func(ctx context.Context, data []byte) error
+
+// Queue is a first-in first-out queue of requests persisted as files of a
+// directory. The requests are sent with its SendFunc in the background, in a
+// single goroutine.
+//
+// A Queue is safe for concurrent use. The Flush and Close methods of a nil
+// Queue do nothing.
+type Queue struct {
+	dir      string
+	maxBytes int64
+	send     SendFunc
+
+	mu    sync.Mutex
+	files []file
+	size  int64
+	next  uint64
+
+	trigger chan struct{}
+	stop    context.CancelFunc
+	done    chan struct{}
+}
+
+// file is a queued request.
+type file struct {
+	name string
+	size int64
+}
+
+// New returns a Queue of the requests of the directory of cfg, sent with
+// send. The requests already in the directory, queued by a previous Queue,
+// are sent by the first call of Flush.
+func New(cfg Config, send SendFunc) (*Queue, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("disk queue: %w", err)
+	}
+	entries, err := os.ReadDir(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("disk queue: %w", err)
+	}
+
+	q := &Queue{
+		dir:      cfg.Dir,
+		maxBytes: cfg.MaxBytes,
+		send:     send,
+		trigger:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	if q.maxBytes <= 0 {
+		q.maxBytes = DefaultMaxBytes
+	}
+
+	// ReadDir sorts the entries by name: the zero-padded sequence numbers of
+	// the names keep the requests in order.
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case strings.HasSuffix(name, tmpExt):
+			// The process stopped while writing the request.
+			_ = os.Remove(filepath.Join(q.dir, name))
+		case strings.HasSuffix(name, fileExt):
+			seq, err := strconv.ParseUint(strings.TrimSuffix(name, fileExt), 10, 64)
+			if err != nil {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			q.files = append(q.files, file{name: name, size: info.Size()})
+			q.size += info.Size()
+			q.next = max(q.next, seq+1)
+		}
+	}
+	if err := q.evict(); err != nil {
+		otel.Handle(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q.stop = cancel
+	go q.run(ctx)
+
+	return q, nil
+}
+
+// Push adds the request data to the queue. The oldest requests are dropped if
+// the queue would exceed its maximum size, and an error reporting it is
+// returned.
+func (q *Queue) Push(data []byte) error {
+	if int64(len(data)) > q.maxBytes {
+		return fmt.Errorf("disk queue: request of %d bytes larger than the queue (%d bytes)", len(data), q.maxBytes)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	name := fmt.Sprintf("%020d%s", q.next, fileExt)
+	q.next++
+
+	path := filepath.Join(q.dir, name)
+	// Write to a temporary file first, so that a request is never read
+	// partially written.
+	tmp := path + tmpExt
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("disk queue: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("disk queue: %w", err)
+	}
+
+	q.files = append(q.files, file{name: name, size: int64(len(data))})
+	q.size += int64(len(data))
+	return q.evict()
+}
+
+// evict drops the oldest requests until the queue does not exceed its maximum
+// size. q.mu must be held.
+func (q *Queue) evict() error {
+	var (
+		n    int
+		errs []error
+	)
+	for q.size > q.maxBytes && len(q.files) > 0 {
+		f := q.files[0]
+		q.files = q.files[1:]
+		q.size -= f.size
+		n++
+
+		err := os.Remove(filepath.Join(q.dir, f.name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	if n > 0 {
+		errs = append(errs, fmt.Errorf("disk queue full: %d oldest requests dropped", n))
+	}
+	return errors.Join(errs...)
+}
+
+// Len returns the number of queued requests.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.files)
+}
+
+// Flush starts sending the queued requests in the background, oldest first,
+// unless they are already being sent. Sending stops at the first request that
+// fails to be sent, until Flush is called again.
+func (q *Queue) Flush() {
+	if q == nil {
+		return
+	}
+
+	select {
+	case q.trigger <- struct{}{}:
+	default:
+		// Already triggered.
+	}
+}
+
+// Close stops sending the queued requests and waits for the request being
+// sent, if any, to be canceled. The requests left are kept in the directory,
+// to be sent by the next Queue created with it.
+func (q *Queue) Close() {
+	if q == nil {
+		return
+	}
+
+	q.stop()
+	<-q.done
+}
+
+func (q *Queue) run(ctx context.Context) {
+	defer close(q.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.trigger:
+		}
+		q.replay(ctx)
+	}
+}
+
+// replay sends the queued requests until one fails to be sent.
+func (q *Queue) replay(ctx context.Context) {
+	for ctx.Err() == nil {
+		f, ok := q.head()
+		if !ok {
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join(q.dir, f.name))
+		switch {
+		case err == nil:
+			if err := q.send(ctx, data); err != nil {
+				return
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			otel.Handle(fmt.Errorf("disk queue: dropping unreadable request: %w", err))
+		}
+		// Files that do not exist anymore have been dropped by Push.
+		q.remove(f)
+	}
+}
+
+// head returns the oldest queued request.
+func (q *Queue) head() (file, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.files) == 0 {
+		return file{}, false
+	}
+	return q.files[0], true
+}
+
+// remove removes f from the queue, unless it has already been dropped.
+func (q *Queue) remove(f file) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.files) == 0 || q.files[0] != f {
+		return
+	}
+	q.files = q.files[1:]
+	q.size -= f.size
+
+	err := os.Remove(filepath.Join(q.dir, f.name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		otel.Handle(fmt.Errorf("disk queue: %w", err))
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
@@ -341,6 +342,16 @@ func (c *HTTPCollector) readBody(r *http.Request) (body []byte, err error) {
 				Status: http.StatusInternalServerError,
 			}
 		}
+	case "zstd":
+		var dec *zstd.Decoder
+		dec, err = zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, &HTTPResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = dec.IOReadCloser()
 	default:
 		reader = r.Body
 	}
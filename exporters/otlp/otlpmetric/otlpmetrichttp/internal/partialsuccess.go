@@ -6,7 +6,13 @@
 
 package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal"
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
 
 // PartialSuccess represents the underlying error for all handling
 // OTLP partial success messages.  Use `errors.Is(err,
@@ -54,3 +60,103 @@ func MetricPartialSuccessError(itemsRejected int64, errorMessage string) error {
 		RejectedKind:  "metric data points",
 	}
 }
+
+// LogPartialSuccessError returns an error describing a partial success
+// response for the log signal.
+func LogPartialSuccessError(itemsRejected int64, errorMessage string) error {
+	return PartialSuccess{
+		ErrorMessage:  errorMessage,
+		RejectedItems: itemsRejected,
+		RejectedKind:  "log records",
+	}
+}
+
+// PartialSuccessHandler handles the partial success responses received by an
+// OTLP exporter.
+//
+// The number of rejected items is counted with an exporter self-metric and
+// the PartialSuccess is passed to a user provided function, or to the global
+// error handler if none was provided.
+type PartialSuccessHandler struct {
+	newErr   func(int64, string) error
+	handle   func(PartialSuccess)
+	rejected metric.Int64Counter
+}
+
+// NewTracePartialSuccessHandler returns a PartialSuccessHandler for an
+// exporter of spans. The rejected spans are counted with an instrument created
+// by mp using the instrumentation scope name and version.
+func NewTracePartialSuccessHandler(mp metric.MeterProvider, name, version string, handle func(PartialSuccess)) *PartialSuccessHandler {
+	return newPartialSuccessHandler(
+		TracePartialSuccessError, handle,
+		mp, name, version,
+		"otel.sdk.exporter.span.rejected", "{span}",
+		"The number of spans rejected by the OTLP server in partial success responses.",
+	)
+}
+
+// NewMetricPartialSuccessHandler returns a PartialSuccessHandler for an
+// exporter of metrics. The rejected data points are counted with an
+// instrument created by mp using the instrumentation scope name and version.
+func NewMetricPartialSuccessHandler(mp metric.MeterProvider, name, version string, handle func(PartialSuccess)) *PartialSuccessHandler {
+	return newPartialSuccessHandler(
+		MetricPartialSuccessError, handle,
+		mp, name, version,
+		"otel.sdk.exporter.metric_data_point.rejected", "{data_point}",
+		"The number of metric data points rejected by the OTLP server in partial success responses.",
+	)
+}
+
+// NewLogPartialSuccessHandler returns a PartialSuccessHandler for an exporter
+// of log records. The rejected log records are counted with an instrument
+// created by mp using the instrumentation scope name and version.
+func NewLogPartialSuccessHandler(mp metric.MeterProvider, name, version string, handle func(PartialSuccess)) *PartialSuccessHandler {
+	return newPartialSuccessHandler(
+		LogPartialSuccessError, handle,
+		mp, name, version,
+		"otel.sdk.exporter.log.rejected", "{log_record}",
+		"The number of log records rejected by the OTLP server in partial success responses.",
+	)
+}
+
+func newPartialSuccessHandler(
+	newErr func(int64, string) error,
+	handle func(PartialSuccess),
+	mp metric.MeterProvider,
+	name, version, counter, unit, desc string,
+) *PartialSuccessHandler {
+	meter := mp.Meter(name, metric.WithInstrumentationVersion(version))
+	rejected, err := meter.Int64Counter(
+		counter,
+		metric.WithUnit(unit),
+		metric.WithDescription(desc),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &PartialSuccessHandler{
+		newErr:   newErr,
+		handle:   handle,
+		rejected: rejected,
+	}
+}
+
+// Handle handles a partial success response that rejected n items with the
+// message msg. Responses that reject nothing and have an empty message are
+// successes and are ignored.
+func (h *PartialSuccessHandler) Handle(ctx context.Context, n int64, msg string) {
+	if n == 0 && msg == "" {
+		return
+	}
+
+	if n > 0 && h.rejected != nil {
+		h.rejected.Add(ctx, n)
+	}
+
+	err := h.newErr(n, msg)
+	if h.handle != nil {
+		h.handle(err.(PartialSuccess))
+		return
+	}
+	otel.Handle(err)
+}
@@ -0,0 +1,215 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/metrics_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+func newMetric(name string) *mpb.Metric {
+	return &mpb.Metric{
+		Name: name,
+		Data: &mpb.Metric_Gauge{
+			Gauge: &mpb.Gauge{
+				DataPoints: []*mpb.NumberDataPoint{
+					{
+						TimeUnixNano: 1,
+						Value:        &mpb.NumberDataPoint_AsInt{AsInt: 1},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	var metrics []*mpb.Metric
+	for i := 0; i < 50; i++ {
+		metrics = append(metrics, newMetric(strings.Repeat("m", i)))
+	}
+	metrics = append(metrics, newMetric(strings.Repeat("m", 500)))
+	rm := &mpb.ResourceMetrics{
+		Resource: &rpb.Resource{DroppedAttributesCount: 1},
+		ScopeMetrics: []*mpb.ScopeMetrics{
+			{Scope: &cpb.InstrumentationScope{Name: "a"}, Metrics: metrics[:25]},
+			{Scope: &cpb.InstrumentationScope{Name: "b"}, Metrics: metrics[25:]},
+		},
+	}
+
+	const maxSize = 250
+	parts, err := Metrics(rm, maxSize, nil)
+	assert.EqualError(t, err, "1 metric data points dropped: larger than the maximum request size of 250 bytes")
+	require.Greater(t, len(parts), 1)
+
+	var got []*mpb.Metric
+	for _, part := range parts {
+		req := &colmetricpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*mpb.ResourceMetrics{part},
+		}
+		assert.LessOrEqual(t, proto.Size(req), maxSize)
+		assert.Same(t, rm.Resource, part.Resource)
+		for _, sm := range part.ScopeMetrics {
+			got = append(got, sm.Metrics...)
+		}
+	}
+	assert.Equal(t, metrics[:50], got)
+
+	parts, err = Metrics(rm, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*mpb.ResourceMetrics{rm}, parts)
+}
+
+func TestMetricsSplitDataPoints(t *testing.T) {
+	var dp []*mpb.NumberDataPoint
+	for i := 0; i < 50; i++ {
+		dp = append(dp, &mpb.NumberDataPoint{
+			TimeUnixNano: uint64(i),
+			Value:        &mpb.NumberDataPoint_AsInt{AsInt: int64(i)},
+		})
+	}
+	big := &mpb.NumberDataPoint{
+		Attributes: []*cpb.KeyValue{
+			{
+				Key: "k",
+				Value: &cpb.AnyValue{
+					Value: &cpb.AnyValue_StringValue{StringValue: strings.Repeat("v", 500)},
+				},
+			},
+		},
+	}
+	dp = append(dp[:25:25], append([]*mpb.NumberDataPoint{big}, dp[25:]...)...)
+	sum := &mpb.Sum{
+		DataPoints:             dp,
+		AggregationTemporality: mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		IsMonotonic:            true,
+	}
+	rm := &mpb.ResourceMetrics{
+		Resource: &rpb.Resource{DroppedAttributesCount: 1},
+		ScopeMetrics: []*mpb.ScopeMetrics{
+			{
+				Scope: &cpb.InstrumentationScope{Name: "a"},
+				Metrics: []*mpb.Metric{
+					{Name: "sum", Unit: "1", Data: &mpb.Metric_Sum{Sum: sum}},
+				},
+			},
+		},
+	}
+
+	const maxSize = 250
+	parts, err := Metrics(rm, maxSize, nil)
+	assert.EqualError(t, err, "1 metric data points dropped: larger than the maximum request size of 250 bytes")
+	require.Greater(t, len(parts), 1)
+
+	var got []*mpb.NumberDataPoint
+	for _, part := range parts {
+		req := &colmetricpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*mpb.ResourceMetrics{part},
+		}
+		assert.LessOrEqual(t, proto.Size(req), maxSize)
+		require.Len(t, part.ScopeMetrics, 1)
+		for _, m := range part.ScopeMetrics[0].Metrics {
+			assert.Equal(t, "sum", m.Name)
+			assert.Equal(t, "1", m.Unit)
+			s := m.GetSum()
+			require.NotNil(t, s)
+			assert.Equal(t, sum.AggregationTemporality, s.AggregationTemporality)
+			assert.True(t, s.IsMonotonic)
+			got = append(got, s.DataPoints...)
+		}
+	}
+	want := append(dp[:25:25], dp[26:]...)
+	assert.Equal(t, want, got)
+}
+
+func TestMetricsSplitSizeIsExact(t *testing.T) {
+	var dp []*mpb.HistogramDataPoint
+	for i := 0; i < 40; i++ {
+		dp = append(dp, &mpb.HistogramDataPoint{
+			Count:        uint64(i),
+			BucketCounts: make([]uint64, i),
+		})
+	}
+	rm := &mpb.ResourceMetrics{
+		ScopeMetrics: []*mpb.ScopeMetrics{
+			{
+				Metrics: []*mpb.Metric{
+					{
+						Name: "histogram",
+						Data: &mpb.Metric_Histogram{Histogram: &mpb.Histogram{DataPoints: dp}},
+					},
+				},
+			},
+		},
+	}
+	size := func(part *mpb.ResourceMetrics) int {
+		return proto.Size(&colmetricpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*mpb.ResourceMetrics{part},
+		})
+	}
+
+	for maxSize := 1; maxSize <= size(rm); maxSize++ {
+		parts, _ := Metrics(rm, maxSize, nil)
+		for _, part := range parts {
+			require.LessOrEqual(t, size(part), maxSize)
+		}
+	}
+}
+
+func TestMetricsSize(t *testing.T) {
+	jsonSize := func(part *mpb.ResourceMetrics) int {
+		b, err := protojson.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*mpb.ResourceMetrics{part},
+		})
+		require.NoError(t, err)
+		return len(b)
+	}
+
+	var dp []*mpb.NumberDataPoint
+	for i := 0; i < 50; i++ {
+		dp = append(dp, &mpb.NumberDataPoint{
+			TimeUnixNano: uint64(i),
+			Value:        &mpb.NumberDataPoint_AsInt{AsInt: int64(i)},
+		})
+	}
+	rm := &mpb.ResourceMetrics{
+		ScopeMetrics: []*mpb.ScopeMetrics{
+			{
+				Metrics: []*mpb.Metric{
+					{Name: "gauge", Data: &mpb.Metric_Gauge{Gauge: &mpb.Gauge{DataPoints: dp}}},
+				},
+			},
+		},
+	}
+
+	const maxSize = 500
+	require.Less(t, maxSize, jsonSize(rm))
+	parts, err := Metrics(rm, maxSize, jsonSize)
+	require.NoError(t, err)
+	require.Greater(t, len(parts), 1)
+
+	var got []*mpb.NumberDataPoint
+	for _, part := range parts {
+		assert.LessOrEqual(t, jsonSize(part), maxSize)
+		for _, sm := range part.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				got = append(got, m.GetGauge().GetDataPoints()...)
+			}
+		}
+	}
+	assert.Equal(t, dp, got)
+}
@@ -0,0 +1,39 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/tlsreload/grpc.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlsreload // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/tlsreload"
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Credentials returns gRPC transport credentials using cfg. The connections
+// are made with the config returned by ForServer for their authority.
+func Credentials(cfg *tls.Config) credentials.TransportCredentials {
+	return &serverCredentials{TransportCredentials: credentials.NewTLS(cfg), cfg: cfg}
+}
+
+type serverCredentials struct {
+	credentials.TransportCredentials
+	cfg *tls.Config
+}
+
+var _ credentials.TransportCredentials = (*serverCredentials)(nil)
+
+// ClientHandshake does the TLS handshake of conn with the server at
+// authority.
+func (c *serverCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(ForServer(c.cfg, authority)).ClientHandshake(ctx, authority, conn)
+}
+
+// Clone returns a copy of c.
+func (c *serverCredentials) Clone() credentials.TransportCredentials {
+	return &serverCredentials{TransportCredentials: c.TransportCredentials.Clone(), cfg: c.cfg}
+}
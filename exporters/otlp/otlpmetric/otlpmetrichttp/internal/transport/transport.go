@@ -0,0 +1,90 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/transport/transport.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package transport provides the HTTP transports of the OTLP/HTTP exporters
+// for Unix domain socket and cleartext HTTP/2 (h2c) endpoints.
+package transport // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/transport"
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// UnixHost is the host of the requests sent to Unix domain sockets.
+const UnixHost = "localhost"
+
+// UnixSocket returns the path of the Unix domain socket of endpoint and true
+// if endpoint has the "unix:path" or "unix://absolute_path" form. Otherwise,
+// false is returned.
+func UnixSocket(endpoint string) (string, bool) {
+	if !strings.HasPrefix(endpoint, "unix:") {
+		return "", false
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false
+	}
+	if u.Opaque != "" {
+		return u.Opaque, true
+	}
+	return u.Path, u.Path != ""
+}
+
+// Host returns the host of the requests sent to endpoint.
+func Host(endpoint string) string {
+	if _, ok := UnixSocket(endpoint); ok {
+		return UnixHost
+	}
+	return endpoint
+}
+
+// New returns the http.RoundTripper sending requests to endpoint. base is
+// returned if endpoint is not a Unix domain socket and h2c is false.
+//
+// If endpoint is a Unix domain socket, connections are made to this socket,
+// regardless of the request host, and proxies are not used.
+//
+// If h2c is true, requests are sent using HTTP/2 over cleartext connections
+// without upgrade (prior knowledge). Proxies and TLS are not used.
+func New(base *http.Transport, endpoint string, h2c bool) http.RoundTripper {
+	socket, unix := UnixSocket(endpoint)
+	if !unix && !h2c {
+		return base
+	}
+
+	dial := base.DialContext
+	if unix {
+		d := &net.Dialer{Timeout: 30 * time.Second}
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return d.DialContext(ctx, "unix", socket)
+		}
+	}
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	if h2c {
+		return &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dial(ctx, network, addr)
+			},
+			IdleConnTimeout: base.IdleConnTimeout,
+		}
+	}
+
+	t := base.Clone()
+	t.DialContext = dial
+	t.Proxy = nil
+	return t
+}
@@ -0,0 +1,121 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/transport/transport_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestUnixSocket(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+		ok       bool
+	}{
+		{endpoint: "unix:///var/run/otel.sock", want: "/var/run/otel.sock", ok: true},
+		{endpoint: "unix:/var/run/otel.sock", want: "/var/run/otel.sock", ok: true},
+		{endpoint: "unix:otel.sock", want: "otel.sock", ok: true},
+		{endpoint: "unix:", ok: false},
+		{endpoint: "localhost:4318", ok: false},
+		{endpoint: "", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, ok := UnixSocket(tt.endpoint)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, UnixHost, Host("unix:///var/run/otel.sock"))
+	assert.Equal(t, "localhost:4318", Host("localhost:4318"))
+}
+
+// serve serves on l an HTTP handler replying with the protocol and host of
+// the requests. If withH2C is true, cleartext HTTP/2 is supported.
+func serve(t *testing.T, l net.Listener, withH2C bool) {
+	var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto+" "+r.Host)
+	})
+	if withH2C {
+		h = h2c.NewHandler(h, &http2.Server{})
+	}
+	srv := httptest.NewUnstartedServer(h)
+	_ = srv.Listener.Close()
+	srv.Listener = l
+	srv.Start()
+	t.Cleanup(srv.Close)
+}
+
+func get(t *testing.T, rt http.RoundTripper, url string) string {
+	client := &http.Client{Transport: rt}
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func unixListener(t *testing.T) (net.Listener, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not tested on Windows")
+	}
+	// Socket paths are limited in length, do not use t.TempDir.
+	dir, err := os.MkdirTemp("", "otlp")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "otlp.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	return l, path
+}
+
+func TestNewBase(t *testing.T) {
+	base := &http.Transport{}
+	assert.Same(t, base, New(base, "localhost:4318", false))
+}
+
+func TestNewUnix(t *testing.T) {
+	l, path := unixListener(t)
+	serve(t, l, false)
+
+	endpoint := "unix://" + path
+	rt := New(http.DefaultTransport.(*http.Transport), endpoint, false)
+	assert.Equal(t, "HTTP/1.1 localhost", get(t, rt, "http://"+Host(endpoint)+"/v1/traces"))
+}
+
+func TestNewH2C(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serve(t, l, true)
+
+	rt := New(http.DefaultTransport.(*http.Transport), l.Addr().String(), true)
+	assert.Equal(t, "HTTP/2.0 "+l.Addr().String(), get(t, rt, "http://"+l.Addr().String()+"/v1/traces"))
+}
+
+func TestNewUnixH2C(t *testing.T) {
+	l, path := unixListener(t)
+	serve(t, l, true)
+
+	endpoint := "unix:" + path
+	rt := New(http.DefaultTransport.(*http.Transport), endpoint, true)
+	assert.Equal(t, "HTTP/2.0 localhost", get(t, rt, "http://"+Host(endpoint)+"/v1/traces"))
+}
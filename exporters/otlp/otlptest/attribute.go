@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// newResource returns the resource r with the schema URL schemaURL.
+func newResource(r *rpb.Resource, schemaURL string) *resource.Resource {
+	return resource.NewWithAttributes(schemaURL, attrs(r.GetAttributes())...)
+}
+
+// scope returns the instrumentation scope s with the schema URL schemaURL.
+func scope(s *cpb.InstrumentationScope, schemaURL string) instrumentation.Scope {
+	return instrumentation.Scope{
+		Name:       s.GetName(),
+		Version:    s.GetVersion(),
+		SchemaURL:  schemaURL,
+		Attributes: attribute.NewSet(attrs(s.GetAttributes())...),
+	}
+}
+
+// attrs returns the attributes kvs. The values that are not valid attribute
+// values, maps or arrays of mixed types, are returned as invalid values.
+func attrs(kvs []*cpb.KeyValue) []attribute.KeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	out := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, attribute.KeyValue{
+			Key:   attribute.Key(kv.GetKey()),
+			Value: attrValue(kv.GetValue()),
+		})
+	}
+	return out
+}
+
+// attrValue returns the attribute value of v.
+func attrValue(v *cpb.AnyValue) attribute.Value {
+	switch v := v.GetValue().(type) {
+	case *cpb.AnyValue_BoolValue:
+		return attribute.BoolValue(v.BoolValue)
+	case *cpb.AnyValue_IntValue:
+		return attribute.Int64Value(v.IntValue)
+	case *cpb.AnyValue_DoubleValue:
+		return attribute.Float64Value(v.DoubleValue)
+	case *cpb.AnyValue_StringValue:
+		return attribute.StringValue(v.StringValue)
+	case *cpb.AnyValue_ArrayValue:
+		return attrSliceValue(v.ArrayValue.GetValues())
+	}
+	return attribute.Value{}
+}
+
+// attrSliceValue returns the attribute slice value of vals, which must all
+// be of the same type. An empty slice is returned as a string slice.
+func attrSliceValue(vals []*cpb.AnyValue) attribute.Value {
+	if len(vals) == 0 {
+		return attribute.StringSliceValue([]string{})
+	}
+
+	switch vals[0].GetValue().(type) {
+	case *cpb.AnyValue_BoolValue:
+		if s, ok := sliceOf(vals, (*cpb.AnyValue).GetBoolValue); ok {
+			return attribute.BoolSliceValue(s)
+		}
+	case *cpb.AnyValue_IntValue:
+		if s, ok := sliceOf(vals, (*cpb.AnyValue).GetIntValue); ok {
+			return attribute.Int64SliceValue(s)
+		}
+	case *cpb.AnyValue_DoubleValue:
+		if s, ok := sliceOf(vals, (*cpb.AnyValue).GetDoubleValue); ok {
+			return attribute.Float64SliceValue(s)
+		}
+	case *cpb.AnyValue_StringValue:
+		if s, ok := sliceOf(vals, (*cpb.AnyValue).GetStringValue); ok {
+			return attribute.StringSliceValue(s)
+		}
+	}
+	return attribute.Value{}
+}
+
+// sliceOf returns the values of vals returned by get, if they all are of the
+// type of the first one.
+func sliceOf[T any](vals []*cpb.AnyValue, get func(*cpb.AnyValue) T) ([]T, bool) {
+	out := make([]T, 0, len(vals))
+	for _, v := range vals {
+		if !sameType(v, vals[0]) {
+			return nil, false
+		}
+		out = append(out, get(v))
+	}
+	return out, true
+}
+
+// sameType returns if a and b hold values of the same type.
+func sameType(a, b *cpb.AnyValue) bool {
+	switch a.GetValue().(type) {
+	case *cpb.AnyValue_BoolValue:
+		_, ok := b.GetValue().(*cpb.AnyValue_BoolValue)
+		return ok
+	case *cpb.AnyValue_IntValue:
+		_, ok := b.GetValue().(*cpb.AnyValue_IntValue)
+		return ok
+	case *cpb.AnyValue_DoubleValue:
+		_, ok := b.GetValue().(*cpb.AnyValue_DoubleValue)
+		return ok
+	case *cpb.AnyValue_StringValue:
+		_, ok := b.GetValue().(*cpb.AnyValue_StringValue)
+		return ok
+	}
+	return false
+}
+
+// timestamp returns the time of the Unix nanoseconds ns. Zero, which the OTLP
+// exporters send for the zero time, is returned as the zero time.
+func timestamp(ns uint64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(ns)) // nolint:gosec // Overflow is not expected before 2262.
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlptest provides an in-process OTLP receiver to test the
+configuration of OTLP exporters.
+
+A [Receiver] serves the OTLP gRPC and OTLP/HTTP protocols on two loopback
+addresses, returned by [Receiver.GRPCEndpoint] and [Receiver.HTTPEndpoint].
+It accepts the export requests of traces, metrics and logs, encoded with
+protobuf or JSON and optionally compressed with gzip or zstd. The data of the
+accepted requests is decoded into [tracetest.SpanStubs],
+[metricdata.ResourceMetrics] and [go.opentelemetry.io/otel/sdk/log.Record]
+values, so it can be compared with the telemetry produced by the OpenTelemetry
+SDK.
+
+The responses of a Receiver are set with [Receiver.Respond]: an export request
+can be delayed, failed with a gRPC status code or HTTP status, throttled, or
+partially accepted. This makes it possible to test the timeouts, retries and
+error handling of an exporter.
+
+OTLP does not preserve all the data of the SDK types. Histograms are decoded
+with float64 values, and data the exporters do not send, for instance the
+trace flags of spans, is decoded as zero values.
+
+This package is meant to be used in tests. It is not meant to receive
+production telemetry.
+*/
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
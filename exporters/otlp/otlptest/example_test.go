@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptest"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func Example() {
+	ctx := context.Background()
+
+	r, err := otlptest.NewReceiver()
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = r.Shutdown(ctx) }()
+
+	// Fail the first request to test the retries of the exporter.
+	r.Respond(otlptest.Response{Code: codes.Unavailable})
+
+	exp, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpoint(r.HTTPEndpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+			MaxElapsedTime:  time.Second,
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer("example").Start(ctx, "span")
+	span.End()
+	if err := tp.Shutdown(ctx); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(r.Requests(), r.Spans()[0].Name)
+	// Output: 2 span
+}
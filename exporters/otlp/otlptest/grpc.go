@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"context"
+	"net/http"
+
+	_ "google.golang.org/grpc/encoding/gzip" // Register the gzip compressor.
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// exportGRPC handles the gRPC export request req and returns its response
+// message.
+func (r *Receiver) exportGRPC(ctx context.Context, req proto.Message) (proto.Message, error) {
+	headers := http.Header{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, vals := range md {
+			for _, v := range vals {
+				headers.Add(k, v)
+			}
+		}
+	}
+
+	resp, err := r.next(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.grpcErr(); err != nil {
+		return nil, err
+	}
+	r.accept(req)
+	return resp.message(req)
+}
+
+type traceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	r *Receiver
+}
+
+func (s traceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	resp, err := s.r.exportGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*coltracepb.ExportTraceServiceResponse), nil
+}
+
+type metricsService struct {
+	colmetricpb.UnimplementedMetricsServiceServer
+
+	r *Receiver
+}
+
+func (s metricsService) Export(ctx context.Context, req *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	resp, err := s.r.exportGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*colmetricpb.ExportMetricsServiceResponse), nil
+}
+
+type logsService struct {
+	collogpb.UnimplementedLogsServiceServer
+
+	r *Receiver
+}
+
+func (s logsService) Export(ctx context.Context, req *collogpb.ExportLogsServiceRequest) (*collogpb.ExportLogsServiceResponse, error) {
+	resp, err := s.r.exportGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*collogpb.ExportLogsServiceResponse), nil
+}
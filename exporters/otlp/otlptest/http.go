@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptest/internal/otlpjson"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+const contentTypeProto = "application/x-protobuf"
+
+// httpHandler returns the handler of the OTLP/HTTP export requests of r.
+func (r *Receiver) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/traces", r.exportHTTP(func() proto.Message {
+		return &coltracepb.ExportTraceServiceRequest{}
+	}))
+	mux.Handle("/v1/metrics", r.exportHTTP(func() proto.Message {
+		return &colmetricpb.ExportMetricsServiceRequest{}
+	}))
+	mux.Handle("/v1/logs", r.exportHTTP(func() proto.Message {
+		return &collogpb.ExportLogsServiceRequest{}
+	}))
+	return mux
+}
+
+// exportHTTP returns the handler of the OTLP/HTTP export requests decoded
+// into the messages returned by newRequest.
+func (r *Receiver) exportHTTP(newRequest func() proto.Message) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		contentType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		var (
+			unmarshal func([]byte, proto.Message) error
+			marshal   func(proto.Message) ([]byte, error)
+		)
+		switch contentType {
+		case contentTypeProto:
+			unmarshal, marshal = proto.Unmarshal, proto.Marshal
+		case otlpjson.ContentType:
+			unmarshal, marshal = otlpjson.Unmarshal, otlpjson.Marshal
+		default:
+			msg := fmt.Sprintf("unsupported content type: %q", contentType)
+			http.Error(w, msg, http.StatusUnsupportedMediaType)
+			return
+		}
+
+		body, err := readBody(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg := newRequest()
+		if err := unmarshal(body, msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := r.next(req.Context(), req.Header)
+		if err != nil {
+			// The client is gone.
+			return
+		}
+
+		if sc := resp.httpStatus(); sc < 200 || sc > 299 {
+			if resp.Throttle > 0 {
+				secs := int64(math.Ceil(resp.Throttle.Seconds()))
+				w.Header().Set("Retry-After", strconv.FormatInt(secs, 10))
+			}
+			http.Error(w, resp.Message, sc)
+			return
+		}
+
+		r.accept(msg)
+		respMsg, err := resp.message(msg)
+		if err == nil {
+			body, err = marshal(respMsg)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(resp.httpStatus())
+		_, _ = w.Write(body)
+	}
+}
+
+// readBody returns the decompressed body of req.
+func readBody(req *http.Request) ([]byte, error) {
+	var reader io.Reader = req.Body
+	switch enc := req.Header.Get("Content-Encoding"); enc {
+	case "":
+	case "gzip":
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	case "zstd":
+		dec, err := zstd.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		reader = dec
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %q", enc)
+	}
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlptest/internal"
+
+//go:generate gotmpl --body=../../../../internal/shared/otlp/otlpjson/otlpjson.go.tmpl "--data={}" --out=otlpjson/otlpjson.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/otlpjson/otlpjson_test.go.tmpl "--data={}" --out=otlpjson/otlpjson_test.go
@@ -0,0 +1,130 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/otlpjson/otlpjson_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var (
+	traceID = []byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c}
+	spanID  = []byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74}
+	otherID = []byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x73}
+)
+
+func newRequest() *coltracepb.ExportTraceServiceRequest {
+	value := &commonpb.AnyValue_BytesValue{BytesValue: []byte("<b>")}
+	attr := &commonpb.KeyValue{
+		Key:   "payload",
+		Value: &commonpb.AnyValue{Value: value},
+	}
+	link := &tracepb.Span_Link{
+		TraceId: traceID,
+		SpanId:  otherID,
+	}
+	span := &tracepb.Span{
+		TraceId:           traceID,
+		SpanId:            spanID,
+		ParentSpanId:      otherID,
+		Name:              "span",
+		Kind:              tracepb.Span_SPAN_KIND_SERVER,
+		StartTimeUnixNano: 1544712660000000000,
+		Attributes:        []*commonpb.KeyValue{attr},
+		Links:             []*tracepb.Span_Link{link},
+	}
+	ss := &tracepb.ScopeSpans{
+		Spans: []*tracepb.Span{span},
+	}
+	rs := &tracepb.ResourceSpans{
+		ScopeSpans: []*tracepb.ScopeSpans{ss},
+	}
+	return &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{rs},
+	}
+}
+
+// requestJSON is the OTLP/JSON encoding of the request returned by
+// newRequest. Keys are sorted and non-ID bytes remain base64-encoded.
+const requestJSON = `
+{
+  "resourceSpans": [
+    {
+      "scopeSpans": [
+        {
+          "spans": [
+            {
+              "attributes": [
+                {
+                  "key": "payload",
+                  "value": {
+                    "bytesValue": "PGI+"
+                  }
+                }
+              ],
+              "kind": 2,
+              "links": [
+                {
+                  "spanId": "eee19b7ec3c1b173",
+                  "traceId": "5b8efff798038103d269b633813fc60c"
+                }
+              ],
+              "name": "span",
+              "parentSpanId": "eee19b7ec3c1b173",
+              "spanId": "eee19b7ec3c1b174",
+              "startTimeUnixNano": "1544712660000000000",
+              "traceId": "5b8efff798038103d269b633813fc60c"
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+func TestMarshal(t *testing.T) {
+	var want bytes.Buffer
+	require.NoError(t, json.Compact(&want, []byte(requestJSON)))
+
+	got, err := Marshal(newRequest())
+	require.NoError(t, err)
+	assert.Equal(t, want.String(), string(got))
+}
+
+func TestUnmarshal(t *testing.T) {
+	var got coltracepb.ExportTraceServiceRequest
+	require.NoError(t, Unmarshal([]byte(requestJSON), &got))
+	assert.True(t, proto.Equal(newRequest(), &got), "unmarshaled request differs")
+}
+
+func TestUnmarshalUnknownFields(t *testing.T) {
+	var got coltracepb.ExportTraceServiceResponse
+	b := []byte(`{"partialSuccess":{"rejectedSpans":"2","errorMessage":"invalid"},"unknown":true}`)
+	require.NoError(t, Unmarshal(b, &got))
+	assert.Equal(t, int64(2), got.GetPartialSuccess().GetRejectedSpans())
+	assert.Equal(t, "invalid", got.GetPartialSuccess().GetErrorMessage())
+}
+
+func TestUnmarshalInvalidID(t *testing.T) {
+	var got coltracepb.ExportTraceServiceRequest
+	b := []byte(`{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"not-hex"}]}]}]}`)
+	assert.ErrorContains(t, Unmarshal(b, &got), "invalid traceId")
+}
+
+func TestUnmarshalInvalidJSON(t *testing.T) {
+	var got coltracepb.ExportTraceServiceRequest
+	assert.Error(t, Unmarshal([]byte(`{`), &got))
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/trace"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	lpb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// records returns the log records of rls.
+func records(rls []*lpb.ResourceLogs) []sdklog.Record {
+	var out []sdklog.Record
+	for _, rl := range rls {
+		res := newResource(rl.GetResource(), rl.GetSchemaUrl())
+		for _, sl := range rl.GetScopeLogs() {
+			is := scope(sl.GetScope(), sl.GetSchemaUrl())
+			for _, lr := range sl.GetLogRecords() {
+				f := logtest.RecordFactory{
+					Timestamp:            timestamp(lr.GetTimeUnixNano()),
+					ObservedTimestamp:    timestamp(lr.GetObservedTimeUnixNano()),
+					Severity:             log.Severity(lr.GetSeverityNumber()),
+					SeverityText:         lr.GetSeverityText(),
+					Body:                 logValue(lr.GetBody()),
+					Attributes:           logAttrs(lr.GetAttributes()),
+					TraceFlags:           trace.TraceFlags(lr.GetFlags() & traceFlagsMask), // nolint:gosec // Masked to 8 bits.
+					Resource:             res,
+					InstrumentationScope: &is,
+					DroppedAttributes:    int(lr.GetDroppedAttributesCount()),
+				}
+				copy(f.TraceID[:], lr.GetTraceId())
+				copy(f.SpanID[:], lr.GetSpanId())
+				out = append(out, f.NewRecord())
+			}
+		}
+	}
+	return out
+}
+
+// logAttrs returns the log attributes kvs.
+func logAttrs(kvs []*cpb.KeyValue) []log.KeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	out := make([]log.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, log.KeyValue{
+			Key:   kv.GetKey(),
+			Value: logValue(kv.GetValue()),
+		})
+	}
+	return out
+}
+
+// logValue returns the log value v.
+func logValue(v *cpb.AnyValue) log.Value {
+	switch v := v.GetValue().(type) {
+	case *cpb.AnyValue_BoolValue:
+		return log.BoolValue(v.BoolValue)
+	case *cpb.AnyValue_IntValue:
+		return log.Int64Value(v.IntValue)
+	case *cpb.AnyValue_DoubleValue:
+		return log.Float64Value(v.DoubleValue)
+	case *cpb.AnyValue_StringValue:
+		return log.StringValue(v.StringValue)
+	case *cpb.AnyValue_BytesValue:
+		return log.BytesValue(v.BytesValue)
+	case *cpb.AnyValue_ArrayValue:
+		vals := v.ArrayValue.GetValues()
+		out := make([]log.Value, 0, len(vals))
+		for _, val := range vals {
+			out = append(out, logValue(val))
+		}
+		return log.SliceValue(out...)
+	case *cpb.AnyValue_KvlistValue:
+		return log.MapValue(logAttrs(v.KvlistValue.GetValues())...)
+	}
+	return log.Value{}
+}
@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// resourceMetrics returns the metrics of rms.
+func resourceMetrics(rms []*mpb.ResourceMetrics) []metricdata.ResourceMetrics {
+	if len(rms) == 0 {
+		return nil
+	}
+
+	out := make([]metricdata.ResourceMetrics, 0, len(rms))
+	for _, rm := range rms {
+		sms := make([]metricdata.ScopeMetrics, 0, len(rm.GetScopeMetrics()))
+		for _, sm := range rm.GetScopeMetrics() {
+			ms := make([]metricdata.Metrics, 0, len(sm.GetMetrics()))
+			for _, m := range sm.GetMetrics() {
+				ms = append(ms, metric(m))
+			}
+			sms = append(sms, metricdata.ScopeMetrics{
+				Scope:   scope(sm.GetScope(), sm.GetSchemaUrl()),
+				Metrics: ms,
+			})
+		}
+		out = append(out, metricdata.ResourceMetrics{
+			Resource:     newResource(rm.GetResource(), rm.GetSchemaUrl()),
+			ScopeMetrics: sms,
+		})
+	}
+	return out
+}
+
+// metric returns the metric m. The value type of gauges and sums is the one
+// of their data points, histograms have float64 values.
+func metric(m *mpb.Metric) metricdata.Metrics {
+	out := metricdata.Metrics{
+		Name:        m.GetName(),
+		Description: m.GetDescription(),
+		Unit:        m.GetUnit(),
+	}
+	switch d := m.GetData().(type) {
+	case *mpb.Metric_Gauge:
+		dPts := d.Gauge.GetDataPoints()
+		if isInt(dPts) {
+			out.Data = metricdata.Gauge[int64]{DataPoints: dataPoints[int64](dPts)}
+		} else {
+			out.Data = metricdata.Gauge[float64]{DataPoints: dataPoints[float64](dPts)}
+		}
+	case *mpb.Metric_Sum:
+		t := temporality(d.Sum.GetAggregationTemporality())
+		mono := d.Sum.GetIsMonotonic()
+		dPts := d.Sum.GetDataPoints()
+		if isInt(dPts) {
+			out.Data = metricdata.Sum[int64]{
+				DataPoints:  dataPoints[int64](dPts),
+				Temporality: t,
+				IsMonotonic: mono,
+			}
+		} else {
+			out.Data = metricdata.Sum[float64]{
+				DataPoints:  dataPoints[float64](dPts),
+				Temporality: t,
+				IsMonotonic: mono,
+			}
+		}
+	case *mpb.Metric_Histogram:
+		out.Data = metricdata.Histogram[float64]{
+			DataPoints:  histogramDataPoints(d.Histogram.GetDataPoints()),
+			Temporality: temporality(d.Histogram.GetAggregationTemporality()),
+		}
+	case *mpb.Metric_ExponentialHistogram:
+		out.Data = metricdata.ExponentialHistogram[float64]{
+			DataPoints:  expHistogramDataPoints(d.ExponentialHistogram.GetDataPoints()),
+			Temporality: temporality(d.ExponentialHistogram.GetAggregationTemporality()),
+		}
+	case *mpb.Metric_Summary:
+		out.Data = metricdata.Summary{
+			DataPoints: summaryDataPoints(d.Summary.GetDataPoints()),
+		}
+	}
+	return out
+}
+
+// isInt returns if the values of dPts are integers.
+func isInt(dPts []*mpb.NumberDataPoint) bool {
+	if len(dPts) == 0 {
+		return false
+	}
+	_, ok := dPts[0].GetValue().(*mpb.NumberDataPoint_AsInt)
+	return ok
+}
+
+func temporality(t mpb.AggregationTemporality) metricdata.Temporality {
+	switch t {
+	case mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA:
+		return metricdata.DeltaTemporality
+	case mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE:
+		return metricdata.CumulativeTemporality
+	}
+	return metricdata.Temporality(0)
+}
+
+func dataPoints[N int64 | float64](dPts []*mpb.NumberDataPoint) []metricdata.DataPoint[N] {
+	out := make([]metricdata.DataPoint[N], 0, len(dPts))
+	for _, dPt := range dPts {
+		var v N
+		switch val := dPt.GetValue().(type) {
+		case *mpb.NumberDataPoint_AsInt:
+			v = N(val.AsInt)
+		case *mpb.NumberDataPoint_AsDouble:
+			v = N(val.AsDouble)
+		}
+		out = append(out, metricdata.DataPoint[N]{
+			Attributes: attribute.NewSet(attrs(dPt.GetAttributes())...),
+			StartTime:  timestamp(dPt.GetStartTimeUnixNano()),
+			Time:       timestamp(dPt.GetTimeUnixNano()),
+			Value:      v,
+			Exemplars:  exemplars[N](dPt.GetExemplars()),
+		})
+	}
+	return out
+}
+
+func histogramDataPoints(dPts []*mpb.HistogramDataPoint) []metricdata.HistogramDataPoint[float64] {
+	out := make([]metricdata.HistogramDataPoint[float64], 0, len(dPts))
+	for _, dPt := range dPts {
+		out = append(out, metricdata.HistogramDataPoint[float64]{
+			Attributes:   attribute.NewSet(attrs(dPt.GetAttributes())...),
+			StartTime:    timestamp(dPt.GetStartTimeUnixNano()),
+			Time:         timestamp(dPt.GetTimeUnixNano()),
+			Count:        dPt.GetCount(),
+			Bounds:       dPt.GetExplicitBounds(),
+			BucketCounts: dPt.GetBucketCounts(),
+			Min:          extrema(dPt.Min),
+			Max:          extrema(dPt.Max),
+			Sum:          dPt.GetSum(),
+			Exemplars:    exemplars[float64](dPt.GetExemplars()),
+		})
+	}
+	return out
+}
+
+func expHistogramDataPoints(dPts []*mpb.ExponentialHistogramDataPoint) []metricdata.ExponentialHistogramDataPoint[float64] {
+	out := make([]metricdata.ExponentialHistogramDataPoint[float64], 0, len(dPts))
+	for _, dPt := range dPts {
+		out = append(out, metricdata.ExponentialHistogramDataPoint[float64]{
+			Attributes:     attribute.NewSet(attrs(dPt.GetAttributes())...),
+			StartTime:      timestamp(dPt.GetStartTimeUnixNano()),
+			Time:           timestamp(dPt.GetTimeUnixNano()),
+			Count:          dPt.GetCount(),
+			Min:            extrema(dPt.Min),
+			Max:            extrema(dPt.Max),
+			Sum:            dPt.GetSum(),
+			Scale:          dPt.GetScale(),
+			ZeroCount:      dPt.GetZeroCount(),
+			PositiveBucket: expBucket(dPt.GetPositive()),
+			NegativeBucket: expBucket(dPt.GetNegative()),
+			ZeroThreshold:  dPt.GetZeroThreshold(),
+			Exemplars:      exemplars[float64](dPt.GetExemplars()),
+		})
+	}
+	return out
+}
+
+func expBucket(b *mpb.ExponentialHistogramDataPoint_Buckets) metricdata.ExponentialBucket {
+	return metricdata.ExponentialBucket{
+		Offset: b.GetOffset(),
+		Counts: b.GetBucketCounts(),
+	}
+}
+
+// extrema returns the extrema of the optional value v.
+func extrema(v *float64) metricdata.Extrema[float64] {
+	if v == nil {
+		return metricdata.Extrema[float64]{}
+	}
+	return metricdata.NewExtrema(*v)
+}
+
+func summaryDataPoints(dPts []*mpb.SummaryDataPoint) []metricdata.SummaryDataPoint {
+	out := make([]metricdata.SummaryDataPoint, 0, len(dPts))
+	for _, dPt := range dPts {
+		qs := make([]metricdata.QuantileValue, 0, len(dPt.GetQuantileValues()))
+		for _, q := range dPt.GetQuantileValues() {
+			qs = append(qs, metricdata.QuantileValue{
+				Quantile: q.GetQuantile(),
+				Value:    q.GetValue(),
+			})
+		}
+		out = append(out, metricdata.SummaryDataPoint{
+			Attributes:     attribute.NewSet(attrs(dPt.GetAttributes())...),
+			StartTime:      timestamp(dPt.GetStartTimeUnixNano()),
+			Time:           timestamp(dPt.GetTimeUnixNano()),
+			Count:          dPt.GetCount(),
+			Sum:            dPt.GetSum(),
+			QuantileValues: qs,
+		})
+	}
+	return out
+}
+
+func exemplars[N int64 | float64](es []*mpb.Exemplar) []metricdata.Exemplar[N] {
+	if len(es) == 0 {
+		return nil
+	}
+
+	out := make([]metricdata.Exemplar[N], 0, len(es))
+	for _, e := range es {
+		var v N
+		switch val := e.GetValue().(type) {
+		case *mpb.Exemplar_AsInt:
+			v = N(val.AsInt)
+		case *mpb.Exemplar_AsDouble:
+			v = N(val.AsDouble)
+		}
+		out = append(out, metricdata.Exemplar[N]{
+			FilteredAttributes: attrs(e.GetFilteredAttributes()),
+			Time:               timestamp(e.GetTimeUnixNano()),
+			Value:              v,
+			SpanID:             e.GetSpanId(),
+			TraceID:            e.GetTraceId(),
+		})
+	}
+	return out
+}
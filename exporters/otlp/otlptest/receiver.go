@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// loopback is the address the Receiver listens at, with an OS chosen port.
+const loopback = "127.0.0.1:0"
+
+// Receiver is an OTLP receiver serving the OTLP gRPC and OTLP/HTTP protocols
+// on loopback addresses. It stores the data of the export requests it accepts.
+//
+// A Receiver should be created with NewReceiver and shut down with Shutdown
+// once the test is done. It is safe for concurrent use.
+type Receiver struct {
+	grpcListener net.Listener
+	grpcServer   *grpc.Server
+	httpListener net.Listener
+	httpServer   *http.Server
+
+	mu        sync.Mutex
+	responses []Response
+	requests  int
+	headers   http.Header
+	spans     []*tracepb.ResourceSpans
+	metrics   []*metricpb.ResourceMetrics
+	logs      []*logpb.ResourceLogs
+}
+
+// NewReceiver returns a started Receiver.
+func NewReceiver() (*Receiver, error) {
+	r := &Receiver{headers: http.Header{}}
+
+	var err error
+	r.grpcListener, err = net.Listen("tcp", loopback)
+	if err != nil {
+		return nil, err
+	}
+	r.httpListener, err = net.Listen("tcp", loopback)
+	if err != nil {
+		_ = r.grpcListener.Close()
+		return nil, err
+	}
+
+	r.grpcServer = grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(r.grpcServer, traceService{r: r})
+	colmetricpb.RegisterMetricsServiceServer(r.grpcServer, metricsService{r: r})
+	collogpb.RegisterLogsServiceServer(r.grpcServer, logsService{r: r})
+	go func() { _ = r.grpcServer.Serve(r.grpcListener) }()
+
+	r.httpServer = &http.Server{
+		Handler:           r.httpHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = r.httpServer.Serve(r.httpListener) }()
+
+	return r, nil
+}
+
+// GRPCEndpoint returns the host and port of the OTLP gRPC endpoint of r. It
+// does not use TLS.
+func (r *Receiver) GRPCEndpoint() string {
+	return r.grpcListener.Addr().String()
+}
+
+// HTTPEndpoint returns the host and port of the OTLP/HTTP endpoint of r. It
+// does not use TLS, and serves the default URL paths of the signals:
+// "/v1/traces", "/v1/metrics" and "/v1/logs".
+func (r *Receiver) HTTPEndpoint() string {
+	return r.httpListener.Addr().String()
+}
+
+// Shutdown stops r. The export requests being handled are canceled.
+func (r *Receiver) Shutdown(ctx context.Context) error {
+	r.grpcServer.Stop()
+	return r.httpServer.Shutdown(ctx)
+}
+
+// Respond sets the responses of r to the next export requests, of any
+// signal and protocol, in order. The export requests received once these
+// responses are used are accepted.
+//
+// Responses are added to the ones not used yet.
+func (r *Receiver) Respond(responses ...Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, responses...)
+}
+
+// Requests returns the number of export requests received by r, including
+// the ones that were not accepted.
+func (r *Receiver) Requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+// Headers returns the headers, or gRPC metadata, of all the export requests
+// received by r. The keys are canonicalized as HTTP header keys.
+func (r *Receiver) Headers() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.headers.Clone()
+}
+
+// Spans returns the spans of the export requests accepted by r.
+func (r *Receiver) Spans() tracetest.SpanStubs {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return spanStubs(r.spans)
+}
+
+// Metrics returns the metrics of the export requests accepted by r, one
+// ResourceMetrics per resource of each request.
+func (r *Receiver) Metrics() []metricdata.ResourceMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return resourceMetrics(r.metrics)
+}
+
+// LogRecords returns the log records of the export requests accepted by r.
+func (r *Receiver) LogRecords() []sdklog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return records(r.logs)
+}
+
+// Reset clears the data, headers and number of the export requests received
+// by r, and the responses not used yet.
+func (r *Receiver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.responses = nil
+	r.requests = 0
+	r.headers = http.Header{}
+	r.spans = nil
+	r.metrics = nil
+	r.logs = nil
+}
+
+// next records the export request with headers and returns the response to
+// it, once its delay has elapsed. An error is returned if ctx is done first.
+func (r *Receiver) next(ctx context.Context, headers map[string][]string) (Response, error) {
+	r.mu.Lock()
+	r.requests++
+	for k, vals := range headers {
+		for _, v := range vals {
+			r.headers.Add(k, v)
+		}
+	}
+	var resp Response
+	if len(r.responses) > 0 {
+		resp, r.responses = r.responses[0], r.responses[1:]
+	}
+	r.mu.Unlock()
+
+	if resp.Delay <= 0 {
+		return resp, nil
+	}
+	timer := time.NewTimer(resp.Delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return resp, nil
+	case <-ctx.Done():
+		return resp, ctx.Err()
+	}
+}
+
+// accept stores the data of the export request req.
+func (r *Receiver) accept(req proto.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch req := req.(type) {
+	case *coltracepb.ExportTraceServiceRequest:
+		r.spans = append(r.spans, req.ResourceSpans...)
+	case *colmetricpb.ExportMetricsServiceRequest:
+		r.metrics = append(r.metrics, req.ResourceMetrics...)
+	case *collogpb.ExportLogsServiceRequest:
+		r.logs = append(r.logs, req.ResourceLogs...)
+	}
+}
+
+// errUnknownRequest is returned for a message that is not an export request.
+var errUnknownRequest = errors.New("otlptest: unknown export request")
@@ -0,0 +1,491 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptest/internal/otlpjson"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+var (
+	start = time.Unix(0, 1_000_000_000)
+	end   = start.Add(time.Second)
+
+	res = resource.NewWithAttributes(
+		"https://opentelemetry.io/schemas/1.26.0",
+		attribute.String("service.name", "test"),
+	)
+	instScope = instrumentation.Scope{
+		Name:       "test",
+		Version:    "v0.1.0",
+		SchemaURL:  "https://opentelemetry.io/schemas/1.26.0",
+		Attributes: attribute.NewSet(attribute.Bool("scope", true)),
+	}
+
+	traceID = trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID  = trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+func newReceiver(t *testing.T) *Receiver {
+	t.Helper()
+	r, err := NewReceiver()
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+	return r
+}
+
+func spans() tracetest.SpanStubs {
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		Remote:  true,
+	})
+	return tracetest.SpanStubs{
+		{
+			Name: "root",
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+			}),
+			SpanKind:  trace.SpanKindServer,
+			StartTime: start,
+			EndTime:   end,
+			Attributes: []attribute.KeyValue{
+				attribute.Bool("bool", true),
+				attribute.Int64("int", 1),
+				attribute.Float64("float", 1.5),
+				attribute.String("string", "a"),
+				attribute.BoolSlice("bools", []bool{true, false}),
+				attribute.Int64Slice("ints", []int64{1, 2}),
+				attribute.Float64Slice("floats", []float64{1.5, 2.5}),
+				attribute.StringSlice("strings", []string{"a", "b"}),
+			},
+			Events: []tracesdk.Event{{
+				Name:                  "event",
+				Attributes:            []attribute.KeyValue{attribute.Int("n", 1)},
+				DroppedAttributeCount: 1,
+				Time:                  start.Add(time.Millisecond),
+			}},
+			Status:                 tracesdk.Status{Code: otelcodes.Error, Description: "failed"},
+			DroppedAttributes:      2,
+			Resource:               res,
+			InstrumentationScope:   instScope,
+			InstrumentationLibrary: instScope, //nolint:staticcheck // This field needs to be set for backwards compatibility.
+		},
+		{
+			Name: "child",
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     trace.SpanID{2},
+				TraceState: trace.TraceState{},
+			}),
+			Parent:    parent,
+			SpanKind:  trace.SpanKindClient,
+			StartTime: start,
+			EndTime:   end,
+			Links: []tracesdk.Link{{
+				SpanContext:           parent,
+				Attributes:            []attribute.KeyValue{attribute.String("link", "a")},
+				DroppedAttributeCount: 1,
+			}},
+			Status:                 tracesdk.Status{Code: otelcodes.Ok},
+			DroppedEvents:          3,
+			DroppedLinks:           4,
+			Resource:               res,
+			InstrumentationScope:   instScope,
+			InstrumentationLibrary: instScope, //nolint:staticcheck // This field needs to be set for backwards compatibility.
+		},
+	}
+}
+
+func TestReceiverSpans(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t)
+
+	t.Run("GRPC", func(t *testing.T) {
+		exp, err := otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(r.GRPCEndpoint()),
+			otlptracegrpc.WithInsecure(),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, exp.Shutdown(ctx)) })
+
+		r.Reset()
+		require.NoError(t, exp.ExportSpans(ctx, spans().Snapshots()))
+		assert.Equal(t, spans(), r.Spans())
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		for _, enc := range []otlptracehttp.Encoding{otlptracehttp.ProtobufEncoding, otlptracehttp.JSONEncoding} {
+			exp, err := otlptracehttp.New(ctx,
+				otlptracehttp.WithEndpoint(r.HTTPEndpoint()),
+				otlptracehttp.WithInsecure(),
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+				otlptracehttp.WithEncoding(enc),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { assert.NoError(t, exp.Shutdown(ctx)) })
+
+			r.Reset()
+			require.NoError(t, exp.ExportSpans(ctx, spans().Snapshots()))
+			assert.Equal(t, spans(), r.Spans())
+		}
+	})
+}
+
+func TestReceiverMetrics(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t)
+
+	exp, err := otlpmetricgrpc.New(ctx,
+		otlpmetricgrpc.WithEndpoint(r.GRPCEndpoint()),
+		otlpmetricgrpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(ctx)) })
+
+	attrs := attribute.NewSet(attribute.String("key", "value"))
+	want := metricdata.ResourceMetrics{
+		Resource: res,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instScope,
+			Metrics: []metricdata.Metrics{
+				{
+					Name:        "gauge",
+					Description: "int gauge",
+					Unit:        "1",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{
+							Attributes: attrs,
+							Time:       end,
+							Value:      2,
+						}},
+					},
+				},
+				{
+					Name: "sum",
+					Data: metricdata.Sum[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[float64]{{
+							Attributes: attrs,
+							StartTime:  start,
+							Time:       end,
+							Value:      1.5,
+							Exemplars: []metricdata.Exemplar[float64]{{
+								FilteredAttributes: []attribute.KeyValue{attribute.Int("n", 1)},
+								Time:               start,
+								Value:              1.5,
+								SpanID:             spanID[:],
+								TraceID:            traceID[:],
+							}},
+						}},
+					},
+				},
+				{
+					Name: "histogram",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes:   attrs,
+							StartTime:    start,
+							Time:         end,
+							Count:        3,
+							Bounds:       []float64{1, 10},
+							BucketCounts: []uint64{1, 1, 1},
+							Min:          metricdata.NewExtrema(0.5),
+							Max:          metricdata.NewExtrema(20.),
+							Sum:          25.5,
+						}},
+					},
+				},
+				{
+					Name: "exponential histogram",
+					Data: metricdata.ExponentialHistogram[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{{
+							Attributes:     attrs,
+							StartTime:      start,
+							Time:           end,
+							Count:          3,
+							Min:            metricdata.NewExtrema(1.),
+							Max:            metricdata.NewExtrema(4.),
+							Sum:            7,
+							Scale:          1,
+							ZeroCount:      0,
+							PositiveBucket: metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{1, 2}},
+							NegativeBucket: metricdata.ExponentialBucket{},
+						}},
+					},
+				},
+				{
+					Name: "summary",
+					Data: metricdata.Summary{
+						DataPoints: []metricdata.SummaryDataPoint{{
+							Attributes: attrs,
+							StartTime:  start,
+							Time:       end,
+							Count:      2,
+							Sum:        3,
+							QuantileValues: []metricdata.QuantileValue{
+								{Quantile: 0, Value: 1},
+								{Quantile: 1, Value: 2},
+							},
+						}},
+					},
+				},
+			},
+		}},
+	}
+	require.NoError(t, exp.Export(ctx, &want))
+
+	got := r.Metrics()
+	require.Len(t, got, 1)
+	metricdatatest.AssertEqual(t, want, got[0])
+}
+
+func TestReceiverLogRecords(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t)
+
+	exp, err := otlploghttp.New(ctx,
+		otlploghttp.WithEndpoint(r.HTTPEndpoint()),
+		otlploghttp.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(ctx)) })
+
+	records := func() []sdklog.Record {
+		s := instScope
+		return []sdklog.Record{
+			logtest.RecordFactory{
+				Timestamp:         start,
+				ObservedTimestamp: end,
+				Severity:          log.SeverityWarn,
+				SeverityText:      "WARN",
+				Body: log.MapValue(
+					log.String("string", "a"),
+					log.Bytes("bytes", []byte("b")),
+					log.Slice("slice", log.IntValue(1), log.BoolValue(true)),
+				),
+				Attributes: []log.KeyValue{
+					log.Float64("float", 1.5),
+					log.Map("map", log.Int("n", 1)),
+				},
+				TraceID:              traceID,
+				SpanID:               spanID,
+				TraceFlags:           trace.FlagsSampled,
+				Resource:             res,
+				InstrumentationScope: &s,
+			}.NewRecord(),
+			logtest.RecordFactory{
+				Body:                 log.StringValue("no timestamp"),
+				Resource:             res,
+				InstrumentationScope: &s,
+			}.NewRecord(),
+		}
+	}
+	require.NoError(t, exp.Export(ctx, records()))
+	assert.Equal(t, records(), r.LogRecords())
+}
+
+func newTraceExporter(t *testing.T, r *Receiver, opts ...otlptracegrpc.Option) *otlptrace.Exporter {
+	t.Helper()
+	ctx := context.Background()
+	opts = append([]otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(r.GRPCEndpoint()),
+		otlptracegrpc.WithInsecure(),
+	}, opts...)
+	exp, err := otlptracegrpc.New(ctx, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(ctx)) })
+	return exp
+}
+
+func TestReceiverRespondGRPC(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t)
+
+	t.Run("Error", func(t *testing.T) {
+		r.Reset()
+		exp := newTraceExporter(t, r, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}))
+		r.Respond(Response{Code: codes.Unavailable, Message: "unavailable"})
+
+		err := exp.ExportSpans(ctx, spans().Snapshots())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.ErrorContains(t, err, "unavailable")
+		assert.Equal(t, 1, r.Requests())
+		assert.Empty(t, r.Spans(), "data of failed requests must not be stored")
+
+		require.NoError(t, exp.ExportSpans(ctx, spans().Snapshots()), "responses must be used once")
+		assert.Equal(t, 2, r.Requests())
+		assert.Len(t, r.Spans(), 2)
+	})
+
+	t.Run("Throttle", func(t *testing.T) {
+		r.Reset()
+		exp := newTraceExporter(t, r, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+			MaxElapsedTime:  time.Minute,
+		}))
+		const throttle = 50 * time.Millisecond
+		r.Respond(Response{Code: codes.ResourceExhausted, Throttle: throttle})
+
+		begin := time.Now()
+		require.NoError(t, exp.ExportSpans(ctx, spans().Snapshots()), "throttled request not retried")
+		assert.GreaterOrEqual(t, time.Since(begin), throttle, "throttle delay not honored")
+		assert.Equal(t, 2, r.Requests())
+	})
+
+	t.Run("Delay", func(t *testing.T) {
+		r.Reset()
+		exp := newTraceExporter(t, r,
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+			otlptracegrpc.WithTimeout(10*time.Millisecond),
+		)
+		r.Respond(Response{Delay: time.Minute})
+
+		err := exp.ExportSpans(ctx, spans().Snapshots())
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Empty(t, r.Spans())
+	})
+
+	t.Run("PartialSuccess", func(t *testing.T) {
+		r.Reset()
+		var got []otlptracegrpc.PartialSuccessError
+		exp := newTraceExporter(t, r, otlptracegrpc.WithPartialSuccessHandler(func(p otlptracegrpc.PartialSuccessError) {
+			got = append(got, p)
+		}))
+		r.Respond(Response{PartialSuccess: &PartialSuccess{Rejected: 1, Message: "rejected"}})
+
+		require.NoError(t, exp.ExportSpans(ctx, spans().Snapshots()))
+		require.Len(t, got, 1)
+		assert.Equal(t, int64(1), got[0].RejectedItems)
+		assert.Equal(t, "rejected", got[0].ErrorMessage)
+		assert.Len(t, r.Spans(), 2, "data of partially accepted requests must be stored")
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		r.Reset()
+		exp := newTraceExporter(t, r, otlptracegrpc.WithHeaders(map[string]string{"x-test": "a"}))
+
+		require.NoError(t, exp.ExportSpans(ctx, spans().Snapshots()))
+		assert.Equal(t, []string{"a"}, r.Headers().Values("X-Test"))
+	})
+}
+
+// post sends an empty OTLP/HTTP trace export request encoded with
+// contentType to r.
+func post(t *testing.T, r *Receiver, contentType string) *http.Response {
+	t.Helper()
+
+	req := &coltracepb.ExportTraceServiceRequest{}
+	body, err := proto.Marshal(req)
+	if contentType == otlpjson.ContentType {
+		body, err = otlpjson.Marshal(req)
+	}
+	require.NoError(t, err)
+
+	resp, err := http.Post("http://"+r.HTTPEndpoint()+"/v1/traces", contentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestReceiverRespondHTTP(t *testing.T) {
+	r := newReceiver(t)
+
+	t.Run("Error", func(t *testing.T) {
+		r.Reset()
+		r.Respond(
+			Response{Code: codes.Unavailable, Message: "unavailable", Throttle: 1500 * time.Millisecond},
+			Response{Code: codes.Unavailable, HTTPStatus: http.StatusBadGateway},
+		)
+
+		resp := post(t, r, contentTypeProto)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "unavailable\n", string(b))
+
+		resp = post(t, r, contentTypeProto)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Retry-After"))
+
+		resp = post(t, r, contentTypeProto)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, r.Requests())
+	})
+
+	t.Run("PartialSuccess", func(t *testing.T) {
+		for _, contentType := range []string{contentTypeProto, otlpjson.ContentType} {
+			r.Reset()
+			r.Respond(Response{PartialSuccess: &PartialSuccess{Rejected: 2, Message: "rejected"}})
+
+			resp := post(t, r, contentType)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			var msg coltracepb.ExportTraceServiceResponse
+			if contentType == otlpjson.ContentType {
+				require.NoError(t, otlpjson.Unmarshal(b, &msg))
+			} else {
+				require.NoError(t, proto.Unmarshal(b, &msg))
+			}
+			assert.Equal(t, int64(2), msg.GetPartialSuccess().GetRejectedSpans())
+			assert.Equal(t, "rejected", msg.GetPartialSuccess().GetErrorMessage())
+		}
+	})
+
+	t.Run("UnsupportedContentType", func(t *testing.T) {
+		r.Reset()
+		resp := post(t, r, "text/plain")
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+		assert.Equal(t, 0, r.Requests())
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusOK, httpStatus(codes.OK))
+	assert.Equal(t, http.StatusTooManyRequests, httpStatus(codes.ResourceExhausted))
+	assert.Equal(t, http.StatusServiceUnavailable, httpStatus(codes.Unavailable))
+	assert.Equal(t, http.StatusGatewayTimeout, httpStatus(codes.DeadlineExceeded))
+	assert.Equal(t, http.StatusBadRequest, httpStatus(codes.InvalidArgument))
+	assert.Equal(t, http.StatusInternalServerError, httpStatus(codes.Internal))
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"net/http"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// Response is the response of a Receiver to an export request.
+//
+// The zero value accepts the request.
+type Response struct {
+	// Delay is the time the Receiver waits before responding. The request is
+	// not accepted if it is canceled in the meantime.
+	Delay time.Duration
+
+	// Code is the gRPC status code of the response. The request is accepted
+	// if it is codes.OK.
+	//
+	// It is converted to its HTTP status equivalent for OTLP/HTTP requests,
+	// unless HTTPStatus is set. Note that the OTLP exporters only retry
+	// codes.ResourceExhausted with a Throttle.
+	Code codes.Code
+	// HTTPStatus is the HTTP status of the response to OTLP/HTTP requests,
+	// instead of the equivalent of Code, if it is not zero. The request is
+	// accepted if it is a 2xx status.
+	HTTPStatus int
+	// Message is the error message of a response not accepting the request.
+	Message string
+	// Throttle is the time the client is asked to wait before retrying a
+	// request that is not accepted, if it is positive. It is sent as the
+	// RetryInfo detail of the gRPC status, or as the Retry-After header of
+	// the HTTP response, in seconds rounded up.
+	Throttle time.Duration
+
+	// PartialSuccess is the partial success of a response accepting the
+	// request, if it is not nil.
+	PartialSuccess *PartialSuccess
+}
+
+// PartialSuccess is the partial success of an accepted export request.
+type PartialSuccess struct {
+	// Rejected is the number of spans, data points or log records rejected.
+	Rejected int64
+	// Message is the error or warning message of the partial success.
+	Message string
+}
+
+// grpcErr returns the error of the response to a gRPC export request, or nil
+// if the request is accepted.
+func (r Response) grpcErr() error {
+	if r.Code == codes.OK {
+		return nil
+	}
+
+	s := status.New(r.Code, r.Message)
+	if r.Throttle > 0 {
+		if ds, err := s.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(r.Throttle),
+		}); err == nil {
+			s = ds
+		}
+	}
+	return s.Err()
+}
+
+// httpStatus returns the HTTP status of the response to an OTLP/HTTP export
+// request.
+func (r Response) httpStatus() int {
+	if r.HTTPStatus != 0 {
+		return r.HTTPStatus
+	}
+	return httpStatus(r.Code)
+}
+
+// httpStatus returns the HTTP status equivalent to the gRPC code c.
+func httpStatus(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// message returns the response message to the accepted export request req.
+func (r Response) message(req proto.Message) (proto.Message, error) {
+	p := r.PartialSuccess
+	switch req.(type) {
+	case *coltracepb.ExportTraceServiceRequest:
+		resp := &coltracepb.ExportTraceServiceResponse{}
+		if p != nil {
+			resp.PartialSuccess = &coltracepb.ExportTracePartialSuccess{
+				RejectedSpans: p.Rejected,
+				ErrorMessage:  p.Message,
+			}
+		}
+		return resp, nil
+	case *colmetricpb.ExportMetricsServiceRequest:
+		resp := &colmetricpb.ExportMetricsServiceResponse{}
+		if p != nil {
+			resp.PartialSuccess = &colmetricpb.ExportMetricsPartialSuccess{
+				RejectedDataPoints: p.Rejected,
+				ErrorMessage:       p.Message,
+			}
+		}
+		return resp, nil
+	case *collogpb.ExportLogsServiceRequest:
+		resp := &collogpb.ExportLogsServiceResponse{}
+		if p != nil {
+			resp.PartialSuccess = &collogpb.ExportLogsPartialSuccess{
+				RejectedLogRecords: p.Rejected,
+				ErrorMessage:       p.Message,
+			}
+		}
+		return resp, nil
+	}
+	return nil, errUnknownRequest
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// spanStubs returns the spans of rss.
+func spanStubs(rss []*tracepb.ResourceSpans) tracetest.SpanStubs {
+	var out tracetest.SpanStubs
+	for _, rs := range rss {
+		res := newResource(rs.GetResource(), rs.GetSchemaUrl())
+		for _, ss := range rs.GetScopeSpans() {
+			is := scope(ss.GetScope(), ss.GetSchemaUrl())
+			for _, s := range ss.GetSpans() {
+				out = append(out, spanStub(s, res, is))
+			}
+		}
+	}
+	return out
+}
+
+// spanStub returns the span s of the resource res and scope is.
+func spanStub(s *tracepb.Span, res *resource.Resource, is instrumentation.Scope) tracetest.SpanStub {
+	var tid trace.TraceID
+	copy(tid[:], s.GetTraceId())
+
+	stub := tracetest.SpanStub{
+		Name:                   s.GetName(),
+		SpanContext:            spanContext(tid, s.GetSpanId(), s.GetTraceState(), s.GetFlags()&^remoteMask),
+		SpanKind:               trace.SpanKind(s.GetKind()),
+		StartTime:              timestamp(s.GetStartTimeUnixNano()),
+		EndTime:                timestamp(s.GetEndTimeUnixNano()),
+		Attributes:             attrs(s.GetAttributes()),
+		Events:                 events(s.GetEvents()),
+		Links:                  links(s.GetLinks()),
+		Status:                 spanStatus(s.GetStatus()),
+		DroppedAttributes:      int(s.GetDroppedAttributesCount()),
+		DroppedEvents:          int(s.GetDroppedEventsCount()),
+		DroppedLinks:           int(s.GetDroppedLinksCount()),
+		Resource:               res,
+		InstrumentationScope:   is,
+		InstrumentationLibrary: is, //nolint:staticcheck // This field needs to be set for backwards compatibility.
+	}
+	if len(s.GetParentSpanId()) > 0 {
+		// The remote flags of a span are the ones of its parent.
+		stub.Parent = spanContext(tid, s.GetParentSpanId(), "", s.GetFlags()&remoteMask)
+	}
+	return stub
+}
+
+const (
+	// remoteMask is the mask of the flags telling if a span context is
+	// remote.
+	remoteMask = uint32(tracepb.SpanFlags_SPAN_FLAGS_CONTEXT_HAS_IS_REMOTE_MASK |
+		tracepb.SpanFlags_SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK)
+	// traceFlagsMask is the mask of the W3C trace flags of a span context.
+	traceFlagsMask = uint32(tracepb.SpanFlags_SPAN_FLAGS_TRACE_FLAGS_MASK)
+)
+
+// spanContext returns the span context of the trace tid and span sid, with
+// the trace state ts and the OTLP span flags.
+func spanContext(tid trace.TraceID, sid []byte, ts string, flags uint32) trace.SpanContext {
+	cfg := trace.SpanContextConfig{
+		TraceID:    tid,
+		TraceFlags: trace.TraceFlags(flags & traceFlagsMask), // nolint:gosec // Masked to 8 bits.
+		Remote:     flags&uint32(tracepb.SpanFlags_SPAN_FLAGS_CONTEXT_IS_REMOTE_MASK) != 0,
+	}
+	copy(cfg.SpanID[:], sid)
+	cfg.TraceState, _ = trace.ParseTraceState(ts)
+	return trace.NewSpanContext(cfg)
+}
+
+// events returns the span events es.
+func events(es []*tracepb.Span_Event) []tracesdk.Event {
+	if len(es) == 0 {
+		return nil
+	}
+
+	out := make([]tracesdk.Event, 0, len(es))
+	for _, e := range es {
+		out = append(out, tracesdk.Event{
+			Name:                  e.GetName(),
+			Attributes:            attrs(e.GetAttributes()),
+			DroppedAttributeCount: int(e.GetDroppedAttributesCount()),
+			Time:                  timestamp(e.GetTimeUnixNano()),
+		})
+	}
+	return out
+}
+
+// links returns the span links ls.
+func links(ls []*tracepb.Span_Link) []tracesdk.Link {
+	if len(ls) == 0 {
+		return nil
+	}
+
+	out := make([]tracesdk.Link, 0, len(ls))
+	for _, l := range ls {
+		var tid trace.TraceID
+		copy(tid[:], l.GetTraceId())
+		out = append(out, tracesdk.Link{
+			SpanContext:           spanContext(tid, l.GetSpanId(), l.GetTraceState(), l.GetFlags()),
+			Attributes:            attrs(l.GetAttributes()),
+			DroppedAttributeCount: int(l.GetDroppedAttributesCount()),
+		})
+	}
+	return out
+}
+
+// spanStatus returns the span status s.
+func spanStatus(s *tracepb.Status) tracesdk.Status {
+	var c codes.Code
+	switch s.GetCode() {
+	case tracepb.Status_STATUS_CODE_OK:
+		c = codes.Ok
+	case tracepb.Status_STATUS_CODE_ERROR:
+		c = codes.Error
+	default:
+		c = codes.Unset
+	}
+	return tracesdk.Status{Code: c, Description: s.GetMessage()}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal/filewriter"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal/otlpjson"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var errStopped = errors.New("client is not started or stopped")
+
+type client struct {
+	cfg config
+
+	// mu protects the writer and closer. They are set in Start and unset in
+	// Stop, which may be called concurrently with UploadTraces.
+	mu     sync.RWMutex
+	writer *filewriter.Writer
+	closer io.Closer
+}
+
+// Compile time check *client implements otlptrace.Client.
+var _ otlptrace.Client = (*client)(nil)
+
+// NewClient creates a new OTLP trace client writing OTLP/JSON lines.
+func NewClient(opts ...Option) otlptrace.Client {
+	return &client{cfg: newConfig(opts)}
+}
+
+// Start opens the file or writer the client writes to.
+func (c *client) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	w, closer, err := c.cfg.open()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.writer, c.closer = w, closer
+	c.mu.Unlock()
+	return nil
+}
+
+// Stop syncs and closes the file the client writes to. Writers set with
+// WithWriter are synced but not closed.
+func (c *client) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.writer == nil {
+		return errStopped
+	}
+	err := c.writer.Sync()
+	if c.closer != nil {
+		err = errors.Join(err, c.closer.Close())
+	}
+	c.writer, c.closer = nil, nil
+	return errors.Join(err, ctx.Err())
+}
+
+// UploadTraces writes protoSpans as a single OTLP/JSON line.
+func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b, err := otlpjson.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.writer == nil {
+		return errStopped
+	}
+	return c.writer.WriteLine(b)
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Client.
+func (c *client) MarshalLog() interface{} {
+	return struct {
+		Type string
+		Path string
+	}{
+		Type: "otlptracefile",
+		Path: c.cfg.path,
+	}
+}
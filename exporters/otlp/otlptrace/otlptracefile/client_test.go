@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal/otlpjson"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// export exports one span named name for each of the names with a
+// TracerProvider using the exporter created with opts. It returns the
+// SpanContexts of the spans.
+func export(t *testing.T, opts []Option, names ...string) []trace.SpanContext {
+	t.Helper()
+
+	ctx := context.Background()
+	exp, err := New(ctx, opts...)
+	require.NoError(t, err)
+
+	res := resource.NewSchemaless(attribute.String("service.name", "test"))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp), sdktrace.WithResource(res))
+	tracer := tp.Tracer("tracer")
+
+	var scs []trace.SpanContext
+	for _, name := range names {
+		_, span := tracer.Start(ctx, name)
+		span.End()
+		scs = append(scs, span.SpanContext())
+	}
+	require.NoError(t, tp.Shutdown(ctx))
+	return scs
+}
+
+// requests decodes the OTLP/JSON lines of s.
+func requests(t *testing.T, s string) []*coltracepb.ExportTraceServiceRequest {
+	t.Helper()
+
+	var out []*coltracepb.ExportTraceServiceRequest
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		req := &coltracepb.ExportTraceServiceRequest{}
+		require.NoError(t, otlpjson.Unmarshal([]byte(line), req))
+		out = append(out, req)
+	}
+	return out
+}
+
+func TestClientWriter(t *testing.T) {
+	var buf bytes.Buffer
+	scs := export(t, []Option{WithWriter(&buf)}, "span 1", "span 2")
+
+	// One line is written for each export.
+	got := requests(t, buf.String())
+	require.Len(t, got, 2)
+	for i, req := range got {
+		require.Len(t, req.ResourceSpans, 1)
+		rs := req.ResourceSpans[0]
+		assert.Equal(t, "service.name", rs.Resource.Attributes[0].Key)
+		require.Len(t, rs.ScopeSpans, 1)
+		require.Len(t, rs.ScopeSpans[0].Spans, 1)
+		span := rs.ScopeSpans[0].Spans[0]
+		assert.Equal(t, fmt.Sprintf("span %d", i+1), span.Name)
+
+		tid := scs[i].TraceID()
+		assert.Equal(t, tid[:], span.TraceId)
+	}
+
+	// IDs are hex-encoded as required by OTLP/JSON.
+	assert.Contains(t, buf.String(), `"traceId":"`+scs[0].TraceID().String()+`"`)
+	assert.Contains(t, buf.String(), `"spanId":"`+scs[0].SpanID().String()+`"`)
+}
+
+func TestClientFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "traces.jsonl")
+	opts := []Option{WithFile(path)}
+
+	export(t, opts, "span 1")
+	// Spans are appended to existing files.
+	export(t, opts, "span 2")
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	got := requests(t, string(b))
+	require.Len(t, got, 2)
+	assert.Equal(t, "span 1", got[0].ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+	assert.Equal(t, "span 2", got[1].ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+}
+
+func TestClientFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "traces.jsonl")
+
+	// Each line is larger than the maximum size, a file holds a single line.
+	export(t, []Option{WithFile(path), WithRotation(1, 1)}, "span 1", "span 2", "span 3")
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, requests(t, string(b)), 1)
+	assert.Contains(t, string(b), "span 3")
+
+	b, err = os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "span 2")
+
+	assert.NoFileExists(t, path+".2")
+}
+
+func TestClientStartError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "traces.jsonl")
+	_, err := New(context.Background(), WithFile(path))
+	assert.ErrorContains(t, err, "failed to open file")
+}
+
+func TestClientStopped(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithWriter(&bytes.Buffer{}))
+	assert.ErrorIs(t, c.UploadTraces(ctx, nil), errStopped)
+
+	require.NoError(t, c.Start(ctx))
+	require.NoError(t, c.UploadTraces(ctx, nil))
+	require.NoError(t, c.Stop(ctx))
+
+	assert.ErrorIs(t, c.UploadTraces(ctx, nil), errStopped)
+	assert.ErrorIs(t, c.Stop(ctx), errStopped)
+}
+
+func TestClientStopDoesNotCloseWriter(t *testing.T) {
+	ctx := context.Background()
+	f, err := os.Create(filepath.Join(t.TempDir(), "traces.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, f.Close()) })
+
+	c := NewClient(WithWriter(f))
+	require.NoError(t, c.Start(ctx))
+	require.NoError(t, c.Stop(ctx))
+
+	_, err = f.WriteString("still open\n")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+import (
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal/filewriter"
+)
+
+// Option applies an option to the Exporter.
+type Option interface {
+	applyOption(config) config
+}
+
+type fnOpt func(config) config
+
+func (f fnOpt) applyOption(c config) config { return f(c) }
+
+type config struct {
+	writer     io.Writer
+	path       string
+	maxSize    int64
+	maxBackups int
+}
+
+func newConfig(options []Option) config {
+	c := config{writer: os.Stdout}
+	for _, opt := range options {
+		c = opt.applyOption(c)
+	}
+	return c
+}
+
+// open returns the writer of the lines written by the exporter and the
+// closer of the file opened for it, if any.
+func (c config) open() (*filewriter.Writer, io.Closer, error) {
+	if c.path == "" {
+		return filewriter.NewWriter(c.writer), nil, nil
+	}
+	f, err := filewriter.OpenFile(c.path, c.maxSize, c.maxBackups)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filewriter.NewWriter(f), f, nil
+}
+
+// WithWriter sets the writer the Exporter writes OTLP/JSON lines to. The
+// writer is not closed when the Exporter is shut down.
+//
+// If both this option and WithFile are used, the last used option will take
+// precedence.
+//
+// By default, if this option or WithFile is not passed, lines are written to
+// os.Stdout.
+func WithWriter(w io.Writer) Option {
+	return fnOpt(func(c config) config {
+		if w != nil {
+			c.writer, c.path = w, ""
+		}
+		return c
+	})
+}
+
+// WithFile sets the path of the file the Exporter appends OTLP/JSON lines
+// to. The file is created if it does not exist, readable and writable by its
+// owner only, and closed when the Exporter is shut down.
+//
+// If both this option and WithWriter are used, the last used option will take
+// precedence.
+func WithFile(path string) Option {
+	return fnOpt(func(c config) config {
+		c.path = path
+		return c
+	})
+}
+
+// WithRotation sets the rotation of the file set with WithFile.
+//
+// Before a line is written that would make the file larger than maxSize
+// bytes, the file is renamed by appending ".1" to its path, previously rotated
+// files are renamed to the next number (".1" to ".2", and so on), and a new
+// file is created. At most maxBackups rotated files are kept, the oldest ones
+// are removed. A line is never split across files.
+//
+// By default, if this option is not passed or maxSize is not greater than
+// zero, the file is not rotated.
+func WithRotation(maxSize int64, maxBackups int) Option {
+	return fnOpt(func(c config) config {
+		c.maxSize, c.maxBackups = maxSize, maxBackups
+		return c
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	var buf bytes.Buffer
+
+	testCases := []struct {
+		name    string
+		options []Option
+		want    config
+	}{
+		{
+			name: "Defaults",
+			want: config{writer: os.Stdout},
+		},
+		{
+			name:    "WithWriter",
+			options: []Option{WithWriter(&buf)},
+			want:    config{writer: &buf},
+		},
+		{
+			name:    "WithWriter/Nil",
+			options: []Option{WithWriter(nil)},
+			want:    config{writer: os.Stdout},
+		},
+		{
+			name:    "WithFile",
+			options: []Option{WithFile("traces.jsonl")},
+			want:    config{writer: os.Stdout, path: "traces.jsonl"},
+		},
+		{
+			name:    "WithFileThenWriter",
+			options: []Option{WithFile("traces.jsonl"), WithWriter(&buf)},
+			want:    config{writer: &buf},
+		},
+		{
+			name:    "WithRotation",
+			options: []Option{WithFile("traces.jsonl"), WithRotation(1024, 3)},
+			want:    config{writer: os.Stdout, path: "traces.jsonl", maxSize: 1024, maxBackups: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, newConfig(tc.options))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlptracefile provides an OTLP span exporter writing spans as OTLP/JSON
+Lines to a file or an [io.Writer].
+
+Each export is written as a single line holding an
+ExportTraceServiceRequest encoded as OTLP/JSON, the format of the OTLP file
+exporter specification. The written files can be read by tools ingesting this
+format, like the otlpjsonfile receiver of the OpenTelemetry Collector.
+
+By default the lines are written to [os.Stdout]. Use [WithFile] to write
+them to a file, optionally rotated with [WithRotation], or [WithWriter] to
+write them to any [io.Writer].
+
+Exporter should be created using [New].
+*/
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
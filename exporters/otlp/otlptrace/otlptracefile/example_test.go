@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile_test
+
+import (
+	"context"
+	"log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func Example() {
+	ctx := context.Background()
+	exp, err := otlptracefile.New(ctx,
+		otlptracefile.WithFile("/var/log/otel/traces.jsonl"),
+		// Keep at most 5 rotated files of 100 MiB.
+		otlptracefile.WithRotation(100<<20, 5),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp))
+	defer func() {
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			log.Println(err)
+		}
+	}()
+	otel.SetTracerProvider(tracerProvider)
+
+	// From here, the tracerProvider can be used by instrumentation to collect
+	// telemetry.
+}
@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal"
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/file.go.tmpl "--data={}" --out=filewriter/file.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/file_test.go.tmpl "--data={}" --out=filewriter/file_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/writer.go.tmpl "--data={}" --out=filewriter/writer.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/writer_test.go.tmpl "--data={}" --out=filewriter/writer_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpjson/otlpjson.go.tmpl "--data={}" --out=otlpjson/otlpjson.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpjson/otlpjson_test.go.tmpl "--data={}" --out=otlpjson/otlpjson_test.go
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+// Version is the current release version of the OpenTelemetry OTLP file trace exporter in use.
+func Version() string {
+	return "0.1.0"
+}
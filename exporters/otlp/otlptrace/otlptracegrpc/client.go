@@ -6,6 +6,7 @@ package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptra
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -14,12 +15,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/balance"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/split"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -31,20 +37,42 @@ type client struct {
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
 
+	// maxRequestSize is the maximum encoded size of an export request.
+	maxRequestSize int
+
+	partialSuccess *internal.PartialSuccessHandler
+
+	// callOpts are the options of every export call.
+	callOpts []grpc.CallOption
+
+	// endpoints are the endpoints a connection is established to, and
+	// endpointMode how spans are distributed between them.
+	endpoints    []string
+	endpointMode otlpconfig.EndpointMode
+	failover     *balance.Failover
+
+	// queueCfg configures queue, the disk queue of the export requests
+	// that failed with transient errors. queue is created on Start if the
+	// Dir of queueCfg is not empty.
+	queueCfg diskqueue.Config
+	queue    *diskqueue.Queue
+
 	// stopCtx is used as a parent context for all exports. Therefore, when it
 	// is canceled with the stopFunc all exports are canceled.
 	stopCtx context.Context
 	// stopFunc cancels stopCtx, stopping any active exports.
 	stopFunc context.CancelFunc
 
-	// ourConn keeps track of where conn was created: true if created here on
-	// Start, or false if passed with an option. This is important on Shutdown
-	// as the conn should only be closed if created here on start. Otherwise,
-	// it is up to the processes that passed the conn to close it.
+	// ourConn keeps track of where conns were created: true if created here
+	// on Start, or false if passed with an option. This is important on
+	// Shutdown as the conns should only be closed if created here on start.
+	// Otherwise, it is up to the processes that passed the conn to close it.
 	ourConn bool
-	conn    *grpc.ClientConn
-	tscMu   sync.RWMutex
-	tsc     coltracepb.TraceServiceClient
+	// conns are the connections to the endpoints, in the same order.
+	conns []*grpc.ClientConn
+
+	tscMu sync.RWMutex
+	tscs  []coltracepb.TraceServiceClient
 }
 
 // Compile time check *client implements otlptrace.Client.
@@ -61,43 +89,97 @@ func newClient(opts ...Option) *client {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := &client{
-		endpoint:      cfg.Traces.Endpoint,
-		exportTimeout: cfg.Traces.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
-		dialOpts:      cfg.DialOptions,
-		stopCtx:       ctx,
-		stopFunc:      cancel,
-		conn:          cfg.GRPCConn,
+		endpoint:       cfg.Traces.Endpoint,
+		exportTimeout:  cfg.Traces.Timeout,
+		requestFunc:    cfg.RetryConfig.RequestFunc(retryable),
+		maxRequestSize: cfg.Traces.MaxRequestSize,
+		partialSuccess: internal.NewTracePartialSuccessHandler(
+			otel.GetMeterProvider(),
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc",
+			otlptrace.Version(),
+			cfg.Traces.PartialSuccessHandler,
+		),
+		dialOpts:     cfg.DialOptions,
+		endpoints:    cfg.Traces.Endpoints,
+		endpointMode: cfg.Traces.EndpointMode,
+		queueCfg:     cfg.DiskQueue,
+		stopCtx:      ctx,
+		stopFunc:     cancel,
+	}
+
+	if cfg.GRPCConn != nil {
+		c.conns = []*grpc.ClientConn{cfg.GRPCConn}
+	}
+	if s := cfg.SharedClient; s != nil {
+		c.conns = []*grpc.ClientConn{s.Conn()}
+		c.requestFunc = s.Do
+	}
+	if len(c.conns) > 0 || len(c.endpoints) == 0 {
+		// A single endpoint is used.
+		c.endpoints = []string{c.endpoint}
 	}
+	c.failover = balance.NewFailover(len(c.endpoints))
 
 	if len(cfg.Traces.Headers) > 0 {
 		c.metadata = metadata.New(cfg.Traces.Headers)
 	}
 
+	if a := cfg.Traces.Authenticator; a != nil {
+		// The transport security of a connection passed with an option is
+		// decided by the creator of the connection.
+		secure := !cfg.Traces.Insecure && len(c.conns) == 0
+		creds := auth.PerRPCCredentials(a, secure)
+		c.callOpts = append(c.callOpts, grpc.PerRPCCredentials(creds))
+	}
+
 	return c
 }
 
 // Start establishes a gRPC connection to the collector.
 func (c *client) Start(context.Context) error {
-	if c.conn == nil {
-		// If the caller did not provide a ClientConn when the client was
-		// created, create one using the configuration they did provide.
-		conn, err := grpc.NewClient(c.endpoint, c.dialOpts...)
+	if c.queueCfg.Dir != "" {
+		q, err := diskqueue.New(c.queueCfg, c.replay)
 		if err != nil {
 			return err
 		}
-		// Keep track that we own the lifecycle of this conn and need to close
-		// it on Shutdown.
+		c.queue = q
+	}
+
+	if len(c.conns) == 0 {
+		// If the caller did not provide a ClientConn when the client was
+		// created, create one per endpoint using the configuration they did
+		// provide.
+		for _, endpoint := range c.endpoints {
+			conn, err := grpc.NewClient(endpoint, c.dialOpts...)
+			if err != nil {
+				for _, conn := range c.conns {
+					_ = conn.Close()
+				}
+				c.conns = nil
+				c.queue.Close()
+				return err
+			}
+			c.conns = append(c.conns, conn)
+		}
+		// Keep track that we own the lifecycle of these conns and need to
+		// close them on Shutdown.
 		c.ourConn = true
-		c.conn = conn
+	}
+
+	tscs := make([]coltracepb.TraceServiceClient, len(c.conns))
+	for i, conn := range c.conns {
+		tscs[i] = coltracepb.NewTraceServiceClient(conn)
 	}
 
 	// The otlptrace.Client interface states this method is called just once,
 	// so no need to check if already started.
 	c.tscMu.Lock()
-	c.tsc = coltracepb.NewTraceServiceClient(c.conn)
+	c.tscs = tscs
 	c.tscMu.Unlock()
 
+	// Send the requests queued before a restart.
+	c.queue.Flush()
+
 	return nil
 }
 
@@ -122,6 +204,9 @@ func (c *client) Stop(ctx context.Context) error {
 	// Make sure to return context error if the context is done when calling this method.
 	err := ctx.Err()
 
+	// Stop sending the queued requests, they are kept for the next start.
+	c.queue.Close()
+
 	// Acquire the c.tscMu lock within the ctx lifetime.
 	acquired := make(chan struct{})
 	go func() {
@@ -137,7 +222,7 @@ func (c *client) Stop(ctx context.Context) error {
 		c.stopFunc()
 		err = ctx.Err()
 
-		// To ensure the client is not left in a dirty state c.tsc needs to be
+		// To ensure the client is not left in a dirty state c.tscs needs to be
 		// set to nil. To avoid the race condition when doing this, ensure
 		// that all the exports are killed (initiated by c.stopFunc).
 		<-acquired
@@ -151,18 +236,23 @@ func (c *client) Stop(ctx context.Context) error {
 	// once, but there is no guarantee it is called after Start. Ensure the
 	// client is started before doing anything and let the called know if they
 	// made a mistake.
-	if c.tsc == nil {
+	if c.tscs == nil {
 		return errAlreadyStopped
 	}
 
-	// Clear c.tsc to signal the client is stopped.
-	c.tsc = nil
+	// Clear c.tscs to signal the client is stopped.
+	c.tscs = nil
 
 	if c.ourConn {
-		closeErr := c.conn.Close()
+		var closeErrs []error
+		for _, conn := range c.conns {
+			if closeErr := conn.Close(); closeErr != nil {
+				closeErrs = append(closeErrs, closeErr)
+			}
+		}
 		// A context timeout error takes precedence over this error.
-		if err == nil && closeErr != nil {
-			err = closeErr
+		if err == nil {
+			err = errors.Join(closeErrs...)
 		}
 	}
 	return err
@@ -175,6 +265,40 @@ var errShutdown = errors.New("the client is shutdown")
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
 func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	parts := [][]*tracepb.ResourceSpans{protoSpans}
+	if c.endpointMode == otlpconfig.TraceIDEndpoints {
+		parts = balance.ByTraceID(protoSpans, len(c.endpoints))
+	}
+
+	var errs []error
+	for i, part := range parts {
+		if len(part) == 0 && len(parts) > 1 {
+			// No span of this export is sent to the endpoint.
+			continue
+		}
+
+		batches, err := split.Spans(part, c.maxRequestSize, nil)
+		if err != nil {
+			otel.Handle(err)
+		}
+
+		for _, batch := range batches {
+			if err := c.uploadTraces(ctx, i, batch, c.requestFunc); err != nil {
+				c.enqueue(batch, err)
+				errs = append(errs, err)
+				continue
+			}
+			// The endpoint is available, send the queued requests.
+			c.queue.Flush()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// uploadTraces sends protoSpans in a single export request, using
+// requestFunc. With the TraceIDEndpoints mode, the request is sent to the
+// endpoint with the part index, otherwise it is sent to the active endpoint.
+func (c *client) uploadTraces(ctx context.Context, part int, protoSpans []*tracepb.ResourceSpans, requestFunc retry.RequestFunc) error {
 	// Hold a read lock to ensure a shut down initiated after this starts does
 	// not abandon the export. This read lock acquire has less priority than a
 	// write lock acquire (i.e. Stop), meaning if the client is shutting down
@@ -182,34 +306,119 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 	c.tscMu.RLock()
 	defer c.tscMu.RUnlock()
 
-	if c.tsc == nil {
+	if c.tscs == nil {
 		return errShutdown
 	}
 
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		resp, err := c.tsc.Export(iCtx, &coltracepb.ExportTraceServiceRequest{
-			ResourceSpans: protoSpans,
-		})
-		if resp != nil && resp.PartialSuccess != nil {
-			msg := resp.PartialSuccess.GetErrorMessage()
-			n := resp.PartialSuccess.GetRejectedSpans()
-			if n != 0 || msg != "" {
-				err := internal.TracePartialSuccessError(n, msg)
-				otel.Handle(err)
-			}
-		}
-		// nil is converted to OK.
-		if status.Code(err) == codes.OK {
-			// Success.
-			return nil
+	return requestFunc(ctx, func(iCtx context.Context) error {
+		if c.endpointMode == otlpconfig.TraceIDEndpoints {
+			return c.export(iCtx, part, protoSpans)
 		}
-		return err
+		return c.failover.Do(iCtx, func(iCtx context.Context, i int) error {
+			return c.export(iCtx, i, protoSpans)
+		}, failover)
 	})
 }
 
+// export sends protoSpans to the endpoint with index i in a single attempt.
+func (c *client) export(ctx context.Context, i int, protoSpans []*tracepb.ResourceSpans) error {
+	resp, err := c.tscs[i].Export(ctx, &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	}, c.callOpts...)
+	if resp != nil && resp.PartialSuccess != nil {
+		msg := resp.PartialSuccess.GetErrorMessage()
+		n := resp.PartialSuccess.GetRejectedSpans()
+		c.partialSuccess.Handle(ctx, n, msg)
+	}
+	// nil is converted to OK.
+	if status.Code(err) == codes.OK {
+		// Success.
+		return nil
+	}
+	return err
+}
+
+// enqueue adds the export request of protoSpans, which failed with err, to the
+// disk queue if the error is transient.
+func (c *client) enqueue(protoSpans []*tracepb.ResourceSpans, err error) {
+	if c.queue == nil || !transient(err) {
+		return
+	}
+
+	data, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err == nil {
+		err = c.queue.Push(data)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// replay sends the export request data read from the disk queue in a single
+// attempt. The request is dropped if it fails with a permanent error.
+//
+// With the TraceIDEndpoints mode, if the request is sent to some of the
+// endpoints only, the parts of the other endpoints are queued as new requests.
+func (c *client) replay(ctx context.Context, data []byte) error {
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		otel.Handle(fmt.Errorf("dropping invalid queued export request: %w", err))
+		return nil
+	}
+
+	parts := [][]*tracepb.ResourceSpans{req.ResourceSpans}
+	if c.endpointMode == otlpconfig.TraceIDEndpoints {
+		parts = balance.ByTraceID(req.ResourceSpans, len(c.endpoints))
+	}
+
+	var (
+		sent   bool
+		failed [][]*tracepb.ResourceSpans
+		errs   []error
+	)
+	for i, part := range parts {
+		if len(part) == 0 && len(parts) > 1 {
+			continue
+		}
+		if err := c.uploadTraces(ctx, i, part, once); err != nil {
+			failed = append(failed, part)
+			errs = append(errs, err)
+			continue
+		}
+		sent = true
+	}
+
+	if sent {
+		// Queue the parts that failed on their own, so that the parts sent
+		// are not sent again with them.
+		for i, part := range failed {
+			if !transient(errs[i]) {
+				otel.Handle(errs[i])
+				continue
+			}
+			c.enqueue(part, errs[i])
+		}
+		return nil
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && !transient(err) {
+		otel.Handle(err)
+		return nil
+	}
+	return err
+}
+
+// once sends a request in a single attempt.
+func once(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
 // exportContext returns a copy of parent with an appropriate deadline and
 // cancellation function.
 //
@@ -250,6 +459,25 @@ func (c *client) exportContext(parent context.Context) (context.Context, context
 	return ctx, cancel
 }
 
+// failover returns if err identifies a request that can be sent to the next
+// endpoint.
+func failover(err error) bool {
+	ok, _ := retryable(err)
+	return ok
+}
+
+// transient returns if err, returned by an export request, may not be
+// returned when sending the request again later: the request failed with a
+// retry-able status, or was not sent.
+func transient(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	ok, _ = retryableGRPCStatus(s)
+	return ok
+}
+
 // retryable returns if err identifies a request that can be retried and a
 // duration to wait for if an explicit throttle time is included in err.
 func retryable(err error) (bool, time.Duration) {
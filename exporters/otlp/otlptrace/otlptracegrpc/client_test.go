@@ -5,9 +5,13 @@ package otlptracegrpc_test
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +22,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -29,6 +34,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlptracetest"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 )
@@ -67,6 +73,12 @@ func TestNewEndToEnd(t *testing.T) {
 				otlptracegrpc.WithCompressor(gzip.Name),
 			},
 		},
+		{
+			name: "WithZstdCompressor",
+			additionalOpts: []otlptracegrpc.Option{
+				otlptracegrpc.WithCompressor("zstd"),
+			},
+		},
 		{
 			name: "WithServiceConfig",
 			additionalOpts: []otlptracegrpc.Option{
@@ -155,6 +167,85 @@ func TestExporterShutdown(t *testing.T) {
 	otlptracetest.RunExporterShutdownTest(t, factory)
 }
 
+func TestUnixSocketEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not tested on Windows")
+	}
+	// Socket paths are limited in length, do not use t.TempDir.
+	dir, err := os.MkdirTemp("", "otlp")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "otlp.sock")
+	mc := runMockCollectorWithConfig(t, &mockConfig{network: "unix", endpoint: socket})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	client := otlptracegrpc.NewClient(otlptracegrpc.WithEndpointURL("unix://" + socket))
+	exp, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Len(t, mc.getSpans(), 1)
+}
+
+// sharedClient is a SharedClient retrying the export requests that fail up
+// to three times.
+type sharedClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c sharedClient) Conn() *grpc.ClientConn { return c.conn }
+
+func (c sharedClient) Do(ctx context.Context, fn func(context.Context) error) error {
+	var err error
+	for i := 0; i < 3; i++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func TestSharedClient(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{
+			status.Error(codes.Unavailable, "backend under pressure"),
+			status.Error(codes.Unavailable, "backend under pressure"),
+		},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	conn, err := grpc.NewClient(mc.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	shared := sharedClient{conn: conn}
+
+	ctx := context.Background()
+	newExporter := func() *otlptrace.Exporter {
+		client := otlptracegrpc.NewClient(
+			otlptracegrpc.WithSharedClient(shared),
+			// Ignored in favor of the retry of the shared client.
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+		)
+		exp, err := otlptrace.New(ctx, client)
+		require.NoError(t, err)
+		return exp
+	}
+
+	exp := newExporter()
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	// The shared connection is not closed by the exporter shutdown.
+	exp = newExporter()
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	assert.Len(t, mc.getSpans(), 2)
+	assert.NoError(t, conn.Close())
+}
+
 func TestNewInvokeStartThenStopManyTimes(t *testing.T) {
 	mc := runMockCollector(t)
 	t.Cleanup(func() { require.NoError(t, mc.stop()) })
@@ -424,3 +515,207 @@ func TestCustomUserAgent(t *testing.T) {
 	headers := mc.getHeaders()
 	require.Contains(t, headers.Get("user-agent")[0], customUserAgent)
 }
+
+func TestAuthenticatorGRPCConn(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	conn, err := grpc.NewClient(mc.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+	// The insecure connection is used without WithInsecure.
+	ctx := context.Background()
+	exp, err := otlptrace.New(ctx, otlptracegrpc.NewClient(
+		otlptracegrpc.WithGRPCConn(conn),
+		otlptracegrpc.WithAuthenticator(otlptracegrpc.AuthenticatorFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"Authorization": "Bearer token"}, nil
+		})),
+	))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Equal(t, []string{"Bearer token"}, mc.getHeaders().Get("authorization"))
+}
+
+func TestEndpointsFailover(t *testing.T) {
+	unavailable := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{status.Error(codes.Unavailable, "backend under pressure")},
+	})
+	t.Cleanup(func() { require.NoError(t, unavailable.stop()) })
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, "",
+		otlptracegrpc.WithEndpoints(otlptracegrpc.FailoverEndpoints, unavailable.endpoint, mc.endpoint),
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+	)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Len(t, mc.getSpans(), 1)
+
+	// The endpoint that succeeded stays active.
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Len(t, mc.getSpans(), 2)
+	assert.Empty(t, unavailable.getSpans())
+}
+
+func TestEndpointsTraceID(t *testing.T) {
+	collectors := make([]*mockCollector, 3)
+	endpoints := make([]string, len(collectors))
+	for i := range collectors {
+		mc := runMockCollector(t)
+		t.Cleanup(func() { require.NoError(t, mc.stop()) })
+		collectors[i], endpoints[i] = mc, mc.endpoint
+	}
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, "", otlptracegrpc.WithEndpoints(otlptracegrpc.TraceIDEndpoints, endpoints...))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	var stubs tracetest.SpanStubs
+	for i := 0; i < 30; i++ {
+		var traceID trace.TraceID
+		binary.BigEndian.PutUint64(traceID[8:], uint64(i+1))
+		sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID})
+		// Two spans for every trace.
+		stubs = append(stubs,
+			tracetest.SpanStub{Name: "a", SpanContext: sc},
+			tracetest.SpanStub{Name: "b", SpanContext: sc},
+		)
+	}
+	require.NoError(t, exp.ExportSpans(ctx, stubs.Snapshots()))
+
+	// All the spans of a trace are sent to the same collector.
+	traces := make(map[string]int)
+	var total int
+	for i, mc := range collectors {
+		spans := mc.getSpans()
+		assert.NotEmptyf(t, spans, "collector %d", i)
+		for _, span := range spans {
+			id := string(span.TraceId)
+			if c, ok := traces[id]; ok {
+				assert.Equal(t, i, c, "trace sent to several collectors")
+			}
+			traces[id] = i
+		}
+		total += len(spans)
+	}
+	assert.Len(t, traces, 30)
+	assert.Equal(t, len(stubs), total)
+}
+
+func TestDiskQueue(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{
+			status.Error(codes.Unavailable, "backend under pressure"),
+			status.Error(codes.InvalidArgument, "invalid"),
+		},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+		otlptracegrpc.WithDiskQueue(otlptracegrpc.DiskQueueConfig{Dir: dir}),
+	)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	// The request failing with a transient error is queued.
+	assert.Error(t, exp.ExportSpans(ctx, roSpans))
+	// The request failing with a permanent error is dropped.
+	assert.Error(t, exp.ExportSpans(ctx, roSpans))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// The queued request is sent once an export succeeds.
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Eventually(t, func() bool {
+		return len(mc.getSpans()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDiskQueueRestart(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{status.Error(codes.Unavailable, "backend under pressure")},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+		otlptracegrpc.WithDiskQueue(otlptracegrpc.DiskQueueConfig{Dir: dir}),
+	}
+
+	exp := newGRPCExporter(t, ctx, mc.endpoint, opts...)
+	assert.Error(t, exp.ExportSpans(ctx, roSpans))
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Empty(t, mc.getSpans())
+
+	// The requests queued before a restart are sent on start.
+	exp = newGRPCExporter(t, ctx, mc.endpoint, opts...)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	assert.Eventually(t, func() bool {
+		return len(mc.getSpans()) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDiskQueueTraceIDEndpoints(t *testing.T) {
+	var stubs tracetest.SpanStubs
+	for i := 0; i < 30; i++ {
+		var traceID trace.TraceID
+		binary.BigEndian.PutUint64(traceID[8:], uint64(i+1))
+		sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID})
+		stubs = append(stubs, tracetest.SpanStub{Name: "a", SpanContext: sc})
+	}
+
+	// Queue a request holding the spans of the two endpoints below.
+	down := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{status.Error(codes.Unavailable, "backend under pressure")},
+	})
+	t.Cleanup(func() { require.NoError(t, down.stop()) })
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+		otlptracegrpc.WithDiskQueue(otlptracegrpc.DiskQueueConfig{Dir: dir}),
+	}
+	exp := newGRPCExporter(t, ctx, down.endpoint, opts...)
+	assert.Error(t, exp.ExportSpans(ctx, stubs.Snapshots()))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	unavailable := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{status.Error(codes.Unavailable, "backend under pressure")},
+	})
+	t.Cleanup(func() { require.NoError(t, unavailable.stop()) })
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	opts = append(opts, otlptracegrpc.WithEndpoints(otlptracegrpc.TraceIDEndpoints, unavailable.endpoint, mc.endpoint))
+	exp = newGRPCExporter(t, ctx, "", opts...)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	// The queued request is sent on start, to the unavailable endpoint first.
+	assert.Eventually(t, func() bool {
+		return len(mc.getSpans()) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Only the part of the unavailable endpoint is sent again.
+	require.NoError(t, exp.ExportSpans(ctx, stubs[:1].Snapshots()))
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, len(stubs)+1, len(unavailable.getSpans())+len(mc.getSpans()))
+}
@@ -0,0 +1,37 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/auth.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auth provides authenticators adding credentials to OTLP export
+// requests.
+package auth // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+
+import (
+	"context"
+	"net/http"
+)
+
+// Authenticator provides the authentication headers of export requests.
+type Authenticator interface {
+	// Headers returns the headers to add to an export request. It is called
+	// before every request, including retries.
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// SetHeaders sets the headers provided by a on h. It does nothing if a is
+// nil.
+func SetHeaders(ctx context.Context, a Authenticator, h http.Header) error {
+	if a == nil {
+		return nil
+	}
+	headers, err := a.Headers(ctx)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return nil
+}
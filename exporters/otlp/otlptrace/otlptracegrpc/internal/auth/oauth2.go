@@ -0,0 +1,145 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/oauth2.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// expiryDelta is how long before their expiry tokens are refreshed, so that
+// they do not expire while a request is in flight.
+const expiryDelta = 10 * time.Second
+
+// maxErrorBody is the maximum number of bytes of a token endpoint error
+// response included in errors.
+const maxErrorBody = 1 << 10
+
+// ClientCredentialsConfig configures an Authenticator that obtains access
+// tokens using the OAuth 2.0 client credentials grant (RFC 6749, section
+// 4.4).
+type ClientCredentialsConfig struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+	// ClientID is the identifier of the client.
+	ClientID string
+	// ClientSecret is the secret of the client. It is sent, together with the
+	// ClientID, using HTTP Basic authentication.
+	ClientSecret string
+	// Scopes are the scopes of the requested access, if any.
+	Scopes []string
+	// EndpointParams are additional parameters sent to the token endpoint,
+	// for example an audience.
+	EndpointParams url.Values
+	// HTTPClient is used to request tokens. If nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+}
+
+// NewClientCredentials returns an Authenticator that sends the access tokens
+// it obtains with cfg as bearer tokens in the Authorization header.
+//
+// A token is requested on first use and cached until shortly before it
+// expires.
+func NewClientCredentials(cfg ClientCredentialsConfig) Authenticator {
+	return &clientCredentials{cfg: cfg, now: time.Now}
+}
+
+type clientCredentials struct {
+	cfg ClientCredentialsConfig
+	now func() time.Time
+
+	mu sync.Mutex
+	// authorization is the Authorization header value of the cached token.
+	authorization string
+	// expiry is when the cached token needs to be refreshed. The zero value
+	// means the token does not expire.
+	expiry time.Time
+}
+
+func (c *clientCredentials) Headers(ctx context.Context) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.authorization == "" || (!c.expiry.IsZero() && !c.now().Before(c.expiry)) {
+		if err := c.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]string{"Authorization": c.authorization}, nil
+}
+
+// tokenResponse is the successful response of a token endpoint (RFC 6749,
+// section 5.1).
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// refresh requests a new access token and caches it.
+func (c *clientCredentials) refresh(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(c.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	}
+	for k, v := range c.cfg.EndpointParams {
+		form[k] = v
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("oauth2: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+
+	client := c.cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	now := c.now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("oauth2: token request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("oauth2: token request: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var tok tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return fmt.Errorf("oauth2: token response: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return errors.New("oauth2: token response: missing access_token")
+	}
+
+	tokenType := tok.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	c.authorization = tokenType + " " + tok.AccessToken
+	c.expiry = time.Time{}
+	if tok.ExpiresIn > 0 {
+		c.expiry = now.Add(time.Duration(tok.ExpiresIn)*time.Second - expiryDelta)
+	}
+	return nil
+}
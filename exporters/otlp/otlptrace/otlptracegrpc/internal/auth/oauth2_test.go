@@ -0,0 +1,136 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/oauth2_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer is an OAuth 2.0 token endpoint issuing numbered tokens.
+type tokenServer struct {
+	*httptest.Server
+
+	expiresIn int
+	status    int
+
+	mu       sync.Mutex
+	requests []url.Values
+	users    []string
+}
+
+func newTokenServer(t *testing.T, expiresIn int) *tokenServer {
+	s := &tokenServer{expiresIn: expiresIn, status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		user, pass, _ := r.BasicAuth()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, r.PostForm)
+		s.users = append(s.users, user+":"+pass)
+
+		if s.status != http.StatusOK {
+			w.WriteHeader(s.status)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, len(s.requests), s.expiresIn)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestClientCredentials(t *testing.T) {
+	srv := newTokenServer(t, 3600)
+	params := url.Values{}
+	params.Set("audience", "otlp")
+	a := NewClientCredentials(ClientCredentialsConfig{
+		TokenURL:       srv.URL,
+		ClientID:       "client id",
+		ClientSecret:   "secret",
+		Scopes:         []string{"a", "b"},
+		EndpointParams: params,
+	})
+	now := time.Now()
+	a.(*clientCredentials).now = func() time.Time { return now }
+
+	ctx := context.Background()
+	h, err := a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token-1"}, h)
+
+	// The token is cached until it is about to expire.
+	now = now.Add(time.Hour - expiryDelta - time.Second)
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", h["Authorization"])
+
+	now = now.Add(time.Second)
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", h["Authorization"])
+
+	require.Len(t, srv.requests, 2)
+	form := srv.requests[0]
+	assert.Equal(t, "client_credentials", form.Get("grant_type"))
+	assert.Equal(t, "a b", form.Get("scope"))
+	assert.Equal(t, "otlp", form.Get("audience"))
+	assert.Equal(t, "client+id:secret", srv.users[0])
+}
+
+func TestClientCredentialsNoExpiry(t *testing.T) {
+	srv := newTokenServer(t, 0)
+	a := NewClientCredentials(ClientCredentialsConfig{TokenURL: srv.URL})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		h, err := a.Headers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", h["Authorization"])
+	}
+}
+
+func TestClientCredentialsError(t *testing.T) {
+	srv := newTokenServer(t, 3600)
+	srv.status = http.StatusUnauthorized
+	a := NewClientCredentials(ClientCredentialsConfig{TokenURL: srv.URL})
+
+	_, err := a.Headers(context.Background())
+	assert.ErrorContains(t, err, "401 Unauthorized")
+	assert.ErrorContains(t, err, "invalid_client")
+
+	srv.status = http.StatusOK
+	h, err := a.Headers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", h["Authorization"])
+}
+
+func TestSetHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "static")
+	require.NoError(t, SetHeaders(context.Background(), nil, h))
+	assert.Equal(t, "static", h.Get("Authorization"))
+
+	srv := newTokenServer(t, 3600)
+	a := NewClientCredentials(ClientCredentialsConfig{TokenURL: srv.URL})
+	require.NoError(t, SetHeaders(context.Background(), a, h))
+	assert.Equal(t, "Bearer token-1", h.Get("Authorization"))
+}
@@ -0,0 +1,222 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/otlptrace/balance/balance_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package balance
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func traceID(i uint64) []byte {
+	id := make([]byte, 16)
+	binary.BigEndian.PutUint64(id[8:], i)
+	return id
+}
+
+func TestIndex(t *testing.T) {
+	assert.Equal(t, 0, Index(traceID(1), 0))
+	assert.Equal(t, 0, Index(traceID(1), 1))
+
+	const traces = 10000
+	counts := make([]int, 4)
+	for i := uint64(0); i < traces; i++ {
+		id := traceID(i)
+		n := Index(id, len(counts))
+		require.GreaterOrEqual(t, n, 0)
+		require.Less(t, n, len(counts))
+		counts[n]++
+
+		assert.Equal(t, n, Index(id, len(counts)), "index must be stable")
+	}
+	for i, c := range counts {
+		assert.InDeltaf(t, traces/len(counts), c, traces/20, "endpoint %d", i)
+	}
+}
+
+func TestIndexConsistent(t *testing.T) {
+	// Adding an endpoint only moves traces to the new endpoint.
+	for i := uint64(0); i < 1000; i++ {
+		id := traceID(i)
+		before, after := Index(id, 3), Index(id, 4)
+		if before != after {
+			assert.Equal(t, 3, after)
+		}
+	}
+}
+
+func TestByTraceID(t *testing.T) {
+	res := &resourcepb.Resource{
+		Attributes: []*commonpb.KeyValue{
+			{Key: "service.name"},
+		},
+	}
+	scope := &commonpb.InstrumentationScope{Name: "scope"}
+
+	var spans []*tracepb.Span
+	for i := uint64(0); i < 100; i++ {
+		// Two spans for every trace.
+		spans = append(spans,
+			&tracepb.Span{TraceId: traceID(i), Name: "a"},
+			&tracepb.Span{TraceId: traceID(i), Name: "b"},
+		)
+	}
+	rs := []*tracepb.ResourceSpans{
+		{
+			Resource:  res,
+			SchemaUrl: "resource schema",
+			ScopeSpans: []*tracepb.ScopeSpans{
+				{
+					Scope:     scope,
+					SchemaUrl: "scope schema",
+					Spans:     spans,
+				},
+			},
+		},
+	}
+
+	const n = 3
+	parts := ByTraceID(rs, n)
+	require.Len(t, parts, n)
+
+	var total int
+	for i, part := range parts {
+		require.Len(t, part, 1, "endpoint %d", i)
+		assert.Same(t, res, part[0].Resource)
+		assert.Equal(t, "resource schema", part[0].SchemaUrl)
+		require.Len(t, part[0].ScopeSpans, 1)
+		assert.Same(t, scope, part[0].ScopeSpans[0].Scope)
+		assert.Equal(t, "scope schema", part[0].ScopeSpans[0].SchemaUrl)
+
+		for _, span := range part[0].ScopeSpans[0].Spans {
+			assert.Equal(t, i, Index(span.TraceId, n))
+			total++
+		}
+	}
+	assert.Equal(t, len(spans), total)
+}
+
+func TestByTraceIDSingleEndpoint(t *testing.T) {
+	rs := []*tracepb.ResourceSpans{
+		{},
+	}
+	parts := ByTraceID(rs, 1)
+	require.Len(t, parts, 1)
+	assert.Equal(t, rs, parts[0])
+}
+
+var (
+	errFailover = errors.New("failover")
+	errFatal    = errors.New("fatal")
+)
+
+func isFailover(err error) bool {
+	return errors.Is(err, errFailover)
+}
+
+func TestFailover(t *testing.T) {
+	f := NewFailover(3)
+	ctx := context.Background()
+
+	var tried []int
+	fn := func(failing ...int) func(context.Context, int) error {
+		return func(_ context.Context, i int) error {
+			tried = append(tried, i)
+			for _, f := range failing {
+				if i == f {
+					return errFailover
+				}
+			}
+			return nil
+		}
+	}
+
+	require.NoError(t, f.Do(ctx, fn(), isFailover))
+	assert.Equal(t, []int{0}, tried)
+
+	tried = nil
+	require.NoError(t, f.Do(ctx, fn(0), isFailover))
+	assert.Equal(t, []int{0, 1}, tried)
+
+	// The endpoint that succeeded stays active.
+	tried = nil
+	require.NoError(t, f.Do(ctx, fn(0), isFailover))
+	assert.Equal(t, []int{1}, tried)
+
+	tried = nil
+	require.NoError(t, f.Do(ctx, fn(1), isFailover))
+	assert.Equal(t, []int{1, 2}, tried)
+
+	// All endpoints failing.
+	tried = nil
+	assert.ErrorIs(t, f.Do(ctx, fn(0, 1, 2), isFailover), errFailover)
+	assert.Equal(t, []int{2, 0, 1}, tried)
+
+	// Errors that do not fail over are returned.
+	tried = nil
+	assert.ErrorIs(t, f.Do(ctx, func(context.Context, int) error {
+		tried = append(tried, -1)
+		return errFatal
+	}, isFailover), errFatal)
+	assert.Equal(t, []int{-1}, tried)
+}
+
+func TestFailoverFailback(t *testing.T) {
+	current := time.Now()
+	orig := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = orig })
+
+	f := NewFailover(2)
+	ctx := context.Background()
+
+	var tried []int
+	fn := func(fail bool) func(context.Context, int) error {
+		return func(_ context.Context, i int) error {
+			tried = append(tried, i)
+			if fail && i == 0 {
+				return errFailover
+			}
+			return nil
+		}
+	}
+
+	require.NoError(t, f.Do(ctx, fn(true), isFailover))
+	assert.Equal(t, []int{0, 1}, tried)
+
+	tried = nil
+	current = current.Add(FailbackDelay - time.Second)
+	require.NoError(t, f.Do(ctx, fn(false), isFailover))
+	assert.Equal(t, []int{1}, tried, "primary tried before FailbackDelay")
+
+	// The primary is tried again once FailbackDelay elapsed.
+	tried = nil
+	current = current.Add(time.Second)
+	require.NoError(t, f.Do(ctx, fn(true), isFailover))
+	assert.Equal(t, []int{0, 1}, tried)
+
+	tried = nil
+	require.NoError(t, f.Do(ctx, fn(false), isFailover))
+	assert.Equal(t, []int{1}, tried, "primary tried again before FailbackDelay")
+
+	tried = nil
+	current = current.Add(FailbackDelay)
+	require.NoError(t, f.Do(ctx, fn(false), isFailover))
+	assert.Equal(t, []int{0}, tried)
+
+	tried = nil
+	require.NoError(t, f.Do(ctx, fn(false), isFailover))
+	assert.Equal(t, []int{0}, tried, "primary must be active again")
+}
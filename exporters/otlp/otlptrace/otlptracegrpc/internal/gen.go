@@ -3,17 +3,43 @@
 
 package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal"
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/auth.go.tmpl "--data={}" --out=auth/auth.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/grpc.go.tmpl "--data={}" --out=auth/grpc.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/grpc_test.go.tmpl "--data={}" --out=auth/grpc_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/oauth2.go.tmpl "--data={}" --out=auth/oauth2.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/oauth2_test.go.tmpl "--data={}" --out=auth/oauth2_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/tokenfile.go.tmpl "--data={}" --out=auth/tokenfile.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/tokenfile_test.go.tmpl "--data={}" --out=auth/tokenfile_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/diskqueue/diskqueue.go.tmpl "--data={}" --out=diskqueue/diskqueue.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/diskqueue/diskqueue_test.go.tmpl "--data={}" --out=diskqueue/diskqueue_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/partialsuccess.go.tmpl "--data={}" --out=partialsuccess.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/partialsuccess_test.go.tmpl "--data={}" --out=partialsuccess_test.go
 
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry.go.tmpl "--data={}" --out=retry/retry.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry_test.go.tmpl "--data={}" --out=retry/retry_test.go
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/split.go.tmpl "--data={}" --out=split/split.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/traces.go.tmpl "--data={}" --out=split/traces.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/traces_test.go.tmpl "--data={}" --out=split/traces_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/tlsreload/grpc.go.tmpl "--data={}" --out=tlsreload/grpc.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/tlsreload/grpc_test.go.tmpl "--data={}" --out=tlsreload/grpc_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/tlsreload/tlsreload.go.tmpl "--data={}" --out=tlsreload/tlsreload.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/tlsreload/tlsreload_test.go.tmpl "--data={}" --out=tlsreload/tlsreload_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/zstd/zstd.go.tmpl "--data={}" --out=zstd/zstd.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/zstd/zstd_test.go.tmpl "--data={}" --out=zstd/zstd_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/envconfig/envconfig.go.tmpl "--data={}" --out=envconfig/envconfig.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/envconfig/envconfig_test.go.tmpl "--data={}" --out=envconfig/envconfig_test.go
 
-//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/envconfig.go.tmpl "--data={\"envconfigImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/envconfig\"}" --out=otlpconfig/envconfig.go
-//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/options.go.tmpl "--data={\"retryImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry\"}" --out=otlpconfig/options.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/balance/balance.go.tmpl "--data={}" --out=balance/balance.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/balance/balance_test.go.tmpl "--data={}" --out=balance/balance_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/envconfig.go.tmpl "--data={\"envconfigImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/envconfig\", \"tlsreloadImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/tlsreload\"}" --out=otlpconfig/envconfig.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/options.go.tmpl "--data={\"internalImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal\", \"authImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth\", \"retryImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry\", \"diskqueueImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/diskqueue\"}" --out=otlpconfig/options.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/options_test.go.tmpl "--data={\"envconfigImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/envconfig\"}" --out=otlpconfig/options_test.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/optiontypes.go.tmpl "--data={}" --out=otlpconfig/optiontypes.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlptrace/otlpconfig/tls.go.tmpl "--data={}" --out=otlpconfig/tls.go
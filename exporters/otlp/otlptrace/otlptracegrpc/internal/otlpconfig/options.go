@@ -7,6 +7,7 @@
 package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlpconfig"
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
@@ -22,6 +23,9 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry"
 	"go.opentelemetry.io/otel/internal/global"
 )
@@ -46,13 +50,36 @@ type (
 		TLSCfg      *tls.Config
 		Headers     map[string]string
 		Compression Compression
+		Marshaler   Marshaler
 		Timeout     time.Duration
 		URLPath     string
 
+		// Endpoints are the endpoints spans are sent to, according to
+		// EndpointMode, in place of Endpoint. They are not used if there
+		// is less than two.
+		Endpoints    []string
+		EndpointMode EndpointMode
+
+		// MaxRequestSize is the maximum encoded size of an export request.
+		// Larger requests are split. It is unlimited if not positive.
+		MaxRequestSize int
+
+		// PartialSuccessHandler is called with the partial success responses
+		// of the server. If nil, they are passed to the global error handler.
+		PartialSuccessHandler func(internal.PartialSuccess)
+
+		// Authenticator provides the authentication headers added to every
+		// export request. It is not used if nil.
+		Authenticator auth.Authenticator
+
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
 
 		Proxy HTTPTransportProxyFunc
+
+		// H2C sends OTLP/HTTP requests using HTTP/2 over cleartext
+		// connections. It is only used with insecure connections.
+		H2C bool
 	}
 
 	Config struct {
@@ -61,11 +88,26 @@ type (
 
 		RetryConfig retry.Config
 
+		// DiskQueue, if its Dir is not empty, persists the export requests
+		// that failed with transient errors to be sent again later.
+		DiskQueue diskqueue.Config
+
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
 		ServiceConfig      string
 		DialOptions        []grpc.DialOption
 		GRPCConn           *grpc.ClientConn
+
+		// SharedClient, if not nil, provides the gRPC connection and the
+		// retry of export requests in place of GRPCConn and RetryConfig.
+		SharedClient SharedClient
+	}
+
+	// SharedClient is a gRPC connection, with a retry policy, shared by
+	// several exporters.
+	SharedClient interface {
+		Conn() *grpc.ClientConn
+		Do(context.Context, func(context.Context) error) error
 	}
 )
 
@@ -136,8 +178,13 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Traces.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Traces.Compression == GzipCompression {
+	switch cfg.Traces.Compression {
+	case GzipCompression:
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		// The zstd compressor is registered by the internal zstd package of
+		// the gRPC exporter.
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor("zstd")))
 	}
 	if cfg.ReconnectionPeriod != 0 {
 		p := grpc.ConnectParams{
@@ -262,6 +309,21 @@ func NewGRPCOption(fn func(cfg Config) Config) GRPCOption {
 func WithEndpoint(endpoint string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.Endpoint = endpoint
+		cfg.Traces.Endpoints = nil
+		return cfg
+	})
+}
+
+// WithEndpoints configures the trace hosts and ports spans are sent to, and
+// how spans are distributed between them.
+func WithEndpoints(mode EndpointMode, endpoints []string) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		if len(endpoints) == 0 {
+			return cfg
+		}
+		cfg.Traces.Endpoint = endpoints[0]
+		cfg.Traces.Endpoints = endpoints
+		cfg.Traces.EndpointMode = mode
 		return cfg
 	})
 }
@@ -276,8 +338,15 @@ func WithEndpointURL(v string) GenericOption {
 			return cfg
 		}
 
-		cfg.Traces.Endpoint = u.Host
-		cfg.Traces.URLPath = u.Path
+		if u.Scheme == "unix" {
+			// The path is the one of the socket, the default URL path is
+			// kept.
+			cfg.Traces.Endpoint = unixEndpoint(u)
+		} else {
+			cfg.Traces.Endpoint = u.Host
+			cfg.Traces.URLPath = u.Path
+		}
+		cfg.Traces.Endpoints = nil
 		if u.Scheme != "https" {
 			cfg.Traces.Insecure = true
 		}
@@ -286,6 +355,16 @@ func WithEndpointURL(v string) GenericOption {
 	})
 }
 
+// unixEndpoint returns the endpoint of the Unix domain socket of u, a URL with
+// the unix scheme, in the "unix:path" form supported by gRPC and the HTTP
+// exporter.
+func unixEndpoint(u *url.URL) string {
+	if u.Opaque != "" {
+		return "unix:" + u.Opaque
+	}
+	return "unix:" + u.Path
+}
+
 func WithCompression(compression Compression) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.Compression = compression
@@ -293,6 +372,13 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithMarshaler(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Marshaler = m
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.URLPath = urlPath
@@ -307,6 +393,13 @@ func WithRetry(rc retry.Config) GenericOption {
 	})
 }
 
+func WithDiskQueue(dq diskqueue.Config) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.DiskQueue = dq
+		return cfg
+	})
+}
+
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg Config) Config {
 		cfg.Traces.TLSCfg = tlsCfg.Clone()
@@ -345,6 +438,34 @@ func WithTimeout(duration time.Duration) GenericOption {
 	})
 }
 
+func WithMaxRequestSize(size int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.MaxRequestSize = size
+		return cfg
+	})
+}
+
+func WithPartialSuccessHandler(h func(internal.PartialSuccess)) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.PartialSuccessHandler = h
+		return cfg
+	})
+}
+
+func WithAuthenticator(a auth.Authenticator) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Authenticator = a
+		return cfg
+	})
+}
+
+func WithH2C() GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.H2C = true
+		return cfg
+	})
+}
+
 func WithProxy(pf HTTPTransportProxyFunc) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.Proxy = pf
@@ -113,6 +113,39 @@ func TestConfigs(t *testing.T) {
 				assert.False(t, c.Traces.Insecure)
 			},
 		},
+		{
+			name: "Test With Unix Endpoint URL",
+			opts: []GenericOption{
+				WithEndpointURL("unix:///var/run/otel.sock"),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, "unix:/var/run/otel.sock", c.Traces.Endpoint)
+				assert.Equal(t, "/v1/traces", c.Traces.URLPath)
+				assert.True(t, c.Traces.Insecure)
+			},
+		},
+		{
+			name: "Test With Endpoints",
+			opts: []GenericOption{
+				WithEndpoints(TraceIDEndpoints, []string{"collector-1:4317", "collector-2:4317"}),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, "collector-1:4317", c.Traces.Endpoint)
+				assert.Equal(t, []string{"collector-1:4317", "collector-2:4317"}, c.Traces.Endpoints)
+				assert.Equal(t, TraceIDEndpoints, c.Traces.EndpointMode)
+			},
+		},
+		{
+			name: "Test With Endpoint Overriding Endpoints",
+			opts: []GenericOption{
+				WithEndpoints(FailoverEndpoints, []string{"collector-1:4317", "collector-2:4317"}),
+				WithEndpoint("collector-3:4317"),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, "collector-3:4317", c.Traces.Endpoint)
+				assert.Empty(t, c.Traces.Endpoints)
+			},
+		},
 		{
 			name: "Test With Invalid Endpoint URL",
 			opts: []GenericOption{
@@ -162,6 +195,19 @@ func TestConfigs(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Test Environment Unix Endpoint",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.True(t, c.Traces.Insecure)
+				assert.Equal(t, "unix:/var/run/otel.sock", c.Traces.Endpoint)
+				if !grpcOption {
+					assert.Equal(t, "/v1/traces", c.Traces.URLPath)
+				}
+			},
+		},
 		{
 			name: "Test Environment Signal Specific Endpoint",
 			env: map[string]string{
@@ -403,6 +449,75 @@ func TestConfigs(t *testing.T) {
 				assert.Equal(t, NoCompression, c.Traces.Compression)
 			},
 		},
+		{
+			name: "Test With Zstd Compression",
+			opts: []GenericOption{
+				WithCompression(ZstdCompression),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, ZstdCompression, c.Traces.Compression)
+			},
+		},
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, ZstdCompression, c.Traces.Compression)
+			},
+		},
+
+		// Marshaler Tests
+		{
+			name: "Test With Marshaler",
+			opts: []GenericOption{
+				WithMarshaler(MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Unsupported Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []GenericOption{
+				WithMarshaler(MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
 
 		// Timeout Tests
 		{
@@ -447,6 +562,23 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// Max Request Size Tests
+		{
+			name: "Test Default Max Request Size",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, 0, c.Traces.MaxRequestSize)
+			},
+		},
+		{
+			name: "Test With Max Request Size",
+			opts: []GenericOption{
+				WithMaxRequestSize(4 << 20),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, 4<<20, c.Traces.MaxRequestSize)
+			},
+		},
+
 		// Proxy Tests
 		{
 			name: "Test With Proxy",
@@ -11,7 +11,10 @@ package retry // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlpt
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -42,8 +45,90 @@ type Config struct {
 	// trying to send a request/batch.  Once this value is reached, the data
 	// is discarded.
 	MaxElapsedTime time.Duration
+
+	// Jitter is the randomization applied to the backoff intervals.
+	Jitter Jitter
+	// Classify, if not nil, classifies the errors of failed requests. The
+	// default classification of the exporter is used when it returns
+	// DefaultClassification.
+	Classify func(error) Classification
+	// CircuitBreaker stops sending requests while the endpoint is failing.
+	CircuitBreaker CircuitBreakerConfig
+}
+
+// Jitter is a strategy randomizing the backoff intervals between retries.
+type Jitter int
+
+const (
+	// ProportionalJitter randomizes every interval by up to half of its
+	// value, in both directions. It is the default.
+	ProportionalJitter Jitter = iota
+	// FullJitter picks every interval at random between zero and its value.
+	FullJitter
+	// NoJitter does not randomize the intervals.
+	NoJitter
+)
+
+// Classification is the classification of the error of a failed request.
+type Classification int
+
+const (
+	// DefaultClassification defers to the default classification of the
+	// exporter.
+	DefaultClassification Classification = iota
+	// Retryable classifies the error as transient: the request is retried.
+	Retryable
+	// NonRetryable classifies the error as permanent: the request is not
+	// retried.
+	NonRetryable
+)
+
+// DefaultOpenDuration is the time a circuit breaker stays open when
+// CircuitBreakerConfig.OpenDuration is not set.
+const DefaultOpenDuration = 30 * time.Second
+
+// CircuitBreakerConfig configures a circuit breaker.
+//
+// The circuit opens after FailureThreshold consecutive requests failed with
+// retry-able errors. While it is open, requests fail immediately with
+// ErrCircuitOpen. Once OpenDuration has elapsed, the circuit is half-open: a
+// single request is sent to probe the endpoint. The circuit closes if it
+// succeeds, otherwise it opens again.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit. The circuit breaker is disabled if it is not positive.
+	FailureThreshold int
+	// OpenDuration is the time the circuit stays open before probing the
+	// endpoint. DefaultOpenDuration is used if it is not positive.
+	OpenDuration time.Duration
+}
+
+// HTTPStatusError is the error of an HTTP request that received a response
+// with an unsuccessful status code.
+type HTTPStatusError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Status is the status of the response, e.g. "503 Service Unavailable".
+	Status string
+	// Err is the error described by the response body, if any.
+	Err error
+}
+
+func (e *HTTPStatusError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Status
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	return e.Err
 }
 
+// ErrCircuitOpen is returned when a request is not sent because the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
 // RequestFunc wraps a request with retry logic.
 type RequestFunc func(context.Context, func(context.Context) error) error
 
@@ -62,19 +147,41 @@ type EvaluateFunc func(error) (bool, time.Duration)
 // if requests can be retried and based on the exponential backoff
 // configuration of c.
 func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
+	evaluate = c.classify(evaluate)
+	cb := newCircuitBreaker(c.CircuitBreaker)
+
 	if !c.Enabled {
+		if cb == nil {
+			return func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+		}
 		return func(ctx context.Context, fn func(context.Context) error) error {
-			return fn(ctx)
+			if !cb.allow() {
+				return ErrCircuitOpen
+			}
+			err := fn(ctx)
+			var retryable bool
+			if err != nil {
+				retryable, _ = evaluate(err)
+			}
+			cb.done(retryable)
+			return err
 		}
 	}
 
+	randomization := backoff.DefaultRandomizationFactor
+	if c.Jitter != ProportionalJitter {
+		randomization = 0
+	}
+
 	return func(ctx context.Context, fn func(context.Context) error) error {
 		// Do not use NewExponentialBackOff since it calls Reset and the code here
 		// must call Reset after changing the InitialInterval (this saves an
 		// unnecessary call to Now).
 		b := &backoff.ExponentialBackOff{
 			InitialInterval:     c.InitialInterval,
-			RandomizationFactor: backoff.DefaultRandomizationFactor,
+			RandomizationFactor: randomization,
 			Multiplier:          backoff.DefaultMultiplier,
 			MaxInterval:         c.MaxInterval,
 			MaxElapsedTime:      c.MaxElapsedTime,
@@ -83,13 +190,23 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 		}
 		b.Reset()
 
+		var err error
 		for {
-			err := fn(ctx)
+			if !cb.allow() {
+				if err == nil {
+					return ErrCircuitOpen
+				}
+				return fmt.Errorf("%w: %w", ErrCircuitOpen, err)
+			}
+
+			err = fn(ctx)
 			if err == nil {
+				cb.done(false)
 				return nil
 			}
 
 			retryable, throttle := evaluate(err)
+			cb.done(retryable)
 			if !retryable {
 				return err
 			}
@@ -98,6 +215,9 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 			if bOff == backoff.Stop {
 				return fmt.Errorf("max retry time elapsed: %w", err)
 			}
+			if c.Jitter == FullJitter {
+				bOff = rand.N(bOff + 1) // nolint:gosec // Jitter does not need a secure random source.
+			}
 
 			// Wait for the greater of the backoff or throttle delay.
 			var delay time.Duration
@@ -118,6 +238,93 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 	}
 }
 
+// classify returns evaluate overridden by the Classify function of c.
+func (c Config) classify(evaluate EvaluateFunc) EvaluateFunc {
+	if c.Classify == nil {
+		return evaluate
+	}
+	return func(err error) (bool, time.Duration) {
+		switch c.Classify(err) {
+		case Retryable:
+			_, throttle := evaluate(err)
+			return true, throttle
+		case NonRetryable:
+			return false, 0
+		}
+		return evaluate(err)
+	}
+}
+
+// Allow override for testing.
+var now = time.Now
+
+// circuitBreaker is the state of a circuit breaker. A nil circuitBreaker
+// allows all requests.
+type circuitBreaker struct {
+	threshold    int
+	openDuration time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		return nil
+	}
+	d := cfg.OpenDuration
+	if d <= 0 {
+		d = DefaultOpenDuration
+	}
+	return &circuitBreaker{threshold: cfg.FailureThreshold, openDuration: d}
+}
+
+// allow returns if a request can be sent.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		// Closed.
+		return true
+	}
+	if b.probing || now().Sub(b.openedAt) < b.openDuration {
+		// Open, or half-open with a probe already being sent.
+		return false
+	}
+	// Half-open: let a single request through to probe the endpoint.
+	b.probing = true
+	return true
+}
+
+// done records the result of a request allowed by allow. Only failures with
+// a retry-able error count as failures: the endpoint handled the other
+// requests.
+func (b *circuitBreaker) done(failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = now()
+	}
+}
+
 // Allow override for testing.
 var waitFunc = wait
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWait(t *testing.T) {
@@ -248,3 +249,198 @@ func TestRetryConcurrentSafe(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestJitter(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+
+	var delays []time.Duration
+	origWait := waitFunc
+	waitFunc = func(_ context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+	t.Cleanup(func() { waitFunc = origWait })
+
+	run := func(j Jitter) {
+		delays = nil
+		reqFunc := Config{
+			Enabled:         true,
+			InitialInterval: time.Second,
+			MaxInterval:     time.Second,
+			Jitter:          j,
+		}.RequestFunc(ev)
+
+		var calls int
+		_ = reqFunc(context.Background(), func(context.Context) error {
+			calls++
+			if calls > 20 {
+				return nil
+			}
+			return assert.AnError
+		})
+	}
+
+	run(NoJitter)
+	for _, d := range delays {
+		assert.Equal(t, time.Second, d)
+	}
+
+	run(ProportionalJitter)
+	for _, d := range delays {
+		assert.GreaterOrEqual(t, d, time.Second/2)
+		assert.LessOrEqual(t, d, 3*time.Second/2)
+	}
+
+	run(FullJitter)
+	for _, d := range delays {
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, time.Second)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	errRetry := errors.New("retry")
+	errDrop := errors.New("drop")
+	ev := func(err error) (bool, time.Duration) {
+		return errors.Is(err, assert.AnError), time.Nanosecond
+	}
+
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		Classify: func(err error) Classification {
+			switch {
+			case errors.Is(err, errRetry):
+				return Retryable
+			case errors.Is(err, errDrop):
+				return NonRetryable
+			}
+			return DefaultClassification
+		},
+	}.RequestFunc(ev)
+
+	request := func(errs ...error) (int, error) {
+		var calls int
+		err := reqFunc(context.Background(), func(context.Context) error {
+			calls++
+			if calls > len(errs) {
+				return nil
+			}
+			return errs[calls-1]
+		})
+		return calls, err
+	}
+
+	calls, err := request(errRetry, errRetry)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls, "Retryable errors must be retried")
+
+	calls, err = request(errDrop)
+	assert.ErrorIs(t, err, errDrop)
+	assert.Equal(t, 1, calls, "NonRetryable errors must not be retried")
+
+	calls, err = request(assert.AnError)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls, "default classification must retry")
+
+	calls, err = request(errors.New("other"))
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "default classification must not retry")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	current := time.Now()
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	errPermanent := errors.New("permanent")
+	ev := func(err error) (bool, time.Duration) {
+		return errors.Is(err, assert.AnError), 0
+	}
+	reqFunc := Config{
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenDuration:     time.Minute,
+		},
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	var calls int
+	request := func(err error) error {
+		return reqFunc(ctx, func(context.Context) error {
+			calls++
+			return err
+		})
+	}
+
+	// Non-retryable errors do not open the circuit.
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, request(errPermanent), errPermanent)
+	}
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+	assert.NoError(t, request(nil), "success must reset the failures")
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+
+	calls = 0
+	assert.ErrorIs(t, request(nil), ErrCircuitOpen)
+	assert.Equal(t, 0, calls, "open circuit must not send requests")
+
+	// Half-open: the failed probe opens the circuit again.
+	current = current.Add(time.Minute)
+	assert.ErrorIs(t, request(assert.AnError), assert.AnError)
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, request(nil), ErrCircuitOpen)
+	assert.Equal(t, 1, calls)
+
+	// Half-open: the successful probe closes the circuit.
+	current = current.Add(time.Minute)
+	assert.NoError(t, request(nil))
+	assert.NoError(t, request(nil))
+	assert.Equal(t, 3, calls)
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	assert.Equal(t, DefaultOpenDuration, b.openDuration)
+
+	current := time.Now()
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	require.True(t, b.allow())
+	b.done(true)
+	assert.False(t, b.allow())
+
+	current = current.Add(DefaultOpenDuration)
+	assert.True(t, b.allow(), "probe")
+	assert.False(t, b.allow(), "concurrent request while probing")
+	b.done(false)
+	assert.True(t, b.allow())
+}
+
+func TestCircuitBreakerRetry(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Config{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		CircuitBreaker:  CircuitBreakerConfig{FailureThreshold: 3},
+	}.RequestFunc(ev)
+
+	var calls int
+	err := reqFunc(context.Background(), func(context.Context) error {
+		calls++
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 3, calls, "retries must stop once the circuit opens")
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	assert.Nil(t, newCircuitBreaker(CircuitBreakerConfig{}))
+}
@@ -0,0 +1,44 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/traces.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/split"
+
+import (
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var spans = hierarchy[*tracepb.ResourceSpans, *tracepb.ScopeSpans, *tracepb.Span]{
+	kind:   "spans",
+	scopes: (*tracepb.ResourceSpans).GetScopeSpans,
+	items:  (*tracepb.ScopeSpans).GetSpans,
+	emptyResource: func(r *tracepb.ResourceSpans) *tracepb.ResourceSpans {
+		return &tracepb.ResourceSpans{Resource: r.Resource, SchemaUrl: r.SchemaUrl}
+	},
+	emptyScope: func(s *tracepb.ScopeSpans) *tracepb.ScopeSpans {
+		return &tracepb.ScopeSpans{Scope: s.Scope, SchemaUrl: s.SchemaUrl}
+	},
+	appendScope: func(r *tracepb.ResourceSpans, s *tracepb.ScopeSpans) {
+		r.ScopeSpans = append(r.ScopeSpans, s)
+	},
+	appendItem: func(s *tracepb.ScopeSpans, span *tracepb.Span) {
+		s.Spans = append(s.Spans, span)
+	},
+}
+
+// Spans splits rs into batches that each encode to an
+// ExportTraceServiceRequest of no more than maxSize bytes. Spans are kept
+// grouped by their resource and instrumentation scope.
+//
+// If size is not nil, it returns the encoded size of the request for a batch,
+// otherwise the size of its protobuf encoding is used.
+//
+// Spans that are larger than maxSize on their own are dropped and an error
+// reporting them is returned alongside the batches.
+//
+// If maxSize is not positive, rs is returned as the only batch.
+func Spans(rs []*tracepb.ResourceSpans, maxSize int, size func([]*tracepb.ResourceSpans) int) ([][]*tracepb.ResourceSpans, error) {
+	return spans.split(rs, maxSize, size)
+}
@@ -0,0 +1,214 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/traces_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func newSpan(name string) *tracepb.Span {
+	return &tracepb.Span{
+		TraceId: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanId:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Name:    name,
+	}
+}
+
+func newResourceSpans(service string, scopes map[string][]*tracepb.Span) *tracepb.ResourceSpans {
+	rs := &tracepb.ResourceSpans{
+		Resource: &rpb.Resource{
+			Attributes: []*cpb.KeyValue{
+				{
+					Key: "service.name",
+					Value: &cpb.AnyValue{
+						Value: &cpb.AnyValue_StringValue{StringValue: service},
+					},
+				},
+			},
+		},
+		SchemaUrl: "https://opentelemetry.io/schemas/1.26.0",
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if spans, ok := scopes[name]; ok {
+			rs.ScopeSpans = append(rs.ScopeSpans, &tracepb.ScopeSpans{
+				Scope: &cpb.InstrumentationScope{Name: name},
+				Spans: spans,
+			})
+		}
+	}
+	return rs
+}
+
+func traceRequestSize(rs []*tracepb.ResourceSpans) int {
+	return proto.Size(&coltracepb.ExportTraceServiceRequest{ResourceSpans: rs})
+}
+
+// spanNames returns the names of all spans in batches, grouped by service and
+// scope name.
+func spanNames(batches [][]*tracepb.ResourceSpans) map[string][]string {
+	names := make(map[string][]string)
+	for _, b := range batches {
+		for _, rs := range b {
+			service := rs.Resource.Attributes[0].Value.GetStringValue()
+			for _, ss := range rs.ScopeSpans {
+				key := service + "/" + ss.Scope.Name
+				for _, s := range ss.Spans {
+					names[key] = append(names[key], s.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestSpansNoSplit(t *testing.T) {
+	rs := []*tracepb.ResourceSpans{
+		newResourceSpans("svc", map[string][]*tracepb.Span{
+			"a": {newSpan("1"), newSpan("2")},
+		}),
+	}
+
+	for _, maxSize := range []int{0, -1, traceRequestSize(rs)} {
+		batches, err := Spans(rs, maxSize, nil)
+		require.NoError(t, err)
+		require.Len(t, batches, 1)
+		assert.Same(t, rs[0], batches[0][0], "max size: %d", maxSize)
+	}
+
+	batches, err := Spans(nil, 10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]*tracepb.ResourceSpans{nil}, batches)
+}
+
+func TestSpansSplit(t *testing.T) {
+	var a, b, c []*tracepb.Span
+	for i := 0; i < 20; i++ {
+		a = append(a, newSpan("a"+strings.Repeat("x", i)))
+		b = append(b, newSpan("b"+strings.Repeat("y", i)))
+		c = append(c, newSpan("c"+strings.Repeat("z", i)))
+	}
+	rs := []*tracepb.ResourceSpans{
+		newResourceSpans("svc0", map[string][]*tracepb.Span{"a": a, "b": b}),
+		newResourceSpans("svc1", map[string][]*tracepb.Span{"c": c}),
+	}
+	want := spanNames([][]*tracepb.ResourceSpans{rs})
+
+	const maxSize = 300
+	batches, err := Spans(rs, maxSize, nil)
+	require.NoError(t, err)
+	require.Greater(t, len(batches), 1)
+
+	for _, batch := range batches {
+		assert.LessOrEqual(t, traceRequestSize(batch), maxSize)
+		for _, r := range batch {
+			assert.Equal(t, rs[0].SchemaUrl, r.SchemaUrl)
+			for _, ss := range r.ScopeSpans {
+				assert.NotEmpty(t, ss.Spans, "empty scope")
+			}
+		}
+	}
+	assert.Equal(t, want, spanNames(batches), "spans lost or regrouped")
+}
+
+func TestSpansSizeIsExact(t *testing.T) {
+	// Splitting at every possible size must never produce a request larger
+	// than allowed, including when length prefixes grow by a byte.
+	var spans []*tracepb.Span
+	for i := 0; i < 40; i++ {
+		spans = append(spans, newSpan(strings.Repeat("n", i)))
+	}
+	rs := []*tracepb.ResourceSpans{
+		newResourceSpans("svc", map[string][]*tracepb.Span{"a": spans[:20], "b": spans[20:]}),
+	}
+
+	for maxSize := 1; maxSize <= traceRequestSize(rs); maxSize++ {
+		batches, _ := Spans(rs, maxSize, nil)
+		for _, batch := range batches {
+			require.LessOrEqual(t, traceRequestSize(batch), maxSize)
+		}
+	}
+}
+
+func TestSpansDropOversized(t *testing.T) {
+	big := newSpan(strings.Repeat("x", 1000))
+	rs := []*tracepb.ResourceSpans{
+		newResourceSpans("svc", map[string][]*tracepb.Span{
+			"a": {newSpan("1"), big, newSpan("2")},
+		}),
+	}
+
+	batches, err := Spans(rs, 200, nil)
+	assert.EqualError(t, err, "1 spans dropped: larger than the maximum request size of 200 bytes")
+	want := make(map[string][]string)
+	want["svc/a"] = []string{"1", "2"}
+	assert.Equal(t, want, spanNames(batches))
+	for _, batch := range batches {
+		assert.LessOrEqual(t, traceRequestSize(batch), 200)
+	}
+
+	batches, err = Spans(rs, 10, nil)
+	assert.Error(t, err)
+	assert.Empty(t, batches)
+}
+
+func TestSpansSize(t *testing.T) {
+	jsonSize := func(rs []*tracepb.ResourceSpans) int {
+		b, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: rs})
+		require.NoError(t, err)
+		return len(b)
+	}
+
+	var spans []*tracepb.Span
+	for i := 0; i < 40; i++ {
+		spans = append(spans, newSpan(strings.Repeat("n", i)))
+	}
+	rs := []*tracepb.ResourceSpans{
+		newResourceSpans("svc", map[string][]*tracepb.Span{"a": spans[:20], "b": spans[20:]}),
+	}
+	want := spanNames([][]*tracepb.ResourceSpans{rs})
+
+	for _, maxSize := range []int{600, 1000, 2000} {
+		require.Less(t, maxSize, jsonSize(rs))
+		batches, err := Spans(rs, maxSize, jsonSize)
+		require.NoError(t, err)
+		for _, batch := range batches {
+			assert.LessOrEqual(t, jsonSize(batch), maxSize)
+		}
+		assert.Equal(t, want, spanNames(batches), "spans lost or regrouped")
+	}
+
+	// A span that fits into a request in the protobuf encoding but not in
+	// the encoding measured is dropped.
+	big := newSpan("big")
+	for i := 0; i < 20; i++ {
+		big.Links = append(big.Links, &tracepb.Span_Link{TraceId: big.TraceId, SpanId: big.SpanId})
+	}
+	rs = []*tracepb.ResourceSpans{
+		newResourceSpans("svc", map[string][]*tracepb.Span{"a": {big}}),
+	}
+	maxSize := traceRequestSize(rs)
+	require.Less(t, maxSize, jsonSize(rs))
+
+	rs[0].ScopeSpans[0].Spans = []*tracepb.Span{newSpan("1"), big, newSpan("2")}
+	batches, err := Spans(rs, maxSize, jsonSize)
+	assert.EqualError(t, err, fmt.Sprintf("1 spans dropped: larger than the maximum request size of %d bytes", maxSize))
+	want = make(map[string][]string)
+	want["svc/a"] = []string{"1", "2"}
+	assert.Equal(t, want, spanNames(batches))
+}
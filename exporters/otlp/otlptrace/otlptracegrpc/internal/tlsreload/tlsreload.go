@@ -0,0 +1,215 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/tlsreload/tlsreload.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tlsreload provides TLS configurations reloading their certificates
+// from files when these change.
+package tlsreload // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/tlsreload"
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+// NewConfig returns a *tls.Config using the PEM encoded root CAs of the file
+// caFile to verify servers, and the PEM encoded client certificate and key of
+// the files certFile and keyFile for mutual TLS. Empty paths are ignored. The
+// files are read with readFile.
+//
+// The files are read again for every new connection. If their content
+// changed, the new root CAs and client certificate are used. If the new
+// content is invalid, for example because only one of a certificate and its
+// key has been updated yet, the error is passed to the global error handler
+// and the previous content is used. Established connections are not affected.
+//
+// The RootCAs and Certificates fields of the returned config hold the content
+// read initially. An error is returned if it is invalid.
+//
+// The server certificate is verified against the server name of the
+// connection. crypto/tls does not send a server name for IP address hosts, so
+// the returned config only connects to them once bound to the server with
+// ForServer.
+func NewConfig(caFile, certFile, keyFile string, readFile func(string) ([]byte, error)) (*tls.Config, error) {
+	cfg := &tls.Config{}
+
+	var errs []error
+	if caFile != "" {
+		r := &rootCAs{path: caFile, readFile: readFile}
+		if pool, err := r.load(); err != nil {
+			errs = append(errs, err)
+		} else {
+			cfg.RootCAs = pool
+			// The server certificate is verified by VerifyConnection using
+			// the current root CAs instead of the fixed RootCAs.
+			cfg.InsecureSkipVerify = true // nolint:gosec // Verified by VerifyConnection.
+			cfg.VerifyConnection = r.verifyConnection
+		}
+	}
+	if certFile != "" && keyFile != "" {
+		c := &clientCert{certPath: certFile, keyPath: keyFile, readFile: readFile}
+		if cert, err := c.load(); err != nil {
+			errs = append(errs, err)
+		} else {
+			cfg.Certificates = []tls.Certificate{*cert}
+			cfg.GetClientCertificate = c.getClientCertificate
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// rootCAs are root CAs read from a file.
+type rootCAs struct {
+	path     string
+	readFile func(string) ([]byte, error)
+
+	mu   sync.Mutex
+	pem  []byte
+	pool *x509.CertPool
+}
+
+// load returns the root CAs, parsing the file again if its content changed.
+func (r *rootCAs) load() (*x509.CertPool, error) {
+	b, err := r.readFile(r.path)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pool != nil && bytes.Equal(b, r.pem) {
+		return r.pool, nil
+	}
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(b); !ok {
+		return nil, errors.New("certificate not added")
+	}
+	r.pem, r.pool = b, pool
+	return pool, nil
+}
+
+// current returns the root CAs to use for a new connection.
+func (r *rootCAs) current() *x509.CertPool {
+	pool, err := r.load()
+	if err != nil {
+		otel.Handle(fmt.Errorf("failed to reload TLS root CAs from %s: %w", r.path, err))
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.pool
+	}
+	return pool
+}
+
+// verifyConnection verifies the certificate chain of the server, as done by
+// crypto/tls, using the current root CAs.
+func (r *rootCAs) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server did not provide a certificate")
+	}
+	if cs.ServerName == "" {
+		// Never accept a certificate regardless of the host it is valid for.
+		return errors.New("tls: no server name to verify the server certificate against")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         r.current(),
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// ForServer returns cfg for the connections to the server at addr, a host
+// with an optional port.
+//
+// If cfg verifies the server certificate with VerifyConnection instead of
+// crypto/tls, as the configs returned by NewConfig do, the returned copy of
+// cfg verifies it against the ServerName of cfg or, if empty, the host of
+// addr. Otherwise, cfg is returned.
+func ForServer(cfg *tls.Config, addr string) *tls.Config {
+	if cfg == nil || cfg.VerifyConnection == nil || !cfg.InsecureSkipVerify {
+		return cfg
+	}
+
+	name := cfg.ServerName
+	if name == "" {
+		name = addr
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			name = host
+		}
+	}
+	verify := cfg.VerifyConnection
+	c := cfg.Clone()
+	c.VerifyConnection = func(cs tls.ConnectionState) error {
+		// The state holds no server name for IP address hosts.
+		cs.ServerName = name
+		return verify(cs)
+	}
+	return c
+}
+
+// clientCert is a client certificate and key read from files.
+type clientCert struct {
+	certPath, keyPath string
+	readFile          func(string) ([]byte, error)
+
+	mu      sync.Mutex
+	certPEM []byte
+	keyPEM  []byte
+	keyPair *tls.Certificate
+}
+
+// load returns the client certificate, parsing the files again if their
+// content changed.
+func (c *clientCert) load() (*tls.Certificate, error) {
+	certPEM, err := c.readFile(c.certPath)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := c.readFile(c.keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.keyPair != nil && bytes.Equal(certPEM, c.certPEM) && bytes.Equal(keyPEM, c.keyPEM) {
+		return c.keyPair, nil
+	}
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	c.certPEM, c.keyPEM, c.keyPair = certPEM, keyPEM, &keyPair
+	return c.keyPair, nil
+}
+
+// getClientCertificate returns the client certificate to use for a new
+// connection.
+func (c *clientCert) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	keyPair, err := c.load()
+	if err != nil {
+		otel.Handle(fmt.Errorf("failed to reload TLS client certificate from %s: %w", c.certPath, err))
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.keyPair, nil
+	}
+	return keyPair, nil
+}
@@ -100,6 +100,7 @@ type mockCollector struct {
 
 type mockConfig struct {
 	errors   []error
+	network  string
 	endpoint string
 	partial  *collectortracepb.ExportTracePartialSuccess
 }
@@ -159,7 +160,11 @@ func runMockCollectorAtEndpoint(t *testing.T, endpoint string) *mockCollector {
 
 func runMockCollectorWithConfig(t *testing.T, mockConfig *mockConfig) *mockCollector {
 	t.Helper()
-	ln, err := net.Listen("tcp", mockConfig.endpoint)
+	network := mockConfig.network
+	if network == "" {
+		network = "tcp"
+	}
+	ln, err := net.Listen(network, mockConfig.endpoint)
 	require.NoError(t, err, "net.Listen")
 
 	srv := grpc.NewServer()
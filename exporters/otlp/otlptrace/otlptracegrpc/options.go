@@ -4,6 +4,7 @@
 package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,8 +12,11 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/zstd"
 )
 
 // Option applies an option to the gRPC driver.
@@ -28,6 +32,15 @@ func asGRPCOptions(opts []Option) []otlpconfig.GRPCOption {
 	return converted
 }
 
+// PartialSuccessError is the error describing a partial success response of the
+// OTLP server, which accepted an export request but rejected spans of it or
+// returned a warning. RejectedItems is the number of rejected spans and
+// ErrorMessage the message of the server.
+//
+// It is passed to the function set with WithPartialSuccessHandler, or otherwise
+// to the global error handler. Use errors.As to identify it there.
+type PartialSuccessError = internal.PartialSuccess
+
 // RetryConfig defines configuration for retrying export of span batches that
 // failed to be received by the target endpoint.
 //
@@ -35,6 +48,10 @@ func asGRPCOptions(opts []Option) []otlpconfig.GRPCOption {
 // entirely handled by the gRPC ClientConn.
 type RetryConfig retry.Config
 
+// DiskQueueConfig configures the disk queue of the export requests that failed
+// with transient errors. See WithDiskQueue.
+type DiskQueueConfig diskqueue.Config
+
 type wrappedOption struct {
 	otlpconfig.GRPCOption
 }
@@ -89,6 +106,9 @@ func WithEndpoint(endpoint string) Option {
 //
 // If an invalid URL is provided, the default value will be kept.
 //
+// A URL with the unix scheme, such as "unix:///var/run/otel.sock", connects
+// to the Unix domain socket at its path without transport security.
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "https://localhost:4317/v1/traces" will be used.
 //
@@ -97,6 +117,41 @@ func WithEndpointURL(u string) Option {
 	return wrappedOption{otlpconfig.WithEndpointURL(u)}
 }
 
+// EndpointMode describes how spans are distributed between the endpoints set
+// with WithEndpoints.
+type EndpointMode otlpconfig.EndpointMode
+
+const (
+	// FailoverEndpoints sends all spans to a single endpoint, the first
+	// available one in the order the endpoints are passed. When the endpoint
+	// spans are sent to fails, they are sent to the next one. The first
+	// endpoint is tried again periodically, and spans are sent to it again
+	// once it recovers.
+	FailoverEndpoints = EndpointMode(otlpconfig.FailoverEndpoints)
+	// TraceIDEndpoints sends all the spans of a trace to the same endpoint,
+	// chosen by consistent hashing of their trace ID. This is required by
+	// receivers processing complete traces, like tail sampling collectors.
+	//
+	// Spans are not sent to another endpoint when the one of their trace
+	// fails, their export is retried according to the retry configuration.
+	TraceIDEndpoints = EndpointMode(otlpconfig.TraceIDEndpoints)
+)
+
+// WithEndpoints sets the target endpoints the Exporter sends spans to, and
+// how spans are distributed between them. Every endpoint is specified as a
+// host and optional port, no path or scheme should be included, as with
+// WithEndpoint. The other options, such as the transport security and
+// headers, apply to all the endpoints.
+//
+// If this option and WithEndpoint or WithEndpointURL are used, the last used
+// option will take precedence for the endpoints. Passing no endpoints has no
+// effect.
+//
+// This option has no effect if WithGRPCConn or WithSharedClient is used.
+func WithEndpoints(mode EndpointMode, endpoints ...string) Option {
+	return wrappedOption{otlpconfig.WithEndpoints(otlpconfig.EndpointMode(mode), endpoints)}
+}
+
 // WithReconnectionPeriod set the minimum amount of time between connection
 // attempts to the target endpoint.
 //
@@ -109,8 +164,11 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) otlpconfig.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return otlpconfig.GzipCompression
+	case zstd.Name:
+		return otlpconfig.ZstdCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -118,7 +176,7 @@ func compressorToCompression(compressor string) otlpconfig.Compression {
 }
 
 // WithCompressor sets the compressor for the gRPC client to use when sending
-// requests. Supported compressor values: "gzip".
+// requests. Supported compressor values: "gzip", "zstd".
 func WithCompressor(compressor string) Option {
 	return wrappedOption{otlpconfig.WithCompression(compressorToCompression(compressor))}
 }
@@ -182,6 +240,36 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})}
 }
 
+// SharedClient is a gRPC connection, with a retry policy, shared by several
+// exporters. The Client of go.opentelemetry.io/otel/exporters/otlp/otlpgrpcshared
+// implements it.
+type SharedClient interface {
+	// Conn returns the gRPC connection the export requests are sent with.
+	Conn() *grpc.ClientConn
+	// Do sends an export request by calling fn, retrying it according to the
+	// retry policy of the SharedClient.
+	Do(ctx context.Context, fn func(context.Context) error) error
+}
+
+// WithSharedClient sets c as the gRPC connection and retry policy of the
+// exporter. All the exporters built from c share its connection, retry
+// policy, and export budget.
+//
+// This option takes precedence over WithGRPCConn, WithRetry, and any other
+// option that relates to establishing or persisting a gRPC connection to a
+// target endpoint. Any other option of those types passed will be ignored.
+//
+// It is the callers responsibility to close c once all the exporters built
+// from it are shut down. The Exporter Shutdown method will not close it.
+func WithSharedClient(c SharedClient) Option {
+	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg otlpconfig.Config) otlpconfig.Config {
+		if c != nil {
+			cfg.SharedClient = c
+		}
+		return cfg
+	})}
+}
+
 // WithTimeout sets the max amount of time a client will attempt to export a
 // batch of spans. This takes precedence over any retry settings defined with
 // WithRetry, once this time limit has been reached the export is abandoned
@@ -192,6 +280,48 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{otlpconfig.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the export requests
+// sent by the client. A batch of spans that would encode to a larger request is
+// split into multiple requests, keeping spans grouped by their resource and
+// instrumentation scope. Spans larger than size on their own are dropped and
+// reported to the global error handler.
+//
+// The size is measured using the protobuf encoding of the request, before any
+// compression is applied. The gRPC server rejects messages larger than its
+// maximum receive message size, which is 4 MiB by default.
+//
+// If unset or not positive, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(size)}
+}
+
+// WithPartialSuccessHandler sets the function called with the partial success
+// responses of the OTLP server. It is called synchronously during the export,
+// so it should not block.
+//
+// If unset, partial successes are passed to the global error handler.
+//
+// Regardless of this option, the number of rejected spans is counted with the
+// otel.sdk.exporter.span.rejected counter of the global MeterProvider.
+func WithPartialSuccessHandler(h func(PartialSuccessError)) Option {
+	return wrappedOption{otlpconfig.WithPartialSuccessHandler(h)}
+}
+
+// WithAuthenticator sets the Authenticator providing the authentication
+// headers of the export requests, for example an Authenticator returned by
+// NewOAuth2Authenticator or NewTokenFileAuthenticator.
+//
+// The headers are sent as the metadata of every gRPC call, including retries.
+// Over the connections created by the Exporter, they are only sent if the
+// connection is secure, unless the Exporter is configured with WithInsecure.
+// Over a connection passed with WithGRPCConn or WithSharedClient, they are
+// sent whatever the transport security of that connection is.
+//
+// An error of the Authenticator fails the export request, it is not retried.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{otlpconfig.WithAuthenticator(a)}
+}
+
 // WithRetry sets the retry policy for transient retryable errors that may be
 // returned by the target endpoint when exporting a batch of spans.
 //
@@ -208,3 +338,21 @@ func WithTimeout(duration time.Duration) Option {
 func WithRetry(settings RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(settings))}
 }
+
+// WithDiskQueue persists the export requests that failed with transient
+// errors, after any retry, to the directory dq.Dir, created if it does not
+// exist. The persisted requests are sent again in the background, oldest
+// first, once an export request succeeds, and when an exporter is created
+// with the same directory, for instance after a restart.
+//
+// The total size of the persisted requests is bounded by dq.MaxBytes, or by
+// 64 MiB if it is not positive: the oldest requests are dropped to make room
+// for new ones. The directory must not be used by another exporter.
+//
+// Persisted requests can be sent more than once if the process stops while
+// sending them.
+//
+// By default, the export requests that failed are dropped.
+func WithDiskQueue(dq DiskQueueConfig) Option {
+	return wrappedOption{otlpconfig.WithDiskQueue(diskqueue.Config(dq))}
+}
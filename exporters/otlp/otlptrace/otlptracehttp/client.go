@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
@@ -18,13 +19,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/balance"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpjson"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/split"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/tlsreload"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/transport"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -38,6 +47,15 @@ var gzPool = sync.Pool{
 	},
 }
 
+var zstdPool = sync.Pool{
+	New: func() interface{} {
+		// Encode synchronously, the encoder is used for a single payload at
+		// a time. The options are valid, no error is returned.
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	},
+}
+
 // Keep it in sync with golang's DefaultTransport from net/http! We
 // have our own copy to avoid handling a situation where the
 // DefaultTransport is overwritten with some different implementation
@@ -60,9 +78,21 @@ type client struct {
 	cfg         otlpconfig.SignalConfig
 	generalCfg  otlpconfig.Config
 	requestFunc retry.RequestFunc
-	client      *http.Client
 	stopCh      chan struct{}
 	stopOnce    sync.Once
+
+	// endpoints are the endpoints requests are sent to, using the HTTP
+	// client with the same index.
+	endpoints []string
+	clients   []*http.Client
+	failover  *balance.Failover
+
+	// queue is the disk queue of the export requests that failed with
+	// transient errors. It is created on Start if the Dir of the DiskQueue
+	// of generalCfg is not empty.
+	queue *diskqueue.Queue
+
+	partialSuccess *internal.PartialSuccessHandler
 }
 
 var _ otlptrace.Client = (*client)(nil)
@@ -71,20 +101,32 @@ var _ otlptrace.Client = (*client)(nil)
 func NewClient(opts ...Option) otlptrace.Client {
 	cfg := otlpconfig.NewHTTPConfig(asHTTPOptions(opts)...)
 
-	httpClient := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.Traces.Timeout,
-	}
-
+	baseTransport := ourTransport
 	if cfg.Traces.TLSCfg != nil || cfg.Traces.Proxy != nil {
-		clonedTransport := ourTransport.Clone()
-		httpClient.Transport = clonedTransport
+		baseTransport = ourTransport.Clone()
 
 		if cfg.Traces.TLSCfg != nil {
-			clonedTransport.TLSClientConfig = cfg.Traces.TLSCfg
+			baseTransport.TLSClientConfig = cfg.Traces.TLSCfg
 		}
 		if cfg.Traces.Proxy != nil {
-			clonedTransport.Proxy = cfg.Traces.Proxy
+			baseTransport.Proxy = cfg.Traces.Proxy
+		}
+	}
+
+	endpoints := cfg.Traces.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{cfg.Traces.Endpoint}
+	}
+	httpClients := make([]*http.Client, len(endpoints))
+	for i, endpoint := range endpoints {
+		endpointTransport := baseTransport
+		if tlsCfg := tlsreload.ForServer(cfg.Traces.TLSCfg, transport.Host(endpoint)); tlsCfg != cfg.Traces.TLSCfg {
+			endpointTransport = baseTransport.Clone()
+			endpointTransport.TLSClientConfig = tlsCfg
+		}
+		httpClients[i] = &http.Client{
+			Transport: transport.New(endpointTransport, endpoint, cfg.Traces.H2C && cfg.Traces.Insecure),
+			Timeout:   cfg.Traces.Timeout,
 		}
 	}
 
@@ -95,24 +137,45 @@ func NewClient(opts ...Option) otlptrace.Client {
 		generalCfg:  cfg,
 		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
 		stopCh:      stopCh,
-		client:      httpClient,
+		endpoints:   endpoints,
+		clients:     httpClients,
+		failover:    balance.NewFailover(len(endpoints)),
+		partialSuccess: internal.NewTracePartialSuccessHandler(
+			otel.GetMeterProvider(),
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp",
+			otlptrace.Version(),
+			cfg.Traces.PartialSuccessHandler,
+		),
 	}
 }
 
-// Start does nothing in a HTTP client.
+// Start creates the disk queue of the client, if any, and starts sending the
+// requests it holds.
 func (d *client) Start(ctx context.Context) error {
-	// nothing to do
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
+
+	if d.generalCfg.DiskQueue.Dir != "" {
+		q, err := diskqueue.New(d.generalCfg.DiskQueue, d.replay)
+		if err != nil {
+			return err
+		}
+		d.queue = q
+		// Send the requests queued before a restart.
+		q.Flush()
+	}
 	return nil
 }
 
 // Stop shuts down the client and interrupt any in-flight request.
 func (d *client) Stop(ctx context.Context) error {
 	d.stopOnce.Do(func() {
+		// Stop sending the queued requests, they are kept for the next
+		// start.
+		d.queue.Close()
 		close(d.stopCh)
 	})
 	select {
@@ -125,10 +188,44 @@ func (d *client) Stop(ctx context.Context) error {
 
 // UploadTraces sends a batch of spans to the collector.
 func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	parts := [][]*tracepb.ResourceSpans{protoSpans}
+	if d.cfg.EndpointMode == otlpconfig.TraceIDEndpoints {
+		parts = balance.ByTraceID(protoSpans, len(d.endpoints))
+	}
+
+	var errs []error
+	for i, part := range parts {
+		if len(part) == 0 && len(parts) > 1 {
+			// No span of this export is sent to the endpoint.
+			continue
+		}
+
+		batches, err := split.Spans(part, d.cfg.MaxRequestSize, d.requestSize())
+		if err != nil {
+			otel.Handle(err)
+		}
+
+		for _, batch := range batches {
+			if err := d.uploadTraces(ctx, i, batch, d.requestFunc); err != nil {
+				d.enqueue(batch, err)
+				errs = append(errs, err)
+				continue
+			}
+			// The endpoint is available, send the queued requests.
+			d.queue.Flush()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// uploadTraces sends protoSpans to the collector in a single request, using
+// requestFunc. With the TraceIDEndpoints mode, the request is sent to the
+// endpoint with the part index, otherwise it is sent to the active endpoint.
+func (d *client) uploadTraces(ctx context.Context, part int, protoSpans []*tracepb.ResourceSpans, requestFunc retry.RequestFunc) error {
 	pbRequest := &coltracepb.ExportTraceServiceRequest{
 		ResourceSpans: protoSpans,
 	}
-	rawRequest, err := proto.Marshal(pbRequest)
+	rawRequest, err := d.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -141,7 +238,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return err
 	}
 
-	return d.requestFunc(ctx, func(ctx context.Context) error {
+	send := func(ctx context.Context, i int) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -149,7 +246,11 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		}
 
 		request.reset(ctx)
-		resp, err := d.client.Do(request.Request)
+		request.setEndpoint(d.endpointURL(d.endpoints[i]))
+		if err := auth.SetHeaders(ctx, d.cfg.Authenticator, request.Header); err != nil {
+			return err
+		}
+		resp, err := d.clients[i].Do(request.Request)
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) && urlErr.Temporary() {
 			return newResponseError(http.Header{}, err)
@@ -178,20 +279,15 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 				return nil
 			}
 
-			if resp.Header.Get("Content-Type") == "application/x-protobuf" {
-				var respProto coltracepb.ExportTraceServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
-					return err
-				}
-
-				if respProto.PartialSuccess != nil {
-					msg := respProto.PartialSuccess.GetErrorMessage()
-					n := respProto.PartialSuccess.GetRejectedSpans()
-					if n != 0 || msg != "" {
-						err := internal.TracePartialSuccessError(n, msg)
-						otel.Handle(err)
-					}
-				}
+			var respProto coltracepb.ExportTraceServiceResponse
+			ok, err := unmarshalResponse(resp.Header.Get("Content-Type"), respData.Bytes(), &respProto)
+			if err != nil {
+				return err
+			}
+			if ok && respProto.PartialSuccess != nil {
+				msg := respProto.PartialSuccess.GetErrorMessage()
+				n := respProto.PartialSuccess.GetRejectedSpans()
+				d.partialSuccess.Handle(ctx, n, msg)
 			}
 			return nil
 
@@ -200,7 +296,8 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			sc == http.StatusServiceUnavailable,
 			sc == http.StatusGatewayTimeout:
 			// Retry-able failures.
-			rErr := newResponseError(resp.Header, nil)
+			statusErr := &retry.HTTPStatusError{StatusCode: sc, Status: resp.Status}
+			rErr := newResponseError(resp.Header, statusErr)
 
 			// server may return a message with the response
 			// body, so we read it to include in the error
@@ -216,18 +313,141 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			// if it is not empty
 			if respStr := strings.TrimSpace(respData.String()); respStr != "" {
 				// Include response for context.
-				e := errors.New(respStr)
-				rErr = newResponseError(resp.Header, e)
+				statusErr.Err = errors.New(respStr)
 			}
 			return rErr
 		default:
-			return fmt.Errorf("failed to send to %s: %s", request.URL, resp.Status)
+			return fmt.Errorf("failed to send to %s: %w", request.URL, &retry.HTTPStatusError{StatusCode: sc, Status: resp.Status})
+		}
+	}
+
+	return requestFunc(ctx, func(ctx context.Context) error {
+		if d.cfg.EndpointMode == otlpconfig.TraceIDEndpoints {
+			return send(ctx, part)
 		}
+		return d.failover.Do(ctx, send, failover)
 	})
 }
 
+// enqueue adds the export request of protoSpans, which failed with err, to the
+// disk queue if the error is transient.
+func (d *client) enqueue(protoSpans []*tracepb.ResourceSpans, err error) {
+	if d.queue == nil || !transient(err) {
+		return
+	}
+
+	data, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err == nil {
+		err = d.queue.Push(data)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// replay sends the export request data read from the disk queue in a single
+// attempt. The request is dropped if it fails with a permanent error.
+//
+// With the TraceIDEndpoints mode, if the request is sent to some of the
+// endpoints only, the parts of the other endpoints are queued as new requests.
+func (d *client) replay(ctx context.Context, data []byte) error {
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		otel.Handle(fmt.Errorf("dropping invalid queued export request: %w", err))
+		return nil
+	}
+
+	parts := [][]*tracepb.ResourceSpans{req.ResourceSpans}
+	if d.cfg.EndpointMode == otlpconfig.TraceIDEndpoints {
+		parts = balance.ByTraceID(req.ResourceSpans, len(d.endpoints))
+	}
+
+	var (
+		sent   bool
+		failed [][]*tracepb.ResourceSpans
+		errs   []error
+	)
+	for i, part := range parts {
+		if len(part) == 0 && len(parts) > 1 {
+			continue
+		}
+		if err := d.uploadTraces(ctx, i, part, once); err != nil {
+			failed = append(failed, part)
+			errs = append(errs, err)
+			continue
+		}
+		sent = true
+	}
+
+	if sent {
+		// Queue the parts that failed on their own, so that the parts sent
+		// are not sent again with them.
+		for i, part := range failed {
+			if !transient(errs[i]) {
+				otel.Handle(errs[i])
+				continue
+			}
+			d.enqueue(part, errs[i])
+		}
+		return nil
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && !transient(err) {
+		otel.Handle(err)
+		return nil
+	}
+	return err
+}
+
+// once sends a request in a single attempt.
+func once(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// requestSize returns the function measuring the size of the export request
+// of a batch, or nil if requests are encoded with protobuf, which the split
+// package measures itself.
+func (d *client) requestSize() func([]*tracepb.ResourceSpans) int {
+	if d.cfg.Marshaler != otlpconfig.MarshalJSON {
+		return nil
+	}
+	return func(rs []*tracepb.ResourceSpans) int {
+		b, err := otlpjson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: rs})
+		if err != nil {
+			// The error is returned when the batch is sent.
+			return 0
+		}
+		return len(b)
+	}
+}
+
+// marshal returns the encoding of m used by the client.
+func (d *client) marshal(m proto.Message) ([]byte, error) {
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		return otlpjson.Marshal(m)
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalResponse parses the response body b with the encoding of
+// contentType into m. It returns false if the encoding is unknown, in which
+// case m is not modified.
+func unmarshalResponse(contentType string, b []byte, m proto.Message) (bool, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case contentTypeProto:
+		return true, proto.Unmarshal(b, m)
+	case otlpjson.ContentType:
+		return true, otlpjson.Unmarshal(b, m)
+	}
+	return false, nil
+}
+
 func (d *client) newRequest(body []byte) (request, error) {
-	u := url.URL{Scheme: d.getScheme(), Host: d.cfg.Endpoint, Path: d.cfg.URLPath}
+	u := d.endpointURL(d.cfg.Endpoint)
 	r, err := http.NewRequest(http.MethodPost, u.String(), nil)
 	if err != nil {
 		return request{Request: r}, err
@@ -239,7 +459,11 @@ func (d *client) newRequest(body []byte) (request, error) {
 	for k, v := range d.cfg.Headers {
 		r.Header.Set(k, v)
 	}
-	r.Header.Set("Content-Type", contentTypeProto)
+	contentType := contentTypeProto
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		contentType = otlpjson.ContentType
+	}
+	r.Header.Set("Content-Type", contentType)
 
 	req := request{Request: r}
 	switch Compression(d.cfg.Compression) {
@@ -266,6 +490,15 @@ func (d *client) newRequest(body []byte) (request, error) {
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+	case ZstdCompression:
+		// Ensure the content length is not used.
+		r.ContentLength = -1
+		r.Header.Set("Content-Encoding", "zstd")
+
+		enc := zstdPool.Get().(*zstd.Encoder)
+		defer zstdPool.Put(enc)
+
+		req.bodyReader = bodyReader(enc.EncodeAll(body, nil))
 	}
 
 	return req, nil
@@ -305,6 +538,12 @@ func (r *request) reset(ctx context.Context) {
 	r.Request = r.Request.WithContext(ctx)
 }
 
+// setEndpoint sets u as the URL of the request.
+func (r *request) setEndpoint(u *url.URL) {
+	r.URL = u
+	r.Host = u.Host
+}
+
 // retryableError represents a request failure that can be retried.
 type retryableError struct {
 	throttle int64
@@ -370,6 +609,37 @@ func evaluate(err error) (bool, time.Duration) {
 	return true, time.Duration(rErr.throttle)
 }
 
+// transient returns if err, returned by an export request, may not be
+// returned when sending the request again later: the request failed with a
+// retry-able status, failed to reach the endpoint, or was not sent.
+func transient(err error) bool {
+	var (
+		rErr   *retryableError
+		urlErr *url.Error
+	)
+	return errors.As(err, &rErr) ||
+		errors.As(err, &urlErr) ||
+		errors.Is(err, retry.ErrCircuitOpen) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// failover returns if err identifies a request that can be sent to the next
+// endpoint: a request that can be retried, or that failed to reach its
+// endpoint.
+func failover(err error) bool {
+	if ok, _ := evaluate(err); ok {
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// endpointURL returns the URL of the requests sent to endpoint.
+func (d *client) endpointURL(endpoint string) *url.URL {
+	return &url.URL{Scheme: d.getScheme(), Host: transport.Host(endpoint), Path: d.cfg.URLPath}
+}
+
 func (d *client) getScheme() string {
 	if d.cfg.Insecure {
 		return "http"
@@ -5,10 +5,13 @@ package otlptracehttp_test
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +23,8 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlptracetest"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 )
 
@@ -65,6 +70,25 @@ func TestEndToEnd(t *testing.T) {
 				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
 			},
 		},
+		{
+			name: "with zstd compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.ZstdCompression),
+			},
+		},
+		{
+			name: "with JSON encoding",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+			},
+		},
+		{
+			name: "with JSON encoding and gzip compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+			},
+		},
 		{
 			name: "retry",
 			opts: []otlptracehttp.Option{
@@ -271,6 +295,45 @@ func TestNoRetry(t *testing.T) {
 	assert.Empty(t, mc.GetSpans())
 }
 
+func TestRetryClassify(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusBadRequest},
+	})
+	defer mc.MustStop(t)
+
+	var classified []int
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 1 * time.Nanosecond,
+			MaxInterval:     1 * time.Nanosecond,
+			MaxElapsedTime:  time.Minute,
+			Classify: func(err error) otlptracehttp.RetryClassification {
+				var sErr *otlptracehttp.HTTPStatusError
+				if !errors.As(err, &sErr) {
+					return otlptracehttp.ClassifyDefault
+				}
+				classified = append(classified, sErr.StatusCode)
+				if sErr.StatusCode == http.StatusBadRequest {
+					return otlptracehttp.ClassifyRetryable
+				}
+				return otlptracehttp.ClassifyDefault
+			},
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	assert.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Equal(t, []int{http.StatusBadRequest}, classified)
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
 func TestEmptyData(t *testing.T) {
 	mcCfg := mockCollectorConfig{}
 	mc := runMockCollector(t, mcCfg)
@@ -417,6 +480,116 @@ func TestPartialSuccess(t *testing.T) {
 	require.Contains(t, errs[0].Error(), "2 spans rejected")
 }
 
+func TestPartialSuccessHandler(t *testing.T) {
+	mcCfg := mockCollectorConfig{
+		Partial: &coltracepb.ExportTracePartialSuccess{
+			RejectedSpans: 2,
+			ErrorMessage:  "partially successful",
+		},
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+
+	var got []otlptracehttp.PartialSuccessError
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithPartialSuccessHandler(func(err otlptracehttp.PartialSuccessError) {
+			got = append(got, err)
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(context.Background()))
+	}()
+
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.NoError(t, err)
+
+	assert.Empty(t, errs, "partial success passed to the global error handler")
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(2), got[0].RejectedItems)
+	assert.Equal(t, "spans", got[0].RejectedKind)
+	assert.Equal(t, "partially successful", got[0].ErrorMessage)
+}
+
+func TestAuthenticator(t *testing.T) {
+	mcCfg := mockCollectorConfig{
+		ExpectedHeaders: map[string]string{"Authorization": "Bearer token-1"},
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+
+	var calls int
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithHeaders(map[string]string{"Authorization": "static"}),
+		otlptracehttp.WithAuthenticator(otlptracehttp.AuthenticatorFunc(func(context.Context) (map[string]string, error) {
+			calls++
+			if calls > 1 {
+				return nil, errors.New("token unavailable")
+			}
+			return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", calls)}, nil
+		})),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(context.Background()))
+	}()
+
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	require.NoError(t, err)
+	assert.Len(t, mc.GetSpans(), 1)
+
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorContains(t, err, "token unavailable")
+	assert.Equal(t, 2, calls, "authentication error retried")
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestPartialSuccessJSON(t *testing.T) {
+	mcCfg := mockCollectorConfig{
+		Partial: &coltracepb.ExportTracePartialSuccess{
+			RejectedSpans: 2,
+			ErrorMessage:  "partially successful",
+		},
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(context.Background()))
+	}()
+
+	errs := []error{}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.NoError(t, err)
+	assert.Len(t, mc.GetSpans(), 1)
+
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "partially successful")
+	require.Contains(t, errs[0].Error(), "2 spans rejected")
+}
+
 func TestOtherHTTPSuccess(t *testing.T) {
 	for code := 201; code <= 299; code++ {
 		t.Run(fmt.Sprintf("status_%d", code), func(t *testing.T) {
@@ -468,3 +641,290 @@ func TestCollectorRespondingNonProtobufContent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, mc.GetSpans(), 1)
 }
+
+func TestMaxRequestSize(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		encoding otlptracehttp.Encoding
+	}{
+		{name: "Protobuf", encoding: otlptracehttp.ProtobufEncoding},
+		{name: "JSON", encoding: otlptracehttp.JSONEncoding},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := runMockCollector(t, mockCollectorConfig{})
+			defer mc.MustStop(t)
+
+			const maxSize = 1000
+			driver := otlptracehttp.NewClient(
+				otlptracehttp.WithEndpoint(mc.Endpoint()),
+				otlptracehttp.WithInsecure(),
+				otlptracehttp.WithMaxRequestSize(maxSize),
+				otlptracehttp.WithEncoding(tc.encoding),
+			)
+			ctx := context.Background()
+			exporter, err := otlptrace.New(ctx, driver)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(context.Background()))
+			}()
+
+			var errs []error
+			otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+				errs = append(errs, err)
+			}))
+
+			stub := tracetest.SpanStubFromReadOnlySpan(otlptracetest.SingleReadOnlySpan()[0])
+			var stubs tracetest.SpanStubs
+			for i := 0; i < 20; i++ {
+				stubs = append(stubs, stub)
+			}
+			oversized := stub
+			oversized.Name = strings.Repeat("x", maxSize)
+			stubs = append(stubs, oversized)
+
+			err = exporter.ExportSpans(ctx, stubs.Snapshots())
+			assert.NoError(t, err)
+			assert.Len(t, mc.GetSpans(), 20)
+
+			sizes := mc.GetRequestSizes()
+			assert.Greater(t, len(sizes), 1, "request not split")
+			for _, size := range sizes {
+				assert.LessOrEqual(t, size, maxSize)
+			}
+
+			require.Len(t, errs, 1)
+			assert.ErrorContains(t, errs[0], "1 spans dropped")
+		})
+	}
+}
+
+func TestEndpointsFailover(t *testing.T) {
+	// An endpoint not accepting connections.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	closed := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	unavailable := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+	})
+	defer unavailable.MustStop(t)
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoints(otlptracehttp.FailoverEndpoints, closed, unavailable.Endpoint(), mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+
+	// The endpoint that succeeded stays active.
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 2)
+	assert.Empty(t, unavailable.GetSpans())
+}
+
+func TestEndpointsTraceID(t *testing.T) {
+	collectors := make([]*mockCollector, 3)
+	endpoints := make([]string, len(collectors))
+	for i := range collectors {
+		collectors[i] = runMockCollector(t, mockCollectorConfig{})
+		defer collectors[i].MustStop(t)
+		endpoints[i] = collectors[i].Endpoint()
+	}
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoints(otlptracehttp.TraceIDEndpoints, endpoints...),
+		otlptracehttp.WithInsecure(),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	stub := tracetest.SpanStubFromReadOnlySpan(otlptracetest.SingleReadOnlySpan()[0])
+	var stubs tracetest.SpanStubs
+	for i := 0; i < 30; i++ {
+		var traceID trace.TraceID
+		binary.BigEndian.PutUint64(traceID[8:], uint64(i+1))
+		// Two spans for every trace.
+		for j := 0; j < 2; j++ {
+			s := stub
+			s.SpanContext = s.SpanContext.WithTraceID(traceID)
+			stubs = append(stubs, s)
+		}
+	}
+	require.NoError(t, exporter.ExportSpans(ctx, stubs.Snapshots()))
+
+	// All the spans of a trace are sent to the same collector.
+	traces := make(map[string]int)
+	var total int
+	for i, mc := range collectors {
+		spans := mc.GetSpans()
+		assert.NotEmptyf(t, spans, "collector %d", i)
+		for _, span := range spans {
+			id := string(span.TraceId)
+			if c, ok := traces[id]; ok {
+				assert.Equal(t, i, c, "trace sent to several collectors")
+			}
+			traces[id] = i
+		}
+		total += len(spans)
+	}
+	assert.Len(t, traces, 30)
+	assert.Equal(t, len(stubs), total)
+}
+
+func newDiskQueueExporter(t *testing.T, endpoint, dir string) *otlptrace.Exporter {
+	t.Helper()
+	client := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(endpoint),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		otlptracehttp.WithDiskQueue(otlptracehttp.DiskQueueConfig{Dir: dir}),
+	)
+	exp, err := otlptrace.New(context.Background(), client)
+	require.NoError(t, err)
+	return exp
+}
+
+func TestDiskQueue(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable, http.StatusBadRequest},
+	})
+	defer mc.MustStop(t)
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	exp := newDiskQueueExporter(t, mc.Endpoint(), dir)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(ctx))
+	}()
+
+	// The request failing with a transient error is queued.
+	assert.Error(t, exp.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	// The request failing with a permanent error is dropped.
+	assert.Error(t, exp.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// The queued request is sent once an export succeeds.
+	require.NoError(t, exp.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Eventually(t, func() bool {
+		return len(mc.GetSpans()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDiskQueueRestart(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+	})
+	defer mc.MustStop(t)
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	exp := newDiskQueueExporter(t, mc.Endpoint(), dir)
+	assert.Error(t, exp.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Empty(t, mc.GetSpans())
+
+	// The requests queued before a restart are sent on start.
+	exp = newDiskQueueExporter(t, mc.Endpoint(), dir)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(ctx))
+	}()
+	assert.Eventually(t, func() bool {
+		return len(mc.GetSpans()) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDiskQueueEndpointDown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	exp := newDiskQueueExporter(t, endpoint, dir)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(ctx))
+	}()
+
+	// Requests that fail to reach the endpoint are queued.
+	assert.Error(t, exp.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestDiskQueueTraceIDEndpoints(t *testing.T) {
+	stub := tracetest.SpanStubFromReadOnlySpan(otlptracetest.SingleReadOnlySpan()[0])
+	var stubs tracetest.SpanStubs
+	for i := 0; i < 30; i++ {
+		var traceID trace.TraceID
+		binary.BigEndian.PutUint64(traceID[8:], uint64(i+1))
+		s := stub
+		s.SpanContext = s.SpanContext.WithTraceID(traceID)
+		stubs = append(stubs, s)
+	}
+
+	// Queue a request holding the spans of the two endpoints below.
+	down := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+	})
+	dir := t.TempDir()
+	ctx := context.Background()
+	exp := newDiskQueueExporter(t, down.Endpoint(), dir)
+	assert.Error(t, exp.ExportSpans(ctx, stubs.Snapshots()))
+	require.NoError(t, exp.Shutdown(ctx))
+	down.MustStop(t)
+
+	failing := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+	})
+	defer failing.MustStop(t)
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+
+	client := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoints(otlptracehttp.TraceIDEndpoints, failing.Endpoint(), mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		otlptracehttp.WithDiskQueue(otlptracehttp.DiskQueueConfig{Dir: dir}),
+	)
+	exp, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(ctx))
+	}()
+
+	// The queued request is sent on start, to the failing endpoint first.
+	assert.Eventually(t, func() bool {
+		return len(mc.GetSpans()) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Only the part of the failing endpoint is sent again.
+	require.NoError(t, exp.ExportSpans(ctx, stubs[:1].Snapshots()))
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, len(stubs)+1, len(failing.GetSpans())+len(mc.GetSpans()))
+}
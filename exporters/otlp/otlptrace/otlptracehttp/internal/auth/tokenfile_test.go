@@ -0,0 +1,45 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/tokenfile_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	a := NewTokenFile(path)
+	ctx := context.Background()
+	h, err := a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer first"}, h)
+
+	// Rotate the token.
+	require.NoError(t, os.WriteFile(path, []byte("second-token\n"), 0o600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer second-token", h["Authorization"])
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0o600))
+	_, err = a.Headers(ctx)
+	assert.ErrorContains(t, err, "is empty")
+
+	require.NoError(t, os.Remove(path))
+	_, err = a.Headers(ctx)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,160 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/otlptrace/balance/balance.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package balance provides the distribution of export requests between
+// several OTLP endpoints.
+package balance // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/balance"
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// FailbackDelay is the time after which an endpoint that failed over to
+// another one is tried again.
+const FailbackDelay = 30 * time.Second
+
+// Allow override for testing.
+var now = time.Now
+
+// Index returns the index of the endpoint, out of n, that the spans of the
+// trace identified by traceID are sent to.
+//
+// Endpoints are assigned using jump consistent hashing: adding an endpoint
+// only moves the traces assigned to the new endpoint.
+func Index(traceID []byte, n int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(traceID)
+	return jumpHash(h.Sum64(), n)
+}
+
+// jumpHash is the jump consistent hash function of Lamping and Veach
+// (https://arxiv.org/abs/1406.2294).
+func jumpHash(key uint64, n int) int {
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// ByTraceID partitions the spans of rs between n endpoints by their trace
+// ID. It returns the resource spans of every endpoint, indexed by the
+// endpoint index. Spans are kept grouped by their resource and
+// instrumentation scope.
+func ByTraceID(rs []*tracepb.ResourceSpans, n int) [][]*tracepb.ResourceSpans {
+	parts := make([][]*tracepb.ResourceSpans, max(n, 1))
+	if n <= 1 {
+		parts[0] = rs
+		return parts
+	}
+
+	resources := make([]*tracepb.ResourceSpans, n)
+	scopes := make([]*tracepb.ScopeSpans, n)
+	for _, r := range rs {
+		clear(resources)
+		for _, s := range r.GetScopeSpans() {
+			clear(scopes)
+			for _, span := range s.GetSpans() {
+				i := Index(span.GetTraceId(), n)
+				if scopes[i] == nil {
+					scopes[i] = &tracepb.ScopeSpans{Scope: s.Scope, SchemaUrl: s.SchemaUrl}
+				}
+				scopes[i].Spans = append(scopes[i].Spans, span)
+			}
+
+			for i, scope := range scopes {
+				if scope == nil {
+					continue
+				}
+				if resources[i] == nil {
+					resources[i] = &tracepb.ResourceSpans{Resource: r.Resource, SchemaUrl: r.SchemaUrl}
+				}
+				resources[i].ScopeSpans = append(resources[i].ScopeSpans, scope)
+			}
+		}
+
+		for i, resource := range resources {
+			if resource != nil {
+				parts[i] = append(parts[i], resource)
+			}
+		}
+	}
+	return parts
+}
+
+// Failover sends export requests to the active endpoint out of several,
+// failing over to the next endpoints when it fails.
+//
+// The first endpoint is the primary one: after a failover, it is tried again
+// once FailbackDelay has elapsed, and becomes active again if it succeeds.
+type Failover struct {
+	n int
+
+	mu     sync.Mutex
+	active int
+	since  time.Time
+}
+
+// NewFailover returns a Failover between n endpoints.
+func NewFailover(n int) *Failover {
+	return &Failover{n: max(n, 1)}
+}
+
+// Do calls fn with the index of the active endpoint. If fn returns an error
+// for which failover returns true, fn is called again with the next
+// endpoints, in order, until it succeeds or all the endpoints were tried. The
+// error of the last call is returned.
+func (f *Failover) Do(ctx context.Context, fn func(context.Context, int) error, failover func(error) bool) error {
+	start := f.first()
+
+	var err error
+	for k := 0; k < f.n; k++ {
+		i := (start + k) % f.n
+		err = fn(ctx, i)
+		if err == nil {
+			f.activate(i)
+			return nil
+		}
+		if !failover(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// first returns the index of the first endpoint to send a request to.
+func (f *Failover) first() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active != 0 && now().Sub(f.since) >= FailbackDelay {
+		// Try the primary endpoint again, at most once per FailbackDelay.
+		f.since = now()
+		return 0
+	}
+	return f.active
+}
+
+// activate records that the endpoint i succeeded.
+func (f *Failover) activate(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active != i {
+		f.active, f.since = i, now()
+	}
+}
@@ -8,7 +8,6 @@ package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"net/url"
 	"os"
 	"path"
@@ -16,6 +15,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/envconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/tlsreload"
+	"go.opentelemetry.io/otel/internal/global"
 )
 
 // DefaultEnvOptionsReader is the default environments reader.
@@ -46,10 +47,14 @@ func ApplyHTTPEnvConfigs(cfg Config) Config {
 func getOptionsFromEnv() []GenericOption {
 	opts := []GenericOption{}
 
-	tlsConf := &tls.Config{}
+	var tlsFiles tlsFiles
 	DefaultEnvOptionsReader.Apply(
 		envconfig.WithURL("ENDPOINT", func(u *url.URL) {
 			opts = append(opts, withEndpointScheme(u))
+			if u.Scheme == "unix" {
+				opts = append(opts, WithEndpoint(unixEndpoint(u)))
+				return
+			}
 			opts = append(opts, newSplitOption(func(cfg Config) Config {
 				cfg.Traces.Endpoint = u.Host
 				// For OTLP/HTTP endpoint URLs without a per-signal
@@ -61,6 +66,10 @@ func getOptionsFromEnv() []GenericOption {
 		}),
 		envconfig.WithURL("TRACES_ENDPOINT", func(u *url.URL) {
 			opts = append(opts, withEndpointScheme(u))
+			if u.Scheme == "unix" {
+				opts = append(opts, WithEndpoint(unixEndpoint(u)))
+				return
+			}
 			opts = append(opts, newSplitOption(func(cfg Config) Config {
 				cfg.Traces.Endpoint = u.Host
 				// For endpoint URLs for OTLP/HTTP per-signal variables, the
@@ -75,17 +84,19 @@ func getOptionsFromEnv() []GenericOption {
 				return cfg
 			}, withEndpointForGRPC(u)))
 		}),
-		envconfig.WithCertPool("CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithCertPool("TRACES_CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithClientCert("CLIENT_CERTIFICATE", "CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
-		envconfig.WithClientCert("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
-		withTLSConfig(tlsConf, func(c *tls.Config) { opts = append(opts, WithTLSClientConfig(c)) }),
+		envconfig.WithString("CERTIFICATE", func(v string) { tlsFiles.ca = v }),
+		envconfig.WithString("TRACES_CERTIFICATE", func(v string) { tlsFiles.ca = v }),
+		withClientCertFiles("CLIENT_CERTIFICATE", "CLIENT_KEY", &tlsFiles),
+		withClientCertFiles("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY", &tlsFiles),
+		withTLSConfig(&tlsFiles, func(c *tls.Config) { opts = append(opts, withTLSReloadConfig(c)) }),
 		envconfig.WithBool("INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
 		envconfig.WithBool("TRACES_INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
 		envconfig.WithHeaders("HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		envconfig.WithHeaders("TRACES_HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		WithEnvCompression("COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
 		WithEnvCompression("TRACES_COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
+		WithEnvProtocol("PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshaler(m)) }),
+		WithEnvProtocol("TRACES_PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshaler(m)) }),
 		envconfig.WithDuration("TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 		envconfig.WithDuration("TRACES_TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 	)
@@ -116,8 +127,11 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	return func(e *envconfig.EnvOptionsReader) {
 		if v, ok := e.GetEnvValue(n); ok {
 			cp := NoCompression
-			if v == "gzip" {
+			switch v {
+			case "gzip":
 				cp = GzipCompression
+			case "zstd":
+				cp = ZstdCompression
 			}
 
 			fn(cp)
@@ -125,6 +139,21 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	}
 }
 
+// WithEnvProtocol retrieves the specified config and passes it to ConfigFn as
+// the Marshaler of an OTLP/HTTP protocol. Other protocols are ignored.
+func WithEnvProtocol(n string, fn func(Marshaler)) func(e *envconfig.EnvOptionsReader) {
+	return func(e *envconfig.EnvOptionsReader) {
+		if v, ok := e.GetEnvValue(n); ok {
+			switch v {
+			case "http/protobuf":
+				fn(MarshalProto)
+			case "http/json":
+				fn(MarshalJSON)
+			}
+		}
+	}
+}
+
 // revive:disable-next-line:flag-parameter
 func withInsecure(b bool) GenericOption {
 	if b {
@@ -133,10 +162,49 @@ func withInsecure(b bool) GenericOption {
 	return WithSecure()
 }
 
-func withTLSConfig(c *tls.Config, fn func(*tls.Config)) func(e *envconfig.EnvOptionsReader) {
+// tlsFiles are the paths of the TLS files set with environment variables.
+type tlsFiles struct {
+	ca, cert, key string
+}
+
+// withClientCertFiles sets the client certificate and key paths of f to the
+// values of the environment variables nc and nk if both are set.
+func withClientCertFiles(nc, nk string, f *tlsFiles) func(e *envconfig.EnvOptionsReader) {
 	return func(e *envconfig.EnvOptionsReader) {
-		if c.RootCAs != nil || len(c.Certificates) > 0 {
-			fn(c)
+		vc, okc := e.GetEnvValue(nc)
+		vk, okk := e.GetEnvValue(nk)
+		if okc && okk {
+			f.cert, f.key = vc, vk
 		}
 	}
 }
+
+// withTLSConfig passes the TLS configuration loaded from the files of f to
+// fn. The configuration reloads the files when they change, so rotated
+// certificates are used for new connections.
+func withTLSConfig(f *tlsFiles, fn func(*tls.Config)) func(e *envconfig.EnvOptionsReader) {
+	return func(e *envconfig.EnvOptionsReader) {
+		if f.ca == "" && f.cert == "" {
+			return
+		}
+		c, err := tlsreload.NewConfig(f.ca, f.cert, f.key, e.ReadFile)
+		if err != nil {
+			global.Error(err, "load tls config")
+			return
+		}
+		fn(c)
+	}
+}
+
+// withTLSReloadConfig is WithTLSClientConfig for a config returned by
+// tlsreload.NewConfig. The gRPC credentials verify the server certificate of
+// every connection against its authority.
+func withTLSReloadConfig(c *tls.Config) GenericOption {
+	return newSplitOption(func(cfg Config) Config {
+		cfg.Traces.TLSCfg = c.Clone()
+		return cfg
+	}, func(cfg Config) Config {
+		cfg.Traces.GRPCCredentials = tlsreload.Credentials(c)
+		return cfg
+	})
+}
@@ -27,6 +27,22 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression
+)
+
+// EndpointMode describes how spans are distributed between several
+// endpoints.
+type EndpointMode int
+
+const (
+	// FailoverEndpoints sends all spans to the first available endpoint, in
+	// the order they are configured.
+	FailoverEndpoints EndpointMode = iota
+	// TraceIDEndpoints sends the spans of the same trace to the same
+	// endpoint, chosen by consistent hashing of the trace ID.
+	TraceIDEndpoints
 )
 
 // Marshaler describes the kind of message format sent to the collector.
@@ -16,11 +16,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpjson"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlptracetest"
 	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
@@ -32,6 +34,7 @@ type mockCollector struct {
 
 	spanLock     sync.Mutex
 	spansStorage otlptracetest.SpansStorage
+	requestSizes []int
 
 	injectHTTPStatus     []int
 	injectResponseHeader []map[string]string
@@ -63,6 +66,14 @@ func (c *mockCollector) GetResourceSpans() []*tracepb.ResourceSpans {
 	return c.spansStorage.GetResourceSpans()
 }
 
+// GetRequestSizes returns the encoded size of every export request received,
+// before compression.
+func (c *mockCollector) GetRequestSizes() []int {
+	c.spanLock.Lock()
+	defer c.spanLock.Unlock()
+	return c.requestSizes
+}
+
 func (c *mockCollector) Endpoint() string {
 	return c.endpoint
 }
@@ -87,14 +98,22 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	response := collectortracepb.ExportTraceServiceResponse{
 		PartialSuccess: c.partial,
 	}
-	rawResponse, err := proto.Marshal(&response)
+	marshal := proto.Marshal
+	if r.Header.Get("content-type") == otlpjson.ContentType {
+		marshal = otlpjson.Marshal
+	}
+	rawResponse, err := marshal(&response)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	ct := c.injectContentType
+	if ct == "" && r.Header.Get("content-type") == otlpjson.ContentType {
+		ct = otlpjson.ContentType
+	}
 	h := c.getInjectResponseHeader()
 	if injectedStatus := c.getInjectHTTPStatus(); injectedStatus != 0 {
-		writeReply(w, rawResponse, injectedStatus, c.injectContentType, h)
+		writeReply(w, rawResponse, injectedStatus, ct, h)
 		return
 	}
 	rawRequest, err := readRequest(r)
@@ -108,19 +127,23 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	writeReply(w, rawResponse, 0, c.injectContentType, h)
+	writeReply(w, rawResponse, 0, ct, h)
 	c.spanLock.Lock()
 	defer c.spanLock.Unlock()
 	c.spansStorage.AddSpans(request)
+	c.requestSizes = append(c.requestSizes, len(rawRequest))
 }
 
 func unmarshalTraceRequest(rawRequest []byte, contentType string) (*collectortracepb.ExportTraceServiceRequest, error) {
 	request := &collectortracepb.ExportTraceServiceRequest{}
-	if contentType != "application/x-protobuf" {
-		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf is supported", contentType)
+	switch contentType {
+	case "application/x-protobuf":
+		return request, proto.Unmarshal(rawRequest, request)
+	case otlpjson.ContentType:
+		return request, otlpjson.Unmarshal(rawRequest, request)
+	default:
+		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf and application/json are supported", contentType)
 	}
-	err := proto.Unmarshal(rawRequest, request)
-	return request, err
 }
 
 func (c *mockCollector) checkHeaders(r *http.Request) bool {
@@ -157,12 +180,24 @@ func (c *mockCollector) getInjectResponseHeader() (h map[string]string) {
 }
 
 func readRequest(r *http.Request) ([]byte, error) {
-	if r.Header.Get("Content-Encoding") == "gzip" {
+	switch r.Header.Get("Content-Encoding") {
+	case "gzip":
 		return readGzipBody(r.Body)
+	case "zstd":
+		return readZstdBody(r.Body)
 	}
 	return io.ReadAll(r.Body)
 }
 
+func readZstdBody(body io.Reader) ([]byte, error) {
+	dec, err := zstd.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return io.ReadAll(dec)
+}
+
 func readGzipBody(body io.Reader) ([]byte, error) {
 	rawRequest := bytes.Buffer{}
 	gunzipper, err := gzip.NewReader(body)
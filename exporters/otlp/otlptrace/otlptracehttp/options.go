@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"time"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/diskqueue"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/retry"
 )
@@ -29,6 +31,22 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(otlpconfig.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(otlpconfig.ZstdCompression)
+)
+
+// Encoding describes the encoding used for payloads sent to the collector.
+type Encoding otlpconfig.Marshaler
+
+const (
+	// ProtobufEncoding tells the driver to send payloads encoded as binary
+	// Protobuf (OTLP/HTTP binary Protobuf).
+	ProtobufEncoding = Encoding(otlpconfig.MarshalProto)
+	// JSONEncoding tells the driver to send payloads encoded as JSON
+	// (OTLP/HTTP JSON), using the Protobuf JSON mapping with hex-encoded
+	// trace and span IDs defined by the OTLP specification.
+	JSONEncoding = Encoding(otlpconfig.MarshalJSON)
 )
 
 // Option applies an option to the HTTP client.
@@ -44,10 +62,23 @@ func asHTTPOptions(opts []Option) []otlpconfig.HTTPOption {
 	return converted
 }
 
+// PartialSuccessError is the error describing a partial success response of the
+// OTLP server, which accepted an export request but rejected spans of it or
+// returned a warning. RejectedItems is the number of rejected spans and
+// ErrorMessage the message of the server.
+//
+// It is passed to the function set with WithPartialSuccessHandler, or otherwise
+// to the global error handler. Use errors.As to identify it there.
+type PartialSuccessError = internal.PartialSuccess
+
 // RetryConfig defines configuration for retrying batches in case of export
 // failure using an exponential backoff.
 type RetryConfig retry.Config
 
+// DiskQueueConfig configures the disk queue of the export requests that failed
+// with transient errors. See WithDiskQueue.
+type DiskQueueConfig diskqueue.Config
+
 type wrappedOption struct {
 	otlpconfig.HTTPOption
 }
@@ -93,6 +124,10 @@ func WithEndpoint(endpoint string) Option {
 //
 // If an invalid URL is provided, the default value will be kept.
 //
+// A URL with the unix scheme, such as "unix:///var/run/otel.sock", sends the
+// requests to the Unix domain socket at its path without transport security.
+// Its path is not used as the URL path of the requests (see WithURLPath).
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "localhost:4318" will be used.
 //
@@ -101,11 +136,57 @@ func WithEndpointURL(u string) Option {
 	return wrappedOption{otlpconfig.WithEndpointURL(u)}
 }
 
+// EndpointMode describes how spans are distributed between the endpoints set
+// with WithEndpoints.
+type EndpointMode otlpconfig.EndpointMode
+
+const (
+	// FailoverEndpoints sends all spans to a single endpoint, the first
+	// available one in the order the endpoints are passed. When the endpoint
+	// spans are sent to fails, they are sent to the next one. The first
+	// endpoint is tried again periodically, and spans are sent to it again
+	// once it recovers.
+	FailoverEndpoints = EndpointMode(otlpconfig.FailoverEndpoints)
+	// TraceIDEndpoints sends all the spans of a trace to the same endpoint,
+	// chosen by consistent hashing of their trace ID. This is required by
+	// receivers processing complete traces, like tail sampling collectors.
+	//
+	// Spans are not sent to another endpoint when the one of their trace
+	// fails, their export is retried according to the retry configuration.
+	TraceIDEndpoints = EndpointMode(otlpconfig.TraceIDEndpoints)
+)
+
+// WithEndpoints sets the target endpoints the Exporter sends spans to, and
+// how spans are distributed between them. Every endpoint is specified as a
+// host and optional port, no path or scheme should be included, as with
+// WithEndpoint. The other options, such as the transport security, URL path,
+// and headers, apply to all the endpoints.
+//
+// If this option and WithEndpoint or WithEndpointURL are used, the last used
+// option will take precedence for the endpoints. Passing no endpoints has no
+// effect.
+func WithEndpoints(mode EndpointMode, endpoints ...string) Option {
+	return wrappedOption{otlpconfig.WithEndpoints(otlpconfig.EndpointMode(mode), endpoints)}
+}
+
 // WithCompression tells the driver to compress the sent data.
 func WithCompression(compression Compression) Option {
 	return wrappedOption{otlpconfig.WithCompression(otlpconfig.Compression(compression))}
 }
 
+// WithEncoding tells the driver how to encode the sent data.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_TRACES_PROTOCOL
+// environment variable is set to "http/protobuf" or "http/json", and this
+// option is not passed, that variable value will be used. If both are set,
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL will take precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufEncoding will be used.
+func WithEncoding(encoding Encoding) Option {
+	return wrappedOption{otlpconfig.WithMarshaler(otlpconfig.Marshaler(encoding))}
+}
+
 // WithURLPath allows one to override the default URL path used
 // for sending traces. If unset, default ("/v1/traces") will be used.
 func WithURLPath(urlPath string) Option {
@@ -138,6 +219,45 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{otlpconfig.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the export requests
+// sent by the client. A batch of spans that would encode to a larger request is
+// split into multiple requests, keeping spans grouped by their resource and
+// instrumentation scope. Spans larger than size on their own are dropped and
+// reported to the global error handler.
+//
+// The size is measured using the encoding of the request, protobuf or JSON,
+// before any compression is applied. Use this to stay below the request body limit of the
+// collector or of a proxy in front of it.
+//
+// If unset or not positive, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(size)}
+}
+
+// WithPartialSuccessHandler sets the function called with the partial success
+// responses of the OTLP server. It is called synchronously during the export,
+// so it should not block.
+//
+// If unset, partial successes are passed to the global error handler.
+//
+// Regardless of this option, the number of rejected spans is counted with the
+// otel.sdk.exporter.span.rejected counter of the global MeterProvider.
+func WithPartialSuccessHandler(h func(PartialSuccessError)) Option {
+	return wrappedOption{otlpconfig.WithPartialSuccessHandler(h)}
+}
+
+// WithAuthenticator sets the Authenticator providing the authentication
+// headers of the export requests, for example an Authenticator returned by
+// NewOAuth2Authenticator or NewTokenFileAuthenticator.
+//
+// The headers are set on every HTTP request, including retries, and take
+// precedence over the headers set with WithHeaders.
+//
+// An error of the Authenticator fails the export request, it is not retried.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{otlpconfig.WithAuthenticator(a)}
+}
+
 // WithRetry configures the retry policy for transient errors that may occurs
 // when exporting traces. An exponential back-off algorithm is used to ensure
 // endpoints are not overwhelmed with retries. If unset, the default retry
@@ -147,9 +267,38 @@ func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(rc))}
 }
 
+// WithDiskQueue persists the export requests that failed with transient
+// errors, after any retry, to the directory dq.Dir, created if it does not
+// exist. The persisted requests are sent again in the background, oldest
+// first, once an export request succeeds, and when an exporter is created
+// with the same directory, for instance after a restart.
+//
+// The total size of the persisted requests is bounded by dq.MaxBytes, or by
+// 64 MiB if it is not positive: the oldest requests are dropped to make room
+// for new ones. The directory must not be used by another exporter.
+//
+// Persisted requests can be sent more than once if the process stops while
+// sending them.
+//
+// By default, the export requests that failed are dropped.
+func WithDiskQueue(dq DiskQueueConfig) Option {
+	return wrappedOption{otlpconfig.WithDiskQueue(diskqueue.Config(dq))}
+}
+
 // WithProxy sets the Proxy function the client will use to determine the
 // proxy to use for an HTTP request. If this option is not used, the client
 // will use [http.ProxyFromEnvironment].
 func WithProxy(pf HTTPTransportProxyFunc) Option {
 	return wrappedOption{otlpconfig.WithProxy(otlpconfig.HTTPTransportProxyFunc(pf))}
 }
+
+// WithH2C sets the Exporter to send requests using HTTP/2 over cleartext
+// connections (h2c) with prior knowledge, instead of HTTP/1.1. The endpoint
+// needs to support it, as the OTLP/HTTP receiver of the OpenTelemetry
+// Collector does.
+//
+// This option only applies to insecure connections (see WithInsecure), and
+// proxies are not used with it.
+func WithH2C() Option {
+	return wrappedOption{otlpconfig.WithH2C()}
+}
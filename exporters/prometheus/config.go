@@ -5,6 +5,7 @@ package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
@@ -20,9 +21,12 @@ type config struct {
 	withoutUnits             bool
 	withoutCounterSuffixes   bool
 	readerOpts               []metric.ManualReaderOption
+	aggregationSelector      metric.AggregationSelector
 	disableScopeInfo         bool
 	namespace                string
 	resourceAttributesFilter attribute.Filter
+	escapingScheme           *model.EscapingScheme
+	cacheTTL                 time.Duration
 }
 
 // newConfig creates a validated config configured with options.
@@ -36,6 +40,16 @@ func newConfig(opts ...Option) config {
 		cfg.registerer = prometheus.DefaultRegisterer
 	}
 
+	if cfg.namespace != "" {
+		ns := escapeName(cfg.namespace, cfg.escapingScheme)
+		if !strings.HasSuffix(ns, "_") {
+			// namespace and metric names should be separated with an underscore,
+			// adds a trailing underscore if there is not one already.
+			ns = ns + "_"
+		}
+		cfg.namespace = ns
+	}
+
 	return cfg
 }
 
@@ -66,6 +80,7 @@ func WithRegisterer(reg prometheus.Registerer) Option {
 func WithAggregationSelector(agg metric.AggregationSelector) Option {
 	return optionFunc(func(cfg config) config {
 		cfg.readerOpts = append(cfg.readerOpts, metric.WithAggregationSelector(agg))
+		cfg.aggregationSelector = agg
 		return cfg
 	})
 }
@@ -132,16 +147,6 @@ func WithoutScopeInfo() Option {
 // have special behavior based on their name.
 func WithNamespace(ns string) Option {
 	return optionFunc(func(cfg config) config {
-		if model.NameValidationScheme != model.UTF8Validation {
-			// Only sanitize if prometheus does not support UTF-8.
-			ns = model.EscapeName(ns, model.NameEscapingScheme)
-		}
-		if !strings.HasSuffix(ns, "_") {
-			// namespace and metric names should be separated with an underscore,
-			// adds a trailing underscore if there is not one already.
-			ns = ns + "_"
-		}
-
 		cfg.namespace = ns
 		return cfg
 	})
@@ -157,3 +162,74 @@ func WithResourceAsConstantLabels(resourceFilter attribute.Filter) Option {
 		return cfg
 	})
 }
+
+// WithEscapingScheme configures how the Exporter escapes metric and label
+// names that contain characters not supported by the legacy Prometheus
+// naming rules (e.g. the dots in http.server.request.duration).
+//
+// With [model.NoEscaping], names are exported unchanged and the escaping is
+// negotiated with each scraper instead: a handler created with
+// [github.com/prometheus/client_golang/prometheus/promhttp] escapes names
+// with the scheme requested in the Accept header of the scrape
+// (allow-utf-8, underscores, dots, or values), or with
+// [model.NameEscapingScheme] if none is requested. This requires
+// [model.NameValidationScheme] to be set to [model.UTF8Validation], otherwise
+// the Prometheus client rejects the unescaped names.
+//
+// With [model.UnderscoreEscaping], [model.DotsEscaping], or
+// [model.ValueEncodingEscaping], names are always escaped using that scheme
+// before the namespace and suffixes are added.
+//
+// By default, names are not escaped if [model.NameValidationScheme] is
+// [model.UTF8Validation], and are otherwise escaped with
+// [model.NameEscapingScheme].
+func WithEscapingScheme(scheme model.EscapingScheme) Option {
+	return optionFunc(func(cfg config) config {
+		switch scheme {
+		case model.NoEscaping, model.UnderscoreEscaping, model.DotsEscaping, model.ValueEncodingEscaping:
+			cfg.escapingScheme = &scheme
+		}
+		return cfg
+	})
+}
+
+// WithCacheTTL configures the Exporter to reuse the metrics it converts
+// during a collection for all collections within ttl. This reduces the cost
+// of collecting when multiple Prometheus servers scrape the same target (e.g.
+// a highly available Prometheus pair). The metrics returned can be up to ttl
+// old.
+//
+// When a cache is used, all metric families are converted on each
+// collection, including when only some of them are requested using the
+// "name[]" query parameter of the Exporter's Handler.
+//
+// By default, or if ttl is less than or equal to zero, metrics are collected
+// and converted for every collection.
+func WithCacheTTL(ttl time.Duration) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.cacheTTL = ttl
+		return cfg
+	})
+}
+
+// noEscaping returns if names are exported without being escaped when using
+// the scheme.
+func noEscaping(scheme *model.EscapingScheme) bool {
+	if scheme != nil {
+		return *scheme == model.NoEscaping
+	}
+	return model.NameValidationScheme == model.UTF8Validation
+}
+
+// escapeName returns name escaped with the scheme if it is not nil, or with
+// the default escaping of the Prometheus client otherwise.
+func escapeName(name string, scheme *model.EscapingScheme) string {
+	if scheme != nil {
+		return model.EscapeName(name, *scheme)
+	}
+	if model.NameValidationScheme == model.UTF8Validation {
+		// Do not sanitize if prometheus supports UTF-8.
+		return name
+	}
+	return model.EscapeName(name, model.NameEscapingScheme)
+}
@@ -6,8 +6,10 @@ package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -47,8 +49,9 @@ func TestNewConfig(t *testing.T) {
 				WithAggregationSelector(aggregationSelector),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
-				readerOpts: []metric.ManualReaderOption{metric.WithAggregationSelector(aggregationSelector)},
+				registerer:          prometheus.DefaultRegisterer,
+				readerOpts:          []metric.ManualReaderOption{metric.WithAggregationSelector(aggregationSelector)},
+				aggregationSelector: aggregationSelector,
 			},
 		},
 		{
@@ -75,6 +78,7 @@ func TestNewConfig(t *testing.T) {
 					metric.WithAggregationSelector(aggregationSelector),
 					metric.WithProducer(producer),
 				},
+				aggregationSelector: aggregationSelector,
 			},
 		},
 		{
@@ -136,6 +140,59 @@ func TestNewConfig(t *testing.T) {
 				namespace:  "test_",
 			},
 		},
+		{
+			name: "with escaping scheme",
+			options: []Option{
+				WithEscapingScheme(model.DotsEscaping),
+			},
+			wantConfig: config{
+				registerer:     prometheus.DefaultRegisterer,
+				escapingScheme: ptr(model.DotsEscaping),
+			},
+		},
+		{
+			name: "with invalid escaping scheme",
+			options: []Option{
+				WithEscapingScheme(model.EscapingScheme(-1)),
+			},
+			wantConfig: config{
+				registerer: prometheus.DefaultRegisterer,
+			},
+		},
+		{
+			name: "with namespace escaped by escaping scheme",
+			options: []Option{
+				WithNamespace("test.ns"),
+				WithEscapingScheme(model.DotsEscaping),
+			},
+			wantConfig: config{
+				registerer:     prometheus.DefaultRegisterer,
+				namespace:      "test_dot_ns_",
+				escapingScheme: ptr(model.DotsEscaping),
+			},
+		},
+		{
+			name: "with namespace not escaped",
+			options: []Option{
+				WithNamespace("test.ns"),
+				WithEscapingScheme(model.NoEscaping),
+			},
+			wantConfig: config{
+				registerer:     prometheus.DefaultRegisterer,
+				namespace:      "test.ns_",
+				escapingScheme: ptr(model.NoEscaping),
+			},
+		},
+		{
+			name: "with cache TTL",
+			options: []Option{
+				WithCacheTTL(time.Minute),
+			},
+			wantConfig: config{
+				registerer: prometheus.DefaultRegisterer,
+				cacheTTL:   time.Minute,
+			},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -144,12 +201,20 @@ func TestNewConfig(t *testing.T) {
 			assert.Equal(t, len(tt.wantConfig.readerOpts), len(cfg.readerOpts))
 			cfg.readerOpts = nil
 			tt.wantConfig.readerOpts = nil
+			// only check the presence of aggregationSelector, since funcs are not comparable
+			assert.Equal(t, tt.wantConfig.aggregationSelector != nil, cfg.aggregationSelector != nil)
+			cfg.aggregationSelector = nil
+			tt.wantConfig.aggregationSelector = nil
 
 			assert.Equal(t, tt.wantConfig, cfg)
 		})
 	}
 }
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
 type noopProducer struct{}
 
 func (*noopProducer) Produce(ctx context.Context) ([]metricdata.ScopeMetrics, error) {
@@ -4,4 +4,8 @@
 // Package prometheus provides a Prometheus Exporter that converts
 // OTLP metrics into the Prometheus exposition format and implements
 // prometheus.Collector to provide a handler for these metrics.
+//
+// For processes that exit before they can be scraped, the TextfileExporter
+// writes metrics to a file that can be read by the textfile collector of the
+// Prometheus node_exporter.
 package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
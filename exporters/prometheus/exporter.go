@@ -11,6 +11,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
@@ -19,6 +20,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/promconv"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -46,6 +48,8 @@ var errScopeInvalid = errors.New("invalid scope")
 // interface for easy instantiation with a MeterProvider.
 type Exporter struct {
 	metric.Reader
+
+	collector *collector
 }
 
 // MarshalLog returns logging data about the Exporter.
@@ -73,17 +77,26 @@ var _ metric.Reader = &Exporter{}
 type keyVals struct {
 	keys []string
 	vals []string
+
+	// escapingScheme is used to escape the attribute keys of data points.
+	escapingScheme *model.EscapingScheme
+}
+
+// source provides the metric data a collector converts.
+type source interface {
+	Collect(context.Context, *metricdata.ResourceMetrics) error
 }
 
 // collector is used to implement prometheus.Collector.
 type collector struct {
-	reader metric.Reader
+	reader source
 
 	withoutUnits             bool
 	withoutCounterSuffixes   bool
 	disableScopeInfo         bool
 	namespace                string
 	resourceAttributesFilter attribute.Filter
+	escapingScheme           *model.EscapingScheme
 
 	mu                sync.Mutex // mu protects all members below from the concurrent access.
 	disableTargetInfo bool
@@ -92,6 +105,11 @@ type collector struct {
 	scopeInfosInvalid map[instrumentation.Scope]struct{}
 	metricFamilies    map[string]*dto.MetricFamily
 	resourceKeyVals   keyVals
+
+	cacheTTL  time.Duration
+	cacheMu   sync.Mutex // cacheMu protects all members below from the concurrent access.
+	cache     []family
+	cacheTime time.Time
 }
 
 // prometheus counters MUST have a _total suffix by default:
@@ -107,7 +125,23 @@ func New(opts ...Option) (*Exporter, error) {
 	// TODO (#3244): Enable some way to configure the reader, but not change temporality.
 	reader := metric.NewManualReader(cfg.readerOpts...)
 
-	collector := &collector{
+	collector := newCollector(cfg, reader)
+	if err := cfg.registerer.Register(collector); err != nil {
+		return nil, fmt.Errorf("cannot register the collector: %w", err)
+	}
+
+	e := &Exporter{
+		Reader:    reader,
+		collector: collector,
+	}
+
+	return e, nil
+}
+
+// newCollector returns a collector configured with cfg that converts the
+// metric data of reader.
+func newCollector(cfg config, reader source) *collector {
+	return &collector{
 		reader:                   reader,
 		disableTargetInfo:        cfg.disableTargetInfo,
 		withoutUnits:             cfg.withoutUnits,
@@ -118,17 +152,9 @@ func New(opts ...Option) (*Exporter, error) {
 		metricFamilies:           make(map[string]*dto.MetricFamily),
 		namespace:                cfg.namespace,
 		resourceAttributesFilter: cfg.resourceAttributesFilter,
+		escapingScheme:           cfg.escapingScheme,
+		cacheTTL:                 cfg.cacheTTL,
 	}
-
-	if err := cfg.registerer.Register(collector); err != nil {
-		return nil, fmt.Errorf("cannot register the collector: %w", err)
-	}
-
-	e := &Exporter{
-		Reader: reader,
-	}
-
-	return e, nil
 }
 
 // Describe implements prometheus.Collector.
@@ -144,16 +170,71 @@ func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 //
 // This method is safe to call concurrently.
 func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, f := range c.families(nil) {
+		for _, m := range f.metrics {
+			ch <- m
+		}
+	}
+}
+
+// family is the converted metrics of a metric family.
+type family struct {
+	name    string
+	metrics []prometheus.Metric
+}
+
+// families returns the metric families with names. If names is empty, all
+// metric families are returned.
+//
+// If a cache TTL is configured, the metric families of all metrics are
+// converted and reused until the TTL expires.
+func (c *collector) families(names map[string]struct{}) []family {
+	if c.cacheTTL <= 0 {
+		return c.convert(names)
+	}
+
+	// Hold the lock while converting so concurrent scrapes wait for and reuse
+	// the result of a single collection.
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if now := time.Now(); c.cache == nil || now.Sub(c.cacheTime) >= c.cacheTTL {
+		c.cache = c.convert(nil)
+		c.cacheTime = now
+	}
+	if len(names) == 0 {
+		return c.cache
+	}
+	out := make([]family, 0, len(names))
+	for _, f := range c.cache {
+		if _, ok := names[f.name]; ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// convert collects metrics from the reader and returns the metric families
+// with names. If names is empty, all metric families are returned.
+func (c *collector) convert(names map[string]struct{}) []family {
+	selected := func(name string) bool {
+		if len(names) == 0 {
+			return true
+		}
+		_, ok := names[name]
+		return ok
+	}
+
 	// TODO (#3047): Use a sync.Pool instead of allocating metrics every Collect.
 	metrics := metricdata.ResourceMetrics{}
 	err := c.reader.Collect(context.TODO(), &metrics)
 	if err != nil {
 		if errors.Is(err, metric.ErrReaderShutdown) {
-			return
+			return nil
 		}
 		otel.Handle(err)
 		if errors.Is(err, metric.ErrReaderNotRegistered) {
-			return
+			return nil
 		}
 	}
 
@@ -165,7 +246,7 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 		defer c.mu.Unlock()
 
 		if c.targetInfo == nil && !c.disableTargetInfo {
-			targetInfo, err := createInfoMetric(targetInfoMetricName, targetInfoDescription, metrics.Resource)
+			targetInfo, err := c.createInfoMetric(targetInfoMetricName, targetInfoDescription, metrics.Resource)
 			if err != nil {
 				// If the target info metric is invalid, disable sending it.
 				c.disableTargetInfo = true
@@ -177,19 +258,37 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 		}
 	}()
 
-	if !c.disableTargetInfo {
-		ch <- c.targetInfo
+	var families []family
+	if !c.disableTargetInfo && selected(targetInfoMetricName) {
+		families = append(families, family{
+			name:    targetInfoMetricName,
+			metrics: []prometheus.Metric{c.targetInfo},
+		})
 	}
 
 	if c.resourceAttributesFilter != nil && len(c.resourceKeyVals.keys) == 0 {
 		c.createResourceAttributes(metrics.Resource)
 	}
 
+	// Metrics of the same family can be produced by multiple scopes. Add
+	// them all to the same family.
+	index := make(map[string]int)
+	add := func(name string, m ...prometheus.Metric) {
+		i, ok := index[name]
+		if !ok {
+			i = len(families)
+			index[name] = i
+			families = append(families, family{name: name})
+		}
+		families[i].metrics = append(families[i].metrics, m...)
+	}
+
 	for _, scopeMetrics := range metrics.ScopeMetrics {
 		n := len(c.resourceKeyVals.keys) + 2 // resource attrs + scope name + scope version
 		kv := keyVals{
-			keys: make([]string, 0, n),
-			vals: make([]string, 0, n),
+			keys:           make([]string, 0, n),
+			vals:           make([]string, 0, n),
+			escapingScheme: c.escapingScheme,
 		}
 
 		if !c.disableScopeInfo {
@@ -203,7 +302,9 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 				continue
 			}
 
-			ch <- scopeInfo
+			if selected(scopeInfoMetricName) {
+				add(scopeInfoMetricName, scopeInfo)
+			}
 
 			kv.keys = append(kv.keys, scopeNameLabel, scopeVersionLabel)
 			kv.vals = append(kv.vals, scopeMetrics.Scope.Name, scopeMetrics.Scope.Version)
@@ -218,6 +319,9 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 				continue
 			}
 			name := c.getName(m, typ)
+			if !selected(name) {
+				continue
+			}
 
 			drop, help := c.validateMetrics(name, m.Description, typ)
 			if drop {
@@ -228,27 +332,36 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 				m.Description = help
 			}
 
+			var pm []prometheus.Metric
 			switch v := m.Data.(type) {
 			case metricdata.Histogram[int64]:
-				addHistogramMetric(ch, v, m, name, kv)
+				pm = addHistogramMetric(pm, v, m, name, kv)
 			case metricdata.Histogram[float64]:
-				addHistogramMetric(ch, v, m, name, kv)
+				pm = addHistogramMetric(pm, v, m, name, kv)
+			case metricdata.ExponentialHistogram[int64]:
+				pm = addExponentialHistogramMetric(pm, v, m, name, kv)
+			case metricdata.ExponentialHistogram[float64]:
+				pm = addExponentialHistogramMetric(pm, v, m, name, kv)
 			case metricdata.Sum[int64]:
-				addSumMetric(ch, v, m, name, kv)
+				pm = addSumMetric(pm, v, m, name, kv)
 			case metricdata.Sum[float64]:
-				addSumMetric(ch, v, m, name, kv)
+				pm = addSumMetric(pm, v, m, name, kv)
 			case metricdata.Gauge[int64]:
-				addGaugeMetric(ch, v, m, name, kv)
+				pm = addGaugeMetric(pm, v, m, name, kv)
 			case metricdata.Gauge[float64]:
-				addGaugeMetric(ch, v, m, name, kv)
+				pm = addGaugeMetric(pm, v, m, name, kv)
+			case metricdata.Summary:
+				pm = addSummaryMetric(pm, v, m, name, kv)
 			}
+			add(name, pm...)
 		}
 	}
+	return families
 }
 
-func addHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogram metricdata.Histogram[N], m metricdata.Metrics, name string, kv keyVals) {
+func addHistogramMetric[N int64 | float64](metrics []prometheus.Metric, histogram metricdata.Histogram[N], m metricdata.Metrics, name string, kv keyVals) []prometheus.Metric {
 	for _, dp := range histogram.DataPoints {
-		keys, values := getAttrs(dp.Attributes)
+		keys, values := getAttrs(dp.Attributes, kv.escapingScheme)
 		keys = append(keys, kv.keys...)
 		values = append(values, kv.vals...)
 
@@ -266,18 +379,61 @@ func addHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogra
 			continue
 		}
 		m = addExemplars(m, dp.Exemplars)
-		ch <- m
+		metrics = append(metrics, m)
 	}
+	return metrics
 }
 
-func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, name string, kv keyVals) {
+// addExponentialHistogramMetric adds the exponential histogram data points as
+// Prometheus native histograms.
+func addExponentialHistogramMetric[N int64 | float64](metrics []prometheus.Metric, histogram metricdata.ExponentialHistogram[N], m metricdata.Metrics, name string, kv keyVals) []prometheus.Metric {
+	for _, dp := range histogram.DataPoints {
+		keys, values := getAttrs(dp.Attributes, kv.escapingScheme)
+		keys = append(keys, kv.keys...)
+		values = append(values, kv.vals...)
+
+		desc := prometheus.NewDesc(name, m.Description, keys, nil)
+		// A histogram without classic buckets validates the description and
+		// label values. The native histogram is added to it when written.
+		m, err := prometheus.NewConstHistogram(desc, dp.Count, float64(dp.Sum), nil, values...)
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		metrics = append(metrics, newNativeHistogram(m, dp))
+	}
+	return metrics
+}
+
+func addSummaryMetric(metrics []prometheus.Metric, summary metricdata.Summary, m metricdata.Metrics, name string, kv keyVals) []prometheus.Metric {
+	for _, dp := range summary.DataPoints {
+		keys, values := getAttrs(dp.Attributes, kv.escapingScheme)
+		keys = append(keys, kv.keys...)
+		values = append(values, kv.vals...)
+
+		desc := prometheus.NewDesc(name, m.Description, keys, nil)
+		quantiles := make(map[float64]float64, len(dp.QuantileValues))
+		for _, q := range dp.QuantileValues {
+			quantiles[q.Quantile] = q.Value
+		}
+		m, err := prometheus.NewConstSummary(desc, dp.Count, dp.Sum, quantiles, values...)
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+func addSumMetric[N int64 | float64](metrics []prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, name string, kv keyVals) []prometheus.Metric {
 	valueType := prometheus.CounterValue
 	if !sum.IsMonotonic {
 		valueType = prometheus.GaugeValue
 	}
 
 	for _, dp := range sum.DataPoints {
-		keys, values := getAttrs(dp.Attributes)
+		keys, values := getAttrs(dp.Attributes, kv.escapingScheme)
 		keys = append(keys, kv.keys...)
 		values = append(values, kv.vals...)
 
@@ -292,13 +448,14 @@ func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata
 		if valueType != prometheus.GaugeValue {
 			m = addExemplars(m, dp.Exemplars)
 		}
-		ch <- m
+		metrics = append(metrics, m)
 	}
+	return metrics
 }
 
-func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metricdata.Gauge[N], m metricdata.Metrics, name string, kv keyVals) {
+func addGaugeMetric[N int64 | float64](metrics []prometheus.Metric, gauge metricdata.Gauge[N], m metricdata.Metrics, name string, kv keyVals) []prometheus.Metric {
 	for _, dp := range gauge.DataPoints {
-		keys, values := getAttrs(dp.Attributes)
+		keys, values := getAttrs(dp.Attributes, kv.escapingScheme)
 		keys = append(keys, kv.keys...)
 		values = append(values, kv.vals...)
 
@@ -308,18 +465,19 @@ func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metric
 			otel.Handle(err)
 			continue
 		}
-		ch <- m
+		metrics = append(metrics, m)
 	}
+	return metrics
 }
 
 // getAttrs converts the attribute.Set to two lists of matching Prometheus-style
 // keys and values.
-func getAttrs(attrs attribute.Set) ([]string, []string) {
+func getAttrs(attrs attribute.Set, scheme *model.EscapingScheme) ([]string, []string) {
 	keys := make([]string, 0, attrs.Len())
 	values := make([]string, 0, attrs.Len())
 	itr := attrs.Iter()
 
-	if model.NameValidationScheme == model.UTF8Validation {
+	if noEscaping(scheme) {
 		// Do not perform sanitization if prometheus supports UTF-8.
 		for itr.Next() {
 			kv := itr.Attribute()
@@ -332,7 +490,7 @@ func getAttrs(attrs attribute.Set) ([]string, []string) {
 		keysMap := make(map[string][]string)
 		for itr.Next() {
 			kv := itr.Attribute()
-			key := model.EscapeName(string(kv.Key), model.NameEscapingScheme)
+			key := escapeName(string(kv.Key), scheme)
 			if _, ok := keysMap[key]; !ok {
 				keysMap[key] = []string{kv.Value.Emit()}
 			} else {
@@ -349,8 +507,8 @@ func getAttrs(attrs attribute.Set) ([]string, []string) {
 	return keys, values
 }
 
-func createInfoMetric(name, description string, res *resource.Resource) (prometheus.Metric, error) {
-	keys, values := getAttrs(*res.Set())
+func (c *collector) createInfoMetric(name, description string, res *resource.Resource) (prometheus.Metric, error) {
+	keys, values := getAttrs(*res.Set(), c.escapingScheme)
 	desc := prometheus.NewDesc(name, description, keys, nil)
 	return prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(1), values...)
 }
@@ -360,49 +518,9 @@ func createScopeInfoMetric(scope instrumentation.Scope) (prometheus.Metric, erro
 	return prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(1), scope.Name, scope.Version)
 }
 
-var unitSuffixes = map[string]string{
-	// Time
-	"d":   "_days",
-	"h":   "_hours",
-	"min": "_minutes",
-	"s":   "_seconds",
-	"ms":  "_milliseconds",
-	"us":  "_microseconds",
-	"ns":  "_nanoseconds",
-
-	// Bytes
-	"By":   "_bytes",
-	"KiBy": "_kibibytes",
-	"MiBy": "_mebibytes",
-	"GiBy": "_gibibytes",
-	"TiBy": "_tibibytes",
-	"KBy":  "_kilobytes",
-	"MBy":  "_megabytes",
-	"GBy":  "_gigabytes",
-	"TBy":  "_terabytes",
-
-	// SI
-	"m": "_meters",
-	"V": "_volts",
-	"A": "_amperes",
-	"J": "_joules",
-	"W": "_watts",
-	"g": "_grams",
-
-	// Misc
-	"Cel": "_celsius",
-	"Hz":  "_hertz",
-	"1":   "_ratio",
-	"%":   "_percent",
-}
-
 // getName returns the sanitized name, prefixed with the namespace and suffixed with unit.
 func (c *collector) getName(m metricdata.Metrics, typ *dto.MetricType) string {
-	name := m.Name
-	if model.NameValidationScheme != model.UTF8Validation {
-		// Only sanitize if prometheus does not support UTF-8.
-		name = model.EscapeName(name, model.NameEscapingScheme)
-	}
+	name := escapeName(m.Name, c.escapingScheme)
 	addCounterSuffix := !c.withoutCounterSuffixes && *typ == dto.MetricType_COUNTER
 	if addCounterSuffix {
 		// Remove the _total suffix here, as we will re-add the total suffix
@@ -412,7 +530,7 @@ func (c *collector) getName(m metricdata.Metrics, typ *dto.MetricType) string {
 	if c.namespace != "" {
 		name = c.namespace + name
 	}
-	if suffix, ok := unitSuffixes[m.Unit]; ok && !c.withoutUnits && !strings.HasSuffix(name, suffix) {
+	if suffix, ok := promconv.UnitSuffix(m.Unit); ok && !c.withoutUnits && !strings.HasSuffix(name, suffix) {
 		name += suffix
 	}
 	if addCounterSuffix {
@@ -423,7 +541,8 @@ func (c *collector) getName(m metricdata.Metrics, typ *dto.MetricType) string {
 
 func (c *collector) metricType(m metricdata.Metrics) *dto.MetricType {
 	switch v := m.Data.(type) {
-	case metricdata.Histogram[int64], metricdata.Histogram[float64]:
+	case metricdata.Histogram[int64], metricdata.Histogram[float64],
+		metricdata.ExponentialHistogram[int64], metricdata.ExponentialHistogram[float64]:
 		return dto.MetricType_HISTOGRAM.Enum()
 	case metricdata.Sum[float64]:
 		if v.IsMonotonic {
@@ -437,6 +556,8 @@ func (c *collector) metricType(m metricdata.Metrics) *dto.MetricType {
 		return dto.MetricType_GAUGE.Enum()
 	case metricdata.Gauge[int64], metricdata.Gauge[float64]:
 		return dto.MetricType_GAUGE.Enum()
+	case metricdata.Summary:
+		return dto.MetricType_SUMMARY.Enum()
 	}
 	return nil
 }
@@ -446,7 +567,7 @@ func (c *collector) createResourceAttributes(res *resource.Resource) {
 	defer c.mu.Unlock()
 
 	resourceAttrs, _ := res.Set().Filter(c.resourceAttributesFilter)
-	resourceKeys, resourceValues := getAttrs(resourceAttrs)
+	resourceKeys, resourceValues := getAttrs(resourceAttrs, c.escapingScheme)
 	c.resourceKeyVals = keyVals{keys: resourceKeys, vals: resourceValues}
 }
 
@@ -518,14 +639,10 @@ func addExemplars[N int64 | float64](m prometheus.Metric, exemplars []metricdata
 	}
 	promExemplars := make([]prometheus.Exemplar, len(exemplars))
 	for i, exemplar := range exemplars {
-		labels := attributesToLabels(exemplar.FilteredAttributes)
-		// Overwrite any existing trace ID or span ID attributes
-		labels[traceIDExemplarKey] = hex.EncodeToString(exemplar.TraceID[:])
-		labels[spanIDExemplarKey] = hex.EncodeToString(exemplar.SpanID[:])
 		promExemplars[i] = prometheus.Exemplar{
 			Value:     float64(exemplar.Value),
 			Timestamp: exemplar.Time,
-			Labels:    labels,
+			Labels:    exemplarLabels(exemplar),
 		}
 	}
 	metricWithExemplar, err := prometheus.NewMetricWithExemplars(m, promExemplars...)
@@ -538,6 +655,15 @@ func addExemplars[N int64 | float64](m prometheus.Metric, exemplars []metricdata
 	return metricWithExemplar
 }
 
+// exemplarLabels returns the Prometheus labels of exemplar.
+func exemplarLabels[N int64 | float64](exemplar metricdata.Exemplar[N]) prometheus.Labels {
+	labels := attributesToLabels(exemplar.FilteredAttributes)
+	// Overwrite any existing trace ID or span ID attributes
+	labels[traceIDExemplarKey] = hex.EncodeToString(exemplar.TraceID[:])
+	labels[spanIDExemplarKey] = hex.EncodeToString(exemplar.SpanID[:])
+	return labels
+}
+
 func attributesToLabels(attrs []attribute.KeyValue) prometheus.Labels {
 	labels := make(map[string]string)
 	for _, attr := range attrs {
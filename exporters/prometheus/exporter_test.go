@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
@@ -192,6 +195,26 @@ func TestPrometheusExporter(t *testing.T) {
 				histogram.Record(ctx, 105, opt)
 			},
 		},
+		{
+			name:         "summary",
+			expectedFile: "testdata/summary.txt",
+			recordMetrics: func(ctx context.Context, meter otelmetric.Meter) {
+				opt := otelmetric.WithAttributes(
+					attribute.Key("A").String("B"),
+					attribute.Key("C").String("D"),
+				)
+				histogram, err := meter.Float64Histogram(
+					"summary_baz",
+					otelmetric.WithDescription("a very nice summary"),
+					otelmetric.WithUnit("s"),
+				)
+				require.NoError(t, err)
+				histogram.Record(ctx, 23, opt)
+				histogram.Record(ctx, 7, opt)
+				histogram.Record(ctx, 101, opt)
+				histogram.Record(ctx, 105, opt)
+			},
+		},
 		{
 			name:         "sanitized attributes to labels",
 			expectedFile: "testdata/sanitized_labels.txt",
@@ -431,6 +454,45 @@ func TestPrometheusExporter(t *testing.T) {
 				counter.Add(ctx, 5, otelmetric.WithAttributeSet(attrs2))
 			},
 		},
+		{
+			name:         "counter with dots escaping",
+			expectedFile: "testdata/counter_dots_escaping.txt",
+			options:      []Option{WithEscapingScheme(model.DotsEscaping)},
+			recordMetrics: func(ctx context.Context, meter otelmetric.Meter) {
+				opt := otelmetric.WithAttributes(
+					attribute.Key("A.G").String("B"),
+					attribute.Key("C_H").String("D"),
+				)
+				counter, err := meter.Float64Counter(
+					"foo.things",
+					otelmetric.WithDescription("a simple counter"),
+					otelmetric.WithUnit("s"),
+				)
+				require.NoError(t, err)
+				counter.Add(ctx, 5, opt)
+				counter.Add(ctx, 10.3, opt)
+			},
+		},
+		{
+			name:         "counter utf-8 with underscore escaping",
+			expectedFile: "testdata/counter_utf8_underscore_escaping.txt",
+			enableUTF8:   true,
+			options:      []Option{WithEscapingScheme(model.UnderscoreEscaping)},
+			recordMetrics: func(ctx context.Context, meter otelmetric.Meter) {
+				opt := otelmetric.WithAttributes(
+					attribute.Key("A.G").String("B"),
+					attribute.Key("C.H").String("D"),
+				)
+				counter, err := meter.Float64Counter(
+					"foo.things",
+					otelmetric.WithDescription("a simple counter"),
+					otelmetric.WithUnit("s"),
+				)
+				require.NoError(t, err)
+				counter.Add(ctx, 5, opt)
+				counter.Add(ctx, 10.3, opt)
+			},
+		},
 		{
 			name:         "non-monotonic sum does not add exemplars",
 			expectedFile: "testdata/non_monotonic_sum_does_not_add_exemplars.txt",
@@ -509,6 +571,12 @@ func TestPrometheusExporter(t *testing.T) {
 						Boundaries: []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 1000},
 					}},
 				)),
+				metric.WithView(metric.NewView(
+					metric.Instrument{Name: "summary_*"},
+					metric.Stream{Aggregation: metric.AggregationSummary{
+						Quantiles: []float64{0, 0.5, 1},
+					}},
+				)),
 			)
 			meter := provider.Meter(
 				"testmeter",
@@ -1060,3 +1128,57 @@ func TestExemplars(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapingSchemeNegotiation(t *testing.T) {
+	model.NameValidationScheme = model.UTF8Validation
+	defer func() {
+		// Reset to defaults
+		model.NameValidationScheme = model.LegacyValidation
+	}()
+
+	registry := prometheus.NewRegistry()
+	exporter, err := New(
+		WithRegisterer(registry),
+		WithEscapingScheme(model.NoEscaping),
+		WithoutTargetInfo(),
+		WithoutScopeInfo(),
+	)
+	require.NoError(t, err)
+
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	meter := provider.Meter("testmeter")
+	counter, err := meter.Int64Counter("foo.things", otelmetric.WithUnit("s"))
+	require.NoError(t, err)
+	counter.Add(context.Background(), 5, otelmetric.WithAttributes(attribute.String("A.B", "C")))
+
+	srv := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		escaping string
+		want     string
+	}{
+		{escaping: "", want: `foo_things_seconds_total{A_B="C"} 5`},
+		{escaping: "allow-utf-8", want: `{"foo.things_seconds_total","A.B"="C"} 5`},
+		{escaping: "underscores", want: `foo_things_seconds_total{A_B="C"} 5`},
+		{escaping: "dots", want: `foo_dot_things__seconds__total{A_dot_B="C"} 5`},
+		{escaping: "values", want: `U__foo_2e_things_seconds_total{U__A_2e_B="C"} 5`},
+	} {
+		t.Run(tc.escaping, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL, http.NoBody)
+			require.NoError(t, err)
+			accept := "text/plain;version=0.0.4"
+			if tc.escaping != "" {
+				accept += ";escaping=" + tc.escaping
+			}
+			req.Header.Set("Accept", accept)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tc.want)
+		})
+	}
+}
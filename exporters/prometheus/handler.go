@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// nameParam is the query parameter used to select the metric families
+// served by the Handler.
+const nameParam = "name[]"
+
+// Handler returns an http.Handler that serves the metrics of the Exporter
+// using opts.
+//
+// The metric families served can be selected with the "name[]" query
+// parameter, which can be repeated. For example,
+// "/metrics?name[]=target_info&name[]=http_server_request_duration_seconds"
+// only serves the target_info and http_server_request_duration_seconds
+// metric families. Metrics of families that are not selected are not
+// converted, unless a cache is configured with WithCacheTTL. If no name is
+// selected, all metric families are served.
+//
+// Only the metrics of the Exporter are served, not the metrics of other
+// collectors registered with the Registerer the Exporter was registered
+// with.
+func (e *Exporter) Handler(opts promhttp.HandlerOpts) http.Handler {
+	// A handler is built for each request to only gather the selected
+	// families, the limit of concurrent requests is enforced here instead so
+	// it is shared by all the requests.
+	var inFlightSem chan struct{}
+	if opts.MaxRequestsInFlight > 0 {
+		inFlightSem = make(chan struct{}, opts.MaxRequestsInFlight)
+	}
+	limit := opts.MaxRequestsInFlight
+	opts.MaxRequestsInFlight = 0
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if inFlightSem != nil {
+			select {
+			case inFlightSem <- struct{}{}:
+				defer func() { <-inFlightSem }()
+			default:
+				http.Error(w, fmt.Sprintf(
+					"Limit of concurrent requests reached (%d), try again later.", limit,
+				), http.StatusServiceUnavailable)
+				return
+			}
+		}
+
+		var names map[string]struct{}
+		if values := r.URL.Query()[nameParam]; len(values) > 0 {
+			names = make(map[string]struct{}, len(values))
+			for _, v := range values {
+				names[v] = struct{}{}
+			}
+		}
+		g := selectedGatherer{collector: e.collector, names: names}
+		promhttp.HandlerFor(g, opts).ServeHTTP(w, r)
+	})
+}
+
+// selectedGatherer is a prometheus.Gatherer that only gathers the metric
+// families with names from a collector.
+type selectedGatherer struct {
+	collector *collector
+	names     map[string]struct{}
+}
+
+// Gather implements prometheus.Gatherer.
+func (g selectedGatherer) Gather() ([]*dto.MetricFamily, error) {
+	reg := prometheus.NewRegistry()
+	// An unchecked collector registered with a new registry cannot fail to
+	// register.
+	_ = reg.Register(selectedCollector(g))
+	return reg.Gather()
+}
+
+// selectedCollector is a prometheus.Collector that only collects the metric
+// families with names from a collector.
+type selectedCollector struct {
+	collector *collector
+	names     map[string]struct{}
+}
+
+// Describe implements prometheus.Collector.
+func (c selectedCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c selectedCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, f := range c.collector.families(c.names) {
+		for _, m := range f.metrics {
+			ch <- m
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func scrape(t *testing.T, srv *httptest.Server, names ...string) string {
+	t.Helper()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	if len(names) > 0 {
+		u.RawQuery = url.Values{nameParam: names}.Encode()
+	}
+	resp, err := http.Get(u.String()) // nolint: noctx  // Test request.
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHandlerNameSelection(t *testing.T) {
+	exporter, err := New(WithRegisterer(prometheus.NewRegistry()), WithoutScopeInfo())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	meter := provider.Meter("testmeter")
+	counter, err := meter.Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(ctx, 1)
+	gauge, err := meter.Int64Gauge("queue")
+	require.NoError(t, err)
+	gauge.Record(ctx, 2)
+
+	srv := httptest.NewServer(exporter.Handler(promhttp.HandlerOpts{}))
+	defer srv.Close()
+
+	got := scrape(t, srv)
+	assert.Contains(t, got, "requests_total 1")
+	assert.Contains(t, got, "queue 2")
+	assert.Contains(t, got, targetInfoMetricName)
+
+	got = scrape(t, srv, "requests_total")
+	assert.Contains(t, got, "requests_total 1")
+	assert.NotContains(t, got, "queue")
+	assert.NotContains(t, got, targetInfoMetricName)
+
+	got = scrape(t, srv, "queue", targetInfoMetricName)
+	assert.NotContains(t, got, "requests_total")
+	assert.Contains(t, got, "queue 2")
+	assert.Contains(t, got, targetInfoMetricName)
+
+	assert.Empty(t, scrape(t, srv, "unknown"))
+}
+
+func TestHandlerCache(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ttl     time.Duration
+		want    string
+		wantSel string
+	}{
+		{name: "NoCache", want: "requests_total 3", wantSel: "requests_total 6"},
+		{name: "Cached", ttl: time.Hour, want: "requests_total 1", wantSel: "requests_total 1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter, err := New(WithRegisterer(prometheus.NewRegistry()), WithoutScopeInfo(), WithCacheTTL(tc.ttl))
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			provider := metric.NewMeterProvider(metric.WithReader(exporter))
+			counter, err := provider.Meter("testmeter").Int64Counter("requests")
+			require.NoError(t, err)
+
+			srv := httptest.NewServer(exporter.Handler(promhttp.HandlerOpts{}))
+			defer srv.Close()
+
+			counter.Add(ctx, 1)
+			assert.Contains(t, scrape(t, srv), "requests_total 1")
+
+			counter.Add(ctx, 2)
+			assert.Contains(t, scrape(t, srv), tc.want)
+
+			// Selected families are served from the same cache.
+			counter.Add(ctx, 3)
+			assert.Contains(t, scrape(t, srv, "requests_total"), tc.wantSel)
+		})
+	}
+}
+
+func TestCollectorCacheExpires(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	exporter, err := New(WithRegisterer(registry), WithoutScopeInfo(), WithCacheTTL(time.Nanosecond))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	counter, err := provider.Meter("testmeter").Int64Counter("requests")
+	require.NoError(t, err)
+
+	value := func() float64 {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		for _, f := range families {
+			if f.GetName() == "requests_total" {
+				return f.GetMetric()[0].GetCounter().GetValue()
+			}
+		}
+		return 0
+	}
+
+	counter.Add(ctx, 1)
+	assert.Equal(t, float64(1), value())
+	time.Sleep(time.Millisecond)
+	counter.Add(ctx, 1)
+	assert.Equal(t, float64(2), value())
+}
+
+func TestHandlerMaxRequestsInFlight(t *testing.T) {
+	exporter, err := New(WithRegisterer(prometheus.NewRegistry()), WithoutScopeInfo())
+	require.NoError(t, err)
+
+	collecting, release := make(chan struct{}), make(chan struct{})
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	_, err = provider.Meter("testmeter").Int64ObservableGauge(
+		"queue",
+		otelmetric.WithInt64Callback(func(context.Context, otelmetric.Int64Observer) error {
+			collecting <- struct{}{}
+			<-release
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(exporter.Handler(promhttp.HandlerOpts{MaxRequestsInFlight: 1}))
+	defer srv.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := http.Get(srv.URL) // nolint: noctx  // Test request.
+		if assert.NoError(t, err) {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-collecting
+
+	resp, err := http.Get(srv.URL) // nolint: noctx  // Test request.
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	close(release)
+	<-done
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/prometheus/internal"
+
+//go:generate gotmpl --body=../../../internal/shared/prometheus/promconv/native.go.tmpl "--data={}" --out=promconv/native.go
+//go:generate gotmpl --body=../../../internal/shared/prometheus/promconv/native_test.go.tmpl "--data={}" --out=promconv/native_test.go
+//go:generate gotmpl --body=../../../internal/shared/prometheus/promconv/units.go.tmpl "--data={}" --out=promconv/units.go
@@ -0,0 +1,103 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/prometheus/promconv/native.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package promconv // import "go.opentelemetry.io/otel/exporters/prometheus/internal/promconv"
+
+import "go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+// Prometheus native histograms support schemas in the range [-4, 8]. The
+// schema of a native histogram is defined the same way as the scale of an
+// OpenTelemetry exponential histogram.
+const (
+	MinNativeHistogramSchema = -4
+	MaxNativeHistogramSchema = 8
+)
+
+// NativeSchema returns the schema of the Prometheus native histogram of an
+// exponential histogram with scale, which is clamped to the schema range
+// supported by Prometheus.
+func NativeSchema(scale int32) int32 {
+	return min(max(scale, MinNativeHistogramSchema), MaxNativeHistogramSchema)
+}
+
+// BucketSpan is a span of consecutive buckets of a Prometheus native
+// histogram.
+type BucketSpan struct {
+	// Offset is the index of the first bucket of the first span, and the
+	// gap to the previous span for the other ones.
+	Offset int32
+	// Length is the number of buckets of the span.
+	Length uint32
+}
+
+// NativeBuckets returns the spans and delta encoded counts of a Prometheus
+// native histogram with schema for the exponential bucket b with scale.
+//
+// When the scale is larger than the schema, the buckets are merged to the
+// schema. When the scale is smaller than the schema, the count of each
+// bucket is added to the highest bucket of the schema it overlaps, which has
+// the same upper bound.
+func NativeBuckets(b metricdata.ExponentialBucket, scale, schema int32) ([]BucketSpan, []int64) {
+	type bucket struct {
+		index int32
+		count uint64
+	}
+	var buckets []bucket
+	for i, c := range b.Counts {
+		if c == 0 {
+			continue
+		}
+		idx := nativeIndex(b.Offset+int32(i), scale, schema) // nolint: gosec  // Bucket count limited by the aggregation.
+		if n := len(buckets); n > 0 && buckets[n-1].index == idx {
+			buckets[n-1].count += c
+			continue
+		}
+		buckets = append(buckets, bucket{index: idx, count: c})
+	}
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	var (
+		spans  []BucketSpan
+		deltas = make([]int64, 0, len(buckets))
+		prev   int64
+	)
+	for i, bkt := range buckets {
+		switch {
+		case i == 0:
+			spans = append(spans, BucketSpan{Offset: bkt.index, Length: 1})
+		case bkt.index == buckets[i-1].index+1:
+			spans[len(spans)-1].Length++
+		default:
+			// Offsets of all but the first span are relative to the end
+			// of the previous span.
+			spans = append(spans, BucketSpan{Offset: bkt.index - buckets[i-1].index - 1, Length: 1})
+		}
+		count := int64(bkt.count) // nolint: gosec  // Counts larger than MaxInt64 are not expected.
+		deltas = append(deltas, count-prev)
+		prev = count
+	}
+	return spans, deltas
+}
+
+// nativeIndex returns the index of the Prometheus native histogram bucket
+// with schema for the OpenTelemetry exponential histogram bucket i with
+// scale.
+//
+// OpenTelemetry defines bucket i to contain values in (base^i, base^(i+1)]
+// where Prometheus uses (base^(i-1), base^i]. The Prometheus index is
+// therefore one more than the OpenTelemetry index at the same scale.
+func nativeIndex(i, scale, schema int32) int32 {
+	switch {
+	case scale > schema:
+		i >>= scale - schema
+	case scale < schema:
+		// Use the highest bucket with the same upper bound.
+		i = (i+1)<<(schema-scale) - 1
+	}
+	return i + 1
+}
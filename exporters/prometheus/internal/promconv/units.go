@@ -0,0 +1,52 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/prometheus/promconv/units.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package promconv provides the conversions of OpenTelemetry metric data to
+// Prometheus shared by the Prometheus exporters.
+package promconv // import "go.opentelemetry.io/otel/exporters/prometheus/internal/promconv"
+
+var unitSuffixes = map[string]string{
+	// Time
+	"d":   "_days",
+	"h":   "_hours",
+	"min": "_minutes",
+	"s":   "_seconds",
+	"ms":  "_milliseconds",
+	"us":  "_microseconds",
+	"ns":  "_nanoseconds",
+
+	// Bytes
+	"By":   "_bytes",
+	"KiBy": "_kibibytes",
+	"MiBy": "_mebibytes",
+	"GiBy": "_gibibytes",
+	"TiBy": "_tibibytes",
+	"KBy":  "_kilobytes",
+	"MBy":  "_megabytes",
+	"GBy":  "_gigabytes",
+	"TBy":  "_terabytes",
+
+	// SI
+	"m": "_meters",
+	"V": "_volts",
+	"A": "_amperes",
+	"J": "_joules",
+	"W": "_watts",
+	"g": "_grams",
+
+	// Misc
+	"Cel": "_celsius",
+	"Hz":  "_hertz",
+	"1":   "_ratio",
+	"%":   "_percent",
+}
+
+// UnitSuffix returns the suffix of the names of the metrics with the UCUM
+// unit, and if the unit has one.
+func UnitSuffix(unit string) (string, bool) {
+	suffix, ok := unitSuffixes[unit]
+	return suffix, ok
+}
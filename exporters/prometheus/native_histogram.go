@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/promconv"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// nativeHistogram is a Prometheus histogram that is written with the
+// buckets of a native histogram.
+type nativeHistogram struct {
+	prometheus.Metric
+
+	schema        int32
+	zeroThreshold float64
+	zeroCount     uint64
+	posSpans      []*dto.BucketSpan
+	posDeltas     []int64
+	negSpans      []*dto.BucketSpan
+	negDeltas     []int64
+	exemplars     []*dto.Exemplar
+}
+
+// newNativeHistogram returns the histogram m with the buckets of dp added as
+// a native histogram.
+//
+// The scale of dp is clamped to the schema range supported by Prometheus.
+// When the scale is larger than the maximum schema, the buckets are merged
+// to the maximum schema. When the scale is smaller than the minimum schema,
+// the count of each bucket is added to the highest bucket of the minimum
+// schema it overlaps, which has the same upper bound.
+func newNativeHistogram[N int64 | float64](m prometheus.Metric, dp metricdata.ExponentialHistogramDataPoint[N]) prometheus.Metric {
+	schema := promconv.NativeSchema(dp.Scale)
+	h := nativeHistogram{
+		Metric:        m,
+		schema:        schema,
+		zeroThreshold: dp.ZeroThreshold,
+		zeroCount:     dp.ZeroCount,
+		exemplars:     nativeExemplars(dp.Exemplars),
+	}
+	h.posSpans, h.posDeltas = nativeBuckets(dp.PositiveBucket, dp.Scale, schema)
+	h.negSpans, h.negDeltas = nativeBuckets(dp.NegativeBucket, dp.Scale, schema)
+	return h
+}
+
+// Write writes the histogram, including its native histogram buckets, to pb.
+func (h nativeHistogram) Write(pb *dto.Metric) error {
+	if err := h.Metric.Write(pb); err != nil {
+		return err
+	}
+	hist := pb.Histogram
+	if hist == nil {
+		hist = &dto.Histogram{}
+		pb.Histogram = hist
+	}
+	hist.Schema = proto.Int32(h.schema)
+	hist.ZeroThreshold = proto.Float64(h.zeroThreshold)
+	hist.ZeroCount = proto.Uint64(h.zeroCount)
+	hist.PositiveSpan, hist.PositiveDelta = h.posSpans, h.posDeltas
+	hist.NegativeSpan, hist.NegativeDelta = h.negSpans, h.negDeltas
+	hist.Exemplars = h.exemplars
+	if len(h.posSpans) == 0 && len(h.negSpans) == 0 && h.zeroThreshold == 0 && h.zeroCount == 0 {
+		// An empty span identifies an empty histogram as a native histogram.
+		hist.PositiveSpan = []*dto.BucketSpan{{
+			Offset: proto.Int32(0),
+			Length: proto.Uint32(0),
+		}}
+	}
+	return nil
+}
+
+// nativeBuckets returns the spans and delta encoded counts of a Prometheus
+// native histogram with schema for the exponential bucket b with scale.
+func nativeBuckets(b metricdata.ExponentialBucket, scale, schema int32) ([]*dto.BucketSpan, []int64) {
+	spans, deltas := promconv.NativeBuckets(b, scale, schema)
+	if len(spans) == 0 {
+		return nil, deltas
+	}
+	out := make([]*dto.BucketSpan, 0, len(spans))
+	for _, s := range spans {
+		out = append(out, &dto.BucketSpan{
+			Offset: proto.Int32(s.Offset),
+			Length: proto.Uint32(s.Length),
+		})
+	}
+	return out, deltas
+}
+
+// nativeExemplars returns exemplars as the exemplars of a Prometheus native
+// histogram.
+func nativeExemplars[N int64 | float64](exemplars []metricdata.Exemplar[N]) []*dto.Exemplar {
+	if len(exemplars) == 0 {
+		return nil
+	}
+	out := make([]*dto.Exemplar, 0, len(exemplars))
+	for _, exemplar := range exemplars {
+		labels := exemplarLabels(exemplar)
+		pairs := make([]*dto.LabelPair, 0, len(labels))
+		for k, v := range labels {
+			pairs = append(pairs, &dto.LabelPair{
+				Name:  proto.String(k),
+				Value: proto.String(v),
+			})
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			return pairs[i].GetName() < pairs[j].GetName()
+		})
+		out = append(out, &dto.Exemplar{
+			Label:     pairs,
+			Value:     proto.Float64(float64(exemplar.Value)),
+			Timestamp: timestamppb.New(exemplar.Time),
+		})
+	}
+	return out
+}
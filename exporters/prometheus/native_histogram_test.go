@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/promconv"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func span(offset int32, length uint32) *dto.BucketSpan {
+	return &dto.BucketSpan{Offset: proto.Int32(offset), Length: proto.Uint32(length)}
+}
+
+func TestNativeHistogramWrite(t *testing.T) {
+	desc := prometheus.NewDesc("foo", "bar", []string{"A"}, nil)
+
+	t.Run("ClampScale", func(t *testing.T) {
+		base, err := prometheus.NewConstHistogram(desc, 3, 6, nil, "B")
+		require.NoError(t, err)
+		m := newNativeHistogram(base, metricdata.ExponentialHistogramDataPoint[int64]{
+			Count: 3,
+			Sum:   6,
+			Scale: 20,
+			PositiveBucket: metricdata.ExponentialBucket{
+				Offset: 0,
+				Counts: []uint64{3},
+			},
+		})
+
+		var pb dto.Metric
+		require.NoError(t, m.Write(&pb))
+		h := pb.GetHistogram()
+		assert.Equal(t, int32(promconv.MaxNativeHistogramSchema), h.GetSchema())
+		assert.Equal(t, uint64(3), h.GetSampleCount())
+		assert.Equal(t, float64(6), h.GetSampleSum())
+		assert.Equal(t, []*dto.BucketSpan{span(1, 1)}, h.GetPositiveSpan())
+		assert.Equal(t, []int64{3}, h.GetPositiveDelta())
+		assert.Equal(t, "B", pb.GetLabel()[0].GetValue())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		base, err := prometheus.NewConstHistogram(desc, 0, 0, nil, "B")
+		require.NoError(t, err)
+		m := newNativeHistogram(base, metricdata.ExponentialHistogramDataPoint[float64]{})
+
+		var pb dto.Metric
+		require.NoError(t, m.Write(&pb))
+		assert.Equal(t, []*dto.BucketSpan{span(0, 0)}, pb.GetHistogram().GetPositiveSpan())
+	})
+
+	t.Run("Exemplars", func(t *testing.T) {
+		base, err := prometheus.NewConstHistogram(desc, 1, 2, nil, "B")
+		require.NoError(t, err)
+		now := time.Now()
+		m := newNativeHistogram(base, metricdata.ExponentialHistogramDataPoint[float64]{
+			Count: 1,
+			Sum:   2,
+			PositiveBucket: metricdata.ExponentialBucket{
+				Offset: 0,
+				Counts: []uint64{1},
+			},
+			Exemplars: []metricdata.Exemplar[float64]{{
+				FilteredAttributes: []attribute.KeyValue{attribute.String("C", "D")},
+				Time:               now,
+				Value:              2,
+				TraceID:            []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				SpanID:             []byte{0x01, 0, 0, 0, 0, 0, 0, 0},
+			}},
+		})
+
+		var pb dto.Metric
+		require.NoError(t, m.Write(&pb))
+		exemplars := pb.GetHistogram().GetExemplars()
+		require.Len(t, exemplars, 1)
+		assert.Equal(t, float64(2), exemplars[0].GetValue())
+		assert.True(t, now.Equal(exemplars[0].GetTimestamp().AsTime()))
+		labels := make(map[string]string)
+		for _, l := range exemplars[0].GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Equal(t, map[string]string{
+			"C":                "D",
+			traceIDExemplarKey: "01000000000000000000000000000000",
+			spanIDExemplarKey:  "0100000000000000",
+		}, labels)
+	})
+}
+
+func TestExponentialHistogramExport(t *testing.T) {
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	exporter, err := New(WithRegisterer(registry), WithoutTargetInfo(), WithoutScopeInfo())
+	require.NoError(t, err)
+
+	provider := metric.NewMeterProvider(
+		metric.WithReader(exporter),
+		metric.WithView(metric.NewView(
+			metric.Instrument{Name: "histogram_*"},
+			metric.Stream{Aggregation: metric.AggregationBase2ExponentialHistogram{
+				MaxSize:  160,
+				MaxScale: 0,
+			}},
+		)),
+	)
+	meter := provider.Meter("testmeter")
+	histogram, err := meter.Float64Histogram(
+		"histogram_baz",
+		otelmetric.WithDescription("a very nice histogram"),
+		otelmetric.WithUnit("By"),
+	)
+	require.NoError(t, err)
+	opt := otelmetric.WithAttributes(attribute.Key("A").String("B"))
+	histogram.Record(ctx, 0, opt)
+	histogram.Record(ctx, 1, opt)
+	histogram.Record(ctx, 2, opt)
+	histogram.Record(ctx, 8, opt)
+	histogram.Record(ctx, -3, opt)
+
+	got, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	family := got[0]
+	assert.Equal(t, "histogram_baz_bytes", family.GetName())
+	assert.Equal(t, dto.MetricType_HISTOGRAM, family.GetType())
+	require.Len(t, family.GetMetric(), 1)
+
+	h := family.GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(5), h.GetSampleCount())
+	assert.Equal(t, float64(8), h.GetSampleSum())
+	assert.Equal(t, int32(0), h.GetSchema())
+	assert.Equal(t, uint64(1), h.GetZeroCount())
+	// 1 is in (0.5, 1], 2 is in (1, 2], and 8 is in (4, 8].
+	assert.Equal(t, []*dto.BucketSpan{span(0, 2), span(1, 1)}, h.GetPositiveSpan())
+	assert.Equal(t, []int64{1, 0, 0}, h.GetPositiveDelta())
+	// -3 is in [-4, -2).
+	assert.Equal(t, []*dto.BucketSpan{span(2, 1)}, h.GetNegativeSpan())
+	assert.Equal(t, []int64{1}, h.GetNegativeDelta())
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errTextfileShutdown = errors.New("textfile exporter is shutdown")
+
+// TextfileExporter is a metric Exporter that writes metrics to a file in the
+// OpenMetrics text format. The file can be read by the textfile collector of
+// the Prometheus node_exporter.
+//
+// Metrics are converted to Prometheus metrics the same way the Exporter
+// converts them.
+type TextfileExporter struct {
+	path                string
+	aggregationSelector metric.AggregationSelector
+
+	// mu serializes exports and protects all members below.
+	mu       sync.Mutex
+	data     *exportSource
+	registry *prometheus.Registry
+	shutdown bool
+}
+
+var _ metric.Exporter = (*TextfileExporter)(nil)
+
+// NewTextfileExporter returns a TextfileExporter that writes metrics to the
+// file at path. The file is replaced atomically on each export: metrics are
+// written to a temporary file in the same directory that is then renamed to
+// path, so readers never see a partially written file. For use with the
+// node_exporter textfile collector, path needs to have the ".prom"
+// extension.
+//
+// The TextfileExporter is meant to be used with a PeriodicReader. The
+// PeriodicReader also exports on shutdown, so metrics of short-lived
+// processes are written before they exit.
+//
+// WithRegisterer, WithProducer, and WithCacheTTL do not apply to the
+// TextfileExporter and are ignored.
+func NewTextfileExporter(path string, opts ...Option) (*TextfileExporter, error) {
+	cfg := newConfig(opts...)
+	// The collector converts the data of each export as it is and does not
+	// reuse conversions across exports.
+	cfg.cacheTTL = 0
+
+	data := &exportSource{}
+	collector := newCollector(cfg, data)
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		return nil, fmt.Errorf("cannot register the collector: %w", err)
+	}
+
+	selector := cfg.aggregationSelector
+	if selector == nil {
+		selector = metric.DefaultAggregationSelector
+	}
+
+	return &TextfileExporter{
+		path:                path,
+		aggregationSelector: selector,
+		data:                data,
+		registry:            registry,
+	}, nil
+}
+
+// Temporality returns the CumulativeTemporality, the only temporality
+// supported by Prometheus.
+func (e *TextfileExporter) Temporality(metric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *TextfileExporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return e.aggregationSelector(k)
+}
+
+// Export writes rm to the file of the TextfileExporter, replacing the metrics
+// previously written.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *TextfileExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.shutdown {
+		return errTextfileShutdown
+	}
+
+	e.data.rm = rm
+	families, err := e.registry.Gather()
+	e.data.rm = nil
+	// Best effort write of the families gathered.
+	if wErr := e.write(families); wErr != nil {
+		return errors.Join(err, wErr)
+	}
+	return err
+}
+
+// write atomically replaces the file of the TextfileExporter with families
+// encoded in the OpenMetrics text format.
+func (e *TextfileExporter) write(families []*dto.MetricFamily) (err error) {
+	dir, base := filepath.Split(e.path)
+	if dir == "" {
+		dir = "."
+	}
+	// The temporary file does not have the ".prom" extension so it is not
+	// read by the node_exporter while being written.
+	f, err := os.CreateTemp(dir, "."+base+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(f.Name()))
+		}
+	}()
+
+	enc := expfmt.NewEncoder(f, expfmt.NewFormat(expfmt.TypeOpenMetrics))
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return errors.Join(fmt.Errorf("failed to encode %s: %w", mf.GetName(), err), f.Close())
+		}
+	}
+	if closer, ok := enc.(expfmt.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return errors.Join(fmt.Errorf("failed to finalize file: %w", err), f.Close())
+		}
+	}
+	// Temporary files are only readable by their owner. Make the file
+	// readable by the node_exporter.
+	if err := f.Chmod(0o644); err != nil { // nolint: gosec  // Metrics are meant to be read by others.
+		return errors.Join(err, f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), e.path)
+}
+
+// ForceFlush does nothing, the TextfileExporter holds no data to flush.
+//
+// This method returns an error if the method is canceled by the passed context.
+func (e *TextfileExporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops the TextfileExporter. The file written last is kept. After
+// Shutdown is called, calls to Export will return an error.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *TextfileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.shutdown {
+		return errTextfileShutdown
+	}
+	e.shutdown = true
+	return ctx.Err()
+}
+
+// MarshalLog returns logging data about the TextfileExporter.
+func (e *TextfileExporter) MarshalLog() interface{} {
+	return struct {
+		Type string
+		Path string
+	}{
+		Type: "Prometheus textfile exporter",
+		Path: e.path,
+	}
+}
+
+// exportSource is the source of the metric data of a TextfileExporter. It
+// provides the data being exported.
+type exportSource struct {
+	rm *metricdata.ResourceMetrics
+}
+
+// Collect copies the data being exported into rm.
+func (s *exportSource) Collect(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	if s.rm != nil {
+		*rm = *s.rm
+	}
+	return nil
+}
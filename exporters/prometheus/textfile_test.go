@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestTextfileExporter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "job.prom")
+
+	exporter, err := NewTextfileExporter(path, WithoutScopeInfo())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	res := resource.NewSchemaless(attribute.String("service.name", "batch"))
+	provider := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(metric.NewPeriodicReader(exporter)),
+	)
+	meter := provider.Meter("testmeter")
+	counter, err := meter.Float64Counter("processed", otelmetric.WithUnit("By"), otelmetric.WithDescription("Bytes processed"))
+	require.NoError(t, err)
+	counter.Add(ctx, 5, otelmetric.WithAttributes(attribute.String("job", "backup")))
+
+	require.NoError(t, provider.ForceFlush(ctx))
+	want := `# HELP processed_bytes Bytes processed
+# TYPE processed_bytes counter
+processed_bytes_total{job="backup"} 5.0
+# HELP target_info Target metadata
+# TYPE target_info gauge
+target_info{service_name="batch"} 1.0
+# EOF
+`
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	// The final export on shutdown replaces the file.
+	counter.Add(ctx, 2, otelmetric.WithAttributes(attribute.String("job", "backup")))
+	require.NoError(t, provider.Shutdown(ctx))
+	got, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `processed_bytes_total{job="backup"} 7.0`)
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "job.prom", entries[0].Name())
+}
+
+func TestTextfileExporterWriteError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "job.prom")
+	exporter, err := NewTextfileExporter(path)
+	require.NoError(t, err)
+
+	provider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exporter)))
+	assert.ErrorContains(t, provider.ForceFlush(context.Background()), "failed to create temporary file")
+}
+
+func TestTextfileExporterShutdown(t *testing.T) {
+	exporter, err := NewTextfileExporter(filepath.Join(t.TempDir(), "job.prom"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exporter.Shutdown(ctx))
+	assert.ErrorIs(t, exporter.Shutdown(ctx), errTextfileShutdown)
+	assert.ErrorIs(t, exporter.Export(ctx, nil), errTextfileShutdown)
+}
+
+func TestTextfileExporterAggregation(t *testing.T) {
+	exporter, err := NewTextfileExporter("job.prom", WithAggregationSelector(func(metric.InstrumentKind) metric.Aggregation {
+		return metric.AggregationDrop{}
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, metric.AggregationDrop{}, exporter.Aggregation(metric.InstrumentKindCounter))
+
+	exporter, err = NewTextfileExporter("job.prom")
+	require.NoError(t, err)
+	assert.Equal(t, metric.AggregationSum{}, exporter.Aggregation(metric.InstrumentKindCounter))
+}
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/retry"
+)
+
+// remoteWriteVersion is the version of the remote-write protocol sent.
+const remoteWriteVersion = "0.1.0"
+
+// client sends series to a remote-write receiver.
+type client struct {
+	// req is cloned for every request the client makes.
+	req               *http.Request
+	requestFunc       retry.RequestFunc
+	httpClient        *http.Client
+	shards            int
+	maxSamplesPerSend int
+}
+
+// newClient creates a new remote-write client.
+func newClient(cfg config) (*client, error) {
+	u, err := url.Parse(cfg.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %q: scheme must be http or https", cfg.endpoint)
+	}
+
+	// Body is set when this is cloned during upload.
+	req, err := http.NewRequest(http.MethodPost, u.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range cfg.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("User-Agent", "OTel Go Prometheus remote-write exporter/"+Version())
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+
+	return &client{
+		req:               req,
+		requestFunc:       cfg.retryCfg.RequestFunc(evaluate),
+		httpClient:        cfg.httpClient,
+		shards:            cfg.shards,
+		maxSamplesPerSend: cfg.maxSamplesPerSend,
+	}, nil
+}
+
+// upload sends series to the receiver.
+//
+// Series are split across the shards of the client by their labels and each
+// shard is sent concurrently. The series of a shard are sent in order in
+// requests containing at most maxSamplesPerSend samples.
+func (c *client) upload(ctx context.Context, series []prompb.TimeSeries) error {
+	if len(series) == 0 {
+		return nil
+	}
+
+	shards := make([][]prompb.TimeSeries, c.shards)
+	for _, ts := range series {
+		i := shardIndex(ts.Labels, c.shards)
+		shards[i] = append(shards[i], ts)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(shards))
+	for i, shard := range shards {
+		if len(shard) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.sendShard(ctx, shard)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// sendShard sends the series of a shard in batches of at most
+// maxSamplesPerSend samples. Sending stops at the first failed batch so
+// samples are never sent out of order.
+func (c *client) sendShard(ctx context.Context, series []prompb.TimeSeries) error {
+	for len(series) > 0 {
+		var n, samples int
+		for ; n < len(series); n++ {
+			s := len(series[n].Samples) + len(series[n].Histograms)
+			if n > 0 && samples+s > c.maxSamplesPerSend {
+				break
+			}
+			samples += s
+		}
+		if err := c.send(ctx, series[:n]); err != nil {
+			return err
+		}
+		series = series[n:]
+	}
+	return nil
+}
+
+// send sends series in a single remote-write request.
+//
+// Retryable errors from the receiver will be handled according to the
+// RetryConfig the client was created with.
+func (c *client) send(ctx context.Context, series []prompb.TimeSeries) error {
+	wr := prompb.WriteRequest{Timeseries: series}
+	body := snappy.Encode(nil, wr.Marshal())
+
+	return c.requestFunc(ctx, func(iCtx context.Context) error {
+		select {
+		case <-iCtx.Done():
+			return iCtx.Err()
+		default:
+		}
+
+		req := c.req.Clone(iCtx)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		resp, err := c.httpClient.Do(req)
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && urlErr.Temporary() {
+			return newResponseError(http.Header{}, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		var rErr error
+		switch sc := resp.StatusCode; {
+		case sc >= 200 && sc <= 299:
+			// Success, do not retry.
+		case sc == http.StatusTooManyRequests, sc >= 500:
+			// Retry-able failure.
+			rErr = newResponseError(resp.Header, responseBodyError(resp.Body))
+		default:
+			rErr = fmt.Errorf("failed to send metrics to %s: %s", req.URL, resp.Status)
+			if err := responseBodyError(resp.Body); err != nil {
+				rErr = fmt.Errorf("%w: %w", rErr, err)
+			}
+		}
+
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if err := resp.Body.Close(); err != nil {
+			return err
+		}
+		return rErr
+	})
+}
+
+// maxErrorBodySize is the maximum number of bytes of a response body included
+// in an error.
+const maxErrorBodySize = 1024
+
+// responseBodyError returns an error with the message the receiver returned
+// in body, if any.
+func responseBodyError(body io.Reader) error {
+	var b bytes.Buffer
+	if _, err := io.Copy(&b, io.LimitReader(body, maxErrorBodySize)); err != nil {
+		return err
+	}
+	if msg := strings.TrimSpace(b.String()); msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// shardIndex returns the shard of the series with labels.
+func shardIndex(labels []prompb.Label, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+	h := fnv.New64a()
+	for _, l := range labels {
+		_, _ = h.Write([]byte(l.Name))
+		_, _ = h.Write([]byte{0xff})
+		_, _ = h.Write([]byte(l.Value))
+		_, _ = h.Write([]byte{0xff})
+	}
+	return int(h.Sum64() % uint64(shards)) // nolint: gosec  // Less than shards.
+}
+
+// retryableError represents a request failure that can be retried.
+type retryableError struct {
+	throttle int64
+	err      error
+}
+
+// newResponseError returns a retryableError and will extract any explicit
+// throttle delay contained in headers. The returned error wraps wrapped
+// if it is not nil.
+func newResponseError(header http.Header, wrapped error) error {
+	var rErr retryableError
+	if v := header.Get("Retry-After"); v != "" {
+		if t, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rErr.throttle = t
+		}
+	}
+
+	rErr.err = wrapped
+	return rErr
+}
+
+func (e retryableError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("retry-able request failure: %s", e.err.Error())
+	}
+
+	return "retry-able request failure"
+}
+
+func (e retryableError) Unwrap() error {
+	return e.err
+}
+
+// evaluate returns if err is retry-able. If it is and it includes an explicit
+// throttling delay, that delay is also returned.
+func evaluate(err error) (bool, time.Duration) {
+	if err == nil {
+		return false, 0
+	}
+
+	// Do not use errors.As here, this should only be flattened one layer. If
+	// there are several chained errors, all the errors above it will be
+	// discarded if errors.As is used instead.
+	rErr, ok := err.(retryableError) //nolint:errorlint
+	if !ok {
+		return false, 0
+	}
+
+	return true, time.Duration(rErr.throttle) * time.Second
+}
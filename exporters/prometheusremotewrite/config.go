@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite"
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/retry"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	defaultEndpoint          = "http://localhost:9090/api/v1/write"
+	defaultTimeout           = 30 * time.Second
+	defaultShards            = 4
+	defaultMaxSamplesPerSend = 2000
+)
+
+// RetryConfig defines configuration for retrying the export of metric data
+// that failed.
+type RetryConfig retry.Config
+
+// config contains options for the exporter.
+type config struct {
+	endpoint               string
+	headers                map[string]string
+	httpClient             *http.Client
+	timeout                time.Duration
+	retryCfg               retry.Config
+	shards                 int
+	maxSamplesPerSend      int
+	temporalitySelector    metric.TemporalitySelector
+	aggregationSelector    metric.AggregationSelector
+	disableTargetInfo      bool
+	disableScopeInfo       bool
+	withoutUnits           bool
+	withoutCounterSuffixes bool
+	namespace              string
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		endpoint:          defaultEndpoint,
+		timeout:           defaultTimeout,
+		retryCfg:          retry.DefaultConfig,
+		shards:            defaultShards,
+		maxSamplesPerSend: defaultMaxSamplesPerSend,
+	}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+
+	if cfg.httpClient == nil {
+		cfg.httpClient = &http.Client{Transport: http.DefaultTransport}
+	}
+	if cfg.temporalitySelector == nil {
+		cfg.temporalitySelector = cumulativeTemporality
+	}
+	if cfg.aggregationSelector == nil {
+		cfg.aggregationSelector = metric.DefaultAggregationSelector
+	}
+
+	if cfg.namespace != "" {
+		ns := model.EscapeName(cfg.namespace, model.UnderscoreEscaping)
+		if !strings.HasSuffix(ns, "_") {
+			// namespace and metric names should be separated with an underscore,
+			// adds a trailing underscore if there is not one already.
+			ns = ns + "_"
+		}
+		cfg.namespace = ns
+	}
+
+	return cfg
+}
+
+func cumulativeTemporality(metric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+// Option sets exporter option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithEndpoint sets the URL of the remote-write receiver the Exporter sends
+// requests to. For example, "https://mimir.example.com/api/v1/push". If this
+// option is not used, "http://localhost:9090/api/v1/write" is used.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.endpoint = endpoint
+		return cfg
+	})
+}
+
+// WithHeaders sets additional headers sent with every request. This can be
+// used to set the tenant of multi-tenant receivers (e.g. "X-Scope-OrgID") or
+// authorization headers.
+func WithHeaders(headers map[string]string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.headers = headers
+		return cfg
+	})
+}
+
+// WithHTTPClient sets the HTTP client used to send requests. This can be used
+// to configure TLS or proxies. The timeout of the client is not used, use
+// WithTimeout instead. If this option is not used, a client with the
+// http.DefaultTransport is used.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.httpClient = client
+		return cfg
+	})
+}
+
+// WithTimeout sets the maximum time an export, including all of its retries,
+// can take. If this option is not used, a timeout of 30 seconds is used.
+//
+// If timeout is less than or equal to zero, the option is ignored.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(cfg config) config {
+		if timeout > 0 {
+			cfg.timeout = timeout
+		}
+		return cfg
+	})
+}
+
+// WithRetry sets the retry policy for requests that fail with a retryable
+// status code (HTTP 429 and 5xx) or a temporary network error. A Retry-After
+// header returned by the receiver is honored. If this option is not used,
+// failed requests are retried with an exponential backoff for up to one
+// minute.
+func WithRetry(rc RetryConfig) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.retryCfg = retry.Config(rc)
+		return cfg
+	})
+}
+
+// WithShards sets the number of concurrent senders an export is split
+// across. Series are assigned to a shard by their labels so samples of the
+// same series are always sent in order. If this option is not used, 4 shards
+// are used.
+//
+// If n is less than or equal to zero, the option is ignored.
+func WithShards(n int) Option {
+	return optionFunc(func(cfg config) config {
+		if n > 0 {
+			cfg.shards = n
+		}
+		return cfg
+	})
+}
+
+// WithMaxSamplesPerSend sets the maximum number of samples, including native
+// histogram samples, sent in a single request. If this option is not used,
+// a maximum of 2000 samples is used.
+//
+// If n is less than or equal to zero, the option is ignored.
+func WithMaxSamplesPerSend(n int) Option {
+	return optionFunc(func(cfg config) config {
+		if n > 0 {
+			cfg.maxSamplesPerSend = n
+		}
+		return cfg
+	})
+}
+
+// WithTemporalitySelector sets the TemporalitySelector the exporter uses to
+// determine the Temporality of the data it is passed. Data with a delta
+// temporality is converted to a cumulative temporality before it is sent. If
+// this option is not used, a cumulative temporality is used for all
+// instruments.
+func WithTemporalitySelector(selector metric.TemporalitySelector) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.temporalitySelector = selector
+		return cfg
+	})
+}
+
+// WithAggregationSelector sets the AggregationSelector the exporter uses to
+// determine the aggregation to use for an instrument. If this option is not
+// used, the DefaultAggregationSelector is used.
+func WithAggregationSelector(selector metric.AggregationSelector) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.aggregationSelector = selector
+		return cfg
+	})
+}
+
+// WithoutTargetInfo configures the Exporter to not send the resource
+// target_info metric. If not specified, the Exporter sends a target_info
+// metric containing the metrics' resource.Resource attributes.
+func WithoutTargetInfo() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.disableTargetInfo = true
+		return cfg
+	})
+}
+
+// WithoutScopeInfo configures the Exporter to not add the otel_scope_name
+// and otel_scope_version labels of the Instrumentation Scope to all series.
+func WithoutScopeInfo() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.disableScopeInfo = true
+		return cfg
+	})
+}
+
+// WithoutUnits disables exporter's addition of unit suffixes to metric names.
+//
+// By default, metric names include a unit suffix to follow Prometheus naming
+// conventions. For example, the counter metric request.duration, with unit
+// milliseconds would become request_duration_milliseconds_total.
+// With this option set, the name would instead be request_duration_total.
+func WithoutUnits() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.withoutUnits = true
+		return cfg
+	})
+}
+
+// WithoutCounterSuffixes disables exporter's addition _total suffixes on counters.
+//
+// By default, metric names include a _total suffix to follow Prometheus naming
+// conventions. For example, the counter metric happy.people would become
+// happy_people_total. With this option set, the name would instead be
+// happy_people.
+func WithoutCounterSuffixes() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.withoutCounterSuffixes = true
+		return cfg
+	})
+}
+
+// WithNamespace configures the Exporter to prefix metric with the given
+// namespace. Metadata metrics such as target_info are not prefixed since
+// these have special behavior based on their name.
+func WithNamespace(ns string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.namespace = ns
+		return cfg
+	})
+}
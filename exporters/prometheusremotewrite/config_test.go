@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/retry"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewConfig(t *testing.T) {
+	client := &http.Client{}
+	retryCfg := RetryConfig{Enabled: false}
+
+	testCases := []struct {
+		name    string
+		options []Option
+		check   func(*testing.T, config)
+	}{
+		{
+			name: "Defaults",
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, defaultEndpoint, cfg.endpoint)
+				assert.Equal(t, defaultTimeout, cfg.timeout)
+				assert.Equal(t, retry.DefaultConfig, cfg.retryCfg)
+				assert.Equal(t, defaultShards, cfg.shards)
+				assert.Equal(t, defaultMaxSamplesPerSend, cfg.maxSamplesPerSend)
+				assert.NotNil(t, cfg.httpClient)
+				assert.Equal(t, metricdata.CumulativeTemporality, cfg.temporalitySelector(metric.InstrumentKindCounter))
+				assert.Equal(t, metric.AggregationSum{}, cfg.aggregationSelector(metric.InstrumentKindCounter))
+				assert.Empty(t, cfg.namespace)
+			},
+		},
+		{
+			name: "WithOptions",
+			options: []Option{
+				WithEndpoint("https://example.com/api/v1/push"),
+				WithHeaders(map[string]string{"X-Scope-OrgID": "tenant"}),
+				WithHTTPClient(client),
+				WithTimeout(time.Second),
+				WithRetry(retryCfg),
+				WithShards(2),
+				WithMaxSamplesPerSend(10),
+				WithTemporalitySelector(metric.DefaultTemporalitySelector),
+				WithAggregationSelector(func(metric.InstrumentKind) metric.Aggregation {
+					return metric.AggregationDrop{}
+				}),
+				WithoutTargetInfo(),
+				WithoutScopeInfo(),
+				WithoutUnits(),
+				WithoutCounterSuffixes(),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, "https://example.com/api/v1/push", cfg.endpoint)
+				assert.Equal(t, map[string]string{"X-Scope-OrgID": "tenant"}, cfg.headers)
+				assert.Same(t, client, cfg.httpClient)
+				assert.Equal(t, time.Second, cfg.timeout)
+				assert.Equal(t, retry.Config(retryCfg), cfg.retryCfg)
+				assert.Equal(t, 2, cfg.shards)
+				assert.Equal(t, 10, cfg.maxSamplesPerSend)
+				assert.Equal(t, metric.AggregationDrop{}, cfg.aggregationSelector(metric.InstrumentKindCounter))
+				assert.True(t, cfg.disableTargetInfo)
+				assert.True(t, cfg.disableScopeInfo)
+				assert.True(t, cfg.withoutUnits)
+				assert.True(t, cfg.withoutCounterSuffixes)
+			},
+		},
+		{
+			name: "InvalidValuesIgnored",
+			options: []Option{
+				WithTimeout(-time.Second),
+				WithShards(0),
+				WithMaxSamplesPerSend(-1),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, defaultTimeout, cfg.timeout)
+				assert.Equal(t, defaultShards, cfg.shards)
+				assert.Equal(t, defaultMaxSamplesPerSend, cfg.maxSamplesPerSend)
+			},
+		},
+		{
+			name:    "Namespace",
+			options: []Option{WithNamespace("my.app")},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, "my_app_", cfg.namespace)
+			},
+		},
+		{
+			name:    "NamespaceWithSeparator",
+			options: []Option{WithNamespace("app_")},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, "app_", cfg.namespace)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, newConfig(tc.options...))
+		})
+	}
+}
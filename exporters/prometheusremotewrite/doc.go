@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prometheusremotewrite provides a metric Exporter that pushes
+// OpenTelemetry metrics to a receiver implementing the Prometheus
+// remote-write 1.0 protocol (e.g. Prometheus, Mimir, Thanos, or Cortex).
+//
+// This is useful for short-lived or serverless workloads that cannot be
+// scraped by a Prometheus server. Use the Exporter with a PeriodicReader.
+//
+// Metric data is translated following the OpenTelemetry Prometheus
+// compatibility specification:
+//
+//   - Monotonic Sums are sent as counters with a "_total" suffix.
+//   - Non-monotonic Sums and Gauges are sent as gauges.
+//   - Histograms are sent as "_bucket", "_sum", and "_count" series.
+//   - ExponentialHistograms are sent as native histograms.
+//   - Summaries are sent as quantile, "_sum", and "_count" series.
+//
+// Data with a delta temporality is converted to a cumulative temporality
+// before it is sent. The service.name, service.namespace, and
+// service.instance.id resource attributes are sent as the job and instance
+// labels of all series and all other resource attributes as a target_info
+// series. Exemplars are sent with their trace and span IDs as the "trace_id"
+// and "span_id" labels.
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite"
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite_test
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func ExampleNew() {
+	// Create an exporter that pushes to a multi-tenant receiver.
+	exp, err := prometheusremotewrite.New(
+		prometheusremotewrite.WithEndpoint("https://mimir.example.com/api/v1/push"),
+		prometheusremotewrite.WithHeaders(map[string]string{"X-Scope-OrgID": "tenant"}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Periodically push metrics with the exporter.
+	provider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exp)))
+	// Ensure the final metrics are pushed before the application exits.
+	defer func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			panic(err)
+		}
+	}()
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errShutdown = errors.New("prometheus remote-write exporter is shutdown")
+
+// Exporter is a metric Exporter that sends metric data to a Prometheus
+// remote-write receiver.
+type Exporter struct {
+	// exporter converts the temporality of data before it is passed to the
+	// writer.
+	exporter metric.Exporter
+}
+
+var _ metric.Exporter = (*Exporter)(nil)
+
+// New returns an Exporter that sends metric data to a Prometheus remote-write
+// receiver. The Exporter is meant to be used with a PeriodicReader.
+func New(opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts...)
+	c, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	w := &writer{
+		client:              c,
+		translator:          newTranslator(cfg),
+		aggregationSelector: cfg.aggregationSelector,
+		timeout:             cfg.timeout,
+	}
+	return &Exporter{
+		exporter: metric.NewTemporalityConverter(w, metric.WithInputTemporalitySelector(cfg.temporalitySelector)),
+	}, nil
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (e *Exporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return e.exporter.Temporality(k)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return e.exporter.Aggregation(k)
+}
+
+// Export converts rm to remote-write series and sends them to the receiver.
+//
+// Metrics that cannot be converted are dropped and reported in the returned
+// error. All other metrics are still sent.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	defer global.Debug("Prometheus remote-write exporter export", "Data", rm)
+	return e.exporter.Export(ctx, rm)
+}
+
+// ForceFlush does nothing, the Exporter holds no data to flush.
+//
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return e.exporter.ForceFlush(ctx)
+}
+
+// Shutdown releases any held computational resources. After Shutdown is
+// called, calls to Export will return an error.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.exporter.Shutdown(ctx)
+}
+
+// MarshalLog returns logging data about the Exporter.
+func (e *Exporter) MarshalLog() interface{} {
+	return struct{ Type string }{Type: "Prometheus remote-write"}
+}
+
+// writer is an Exporter that sends cumulative metric data using a client.
+type writer struct {
+	translator          translator
+	aggregationSelector metric.AggregationSelector
+	timeout             time.Duration
+
+	client   *client
+	shutdown atomic.Bool
+}
+
+func (w *writer) Temporality(metric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+func (w *writer) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return w.aggregationSelector(k)
+}
+
+func (w *writer) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if w.shutdown.Load() {
+		return errShutdown
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	series, err := w.translator.timeSeries(rm, time.Now())
+	// Best effort upload of translatable metrics.
+	if upErr := w.client.upload(ctx, series); upErr != nil {
+		if err == nil {
+			return fmt.Errorf("failed to upload metrics: %w", upErr)
+		}
+		// Merge the two errors.
+		return fmt.Errorf("failed to upload incomplete metrics (%w): %w", err, upErr)
+	}
+	return err
+}
+
+func (w *writer) ForceFlush(ctx context.Context) error {
+	// The writer holds no state, nothing to flush.
+	return ctx.Err()
+}
+
+func (w *writer) Shutdown(ctx context.Context) error {
+	if w.shutdown.Swap(true) {
+		return errShutdown
+	}
+	w.client.httpClient.CloseIdleConnections()
+	return ctx.Err()
+}
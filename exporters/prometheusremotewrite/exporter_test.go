@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompbtest"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// receiver is a remote-write receiver that records the requests it receives.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	writes   []prompb.WriteRequest
+	// statuses are the status codes returned for the first requests
+	// received. All requests after are successful.
+	statuses []int
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.requests = append(r.requests, req)
+		if len(r.statuses) > 0 {
+			status := r.statuses[0]
+			r.statuses = r.statuses[1:]
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "failure", status)
+			return
+		}
+
+		compressed, err := io.ReadAll(req.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		b, err := snappy.Decode(nil, compressed)
+		if !assert.NoError(t, err) {
+			return
+		}
+		wr, err := prompbtest.Unmarshal(b)
+		if !assert.NoError(t, err) {
+			return
+		}
+		r.writes = append(r.writes, wr)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// series returns all series received keyed by their metric name.
+func (r *receiver) series() map[string][]prompb.TimeSeries {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make(map[string][]prompb.TimeSeries)
+	for _, wr := range r.writes {
+		for _, ts := range wr.Timeseries {
+			for _, l := range ts.Labels {
+				if l.Name == metricNameLabel {
+					out[l.Value] = append(out[l.Value], ts)
+				}
+			}
+		}
+	}
+	return out
+}
+
+var noRetry = WithRetry(RetryConfig{Enabled: false})
+
+func TestExporterExport(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(
+		WithEndpoint(r.URL+"/api/v1/write"),
+		WithHeaders(map[string]string{"X-Scope-OrgID": "tenant"}),
+		noRetry,
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	provider := metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(exp)),
+		metric.WithResource(testResource),
+	)
+	meter := provider.Meter("testmeter", otelmetric.WithInstrumentationVersion("v0.1.0"))
+	counter, err := meter.Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(ctx, 5, otelmetric.WithAttributes(attribute.String("A", "B")))
+	require.NoError(t, provider.Shutdown(ctx))
+
+	series := r.series()
+	require.Contains(t, series, "requests_total")
+	ts := series["requests_total"][0]
+	assert.Equal(t, seriesLabels("requests_total"), ts.Labels)
+	require.Len(t, ts.Samples, 1)
+	assert.Equal(t, float64(5), ts.Samples[0].Value)
+	assert.Contains(t, series, targetInfoMetricName)
+
+	require.NotEmpty(t, r.requests)
+	req := r.requests[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/api/v1/write", req.URL.Path)
+	assert.Equal(t, "snappy", req.Header.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+	assert.Equal(t, remoteWriteVersion, req.Header.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, "tenant", req.Header.Get("X-Scope-OrgID"))
+	assert.Contains(t, req.Header.Get("User-Agent"), Version())
+
+	assert.ErrorIs(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), errShutdown)
+	assert.ErrorIs(t, exp.Shutdown(ctx), errShutdown)
+}
+
+func deltaSum(value int64, start, end time.Time) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "testmeter"},
+			Metrics: []metricdata.Metrics{{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{{
+						StartTime: start,
+						Time:      end,
+						Value:     value,
+					}},
+				},
+			}},
+		}},
+	}
+}
+
+func TestExporterConvertsDelta(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(
+		WithEndpoint(r.URL),
+		WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}),
+		noRetry,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.DeltaTemporality, exp.Temporality(metric.InstrumentKindCounter))
+
+	ctx := context.Background()
+	t0 := time.Now()
+	t1, t2 := t0.Add(time.Second), t0.Add(2*time.Second)
+	require.NoError(t, exp.Export(ctx, deltaSum(2, t0, t1)))
+	require.NoError(t, exp.Export(ctx, deltaSum(3, t1, t2)))
+
+	series := r.series()["requests_total"]
+	require.Len(t, series, 2)
+	assert.Equal(t, []prompb.Sample{{Value: 2, Timestamp: t1.UnixMilli()}}, series[0].Samples)
+	assert.Equal(t, []prompb.Sample{{Value: 5, Timestamp: t2.UnixMilli()}}, series[1].Samples)
+}
+
+func TestExporterRetry(t *testing.T) {
+	r := newReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	exp, err := New(WithEndpoint(r.URL), WithRetry(RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Minute,
+	}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Export(ctx, deltaSum(1, time.Now(), time.Now())))
+	assert.Len(t, r.requests, 3)
+	assert.Len(t, r.series()["requests_total"], 1)
+}
+
+func TestExporterNonRetryableError(t *testing.T) {
+	r := newReceiver(t, http.StatusBadRequest)
+	exp, err := New(WithEndpoint(r.URL))
+	require.NoError(t, err)
+
+	err = exp.Export(context.Background(), deltaSum(1, time.Now(), time.Now()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400 Bad Request")
+	assert.Contains(t, err.Error(), "failure")
+	assert.Len(t, r.requests, 1)
+}
+
+func TestExporterShardsAndBatches(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(
+		WithEndpoint(r.URL),
+		WithShards(3),
+		WithMaxSamplesPerSend(2),
+		WithoutTargetInfo(),
+		noRetry,
+	)
+	require.NoError(t, err)
+
+	const n = 20
+	dPts := make([]metricdata.DataPoint[float64], n)
+	for i := range dPts {
+		dPts[i] = metricdata.DataPoint[float64]{
+			Attributes: attribute.NewSet(attribute.Int("i", i)),
+			Time:       now,
+			Value:      float64(i),
+		}
+	}
+	require.NoError(t, exp.Export(context.Background(), &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "gauge",
+				Data: metricdata.Gauge[float64]{DataPoints: dPts},
+			}},
+		}},
+	}))
+
+	got := make(map[string]bool)
+	for _, ts := range r.series()["gauge"] {
+		for _, l := range ts.Labels {
+			if l.Name == "i" {
+				got[l.Value] = true
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		assert.Truef(t, got[fmt.Sprint(i)], "missing series %d", i)
+	}
+	// Each request contains at most 2 samples.
+	assert.GreaterOrEqual(t, len(r.writes), n/2)
+	for _, wr := range r.writes {
+		assert.LessOrEqual(t, len(wr.Timeseries), 2)
+	}
+}
+
+func TestShardIndex(t *testing.T) {
+	a := labels(metricNameLabel, "a", "i", "1")
+	b := labels(metricNameLabel, "a", "i", "1")
+	assert.Equal(t, shardIndex(a, 8), shardIndex(b, 8), "same labels")
+	assert.Equal(t, 0, shardIndex(a, 1))
+	for i := 0; i < 100; i++ {
+		idx := shardIndex(labels("i", fmt.Sprint(i)), 8)
+		assert.GreaterOrEqual(t, idx, 0)
+		assert.Less(t, idx, 8)
+	}
+}
+
+func TestNewInvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:9090", "ftp://localhost", "http://[::1"} {
+		_, err := New(WithEndpoint(endpoint))
+		assert.Error(t, err, endpoint)
+	}
+}
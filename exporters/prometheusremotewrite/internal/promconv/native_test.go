@@ -0,0 +1,94 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/prometheus/promconv/native_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package promconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func span(offset int32, length uint32) BucketSpan {
+	return BucketSpan{Offset: offset, Length: length}
+}
+
+func TestNativeSchema(t *testing.T) {
+	assert.Equal(t, int32(MinNativeHistogramSchema), NativeSchema(-10))
+	assert.Equal(t, int32(2), NativeSchema(2))
+	assert.Equal(t, int32(MaxNativeHistogramSchema), NativeSchema(20))
+}
+
+func TestNativeIndex(t *testing.T) {
+	testCases := []struct {
+		name          string
+		i             int32
+		scale, schema int32
+		want          int32
+	}{
+		{name: "SameScale", i: 3, scale: 2, schema: 2, want: 4},
+		{name: "SameScaleNegative", i: -3, scale: 2, schema: 2, want: -2},
+		{name: "Downscale", i: 5, scale: 10, schema: 8, want: 2},
+		{name: "DownscaleNegative", i: -5, scale: 10, schema: 8, want: -1},
+		// At scale -5, bucket 0 is (1, 2^32]. The highest bucket at schema -4
+		// with the same upper bound is 1, (2^16, 2^32].
+		{name: "Upscale", i: 0, scale: -5, schema: -4, want: 2},
+		{name: "UpscaleNegative", i: -1, scale: -5, schema: -4, want: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, nativeIndex(tc.i, tc.scale, tc.schema))
+		})
+	}
+}
+
+func TestNativeBuckets(t *testing.T) {
+	testCases := []struct {
+		name          string
+		bucket        metricdata.ExponentialBucket
+		scale, schema int32
+		wantSpans     []BucketSpan
+		wantDeltas    []int64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:   "AllZero",
+			bucket: metricdata.ExponentialBucket{Offset: 3, Counts: []uint64{0, 0}},
+		},
+		{
+			name:       "Contiguous",
+			bucket:     metricdata.ExponentialBucket{Offset: -2, Counts: []uint64{1, 3, 2}},
+			wantSpans:  []BucketSpan{span(-1, 3)},
+			wantDeltas: []int64{1, 2, -1},
+		},
+		{
+			name:       "Gaps",
+			bucket:     metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{0, 2, 0, 0, 1, 1, 0, 5}},
+			wantSpans:  []BucketSpan{span(2, 1), span(2, 2), span(1, 1)},
+			wantDeltas: []int64{2, -1, 0, 4},
+		},
+		{
+			name:   "Downscale",
+			bucket: metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1, 2, 3, 4, 0, 0, 0, 0, 5}},
+			scale:  10,
+			schema: 8,
+			// Buckets 0-3 are merged into bucket 0 and bucket 8 into bucket 2.
+			wantSpans:  []BucketSpan{span(1, 1), span(1, 1)},
+			wantDeltas: []int64{10, -5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spans, deltas := NativeBuckets(tc.bucket, tc.scale, tc.schema)
+			assert.Equal(t, tc.wantSpans, spans)
+			assert.Equal(t, tc.wantDeltas, deltas)
+		})
+	}
+}
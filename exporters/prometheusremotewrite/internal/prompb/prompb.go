@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prompb provides the Prometheus remote-write 1.0 protobuf messages
+// used by the exporter.
+//
+// The messages are encoded directly with protowire to avoid depending on the
+// Prometheus server module. Only the fields used by the exporter are
+// supported.
+package prompb // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// WriteRequest is a remote-write request.
+type WriteRequest struct {
+	Timeseries []TimeSeries
+}
+
+// TimeSeries is a set of samples, exemplars, and native histograms of a
+// single series identified by its labels.
+type TimeSeries struct {
+	// Labels are expected to be sorted by name.
+	Labels     []Label
+	Samples    []Sample
+	Exemplars  []Exemplar
+	Histograms []Histogram
+}
+
+// Label is a label name-value pair.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a float sample with a timestamp in milliseconds since the epoch.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Exemplar is an exemplar with a timestamp in milliseconds since the epoch.
+type Exemplar struct {
+	Labels    []Label
+	Value     float64
+	Timestamp int64
+}
+
+// Histogram is an integer native histogram with a timestamp in milliseconds
+// since the epoch.
+type Histogram struct {
+	Count          uint64
+	Sum            float64
+	Schema         int32
+	ZeroThreshold  float64
+	ZeroCount      uint64
+	NegativeSpans  []BucketSpan
+	NegativeDeltas []int64
+	PositiveSpans  []BucketSpan
+	PositiveDeltas []int64
+	Timestamp      int64
+}
+
+// BucketSpan is a span of consecutive buckets of a native histogram.
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
+
+// Field numbers as defined in the Prometheus remote-write protobuf
+// definitions.
+const (
+	writeRequestTimeseries protowire.Number = 1
+
+	timeSeriesLabels     protowire.Number = 1
+	timeSeriesSamples    protowire.Number = 2
+	timeSeriesExemplars  protowire.Number = 3
+	timeSeriesHistograms protowire.Number = 4
+
+	labelName  protowire.Number = 1
+	labelValue protowire.Number = 2
+
+	sampleValue     protowire.Number = 1
+	sampleTimestamp protowire.Number = 2
+
+	exemplarLabels    protowire.Number = 1
+	exemplarValue     protowire.Number = 2
+	exemplarTimestamp protowire.Number = 3
+
+	histogramCountInt       protowire.Number = 1
+	histogramSum            protowire.Number = 3
+	histogramSchema         protowire.Number = 4
+	histogramZeroThreshold  protowire.Number = 5
+	histogramZeroCountInt   protowire.Number = 6
+	histogramNegativeSpans  protowire.Number = 8
+	histogramNegativeDeltas protowire.Number = 9
+	histogramPositiveSpans  protowire.Number = 11
+	histogramPositiveDeltas protowire.Number = 12
+	histogramTimestamp      protowire.Number = 15
+
+	bucketSpanOffset protowire.Number = 1
+	bucketSpanLength protowire.Number = 2
+)
+
+// Marshal returns the protobuf encoding of r.
+func (r *WriteRequest) Marshal() []byte {
+	var b []byte
+	for i := range r.Timeseries {
+		b = appendMessage(b, writeRequestTimeseries, r.Timeseries[i].appendTo)
+	}
+	return b
+}
+
+func (ts *TimeSeries) appendTo(b []byte) []byte {
+	for i := range ts.Labels {
+		b = appendMessage(b, timeSeriesLabels, ts.Labels[i].appendTo)
+	}
+	for i := range ts.Samples {
+		b = appendMessage(b, timeSeriesSamples, ts.Samples[i].appendTo)
+	}
+	for i := range ts.Exemplars {
+		b = appendMessage(b, timeSeriesExemplars, ts.Exemplars[i].appendTo)
+	}
+	for i := range ts.Histograms {
+		b = appendMessage(b, timeSeriesHistograms, ts.Histograms[i].appendTo)
+	}
+	return b
+}
+
+func (l *Label) appendTo(b []byte) []byte {
+	b = appendString(b, labelName, l.Name)
+	return appendString(b, labelValue, l.Value)
+}
+
+func (s *Sample) appendTo(b []byte) []byte {
+	b = appendDouble(b, sampleValue, s.Value)
+	return appendInt64(b, sampleTimestamp, s.Timestamp)
+}
+
+func (e *Exemplar) appendTo(b []byte) []byte {
+	for i := range e.Labels {
+		b = appendMessage(b, exemplarLabels, e.Labels[i].appendTo)
+	}
+	b = appendDouble(b, exemplarValue, e.Value)
+	return appendInt64(b, exemplarTimestamp, e.Timestamp)
+}
+
+func (h *Histogram) appendTo(b []byte) []byte {
+	// The count and zero count are members of a oneof and are always
+	// encoded to identify the histogram as an integer histogram.
+	b = protowire.AppendTag(b, histogramCountInt, protowire.VarintType)
+	b = protowire.AppendVarint(b, h.Count)
+	b = appendDouble(b, histogramSum, h.Sum)
+	if h.Schema != 0 {
+		b = protowire.AppendTag(b, histogramSchema, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(h.Schema)))
+	}
+	b = appendDouble(b, histogramZeroThreshold, h.ZeroThreshold)
+	b = protowire.AppendTag(b, histogramZeroCountInt, protowire.VarintType)
+	b = protowire.AppendVarint(b, h.ZeroCount)
+	for i := range h.NegativeSpans {
+		b = appendMessage(b, histogramNegativeSpans, h.NegativeSpans[i].appendTo)
+	}
+	b = appendDeltas(b, histogramNegativeDeltas, h.NegativeDeltas)
+	for i := range h.PositiveSpans {
+		b = appendMessage(b, histogramPositiveSpans, h.PositiveSpans[i].appendTo)
+	}
+	b = appendDeltas(b, histogramPositiveDeltas, h.PositiveDeltas)
+	return appendInt64(b, histogramTimestamp, h.Timestamp)
+}
+
+func (s *BucketSpan) appendTo(b []byte) []byte {
+	if s.Offset != 0 {
+		b = protowire.AppendTag(b, bucketSpanOffset, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(s.Offset)))
+	}
+	if s.Length != 0 {
+		b = protowire.AppendTag(b, bucketSpanLength, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(s.Length))
+	}
+	return b
+}
+
+// appendMessage appends the message encoded by fn as field num to b.
+func appendMessage(b []byte, num protowire.Number, fn func([]byte) []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, fn(nil))
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	// Compare the bits so negative zero and NaN values are encoded.
+	if math.Float64bits(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendInt64(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v)) // nolint: gosec  // Two's complement encoding.
+}
+
+// appendDeltas appends v as a packed repeated sint64 field num to b.
+func appendDeltas(b []byte, num protowire.Number, v []int64) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, d := range v {
+		packed = protowire.AppendVarint(packed, protowire.EncodeZigZag(d))
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prompb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalEncoding(t *testing.T) {
+	req := WriteRequest{Timeseries: []TimeSeries{{
+		Labels:  []Label{{Name: "a", Value: "b"}},
+		Samples: []Sample{{Value: 1, Timestamp: 2}},
+	}}}
+	want := []byte{
+		0x0a, 0x15, // timeseries, 21 bytes
+		0x0a, 0x06, // labels, 6 bytes
+		0x0a, 0x01, 'a', // name
+		0x12, 0x01, 'b', // value
+		0x12, 0x0b, // samples, 11 bytes
+		0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, // value
+		0x10, 0x02, // timestamp
+	}
+	assert.Equal(t, want, req.Marshal())
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prompbtest provides a decoder of the Prometheus remote write
+// protocol messages for testing.
+package prompbtest // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompbtest"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+)
+
+var errInvalidWireType = errors.New("invalid wire type")
+
+// The field numbers of the messages of the remote write protocol.
+const (
+	writeRequestTimeseries protowire.Number = 1
+
+	timeSeriesLabels     protowire.Number = 1
+	timeSeriesSamples    protowire.Number = 2
+	timeSeriesExemplars  protowire.Number = 3
+	timeSeriesHistograms protowire.Number = 4
+
+	labelName  protowire.Number = 1
+	labelValue protowire.Number = 2
+
+	sampleValue     protowire.Number = 1
+	sampleTimestamp protowire.Number = 2
+
+	exemplarLabels    protowire.Number = 1
+	exemplarValue     protowire.Number = 2
+	exemplarTimestamp protowire.Number = 3
+
+	histogramCountInt       protowire.Number = 1
+	histogramSum            protowire.Number = 3
+	histogramSchema         protowire.Number = 4
+	histogramZeroThreshold  protowire.Number = 5
+	histogramZeroCountInt   protowire.Number = 6
+	histogramNegativeSpans  protowire.Number = 8
+	histogramNegativeDeltas protowire.Number = 9
+	histogramPositiveSpans  protowire.Number = 11
+	histogramPositiveDeltas protowire.Number = 12
+	histogramTimestamp      protowire.Number = 15
+
+	bucketSpanOffset protowire.Number = 1
+	bucketSpanLength protowire.Number = 2
+)
+
+// Unmarshal decodes the protobuf encoding of a WriteRequest from b. Unknown
+// fields are skipped.
+func Unmarshal(b []byte) (prompb.WriteRequest, error) {
+	var r prompb.WriteRequest
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if num != writeRequestTimeseries {
+			return nil
+		}
+		var ts prompb.TimeSeries
+		if err := consumeMessage(typ, v, unmarshalTo(&ts, timeSeries)); err != nil {
+			return err
+		}
+		r.Timeseries = append(r.Timeseries, ts)
+		return nil
+	})
+	return r, err
+}
+
+// unmarshalTo returns a function decoding its argument into dst with fn.
+func unmarshalTo[T any](dst *T, fn func(*T, []byte) error) func([]byte) error {
+	return func(b []byte) error { return fn(dst, b) }
+}
+
+func timeSeries(ts *prompb.TimeSeries, b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case timeSeriesLabels:
+			var l prompb.Label
+			if err := consumeMessage(typ, v, unmarshalTo(&l, label)); err != nil {
+				return err
+			}
+			ts.Labels = append(ts.Labels, l)
+		case timeSeriesSamples:
+			var s prompb.Sample
+			if err := consumeMessage(typ, v, unmarshalTo(&s, sample)); err != nil {
+				return err
+			}
+			ts.Samples = append(ts.Samples, s)
+		case timeSeriesExemplars:
+			var e prompb.Exemplar
+			if err := consumeMessage(typ, v, unmarshalTo(&e, exemplar)); err != nil {
+				return err
+			}
+			ts.Exemplars = append(ts.Exemplars, e)
+		case timeSeriesHistograms:
+			var h prompb.Histogram
+			if err := consumeMessage(typ, v, unmarshalTo(&h, histogram)); err != nil {
+				return err
+			}
+			ts.Histograms = append(ts.Histograms, h)
+		}
+		return nil
+	})
+}
+
+func label(l *prompb.Label, b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case labelName:
+			l.Name, err = consumeString(typ, v)
+		case labelValue:
+			l.Value, err = consumeString(typ, v)
+		}
+		return err
+	})
+}
+
+func sample(s *prompb.Sample, b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case sampleValue:
+			s.Value, err = consumeDouble(typ, v)
+		case sampleTimestamp:
+			s.Timestamp, err = consumeInt64(typ, v)
+		}
+		return err
+	})
+}
+
+func exemplar(e *prompb.Exemplar, b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case exemplarLabels:
+			var l prompb.Label
+			err = consumeMessage(typ, v, unmarshalTo(&l, label))
+			e.Labels = append(e.Labels, l)
+		case exemplarValue:
+			e.Value, err = consumeDouble(typ, v)
+		case exemplarTimestamp:
+			e.Timestamp, err = consumeInt64(typ, v)
+		}
+		return err
+	})
+}
+
+func histogram(h *prompb.Histogram, b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case histogramCountInt:
+			h.Count, err = consumeUint64(typ, v)
+		case histogramSum:
+			h.Sum, err = consumeDouble(typ, v)
+		case histogramSchema:
+			var schema int64
+			schema, err = consumeSint64(typ, v)
+			h.Schema = int32(schema) // nolint: gosec  // Encoded as sint32.
+		case histogramZeroThreshold:
+			h.ZeroThreshold, err = consumeDouble(typ, v)
+		case histogramZeroCountInt:
+			h.ZeroCount, err = consumeUint64(typ, v)
+		case histogramNegativeSpans:
+			var s prompb.BucketSpan
+			err = consumeMessage(typ, v, unmarshalTo(&s, bucketSpan))
+			h.NegativeSpans = append(h.NegativeSpans, s)
+		case histogramNegativeDeltas:
+			h.NegativeDeltas, err = consumeDeltas(h.NegativeDeltas, typ, v)
+		case histogramPositiveSpans:
+			var s prompb.BucketSpan
+			err = consumeMessage(typ, v, unmarshalTo(&s, bucketSpan))
+			h.PositiveSpans = append(h.PositiveSpans, s)
+		case histogramPositiveDeltas:
+			h.PositiveDeltas, err = consumeDeltas(h.PositiveDeltas, typ, v)
+		case histogramTimestamp:
+			h.Timestamp, err = consumeInt64(typ, v)
+		}
+		return err
+	})
+}
+
+func bucketSpan(s *prompb.BucketSpan, b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case bucketSpanOffset:
+			var offset int64
+			offset, err = consumeSint64(typ, v)
+			s.Offset = int32(offset) // nolint: gosec  // Encoded as sint32.
+		case bucketSpanLength:
+			var length uint64
+			length, err = consumeUint64(typ, v)
+			s.Length = uint32(length) // nolint: gosec  // Encoded as uint32.
+		}
+		return err
+	})
+}
+
+// consumeFields calls fn with the number, type, and encoded value of each
+// field in b.
+func consumeFields(b []byte, fn func(protowire.Number, protowire.Type, []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		if err := fn(num, typ, b[:n]); err != nil {
+			return fmt.Errorf("field %d: %w", num, err)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func consumeMessage(typ protowire.Type, b []byte, fn func([]byte) error) error {
+	if typ != protowire.BytesType {
+		return errInvalidWireType
+	}
+	v, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return protowire.ParseError(n)
+	}
+	return fn(v)
+}
+
+func consumeString(typ protowire.Type, b []byte) (string, error) {
+	if typ != protowire.BytesType {
+		return "", errInvalidWireType
+	}
+	v, n := protowire.ConsumeString(b)
+	if n < 0 {
+		return "", protowire.ParseError(n)
+	}
+	return v, nil
+}
+
+func consumeDouble(typ protowire.Type, b []byte) (float64, error) {
+	if typ != protowire.Fixed64Type {
+		return 0, errInvalidWireType
+	}
+	v, n := protowire.ConsumeFixed64(b)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return math.Float64frombits(v), nil
+}
+
+func consumeUint64(typ protowire.Type, b []byte) (uint64, error) {
+	if typ != protowire.VarintType {
+		return 0, errInvalidWireType
+	}
+	v, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return v, nil
+}
+
+func consumeInt64(typ protowire.Type, b []byte) (int64, error) {
+	v, err := consumeUint64(typ, b)
+	return int64(v), err // nolint: gosec  // Two's complement encoding.
+}
+
+func consumeSint64(typ protowire.Type, b []byte) (int64, error) {
+	v, err := consumeUint64(typ, b)
+	return protowire.DecodeZigZag(v), err
+}
+
+// consumeDeltas appends the packed or unpacked sint64 values in b to dst.
+func consumeDeltas(dst []int64, typ protowire.Type, b []byte) ([]int64, error) {
+	if typ == protowire.VarintType {
+		v, err := consumeSint64(typ, b)
+		return append(dst, v), err
+	}
+	if typ != protowire.BytesType {
+		return dst, errInvalidWireType
+	}
+	packed, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return dst, protowire.ParseError(n)
+	}
+	for len(packed) > 0 {
+		v, n := protowire.ConsumeVarint(packed)
+		if n < 0 {
+			return dst, protowire.ParseError(n)
+		}
+		dst = append(dst, protowire.DecodeZigZag(v))
+		packed = packed[n:]
+	}
+	return dst, nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prompbtest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	want := prompb.WriteRequest{Timeseries: []prompb.TimeSeries{
+		{
+			Labels: []prompb.Label{
+				{Name: "__name__", Value: "requests_total"},
+				{Name: "job", Value: "svc"},
+			},
+			Samples: []prompb.Sample{{Value: 3.5, Timestamp: 1700000000000}},
+			Exemplars: []prompb.Exemplar{{
+				Labels:    []prompb.Label{{Name: "trace_id", Value: "01"}},
+				Value:     2,
+				Timestamp: 1700000000001,
+			}},
+		},
+		{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "negative"}},
+			Samples: []prompb.Sample{{Value: -1, Timestamp: -5}},
+		},
+		{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "inf"}},
+			Samples: []prompb.Sample{{Value: math.Inf(1)}},
+		},
+		{
+			Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+			Histograms: []prompb.Histogram{{
+				Count:          12,
+				Sum:            42.5,
+				Schema:         -2,
+				ZeroThreshold:  1e-9,
+				ZeroCount:      1,
+				NegativeSpans:  []prompb.BucketSpan{{Offset: -3, Length: 1}},
+				NegativeDeltas: []int64{2},
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}, {Offset: 3, Length: 1}},
+				PositiveDeltas: []int64{4, -1, 3},
+				Timestamp:      1700000000000,
+			}},
+		},
+		{
+			Labels:     []prompb.Label{{Name: "__name__", Value: "empty"}},
+			Histograms: []prompb.Histogram{{}},
+		},
+	}}
+
+	got, err := Unmarshal(want.Marshal())
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	_, err := Unmarshal([]byte{0x0a, 0x05, 0x0a})
+	assert.Error(t, err, "truncated")
+	// A timeseries field encoded as a varint.
+	_, err = Unmarshal([]byte{0x08, 0x01})
+	assert.ErrorIs(t, err, errInvalidWireType)
+}
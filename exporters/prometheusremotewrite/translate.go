@@ -0,0 +1,363 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/promconv"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+const (
+	targetInfoMetricName = "target_info"
+
+	metricNameLabel   = model.MetricNameLabel
+	jobLabel          = model.JobLabel
+	instanceLabel     = model.InstanceLabel
+	bucketLabel       = model.BucketLabel
+	quantileLabel     = model.QuantileLabel
+	scopeNameLabel    = "otel_scope_name"
+	scopeVersionLabel = "otel_scope_version"
+
+	traceIDExemplarKey = "trace_id"
+	spanIDExemplarKey  = "span_id"
+
+	counterSuffix = "_total"
+	bucketSuffix  = "_bucket"
+	sumSuffix     = "_sum"
+	countSuffix   = "_count"
+)
+
+var errUnsupportedAggregation = errors.New("unsupported aggregation")
+
+// translator translates cumulative OpenTelemetry metric data into
+// remote-write series.
+type translator struct {
+	namespace              string
+	disableTargetInfo      bool
+	disableScopeInfo       bool
+	withoutUnits           bool
+	withoutCounterSuffixes bool
+}
+
+func newTranslator(cfg config) translator {
+	return translator{
+		namespace:              cfg.namespace,
+		disableTargetInfo:      cfg.disableTargetInfo,
+		disableScopeInfo:       cfg.disableScopeInfo,
+		withoutUnits:           cfg.withoutUnits,
+		withoutCounterSuffixes: cfg.withoutCounterSuffixes,
+	}
+}
+
+// timeSeries returns the series of rm. The target_info series is
+// timestamped with now.
+//
+// Metrics that cannot be translated are dropped and an error describing them
+// is returned along with the series of all other metrics.
+func (t translator) timeSeries(rm *metricdata.ResourceMetrics, now time.Time) ([]prompb.TimeSeries, error) {
+	res := rm.Resource
+	if res == nil {
+		res = resource.Empty()
+	}
+	b := seriesBuilder{resourceLabels: resourceLabels(res)}
+
+	if !t.disableTargetInfo {
+		if attrs := targetInfoAttributes(res); attrs.Len() > 0 {
+			b.addSample(targetInfoMetricName, attrs, nil, 1, now, nil)
+		}
+	}
+
+	var errs []error
+	for _, sm := range rm.ScopeMetrics {
+		b.scopeLabels = nil
+		if !t.disableScopeInfo {
+			b.scopeLabels = scopeLabels(sm.Scope)
+		}
+		for _, m := range sm.Metrics {
+			switch v := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				addSum(&b, t.name(m, v.IsMonotonic), v)
+			case metricdata.Sum[float64]:
+				addSum(&b, t.name(m, v.IsMonotonic), v)
+			case metricdata.Gauge[int64]:
+				addGauge(&b, t.name(m, false), v)
+			case metricdata.Gauge[float64]:
+				addGauge(&b, t.name(m, false), v)
+			case metricdata.Histogram[int64]:
+				addHistogram(&b, t.name(m, false), v)
+			case metricdata.Histogram[float64]:
+				addHistogram(&b, t.name(m, false), v)
+			case metricdata.ExponentialHistogram[int64]:
+				addExponentialHistogram(&b, t.name(m, false), v)
+			case metricdata.ExponentialHistogram[float64]:
+				addExponentialHistogram(&b, t.name(m, false), v)
+			case metricdata.Summary:
+				addSummary(&b, t.name(m, false), v)
+			default:
+				errs = append(errs, fmt.Errorf("%w: %s (%T)", errUnsupportedAggregation, m.Name, m.Data))
+			}
+		}
+	}
+	return b.series, errors.Join(errs...)
+}
+
+// name returns the sanitized name of m, prefixed with the namespace and
+// suffixed with its unit. A counter suffix is added if counter is true.
+func (t translator) name(m metricdata.Metrics, counter bool) string {
+	name := model.EscapeName(m.Name, model.UnderscoreEscaping)
+	addCounterSuffix := counter && !t.withoutCounterSuffixes
+	if addCounterSuffix {
+		// Remove the _total suffix here, as we will re-add the total suffix
+		// later, and it needs to come after the unit suffix.
+		name = strings.TrimSuffix(name, counterSuffix)
+	}
+	name = t.namespace + name
+	if suffix, ok := promconv.UnitSuffix(m.Unit); ok && !t.withoutUnits && !strings.HasSuffix(name, suffix) {
+		name += suffix
+	}
+	if addCounterSuffix {
+		name += counterSuffix
+	}
+	return name
+}
+
+// resourceLabels returns the job and instance labels identifying the target
+// described by res.
+func resourceLabels(res *resource.Resource) map[string]string {
+	labels := make(map[string]string, 2)
+	set := res.Set()
+	if v, ok := set.Value(semconv.ServiceNameKey); ok {
+		job := v.Emit()
+		if ns, ok := set.Value(semconv.ServiceNamespaceKey); ok && ns.Emit() != "" {
+			job = ns.Emit() + "/" + job
+		}
+		labels[jobLabel] = job
+	}
+	if v, ok := set.Value(semconv.ServiceInstanceIDKey); ok {
+		labels[instanceLabel] = v.Emit()
+	}
+	return labels
+}
+
+// targetInfoAttributes returns the attributes of res that are not already
+// represented by the job and instance labels.
+func targetInfoAttributes(res *resource.Resource) attribute.Set {
+	attrs, _ := res.Set().Filter(func(kv attribute.KeyValue) bool {
+		switch kv.Key {
+		case semconv.ServiceNameKey, semconv.ServiceNamespaceKey, semconv.ServiceInstanceIDKey:
+			return false
+		}
+		return true
+	})
+	return attrs
+}
+
+func scopeLabels(scope instrumentation.Scope) map[string]string {
+	labels := map[string]string{scopeNameLabel: scope.Name}
+	if scope.Version != "" {
+		labels[scopeVersionLabel] = scope.Version
+	}
+	return labels
+}
+
+// seriesBuilder accumulates the series of an export.
+type seriesBuilder struct {
+	resourceLabels map[string]string
+	scopeLabels    map[string]string
+
+	series []prompb.TimeSeries
+}
+
+func (b *seriesBuilder) addSample(name string, attrs attribute.Set, extra []prompb.Label, value float64, t time.Time, exemplars []prompb.Exemplar) {
+	b.series = append(b.series, prompb.TimeSeries{
+		Labels:    b.labels(name, attrs, extra),
+		Samples:   []prompb.Sample{{Value: value, Timestamp: timestamp(t)}},
+		Exemplars: exemplars,
+	})
+}
+
+func (b *seriesBuilder) addHistogram(name string, attrs attribute.Set, h prompb.Histogram, exemplars []prompb.Exemplar) {
+	b.series = append(b.series, prompb.TimeSeries{
+		Labels:     b.labels(name, attrs, nil),
+		Histograms: []prompb.Histogram{h},
+		Exemplars:  exemplars,
+	})
+}
+
+// labels returns the sorted labels of a series named name with attrs and
+// extra labels.
+//
+// Attribute keys are sanitized and the values of attributes whose keys are
+// identical after sanitization are sorted and concatenated. The resource,
+// scope, extra, and name labels take precedence over attributes.
+func (b *seriesBuilder) labels(name string, attrs attribute.Set, extra []prompb.Label) []prompb.Label {
+	values := make(map[string][]string, attrs.Len())
+	for itr := attrs.Iter(); itr.Next(); {
+		kv := itr.Attribute()
+		key := labelName(string(kv.Key))
+		values[key] = append(values[key], kv.Value.Emit())
+	}
+
+	labels := make(map[string]string, len(values)+len(b.resourceLabels)+len(b.scopeLabels)+len(extra)+1)
+	for k, v := range values {
+		slices.Sort(v)
+		labels[k] = strings.Join(v, ";")
+	}
+	for k, v := range b.resourceLabels {
+		labels[k] = v
+	}
+	for k, v := range b.scopeLabels {
+		labels[k] = v
+	}
+	for _, l := range extra {
+		labels[l.Name] = l.Value
+	}
+	labels[metricNameLabel] = name
+	return sortedLabels(labels)
+}
+
+func sortedLabels(labels map[string]string) []prompb.Label {
+	out := make([]prompb.Label, 0, len(labels))
+	for k, v := range labels {
+		out = append(out, prompb.Label{Name: k, Value: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// labelName returns key sanitized to a valid Prometheus label name.
+func labelName(key string) string {
+	// Colons are valid in metric names, but not in label names.
+	return strings.ReplaceAll(model.EscapeName(key, model.UnderscoreEscaping), ":", "_")
+}
+
+// timestamp returns t as milliseconds since the epoch.
+func timestamp(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+func addSum[N int64 | float64](b *seriesBuilder, name string, sum metricdata.Sum[N]) {
+	for _, dp := range sum.DataPoints {
+		b.addSample(name, dp.Attributes, nil, float64(dp.Value), dp.Time, exemplars(dp.Exemplars))
+	}
+}
+
+func addGauge[N int64 | float64](b *seriesBuilder, name string, gauge metricdata.Gauge[N]) {
+	for _, dp := range gauge.DataPoints {
+		b.addSample(name, dp.Attributes, nil, float64(dp.Value), dp.Time, exemplars(dp.Exemplars))
+	}
+}
+
+func addHistogram[N int64 | float64](b *seriesBuilder, name string, histogram metricdata.Histogram[N]) {
+	for _, dp := range histogram.DataPoints {
+		// Exemplars are added to the bucket series of the bucket they
+		// belong to.
+		buckets := make([][]metricdata.Exemplar[N], len(dp.Bounds)+1)
+		for _, e := range dp.Exemplars {
+			i := sort.SearchFloat64s(dp.Bounds, float64(e.Value))
+			buckets[i] = append(buckets[i], e)
+		}
+
+		var cumulative uint64
+		for i, bound := range dp.Bounds {
+			if i < len(dp.BucketCounts) {
+				cumulative += dp.BucketCounts[i]
+			}
+			le := []prompb.Label{{Name: bucketLabel, Value: formatFloat(bound)}}
+			b.addSample(name+bucketSuffix, dp.Attributes, le, float64(cumulative), dp.Time, exemplars(buckets[i]))
+		}
+		inf := []prompb.Label{{Name: bucketLabel, Value: formatFloat(math.Inf(1))}}
+		b.addSample(name+bucketSuffix, dp.Attributes, inf, float64(dp.Count), dp.Time, exemplars(buckets[len(dp.Bounds)]))
+		b.addSample(name+sumSuffix, dp.Attributes, nil, float64(dp.Sum), dp.Time, nil)
+		b.addSample(name+countSuffix, dp.Attributes, nil, float64(dp.Count), dp.Time, nil)
+	}
+}
+
+func addExponentialHistogram[N int64 | float64](b *seriesBuilder, name string, histogram metricdata.ExponentialHistogram[N]) {
+	for _, dp := range histogram.DataPoints {
+		schema := promconv.NativeSchema(dp.Scale)
+		h := prompb.Histogram{
+			Count:         dp.Count,
+			Sum:           float64(dp.Sum),
+			Schema:        schema,
+			ZeroThreshold: dp.ZeroThreshold,
+			ZeroCount:     dp.ZeroCount,
+			Timestamp:     timestamp(dp.Time),
+		}
+		h.PositiveSpans, h.PositiveDeltas = nativeBuckets(dp.PositiveBucket, dp.Scale, schema)
+		h.NegativeSpans, h.NegativeDeltas = nativeBuckets(dp.NegativeBucket, dp.Scale, schema)
+		b.addHistogram(name, dp.Attributes, h, exemplars(dp.Exemplars))
+	}
+}
+
+func addSummary(b *seriesBuilder, name string, summary metricdata.Summary) {
+	for _, dp := range summary.DataPoints {
+		for _, q := range dp.QuantileValues {
+			quantile := []prompb.Label{{Name: quantileLabel, Value: formatFloat(q.Quantile)}}
+			b.addSample(name, dp.Attributes, quantile, q.Value, dp.Time, nil)
+		}
+		b.addSample(name+sumSuffix, dp.Attributes, nil, dp.Sum, dp.Time, nil)
+		b.addSample(name+countSuffix, dp.Attributes, nil, float64(dp.Count), dp.Time, nil)
+	}
+}
+
+func formatFloat(f float64) string {
+	return model.SampleValue(f).String()
+}
+
+// nativeBuckets returns the spans and delta encoded counts of a Prometheus
+// native histogram with schema for the exponential bucket b with scale.
+func nativeBuckets(b metricdata.ExponentialBucket, scale, schema int32) ([]prompb.BucketSpan, []int64) {
+	spans, deltas := promconv.NativeBuckets(b, scale, schema)
+	if len(spans) == 0 {
+		return nil, deltas
+	}
+	out := make([]prompb.BucketSpan, 0, len(spans))
+	for _, s := range spans {
+		out = append(out, prompb.BucketSpan(s))
+	}
+	return out, deltas
+}
+
+// exemplars returns the remote-write exemplars of exemplars.
+func exemplars[N int64 | float64](exemplars []metricdata.Exemplar[N]) []prompb.Exemplar {
+	if len(exemplars) == 0 {
+		return nil
+	}
+	out := make([]prompb.Exemplar, 0, len(exemplars))
+	for _, e := range exemplars {
+		labels := make(map[string]string, len(e.FilteredAttributes)+2)
+		for _, kv := range e.FilteredAttributes {
+			labels[labelName(string(kv.Key))] = kv.Value.Emit()
+		}
+		if len(e.TraceID) > 0 {
+			labels[traceIDExemplarKey] = hex.EncodeToString(e.TraceID)
+		}
+		if len(e.SpanID) > 0 {
+			labels[spanIDExemplarKey] = hex.EncodeToString(e.SpanID)
+		}
+		out = append(out, prompb.Exemplar{
+			Labels:    sortedLabels(labels),
+			Value:     float64(e.Value),
+			Timestamp: timestamp(e.Time),
+		})
+	}
+	return out
+}
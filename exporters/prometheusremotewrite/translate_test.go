@@ -0,0 +1,340 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/promconv"
+	"go.opentelemetry.io/otel/exporters/prometheusremotewrite/internal/prompb"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+var (
+	now  = time.Unix(1700000000, 0)
+	nowM = now.UnixMilli()
+
+	testResource = resource.NewSchemaless(
+		semconv.ServiceName("checkout"),
+		semconv.ServiceNamespace("shop"),
+		semconv.ServiceInstanceID("pod-1"),
+		attribute.String("host.name", "node-1"),
+	)
+	testScope = instrumentation.Scope{Name: "testmeter", Version: "v0.1.0"}
+	testAttrs = attribute.NewSet(attribute.String("A", "B"))
+)
+
+func labels(kv ...string) []prompb.Label {
+	out := make([]prompb.Label, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		out = append(out, prompb.Label{Name: kv[i], Value: kv[i+1]})
+	}
+	return out
+}
+
+// seriesLabels returns the sorted labels of a series from testScope and
+// testResource with the A="B" attribute and the extra labels kv.
+func seriesLabels(name string, kv ...string) []prompb.Label {
+	m := map[string]string{
+		"A":               "B",
+		metricNameLabel:   name,
+		jobLabel:          "shop/checkout",
+		instanceLabel:     "pod-1",
+		scopeNameLabel:    "testmeter",
+		scopeVersionLabel: "v0.1.0",
+	}
+	for i := 0; i < len(kv); i += 2 {
+		m[kv[i]] = kv[i+1]
+	}
+	return sortedLabels(m)
+}
+
+func translate(t *testing.T, tr translator, metrics ...metricdata.Metrics) []prompb.TimeSeries {
+	t.Helper()
+	series, err := tr.timeSeries(&metricdata.ResourceMetrics{
+		Resource: testResource,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope:   testScope,
+			Metrics: metrics,
+		}},
+	}, now)
+	require.NoError(t, err)
+	return series
+}
+
+func TestTranslateTargetInfo(t *testing.T) {
+	series := translate(t, translator{})
+	require.Len(t, series, 1)
+	assert.Equal(t, prompb.TimeSeries{
+		Labels: labels(
+			metricNameLabel, targetInfoMetricName,
+			"host_name", "node-1",
+			instanceLabel, "pod-1",
+			jobLabel, "shop/checkout",
+		),
+		Samples: []prompb.Sample{{Value: 1, Timestamp: nowM}},
+	}, series[0])
+
+	assert.Empty(t, translate(t, translator{disableTargetInfo: true}))
+}
+
+func TestTranslateSum(t *testing.T) {
+	tr := translator{disableTargetInfo: true}
+	series := translate(t, tr,
+		metricdata.Metrics{
+			Name: "http.requests",
+			Unit: "ms",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: testAttrs,
+					Time:       now,
+					Value:      5,
+					Exemplars: []metricdata.Exemplar[int64]{{
+						FilteredAttributes: []attribute.KeyValue{attribute.String("user.id", "1")},
+						Time:               now,
+						Value:              3,
+						TraceID:            []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+						SpanID:             []byte{0x02, 0, 0, 0, 0, 0, 0, 0},
+					}},
+				}},
+			},
+		},
+		metricdata.Metrics{
+			Name: "queue.size",
+			Data: metricdata.Sum[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[float64]{{
+					Attributes: testAttrs,
+					Time:       now,
+					Value:      -2.5,
+				}},
+			},
+		},
+	)
+	assert.Equal(t, []prompb.TimeSeries{
+		{
+			Labels:  seriesLabels("http_requests_milliseconds_total"),
+			Samples: []prompb.Sample{{Value: 5, Timestamp: nowM}},
+			Exemplars: []prompb.Exemplar{{
+				Labels: labels(
+					spanIDExemplarKey, "0200000000000000",
+					traceIDExemplarKey, "01000000000000000000000000000000",
+					"user_id", "1",
+				),
+				Value:     3,
+				Timestamp: nowM,
+			}},
+		},
+		{
+			Labels:  seriesLabels("queue_size"),
+			Samples: []prompb.Sample{{Value: -2.5, Timestamp: nowM}},
+		},
+	}, series)
+}
+
+func TestTranslateNaming(t *testing.T) {
+	sum := metricdata.Sum[int64]{
+		IsMonotonic: true,
+		DataPoints:  []metricdata.DataPoint[int64]{{Time: now}},
+	}
+	m := metricdata.Metrics{Name: "bytes.sent_total", Unit: "By", Data: sum}
+
+	testCases := []struct {
+		name string
+		tr   translator
+		want string
+	}{
+		{name: "Default", want: "bytes_sent_bytes_total"},
+		{name: "Namespace", tr: translator{namespace: "ns_"}, want: "ns_bytes_sent_bytes_total"},
+		{name: "WithoutUnits", tr: translator{withoutUnits: true}, want: "bytes_sent_total"},
+		{name: "WithoutCounterSuffixes", tr: translator{withoutCounterSuffixes: true}, want: "bytes_sent_total_bytes"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.tr.name(m, true))
+		})
+	}
+}
+
+func TestTranslateLabels(t *testing.T) {
+	tr := translator{disableTargetInfo: true, disableScopeInfo: true}
+	series := translate(t, tr, metricdata.Metrics{
+		Name: "gauge",
+		Data: metricdata.Gauge[int64]{
+			DataPoints: []metricdata.DataPoint[int64]{{
+				Attributes: attribute.NewSet(
+					attribute.String("foo.bar", "b"),
+					attribute.String("foo_bar", "a"),
+					attribute.String("job", "ignored"),
+					attribute.String("0key", "c"),
+				),
+				Time:  now,
+				Value: 1,
+			}},
+		},
+	})
+	require.Len(t, series, 1)
+	assert.Equal(t, labels(
+		metricNameLabel, "gauge",
+		"_key", "c",
+		"foo_bar", "a;b",
+		instanceLabel, "pod-1",
+		jobLabel, "shop/checkout",
+	), series[0].Labels)
+}
+
+func TestTranslateHistogram(t *testing.T) {
+	tr := translator{disableTargetInfo: true}
+	series := translate(t, tr, metricdata.Metrics{
+		Name: "latency",
+		Unit: "s",
+		Data: metricdata.Histogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.HistogramDataPoint[float64]{{
+				Attributes:   testAttrs,
+				Time:         now,
+				Count:        6,
+				Sum:          12.5,
+				Bounds:       []float64{1, 5},
+				BucketCounts: []uint64{1, 3, 2},
+				Exemplars: []metricdata.Exemplar[float64]{
+					{Time: now, Value: 5},
+					{Time: now, Value: 7},
+				},
+			}},
+		},
+	})
+	assert.Equal(t, []prompb.TimeSeries{
+		{
+			Labels:  seriesLabels("latency_seconds_bucket", bucketLabel, "1"),
+			Samples: []prompb.Sample{{Value: 1, Timestamp: nowM}},
+		},
+		{
+			Labels:    seriesLabels("latency_seconds_bucket", bucketLabel, "5"),
+			Samples:   []prompb.Sample{{Value: 4, Timestamp: nowM}},
+			Exemplars: []prompb.Exemplar{{Labels: []prompb.Label{}, Value: 5, Timestamp: nowM}},
+		},
+		{
+			Labels:    seriesLabels("latency_seconds_bucket", bucketLabel, "+Inf"),
+			Samples:   []prompb.Sample{{Value: 6, Timestamp: nowM}},
+			Exemplars: []prompb.Exemplar{{Labels: []prompb.Label{}, Value: 7, Timestamp: nowM}},
+		},
+		{
+			Labels:  seriesLabels("latency_seconds_sum"),
+			Samples: []prompb.Sample{{Value: 12.5, Timestamp: nowM}},
+		},
+		{
+			Labels:  seriesLabels("latency_seconds_count"),
+			Samples: []prompb.Sample{{Value: 6, Timestamp: nowM}},
+		},
+	}, series)
+}
+
+func TestTranslateExponentialHistogram(t *testing.T) {
+	tr := translator{disableTargetInfo: true}
+	series := translate(t, tr, metricdata.Metrics{
+		Name: "size",
+		Data: metricdata.ExponentialHistogram[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+				Attributes:    testAttrs,
+				Time:          now,
+				Count:         9,
+				Sum:           40,
+				Scale:         10,
+				ZeroCount:     1,
+				ZeroThreshold: 0.001,
+				// Buckets 0-3 are merged into bucket 0 and bucket 8 into
+				// bucket 2 at schema 8.
+				PositiveBucket: metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1, 2, 1, 1, 0, 0, 0, 0, 2}},
+				NegativeBucket: metricdata.ExponentialBucket{Offset: -4, Counts: []uint64{1}},
+				Exemplars:      []metricdata.Exemplar[int64]{{Time: now, Value: 4}},
+			}},
+		},
+	})
+	assert.Equal(t, []prompb.TimeSeries{{
+		Labels: seriesLabels("size"),
+		Histograms: []prompb.Histogram{{
+			Count:          9,
+			Sum:            40,
+			Schema:         promconv.MaxNativeHistogramSchema,
+			ZeroThreshold:  0.001,
+			ZeroCount:      1,
+			NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}},
+			NegativeDeltas: []int64{1},
+			PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}, {Offset: 1, Length: 1}},
+			PositiveDeltas: []int64{5, -3},
+			Timestamp:      nowM,
+		}},
+		Exemplars: []prompb.Exemplar{{Labels: []prompb.Label{}, Value: 4, Timestamp: nowM}},
+	}}, series)
+}
+
+func TestTranslateSummary(t *testing.T) {
+	tr := translator{disableTargetInfo: true}
+	series := translate(t, tr, metricdata.Metrics{
+		Name: "rpc.duration",
+		Data: metricdata.Summary{
+			DataPoints: []metricdata.SummaryDataPoint{{
+				Attributes: testAttrs,
+				Time:       now,
+				Count:      4,
+				Sum:        10,
+				QuantileValues: []metricdata.QuantileValue{
+					{Quantile: 0.5, Value: 2},
+					{Quantile: 0.99, Value: 4},
+				},
+			}},
+		},
+	})
+	assert.Equal(t, []prompb.TimeSeries{
+		{
+			Labels:  seriesLabels("rpc_duration", quantileLabel, "0.5"),
+			Samples: []prompb.Sample{{Value: 2, Timestamp: nowM}},
+		},
+		{
+			Labels:  seriesLabels("rpc_duration", quantileLabel, "0.99"),
+			Samples: []prompb.Sample{{Value: 4, Timestamp: nowM}},
+		},
+		{
+			Labels:  seriesLabels("rpc_duration_sum"),
+			Samples: []prompb.Sample{{Value: 10, Timestamp: nowM}},
+		},
+		{
+			Labels:  seriesLabels("rpc_duration_count"),
+			Samples: []prompb.Sample{{Value: 4, Timestamp: nowM}},
+		},
+	}, series)
+}
+
+type unknownAggregation struct {
+	metricdata.Aggregation
+}
+
+func TestTranslateUnsupportedAggregation(t *testing.T) {
+	tr := translator{disableTargetInfo: true}
+	series, err := tr.timeSeries(&metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{
+				{Name: "unknown", Data: unknownAggregation{}},
+				{Name: "gauge", Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Time: now, Value: 1}},
+				}},
+			},
+		}},
+	}, now)
+	assert.ErrorIs(t, err, errUnsupportedAggregation)
+	require.Len(t, series, 1)
+	assert.Equal(t, labels(metricNameLabel, "gauge", scopeNameLabel, ""), series[0].Labels)
+}
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheusremotewrite"
+
+// Version is the current release version of the Prometheus remote-write
+// exporter in use.
+func Version() string {
+	return "0.53.0"
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// client sends lines to a StatsD agent.
+type client struct {
+	network       string
+	address       string
+	maxPacketSize int
+	dialer        net.Dialer
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newClient(cfg config) (*client, error) {
+	switch cfg.network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unixgram":
+	default:
+		return nil, fmt.Errorf("unsupported network: %q", cfg.network)
+	}
+	return &client{
+		network:       cfg.network,
+		address:       cfg.address,
+		maxPacketSize: cfg.maxPacketSize,
+	}, nil
+}
+
+// stream returns if the client sends to a stream oriented network.
+func (c *client) stream() bool {
+	switch c.network {
+	case "tcp", "tcp4", "tcp6":
+		return true
+	}
+	return false
+}
+
+// send sends lines to the agent in packets of at most maxPacketSize bytes.
+//
+// The connection to the agent is established on the first send. If sending
+// fails, the connection is closed and established again on the next send.
+func (c *client) send(ctx context.Context, lines [][]byte) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		conn, err := c.dialer.DialContext(ctx, c.network, c.address)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s://%s: %w", c.network, c.address, err)
+		}
+		c.conn = conn
+	}
+
+	// The zero deadline of a context without a deadline clears it.
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetWriteDeadline(deadline); err != nil {
+		return c.reset(err)
+	}
+
+	for _, packet := range packets(lines, c.maxPacketSize, c.stream()) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := c.conn.Write(packet); err != nil {
+			return c.reset(err)
+		}
+	}
+	return nil
+}
+
+// reset closes the connection so it is established again on the next send
+// and returns err. The caller must hold the lock.
+func (c *client) reset(err error) error {
+	err = fmt.Errorf("failed to send to %s://%s: %w", c.network, c.address, err)
+	if cErr := c.conn.Close(); cErr != nil {
+		err = errors.Join(err, cErr)
+	}
+	c.conn = nil
+	return err
+}
+
+// shutdown closes the connection to the agent.
+func (c *client) shutdown() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// packets returns lines joined by newlines into packets of at most size
+// bytes. A line larger than size is returned in its own packet. If
+// terminate is true, every line is terminated by a newline so packets can be
+// written to a stream.
+func packets(lines [][]byte, size int, terminate bool) [][]byte {
+	var (
+		out    [][]byte
+		packet []byte
+	)
+	for _, line := range lines {
+		n := len(line)
+		if terminate || len(packet) > 0 {
+			// Account for the newline.
+			n++
+		}
+		if len(packet) > 0 && len(packet)+n > size {
+			out = append(out, packet)
+			packet = nil
+		}
+		if len(packet) > 0 && !terminate {
+			packet = append(packet, '\n')
+		}
+		packet = append(packet, line...)
+		if terminate {
+			packet = append(packet, '\n')
+		}
+	}
+	if len(packet) > 0 {
+		out = append(out, packet)
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackets(t *testing.T) {
+	lines := [][]byte{
+		[]byte("a:1|c"),
+		[]byte("b:2|c"),
+		[]byte("c:3|c"),
+		[]byte("long.metric.name:4|c"),
+	}
+
+	testCases := []struct {
+		name      string
+		size      int
+		terminate bool
+		want      []string
+	}{
+		{
+			name: "AllInOne",
+			size: 1432,
+			want: []string{"a:1|c\nb:2|c\nc:3|c\nlong.metric.name:4|c"},
+		},
+		{
+			name: "Split",
+			size: 11,
+			want: []string{"a:1|c\nb:2|c", "c:3|c", "long.metric.name:4|c"},
+		},
+		{
+			name:      "Terminated",
+			size:      12,
+			terminate: true,
+			want:      []string{"a:1|c\nb:2|c\n", "c:3|c\n", "long.metric.name:4|c\n"},
+		},
+		{
+			name: "Oversized",
+			size: 1,
+			want: []string{"a:1|c", "b:2|c", "c:3|c", "long.metric.name:4|c"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := packets(lines, tc.size, tc.terminate)
+			assert.Equal(t, tc.want, strs(got))
+			for _, p := range got {
+				if len(p) > tc.size {
+					// Only single lines can exceed the size.
+					assert.NotContains(t, string(p[:len(p)-1]), "\n")
+				}
+			}
+		})
+	}
+
+	assert.Empty(t, packets(nil, 10, false))
+}
+
+func TestNewClientInvalidNetwork(t *testing.T) {
+	_, err := newClient(newConfig(WithEndpoint("ip", "localhost")))
+	assert.ErrorContains(t, err, "unsupported network")
+}
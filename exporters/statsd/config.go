@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	defaultNetwork       = "udp"
+	defaultAddress       = "localhost:8125"
+	defaultMaxPacketSize = 1432
+	defaultTimeout       = 10 * time.Second
+)
+
+// Flavor is the dialect of the StatsD protocol used by the Exporter.
+type Flavor int
+
+const (
+	// DogStatsD is the DogStatsD dialect. Attributes are sent as tags and
+	// histograms are sent as distributions.
+	DogStatsD Flavor = iota
+	// StatsD is the original StatsD dialect. It does not support tags, so
+	// attributes are not sent. Histograms are sent as timings in
+	// milliseconds.
+	StatsD
+)
+
+// config contains options for the exporter.
+type config struct {
+	network             string
+	address             string
+	maxPacketSize       int
+	timeout             time.Duration
+	flavor              Flavor
+	prefix              string
+	resourceFilter      attribute.Filter
+	aggregationSelector metric.AggregationSelector
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(options ...Option) config {
+	cfg := config{
+		network:       defaultNetwork,
+		address:       defaultAddress,
+		maxPacketSize: defaultMaxPacketSize,
+		timeout:       defaultTimeout,
+	}
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+
+	if cfg.aggregationSelector == nil {
+		cfg.aggregationSelector = metric.DefaultAggregationSelector
+	}
+
+	return cfg
+}
+
+// temporality returns the Temporality the Exporter uses for an instrument
+// kind. Counters and histograms are sent as deltas, all other instruments
+// are sent as gauges of their current value.
+func temporality(k metric.InstrumentKind) metricdata.Temporality {
+	switch k {
+	case metric.InstrumentKindCounter, metric.InstrumentKindObservableCounter, metric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	}
+	return metricdata.CumulativeTemporality
+}
+
+// Option sets exporter option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (o optionFunc) apply(c config) config {
+	return o(c)
+}
+
+// WithEndpoint sets the network and address of the StatsD agent. The network
+// must be "udp", "tcp", or "unixgram". For "unixgram", address is the path
+// of the socket. If this option is not used, the "udp" network and
+// "localhost:8125" address are used.
+func WithEndpoint(network, address string) Option {
+	return optionFunc(func(c config) config {
+		c.network = network
+		c.address = address
+		return c
+	})
+}
+
+// WithMaxPacketSize sets the maximum size in bytes of a packet sent to the
+// agent. Lines are batched into packets of up to this size. A line larger
+// than size is sent in its own packet. If this option is not used, a size of
+// 1432 bytes is used, which fits in the UDP payload of an Ethernet frame.
+//
+// If size is less than or equal to zero, the option is ignored.
+func WithMaxPacketSize(size int) Option {
+	return optionFunc(func(c config) config {
+		if size > 0 {
+			c.maxPacketSize = size
+		}
+		return c
+	})
+}
+
+// WithTimeout sets the maximum time an export can take to connect to the
+// agent and send all packets. If this option is not used, a timeout of 10
+// seconds is used.
+//
+// If timeout is less than or equal to zero, the option is ignored.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c config) config {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+		return c
+	})
+}
+
+// WithFlavor sets the dialect of the StatsD protocol used. If this option is
+// not used, DogStatsD is used.
+func WithFlavor(flavor Flavor) Option {
+	return optionFunc(func(c config) config {
+		c.flavor = flavor
+		return c
+	})
+}
+
+// WithPrefix sets a prefix added to the name of all metrics. For example,
+// with the prefix "checkout." the metric http.server.request.duration is
+// sent as checkout.http.server.request.duration.
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(c config) config {
+		c.prefix = prefix
+		return c
+	})
+}
+
+// WithResourceAsTags configures the Exporter to send the resource attributes
+// allowed by filter as tags of all metrics. Tags are only sent with the
+// DogStatsD flavor. By default, no resource attributes are sent.
+func WithResourceAsTags(filter attribute.Filter) Option {
+	return optionFunc(func(c config) config {
+		c.resourceFilter = filter
+		return c
+	})
+}
+
+// WithAggregationSelector sets the AggregationSelector the exporter uses to
+// determine the aggregation to use for an instrument. If this option is not
+// used, the DefaultAggregationSelector is used.
+func WithAggregationSelector(selector metric.AggregationSelector) Option {
+	return optionFunc(func(c config) config {
+		c.aggregationSelector = selector
+		return c
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewConfig(t *testing.T) {
+	filter := func(attribute.KeyValue) bool { return true }
+	aggSelector := func(metric.InstrumentKind) metric.Aggregation {
+		return metric.AggregationDrop{}
+	}
+
+	testCases := []struct {
+		name    string
+		options []Option
+		check   func(*testing.T, config)
+	}{
+		{
+			name: "Defaults",
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, defaultNetwork, cfg.network)
+				assert.Equal(t, defaultAddress, cfg.address)
+				assert.Equal(t, defaultMaxPacketSize, cfg.maxPacketSize)
+				assert.Equal(t, defaultTimeout, cfg.timeout)
+				assert.Equal(t, DogStatsD, cfg.flavor)
+				assert.Empty(t, cfg.prefix)
+				assert.Nil(t, cfg.resourceFilter)
+				assert.Equal(t, metric.AggregationSum{}, cfg.aggregationSelector(metric.InstrumentKindCounter))
+			},
+		},
+		{
+			name: "WithEndpoint",
+			options: []Option{
+				WithEndpoint("unixgram", "/var/run/statsd.sock"),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, "unixgram", cfg.network)
+				assert.Equal(t, "/var/run/statsd.sock", cfg.address)
+			},
+		},
+		{
+			name: "WithMaxPacketSize",
+			options: []Option{
+				WithMaxPacketSize(8192),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, 8192, cfg.maxPacketSize)
+			},
+		},
+		{
+			name: "WithMaxPacketSize/Invalid",
+			options: []Option{
+				WithMaxPacketSize(0),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, defaultMaxPacketSize, cfg.maxPacketSize)
+			},
+		},
+		{
+			name: "WithTimeout",
+			options: []Option{
+				WithTimeout(time.Second),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, time.Second, cfg.timeout)
+			},
+		},
+		{
+			name: "WithTimeout/Invalid",
+			options: []Option{
+				WithTimeout(-time.Second),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, defaultTimeout, cfg.timeout)
+			},
+		},
+		{
+			name: "WithFlavor",
+			options: []Option{
+				WithFlavor(StatsD),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, StatsD, cfg.flavor)
+			},
+		},
+		{
+			name: "WithPrefix",
+			options: []Option{
+				WithPrefix("app."),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, "app.", cfg.prefix)
+			},
+		},
+		{
+			name: "WithResourceAsTags",
+			options: []Option{
+				WithResourceAsTags(filter),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.NotNil(t, cfg.resourceFilter)
+			},
+		},
+		{
+			name: "WithAggregationSelector",
+			options: []Option{
+				WithAggregationSelector(aggSelector),
+			},
+			check: func(t *testing.T, cfg config) {
+				assert.Equal(t, metric.AggregationDrop{}, cfg.aggregationSelector(metric.InstrumentKindCounter))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, newConfig(tc.options...))
+		})
+	}
+}
+
+func TestTemporality(t *testing.T) {
+	want := map[metric.InstrumentKind]metricdata.Temporality{
+		metric.InstrumentKindCounter:                 metricdata.DeltaTemporality,
+		metric.InstrumentKindObservableCounter:       metricdata.DeltaTemporality,
+		metric.InstrumentKindHistogram:               metricdata.DeltaTemporality,
+		metric.InstrumentKindUpDownCounter:           metricdata.CumulativeTemporality,
+		metric.InstrumentKindObservableUpDownCounter: metricdata.CumulativeTemporality,
+		metric.InstrumentKindGauge:                   metricdata.CumulativeTemporality,
+		metric.InstrumentKindObservableGauge:         metricdata.CumulativeTemporality,
+	}
+	for k, temp := range want {
+		assert.Equal(t, temp, temporality(k), k.String())
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statsd provides a metric Exporter that sends OpenTelemetry metrics
+// to a StatsD or DogStatsD agent over UDP, TCP, or a Unix datagram socket.
+//
+// Metric data is sent as follows:
+//
+//   - Monotonic Sums are sent as counters of their delta value.
+//   - Non-monotonic Sums and Gauges are sent as gauges of their current
+//     value.
+//   - Histograms and ExponentialHistograms are sent as distributions with
+//     the DogStatsD flavor and as timings with the StatsD flavor. A line
+//     is sent for each non-empty bucket with the midpoint of the bucket as
+//     its value and a sample rate of the inverse of the bucket count.
+//
+// Summaries are not supported.
+//
+// Counters and histograms use a delta temporality. Monotonic sums and
+// histograms with a cumulative temporality, e.g. from a Producer, are not
+// supported and are dropped with an error.
+//
+// With the DogStatsD flavor, attributes are sent as tags. Lines are batched
+// into packets that do not exceed the size set with WithMaxPacketSize.
+//
+// Use the Exporter with a PeriodicReader.
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// StatsD metric types.
+const (
+	counterType      = "c"
+	gaugeType        = "g"
+	timingType       = "ms"
+	distributionType = "d"
+)
+
+var (
+	errUnsupportedAggregation = errors.New("unsupported aggregation")
+	errUnsupportedTemporality = errors.New("unsupported temporality")
+)
+
+// nameReplacer replaces the characters of a metric name that are reserved by
+// the StatsD protocol.
+var nameReplacer = strings.NewReplacer(
+	":", "_",
+	"|", "_",
+	"@", "_",
+	"#", "_",
+	",", "_",
+	" ", "_",
+	"\n", "_",
+)
+
+// tagReplacer replaces the characters of a tag that are reserved by the
+// DogStatsD protocol.
+var tagReplacer = strings.NewReplacer(
+	"|", "_",
+	",", "_",
+	"#", "_",
+	"\n", "_",
+)
+
+// encoder encodes metric data as StatsD lines.
+type encoder struct {
+	flavor         Flavor
+	prefix         string
+	resourceFilter attribute.Filter
+}
+
+func newEncoder(cfg config) encoder {
+	return encoder{
+		flavor:         cfg.flavor,
+		prefix:         cfg.prefix,
+		resourceFilter: cfg.resourceFilter,
+	}
+}
+
+// encode returns the StatsD lines of rm.
+//
+// Metrics that cannot be encoded are dropped and an error describing them is
+// returned along with the lines of all other metrics.
+func (e encoder) encode(rm *metricdata.ResourceMetrics) ([][]byte, error) {
+	var resTags []string
+	if e.resourceFilter != nil && e.flavor == DogStatsD {
+		res := rm.Resource
+		if res == nil {
+			res = resource.Empty()
+		}
+		attrs, _ := res.Set().Filter(e.resourceFilter)
+		resTags = tags(attrs)
+	}
+
+	var (
+		lines [][]byte
+		errs  []error
+	)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			name := e.prefix + nameReplacer.Replace(m.Name)
+			w := lineWriter{flavor: e.flavor, name: name, resourceTags: resTags}
+			var err error
+			switch v := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				err = encodeSum(&w, v)
+			case metricdata.Sum[float64]:
+				err = encodeSum(&w, v)
+			case metricdata.Gauge[int64]:
+				encodeGauge(&w, v)
+			case metricdata.Gauge[float64]:
+				encodeGauge(&w, v)
+			case metricdata.Histogram[int64]:
+				err = encodeHistogram(&w, v, m.Unit)
+			case metricdata.Histogram[float64]:
+				err = encodeHistogram(&w, v, m.Unit)
+			case metricdata.ExponentialHistogram[int64]:
+				err = encodeExponentialHistogram(&w, v, m.Unit)
+			case metricdata.ExponentialHistogram[float64]:
+				err = encodeExponentialHistogram(&w, v, m.Unit)
+			default:
+				err = fmt.Errorf("%w: %T", errUnsupportedAggregation, m.Data)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", m.Name, err))
+			}
+			lines = append(lines, w.lines...)
+		}
+	}
+	return lines, errors.Join(errs...)
+}
+
+func encodeSum[N int64 | float64](w *lineWriter, sum metricdata.Sum[N]) error {
+	if !sum.IsMonotonic {
+		// Non-monotonic sums are sent as the gauge of their current value.
+		if sum.Temporality != metricdata.CumulativeTemporality {
+			return fmt.Errorf("%w: non-monotonic %s sum", errUnsupportedTemporality, sum.Temporality)
+		}
+		for _, dp := range sum.DataPoints {
+			w.write(float64(dp.Value), gaugeType, 1, dp.Attributes)
+		}
+		return nil
+	}
+	if sum.Temporality != metricdata.DeltaTemporality {
+		return fmt.Errorf("%w: monotonic %s sum", errUnsupportedTemporality, sum.Temporality)
+	}
+	for _, dp := range sum.DataPoints {
+		w.write(float64(dp.Value), counterType, 1, dp.Attributes)
+	}
+	return nil
+}
+
+func encodeGauge[N int64 | float64](w *lineWriter, gauge metricdata.Gauge[N]) {
+	for _, dp := range gauge.DataPoints {
+		w.write(float64(dp.Value), gaugeType, 1, dp.Attributes)
+	}
+}
+
+// encodeHistogram writes a line for each non-empty bucket of the histogram.
+// The value of a line is the midpoint of the bucket, narrowed by the minimum
+// and maximum of the data point, and its sample rate is the inverse of the
+// bucket count so the agent counts every measurement of the bucket.
+func encodeHistogram[N int64 | float64](w *lineWriter, histogram metricdata.Histogram[N], unit string) error {
+	if histogram.Temporality != metricdata.DeltaTemporality {
+		return fmt.Errorf("%w: %s histogram", errUnsupportedTemporality, histogram.Temporality)
+	}
+	typ, scale := w.histogramType(unit)
+	for _, dp := range histogram.DataPoints {
+		minV, minOK := dp.Min.Value()
+		maxV, maxOK := dp.Max.Value()
+		for i, count := range dp.BucketCounts {
+			if count == 0 {
+				continue
+			}
+			lower, upper := math.Inf(-1), math.Inf(1)
+			if i > 0 && i-1 < len(dp.Bounds) {
+				lower = dp.Bounds[i-1]
+			}
+			if i < len(dp.Bounds) {
+				upper = dp.Bounds[i]
+			}
+			if minOK {
+				lower = max(lower, float64(minV))
+			}
+			if maxOK {
+				upper = min(upper, float64(maxV))
+			}
+
+			var v float64
+			switch {
+			case !math.IsInf(lower, 0) && !math.IsInf(upper, 0):
+				v = (lower + upper) / 2
+			case !math.IsInf(upper, 0):
+				v = upper
+			case !math.IsInf(lower, 0):
+				v = lower
+			default:
+				v = float64(dp.Sum) / float64(dp.Count)
+			}
+			w.write(v*scale, typ, 1/float64(count), dp.Attributes)
+		}
+	}
+	return nil
+}
+
+// encodeExponentialHistogram writes a line for the zero bucket and each
+// non-empty bucket of the histogram. The value of a line is the midpoint of
+// the bucket and its sample rate is the inverse of the bucket count so the
+// agent counts every measurement of the bucket.
+func encodeExponentialHistogram[N int64 | float64](w *lineWriter, histogram metricdata.ExponentialHistogram[N], unit string) error {
+	if histogram.Temporality != metricdata.DeltaTemporality {
+		return fmt.Errorf("%w: %s exponential histogram", errUnsupportedTemporality, histogram.Temporality)
+	}
+	typ, scale := w.histogramType(unit)
+	for _, dp := range histogram.DataPoints {
+		base := math.Exp2(math.Exp2(-float64(dp.Scale)))
+		if dp.ZeroCount > 0 {
+			w.write(0, typ, 1/float64(dp.ZeroCount), dp.Attributes)
+		}
+		for i, count := range dp.NegativeBucket.Counts {
+			if count == 0 {
+				continue
+			}
+			v := -exponentialMidpoint(base, dp.NegativeBucket.Offset+int32(i)) // nolint: gosec  // Bucket count limited by the aggregation.
+			w.write(v*scale, typ, 1/float64(count), dp.Attributes)
+		}
+		for i, count := range dp.PositiveBucket.Counts {
+			if count == 0 {
+				continue
+			}
+			v := exponentialMidpoint(base, dp.PositiveBucket.Offset+int32(i)) // nolint: gosec  // Bucket count limited by the aggregation.
+			w.write(v*scale, typ, 1/float64(count), dp.Attributes)
+		}
+	}
+	return nil
+}
+
+// exponentialMidpoint returns the midpoint of the exponential histogram
+// bucket with index, which contains values in (base^index, base^(index+1)].
+func exponentialMidpoint(base float64, index int32) float64 {
+	lower := math.Pow(base, float64(index))
+	return (lower + lower*base) / 2
+}
+
+// lineWriter writes the lines of a metric.
+type lineWriter struct {
+	flavor       Flavor
+	name         string
+	resourceTags []string
+
+	lines [][]byte
+}
+
+// histogramType returns the StatsD type histograms are sent as and the
+// factor to scale their values with to match the type.
+func (w *lineWriter) histogramType(unit string) (string, float64) {
+	if w.flavor == DogStatsD {
+		return distributionType, 1
+	}
+	// Timings are in milliseconds.
+	switch unit {
+	case "s":
+		return timingType, 1e3
+	case "us":
+		return timingType, 1e-3
+	case "ns":
+		return timingType, 1e-6
+	}
+	return timingType, 1
+}
+
+// write writes a line with value, type, sample rate, and the tags of attrs.
+// Values that are not finite cannot be represented and are not written.
+func (w *lineWriter) write(value float64, typ string, rate float64, attrs attribute.Set) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	var b []byte
+	b = append(b, w.name...)
+	b = append(b, ':')
+	b = strconv.AppendFloat(b, value, 'f', -1, 64)
+	b = append(b, '|')
+	b = append(b, typ...)
+	if rate < 1 {
+		b = append(b, "|@"...)
+		b = strconv.AppendFloat(b, rate, 'g', -1, 64)
+	}
+	if w.flavor == DogStatsD {
+		t := append(tags(attrs), w.resourceTags...)
+		if len(t) > 0 {
+			b = append(b, "|#"...)
+			b = append(b, strings.Join(t, ",")...)
+		}
+	}
+	w.lines = append(w.lines, b)
+}
+
+// tags returns attrs as DogStatsD tags.
+func tags(attrs attribute.Set) []string {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	out := make([]string, 0, attrs.Len())
+	for itr := attrs.Iter(); itr.Next(); {
+		kv := itr.Attribute()
+		// The first colon separates the key from the value.
+		out = append(out, nameReplacer.Replace(string(kv.Key))+":"+tagReplacer.Replace(kv.Value.Emit()))
+	}
+	return out
+}
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var attrs = attribute.NewSet(attribute.String("method", "GET"), attribute.Int("code", 200))
+
+func resourceMetrics(metrics ...metricdata.Metrics) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(attribute.String("service.name", "checkout"), attribute.String("host.name", "a|b")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope:   instrumentation.Scope{Name: "test"},
+			Metrics: metrics,
+		}},
+	}
+}
+
+func strs(lines [][]byte) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = string(l)
+	}
+	return out
+}
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []Option
+		metric  metricdata.Metrics
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Counter",
+			metric: metricdata.Metrics{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					IsMonotonic: true,
+					DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 3}},
+				},
+			},
+			want: []string{"requests:3|c|#code:200,method:GET"},
+		},
+		{
+			name: "UpDownCounter",
+			metric: metricdata.Metrics{
+				Name: "queue.size",
+				Data: metricdata.Sum[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  []metricdata.DataPoint[float64]{{Value: -1.5}},
+				},
+			},
+			want: []string{"queue.size:-1.5|g"},
+		},
+		{
+			name: "Gauge",
+			metric: metricdata.Metrics{
+				Name: "temperature",
+				Data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{
+						{Value: 21.5},
+						{Value: math.NaN()},
+					},
+				},
+			},
+			want: []string{"temperature:21.5|g"},
+		},
+		{
+			name: "Histogram",
+			metric: metricdata.Metrics{
+				Name: "latency",
+				Unit: "s",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.DeltaTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Count:        7,
+						Sum:          10,
+						Bounds:       []float64{1, 2, 5},
+						BucketCounts: []uint64{1, 0, 4, 2},
+						Min:          metricdata.NewExtrema(0.5),
+						Max:          metricdata.NewExtrema(7.0),
+					}},
+				},
+			},
+			want: []string{
+				"latency:0.75|d",
+				"latency:3.5|d|@0.25",
+				"latency:6|d|@0.5",
+			},
+		},
+		{
+			name:    "Histogram/StatsD",
+			options: []Option{WithFlavor(StatsD)},
+			metric: metricdata.Metrics{
+				Name: "latency",
+				Unit: "s",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.DeltaTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Attributes:   attrs,
+						Count:        2,
+						Sum:          1,
+						Bounds:       []float64{0.1, 1},
+						BucketCounts: []uint64{0, 2, 0},
+					}},
+				},
+			},
+			want: []string{"latency:550|ms|@0.5"},
+		},
+		{
+			name: "ExponentialHistogram",
+			metric: metricdata.Metrics{
+				Name: "size",
+				Data: metricdata.ExponentialHistogram[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+						Count:          4,
+						Scale:          0,
+						ZeroCount:      1,
+						PositiveBucket: metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{2}},
+						NegativeBucket: metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1}},
+					}},
+				},
+			},
+			want: []string{
+				"size:0|d",
+				"size:-1.5|d",
+				"size:3|d|@0.5",
+			},
+		},
+		{
+			name:    "Prefix",
+			options: []Option{WithPrefix("app.")},
+			metric: metricdata.Metrics{
+				Name: "http:requests",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Value: 1}},
+				},
+			},
+			want: []string{"app.http_requests:1|g"},
+		},
+		{
+			name: "ResourceAsTags",
+			options: []Option{WithResourceAsTags(func(attribute.KeyValue) bool {
+				return true
+			})},
+			metric: metricdata.Metrics{
+				Name: "up",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 1}},
+				},
+			},
+			want: []string{"up:1|g|#code:200,method:GET,host.name:a_b,service.name:checkout"},
+		},
+		{
+			name: "StatsD/NoTags",
+			options: []Option{
+				WithFlavor(StatsD),
+				WithResourceAsTags(func(attribute.KeyValue) bool { return true }),
+			},
+			metric: metricdata.Metrics{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					IsMonotonic: true,
+					DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 3}},
+				},
+			},
+			want: []string{"requests:3|c"},
+		},
+		{
+			name: "CumulativeCounter",
+			metric: metricdata.Metrics{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints:  []metricdata.DataPoint[int64]{{Value: 3}},
+				},
+			},
+			wantErr: errUnsupportedTemporality,
+		},
+		{
+			name: "Summary",
+			metric: metricdata.Metrics{
+				Name: "summary",
+				Data: metricdata.Summary{},
+			},
+			wantErr: errUnsupportedAggregation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newEncoder(newConfig(tc.options...))
+			lines, err := e.encode(resourceMetrics(tc.metric))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, lines)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, strs(lines))
+		})
+	}
+}
+
+func TestEncodePartial(t *testing.T) {
+	e := newEncoder(newConfig())
+	lines, err := e.encode(resourceMetrics(
+		metricdata.Metrics{Name: "summary", Data: metricdata.Summary{}},
+		metricdata.Metrics{
+			Name: "up",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{{Value: 1}},
+			},
+		},
+	))
+	assert.ErrorIs(t, err, errUnsupportedAggregation)
+	assert.Equal(t, []string{"up:1|g"}, strs(lines))
+}
+
+func TestHistogramType(t *testing.T) {
+	testCases := []struct {
+		flavor    Flavor
+		unit      string
+		wantType  string
+		wantScale float64
+	}{
+		{flavor: DogStatsD, unit: "s", wantType: distributionType, wantScale: 1},
+		{flavor: StatsD, unit: "s", wantType: timingType, wantScale: 1e3},
+		{flavor: StatsD, unit: "ms", wantType: timingType, wantScale: 1},
+		{flavor: StatsD, unit: "us", wantType: timingType, wantScale: 1e-3},
+		{flavor: StatsD, unit: "ns", wantType: timingType, wantScale: 1e-6},
+		{flavor: StatsD, unit: "By", wantType: timingType, wantScale: 1},
+	}
+	for _, tc := range testCases {
+		w := lineWriter{flavor: tc.flavor}
+		typ, scale := w.histogramType(tc.unit)
+		assert.Equal(t, tc.wantType, typ, tc.unit)
+		assert.Equal(t, tc.wantScale, scale, tc.unit)
+	}
+}
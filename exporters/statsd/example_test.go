@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd_test
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/statsd"
+	"go.opentelemetry.io/otel/sdk/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+func ExampleNew() {
+	// Create an exporter that sends to a local DogStatsD agent and tags all
+	// metrics with the service name.
+	exp, err := statsd.New(
+		statsd.WithEndpoint("udp", "localhost:8125"),
+		statsd.WithResourceAsTags(func(kv attribute.KeyValue) bool {
+			return kv.Key == semconv.ServiceNameKey
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Periodically send metrics with the exporter.
+	provider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exp)))
+	// Ensure the final metrics are sent before the application exits.
+	defer func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			panic(err)
+		}
+	}()
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errShutdown = errors.New("statsd exporter is shutdown")
+
+// Exporter is a metric Exporter that sends metric data to a StatsD agent.
+type Exporter struct {
+	encoder             encoder
+	aggregationSelector metric.AggregationSelector
+	timeout             time.Duration
+
+	client   *client
+	shutdown atomic.Bool
+}
+
+var _ metric.Exporter = (*Exporter)(nil)
+
+// New returns an Exporter that sends metric data to a StatsD agent. The
+// Exporter is meant to be used with a PeriodicReader.
+//
+// The connection to the agent is established on the first export.
+func New(options ...Option) (*Exporter, error) {
+	cfg := newConfig(options...)
+	c, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		client:              c,
+		encoder:             newEncoder(cfg),
+		aggregationSelector: cfg.aggregationSelector,
+		timeout:             cfg.timeout,
+	}, nil
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (e *Exporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return temporality(k)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return e.aggregationSelector(k)
+}
+
+// Export encodes rm as StatsD lines and sends them to the agent.
+//
+// Metrics that cannot be encoded are dropped and reported in the returned
+// error. All other metrics are still sent.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	defer global.Debug("StatsD exporter export", "Data", rm)
+	if e.shutdown.Load() {
+		return errShutdown
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	lines, err := e.encoder.encode(rm)
+	// Best effort send of encodable metrics.
+	if sendErr := e.client.send(ctx, lines); sendErr != nil {
+		if err == nil {
+			return sendErr
+		}
+		// Merge the two errors.
+		return fmt.Errorf("failed to send incomplete metrics (%w): %w", err, sendErr)
+	}
+	return err
+}
+
+// ForceFlush does nothing, the Exporter holds no data to flush.
+//
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown closes the connection to the agent. After Shutdown is called,
+// calls to Export will return an error.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	if e.shutdown.Swap(true) {
+		return errShutdown
+	}
+	if err := e.client.shutdown(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// MarshalLog returns logging data about the Exporter.
+func (e *Exporter) MarshalLog() interface{} {
+	return struct{ Type string }{Type: "StatsD"}
+}
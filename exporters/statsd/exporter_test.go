@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// record records measurements with a MeterProvider using exp and returns the
+// provider.
+func record(t *testing.T, exp *Exporter) *metric.MeterProvider {
+	t.Helper()
+
+	ctx := context.Background()
+	reader := metric.NewPeriodicReader(exp, metric.WithInterval(time.Hour))
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	counter, err := meter.Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(ctx, 2, otelmetric.WithAttributes(attribute.String("method", "GET")))
+
+	gauge, err := meter.Float64Gauge("temperature")
+	require.NoError(t, err)
+	gauge.Record(ctx, 21.5)
+
+	return provider
+}
+
+// readPackets reads n packets from conn.
+func readPackets(t *testing.T, conn net.PacketConn, n int) []string {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var out []string
+	buf := make([]byte, 65535)
+	for i := 0; i < n; i++ {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		out = append(out, string(buf[:n]))
+	}
+	return out
+}
+
+func TestExporterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exp, err := New(WithEndpoint("udp", conn.LocalAddr().String()))
+	require.NoError(t, err)
+	provider := record(t, exp)
+
+	ctx := context.Background()
+	require.NoError(t, provider.ForceFlush(ctx))
+	got := readPackets(t, conn, 1)
+	assert.Equal(t, []string{"requests:2|c|#method:GET\ntemperature:21.5|g"}, got)
+
+	// Counters are sent as deltas.
+	require.NoError(t, provider.ForceFlush(ctx))
+	got = readPackets(t, conn, 1)
+	assert.Equal(t, []string{"temperature:21.5|g"}, got)
+
+	require.NoError(t, provider.Shutdown(ctx))
+}
+
+func TestExporterUDPMaxPacketSize(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exp, err := New(WithEndpoint("udp", conn.LocalAddr().String()), WithMaxPacketSize(30))
+	require.NoError(t, err)
+	provider := record(t, exp)
+
+	ctx := context.Background()
+	require.NoError(t, provider.ForceFlush(ctx))
+	got := readPackets(t, conn, 2)
+	assert.Equal(t, []string{"requests:2|c|#method:GET", "temperature:21.5|g"}, got)
+
+	require.NoError(t, provider.Shutdown(ctx))
+}
+
+func TestExporterTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	lines := make(chan string)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s := bufio.NewScanner(conn)
+		for s.Scan() {
+			lines <- s.Text()
+		}
+		close(lines)
+	}()
+
+	exp, err := New(WithEndpoint("tcp", ln.Addr().String()), WithFlavor(StatsD))
+	require.NoError(t, err)
+	provider := record(t, exp)
+
+	ctx := context.Background()
+	require.NoError(t, provider.ForceFlush(ctx))
+	assert.Equal(t, "requests:2|c", <-lines)
+	assert.Equal(t, "temperature:21.5|g", <-lines)
+
+	// The final export on shutdown is sent before the connection is closed.
+	require.NoError(t, provider.Shutdown(ctx))
+	assert.Equal(t, "temperature:21.5|g", <-lines)
+	_, ok := <-lines
+	assert.False(t, ok, "connection not closed")
+}
+
+func TestExporterUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exp, err := New(WithEndpoint("unixgram", path))
+	require.NoError(t, err)
+	provider := record(t, exp)
+
+	ctx := context.Background()
+	require.NoError(t, provider.ForceFlush(ctx))
+	got := readPackets(t, conn, 1)
+	assert.Equal(t, []string{"requests:2|c|#method:GET\ntemperature:21.5|g"}, got)
+
+	require.NoError(t, provider.Shutdown(ctx))
+}
+
+func TestExporterConnectError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+	exp, err := New(WithEndpoint("unixgram", path))
+	require.NoError(t, err)
+	provider := record(t, exp)
+
+	err = provider.ForceFlush(context.Background())
+	assert.ErrorContains(t, err, "failed to connect")
+}
+
+func TestExporterShutdown(t *testing.T) {
+	exp, err := New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.ErrorIs(t, exp.Shutdown(ctx), errShutdown)
+	assert.ErrorIs(t, exp.Export(ctx, resourceMetrics()), errShutdown)
+}
+
+func TestNewInvalidNetwork(t *testing.T) {
+	_, err := New(WithEndpoint("ip", "localhost"))
+	assert.Error(t, err)
+}
+
+func TestExporterMarshalLog(t *testing.T) {
+	exp, err := New()
+	require.NoError(t, err)
+	assert.Equal(t, struct{ Type string }{Type: "StatsD"}, exp.MarshalLog())
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+// Version is the current release version of the StatsD exporter in use.
+func Version() string {
+	return "0.53.0"
+}
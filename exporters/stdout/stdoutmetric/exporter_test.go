@@ -178,3 +178,54 @@ func TestAggregationSelector(t *testing.T) {
 	var unknownKind metric.InstrumentKind
 	assert.Equal(t, metric.AggregationDrop{}, exp.Aggregation(unknownKind))
 }
+
+func TestExportSummaryWithoutTimestamps(t *testing.T) {
+	var b bytes.Buffer
+	exp, err := stdoutmetric.New(
+		stdoutmetric.WithWriter(&b),
+		stdoutmetric.WithoutTimestamps(),
+	)
+	require.NoError(t, err)
+
+	now := time.Now()
+	data := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "latency",
+				Data: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{{
+						StartTime: now,
+						Time:      now.Add(time.Second),
+						Count:     4,
+						Sum:       236,
+						QuantileValues: []metricdata.QuantileValue{
+							{Quantile: 0.5, Value: 23},
+							{Quantile: 0.99, Value: 105},
+						},
+					}},
+				},
+			}},
+		}},
+	}
+	require.NoError(t, exp.Export(context.Background(), data))
+
+	var got struct {
+		ScopeMetrics []struct {
+			Metrics []struct {
+				Data struct {
+					DataPoints []metricdata.SummaryDataPoint
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &got))
+	require.Len(t, got.ScopeMetrics, 1)
+	require.Len(t, got.ScopeMetrics[0].Metrics, 1)
+	dPts := got.ScopeMetrics[0].Metrics[0].Data.DataPoints
+	require.Len(t, dPts, 1)
+	assert.True(t, dPts[0].StartTime.IsZero(), "start time not redacted")
+	assert.True(t, dPts[0].Time.IsZero(), "time not redacted")
+	assert.Equal(t, uint64(4), dPts[0].Count)
+	assert.Equal(t, 236.0, dPts[0].Sum)
+	assert.Len(t, dPts[0].QuantileValues, 2)
+}
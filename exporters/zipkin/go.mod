@@ -3,6 +3,7 @@ module go.opentelemetry.io/otel/exporters/zipkin
 go 1.22
 
 require (
+	github.com/cenkalti/backoff/v4 v4.3.0
 	github.com/go-logr/logr v1.4.2
 	github.com/go-logr/stdr v1.2.2
 	github.com/google/go-cmp v0.6.0
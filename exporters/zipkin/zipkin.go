@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -14,10 +15,12 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
 
+	"go.opentelemetry.io/otel/exporters/zipkin/internal/retry"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -32,6 +35,8 @@ type Exporter struct {
 	logger  logr.Logger
 	headers map[string]string
 
+	requestFunc retry.RequestFunc
+
 	stoppedMu sync.RWMutex
 	stopped   bool
 }
@@ -45,6 +50,7 @@ type config struct {
 	client  *http.Client
 	logger  logr.Logger
 	headers map[string]string
+	retry   retry.Config
 }
 
 // Option defines a function that configures the exporter.
@@ -89,6 +95,22 @@ func WithClient(client *http.Client) Option {
 	})
 }
 
+// RetryConfig defines configuration for retrying the export of span batches
+// that failed to be received by the Zipkin collector.
+type RetryConfig retry.Config
+
+// WithRetry configures the retry policy for transient errors that may occur
+// when exporting spans: the request failed to reach the Zipkin collector, or
+// the collector responded with a 429, 502, 503 or 504 status code.
+//
+// By default, failed requests are not retried.
+func WithRetry(rc RetryConfig) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.retry = retry.Config(rc)
+		return cfg
+	})
+}
+
 // New creates a new Zipkin exporter.
 func New(collectorURL string, opts ...Option) (*Exporter, error) {
 	if collectorURL == "" {
@@ -116,6 +138,8 @@ func New(collectorURL string, opts ...Option) (*Exporter, error) {
 		client:  cfg.client,
 		logger:  cfg.logger,
 		headers: cfg.headers,
+
+		requestFunc: cfg.retry.RequestFunc(evaluate),
 	}, nil
 }
 
@@ -139,7 +163,14 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 		return e.errf("failed to serialize zipkin models to JSON: %v", err)
 	}
 	e.logf("about to send a POST request to %s with body %s", e.url, body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewBuffer(body))
+	return e.requestFunc(ctx, func(ctx context.Context) error {
+		return e.send(ctx, body)
+	})
+}
+
+// send sends a POST request with body to the Zipkin collector.
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
 	if err != nil {
 		return e.errf("failed to create request to %s: %v", e.url, err)
 	}
@@ -155,7 +186,8 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 
 	resp, err := e.client.Do(req)
 	if err != nil {
-		return e.errf("request to %s failed: %v", e.url, err)
+		e.logf("request to %s failed: %v", e.url, err)
+		return fmt.Errorf("request to %s failed: %w", e.url, err)
 	}
 	defer resp.Body.Close()
 
@@ -169,12 +201,36 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 	}
 
 	if resp.StatusCode != http.StatusAccepted {
-		return e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+		return &retry.HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Err:        e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode),
+		}
 	}
 
 	return nil
 }
 
+// evaluate returns if err identifies a request that can be retried: the
+// request failed to reach the Zipkin collector, or the collector is
+// overloaded or unavailable.
+func evaluate(err error) (bool, time.Duration) {
+	var sErr *retry.HTTPStatusError
+	if errors.As(err, &sErr) {
+		switch sErr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true, 0
+		}
+		return false, 0
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr), 0
+}
+
 // Shutdown stops the exporter flushing any pending exports.
 func (e *Exporter) Shutdown(ctx context.Context) error {
 	e.stoppedMu.Lock()
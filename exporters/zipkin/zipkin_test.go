@@ -7,17 +7,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
 	"time"
 
 	ottest "go.opentelemetry.io/otel/exporters/zipkin/internal/internaltest"
+	"go.opentelemetry.io/otel/exporters/zipkin/internal/retry"
 
 	"github.com/go-logr/logr/funcr"
 	zkmodel "github.com/openzipkin/zipkin-go/model"
@@ -413,6 +416,8 @@ func TestWithHeaders(t *testing.T) {
 		url:     srv.URL,
 		client:  srv.Client(),
 		headers: headers,
+
+		requestFunc: retry.Config{}.RequestFunc(evaluate),
 	}
 
 	_ = e.ExportSpans(context.Background(), spans)
@@ -421,3 +426,109 @@ func TestWithHeaders(t *testing.T) {
 	assert.Equal(t, headers["name1"], req.Header.Get("name1"))
 	assert.Equal(t, headers["name2"], req.Header.Get("name2"))
 }
+
+func TestWithRetry(t *testing.T) {
+	var requests int
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusAccepted}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(statuses[requests%len(statuses)])
+		requests++
+	}))
+	defer srv.Close()
+
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	fastRetry := RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		MaxElapsedTime:  time.Minute,
+	}
+
+	exp, err := New(srv.URL, WithRetry(fastRetry))
+	require.NoError(t, err)
+	assert.NoError(t, exp.ExportSpans(context.Background(), spans))
+	assert.Equal(t, 3, requests)
+
+	// Not retried by default.
+	requests = 0
+	exp, err = New(srv.URL)
+	require.NoError(t, err)
+	err = exp.ExportSpans(context.Background(), spans)
+	assert.EqualError(t, err, "failed to send spans to zipkin server with status 503")
+	var sErr *HTTPStatusError
+	require.ErrorAs(t, err, &sErr)
+	assert.Equal(t, http.StatusServiceUnavailable, sErr.StatusCode)
+	assert.Equal(t, 1, requests)
+}
+
+func TestWithRetryNotRetryable(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exp, err := New(srv.URL, WithRetry(RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+	}))
+	require.NoError(t, err)
+	err = exp.ExportSpans(context.Background(), tracetest.SpanStubs{{Name: "span"}}.Snapshots())
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestWithRetryCircuitBreaker(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exp, err := New(srv.URL, WithRetry(RetryConfig{
+		// Classify the Bad Request responses as transient.
+		Classify: func(err error) RetryClassification {
+			var sErr *HTTPStatusError
+			if errors.As(err, &sErr) && sErr.StatusCode == http.StatusBadRequest {
+				return ClassifyRetryable
+			}
+			return ClassifyDefault
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenDuration:     time.Hour,
+		},
+	}))
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	for i := 0; i < 2; i++ {
+		assert.Error(t, exp.ExportSpans(context.Background(), spans))
+	}
+	assert.ErrorIs(t, exp.ExportSpans(context.Background(), spans), ErrCircuitOpen)
+	assert.Equal(t, 2, requests)
+}
+
+func TestEvaluate(t *testing.T) {
+	for code, want := range map[int]bool{
+		http.StatusBadRequest:          false,
+		http.StatusInternalServerError: false,
+		http.StatusTooManyRequests:     true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	} {
+		got, _ := evaluate(&retry.HTTPStatusError{StatusCode: code})
+		assert.Equalf(t, want, got, "status %d", code)
+	}
+
+	got, _ := evaluate(fmt.Errorf("request failed: %w", &url.Error{Err: assert.AnError}))
+	assert.True(t, got, "transport errors must be retried")
+
+	got, _ = evaluate(assert.AnError)
+	assert.False(t, got)
+}
@@ -187,3 +187,89 @@ func (e AggregationBase2ExponentialHistogram) err() error {
 	}
 	return nil
 }
+
+// AggregationSummary is an Aggregation that summarizes a set of measurements
+// as a set of quantiles. The quantiles are estimated from a mergeable
+// DDSketch quantile sketch with a bounded relative error.
+//
+// Only non-negative measurements can be summarized. Negative measurements
+// are counted and added to the sum, but are reported as 0 in any quantile
+// they are ranked at.
+type AggregationSummary struct {
+	// Quantiles are the quantiles reported by the summary. Each value needs
+	// to be in the interval [0, 1], and the values need to be increasing.
+	//
+	// If Quantiles is empty, the quantiles 0.5, 0.9, 0.95, and 0.99 are used.
+	Quantiles []float64
+	// RelativeError is the relative accuracy guaranteed for all estimated
+	// quantile values. For example, a value of 0.01 means a reported p99 of
+	// 100ms is within 1ms of the true p99. The quantiles 0 and 1 are always
+	// reported exactly.
+	//
+	// RelativeError needs to be in the interval (0, 1). If RelativeError is
+	// zero, a relative error of 0.01 is used.
+	RelativeError float64
+	// MaxSize is the maximum number of buckets used by the sketch of each
+	// attribute set. When more buckets are needed, the lowest ones are
+	// collapsed and the relative error of low quantiles is no longer
+	// guaranteed.
+	//
+	// If MaxSize is zero, a maximum of 2048 buckets is used. This is enough to
+	// cover values from 1ns to over a day with the default relative error.
+	MaxSize int32
+}
+
+var _ Aggregation = AggregationSummary{}
+
+const (
+	defaultSummaryRelativeError       = 0.01
+	defaultSummaryMaxSize       int32 = 2048
+)
+
+// errSummary is returned by misconfigured Summaries.
+var errSummary = fmt.Errorf("%w: summary", errAgg)
+
+// err returns an error for any misconfiguration.
+func (s AggregationSummary) err() error {
+	for i, q := range s.Quantiles {
+		if q < 0 || q > 1 {
+			return fmt.Errorf("%w: quantile %v outside of [0, 1]", errSummary, q)
+		}
+		if i > 0 && s.Quantiles[i-1] >= q {
+			return fmt.Errorf("%w: non-monotonic quantiles: %v", errSummary, s.Quantiles)
+		}
+	}
+	if s.RelativeError < 0 || s.RelativeError >= 1 {
+		return fmt.Errorf("%w: relative error %v outside of (0, 1)", errSummary, s.RelativeError)
+	}
+	if s.MaxSize < 0 {
+		return fmt.Errorf("%w: max size %d is less than zero", errSummary, s.MaxSize)
+	}
+	return nil
+}
+
+// copy returns a deep copy of s.
+func (s AggregationSummary) copy() Aggregation {
+	return AggregationSummary{
+		Quantiles:     slices.Clone(s.Quantiles),
+		RelativeError: s.RelativeError,
+		MaxSize:       s.MaxSize,
+	}
+}
+
+// params returns the parameters of s with all defaults resolved.
+func (s AggregationSummary) params() (quantiles []float64, relErr float64, maxSize int) {
+	quantiles = s.Quantiles
+	if len(quantiles) == 0 {
+		quantiles = []float64{0.5, 0.9, 0.95, 0.99}
+	}
+	relErr = s.RelativeError
+	if relErr == 0 {
+		relErr = defaultSummaryRelativeError
+	}
+	size := s.MaxSize
+	if size == 0 {
+		size = defaultSummaryMaxSize
+	}
+	return quantiles, relErr, int(size)
+}
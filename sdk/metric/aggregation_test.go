@@ -72,6 +72,34 @@ func TestAggregationErr(t *testing.T) {
 			MaxScale: 30,
 		}.err(), errAgg)
 	})
+
+	t.Run("SummaryOperation", func(t *testing.T) {
+		assert.NoError(t, AggregationSummary{}.err())
+
+		assert.NoError(t, AggregationSummary{
+			Quantiles:     []float64{0, 0.5, 0.99, 1},
+			RelativeError: 0.001,
+			MaxSize:       4096,
+		}.err())
+	})
+
+	t.Run("InvalidSummaryOperation", func(t *testing.T) {
+		assert.ErrorIs(t, AggregationSummary{
+			Quantiles: []float64{0.99, 0.5},
+		}.err(), errAgg)
+
+		assert.ErrorIs(t, AggregationSummary{
+			Quantiles: []float64{1.5},
+		}.err(), errAgg)
+
+		assert.ErrorIs(t, AggregationSummary{
+			RelativeError: 1,
+		}.err(), errAgg)
+
+		assert.ErrorIs(t, AggregationSummary{
+			MaxSize: -1,
+		}.err(), errAgg)
+	})
 }
 
 func TestExplicitBucketHistogramDeepCopy(t *testing.T) {
@@ -82,3 +110,12 @@ func TestExplicitBucketHistogramDeepCopy(t *testing.T) {
 	b[0] = orig + 1
 	assert.Equal(t, orig, cpH.Boundaries[0], "changing the underlying slice data should not affect the copy")
 }
+
+func TestSummaryDeepCopy(t *testing.T) {
+	const orig = 0.5
+	q := []float64{orig}
+	s := AggregationSummary{Quantiles: q}
+	cpS := s.copy().(AggregationSummary)
+	q[0] = orig + 0.1
+	assert.Equal(t, orig, cpS.Quantiles[0], "changing the underlying slice data should not affect the copy")
+}
@@ -143,6 +143,19 @@ func (b Builder[N]) ExponentialBucketHistogram(maxSize, maxScale int32, noMinMax
 	}
 }
 
+// Summary returns a summary aggregate function input and output. The
+// quantiles are estimated with a relative accuracy of relErr using at most
+// maxSize buckets per attribute set.
+func (b Builder[N]) Summary(quantiles []float64, relErr float64, maxSize int) (Measure[N], ComputeAggregation) {
+	s := newSummary[N](quantiles, relErr, maxSize, b.AggregationLimit)
+	switch b.Temporality {
+	case metricdata.DeltaTemporality:
+		return b.filter(s.measure), s.delta
+	default:
+		return b.filter(s.measure), s.cumulative
+	}
+}
+
 // reset ensures s has capacity and sets it length. If the capacity of s too
 // small, a new slice is returned with the specified capacity and length.
 func reset[T any](s []T, length, capacity int) []T {
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+
+import (
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Bucketed is a distribution of values already summarized into buckets. It
+// uses the same layout as the runtime/metrics Float64Histogram: a bucket with
+// index i contains the values in [Buckets[i], Buckets[i+1]). The first and
+// last boundaries may be infinite.
+type Bucketed struct {
+	// Buckets are the len(Counts)+1 increasing bucket boundaries.
+	Buckets []float64
+	// Counts are the number of values in each bucket.
+	Counts []uint64
+}
+
+// value returns the value used to represent all values of bucket i.
+//
+// This is the midpoint of the bucket. If a boundary of the bucket is
+// infinite, the finite boundary is used instead. For an upper infinite
+// bucket, the smallest value greater than the finite boundary is used so the
+// value is not placed in the bucket below when it is converted.
+func (b Bucketed) value(i int) float64 {
+	lo, hi := b.Buckets[i], b.Buckets[i+1]
+	switch {
+	case math.IsInf(lo, -1) && math.IsInf(hi, 1):
+		return 0
+	case math.IsInf(lo, -1):
+		return hi
+	case math.IsInf(hi, 1):
+		return math.Nextafter(lo, hi)
+	}
+	return lo + (hi-lo)/2
+}
+
+// valid returns if b has a consistent layout.
+func (b Bucketed) valid() bool {
+	return len(b.Counts) > 0 && len(b.Buckets) == len(b.Counts)+1
+}
+
+// Bounds returns the finite boundaries of b as explicit bucket histogram
+// boundaries. Converting b to an explicit bucket histogram with these
+// boundaries maps each bucket of b to a bucket of the histogram.
+func (b Bucketed) Bounds() []float64 {
+	bounds := make([]float64, 0, len(b.Buckets))
+	for _, v := range b.Buckets {
+		if !math.IsInf(v, 0) {
+			bounds = append(bounds, v)
+		}
+	}
+	return bounds
+}
+
+// ExplicitBucketHistogram returns b converted into a histogram data point
+// with explicitly defined buckets. The returned data point has no attributes
+// or timestamps set.
+//
+// All values of a bucket of b are assumed to be equal to the midpoint of that
+// bucket. The returned sum is an estimate based on this assumption.
+func (b Bucketed) ExplicitBucketHistogram(bounds []float64) metricdata.HistogramDataPoint[float64] {
+	dPt := metricdata.HistogramDataPoint[float64]{
+		Bounds:       bounds,
+		BucketCounts: make([]uint64, len(bounds)+1),
+	}
+	if !b.valid() {
+		return dPt
+	}
+
+	var minV, maxV float64
+	for i, n := range b.Counts {
+		if n == 0 {
+			continue
+		}
+		v := b.value(i)
+		if dPt.Count == 0 || v < minV {
+			minV = v
+		}
+		if dPt.Count == 0 || v > maxV {
+			maxV = v
+		}
+		dPt.BucketCounts[sort.SearchFloat64s(bounds, v)] += n
+		dPt.Count += n
+		dPt.Sum += v * float64(n)
+	}
+	if dPt.Count > 0 {
+		dPt.Min = metricdata.NewExtrema(minV)
+		dPt.Max = metricdata.NewExtrema(maxV)
+	}
+	return dPt
+}
+
+// ExponentialHistogram returns b converted into a base-2 exponential
+// histogram data point that uses at most maxSize buckets and a scale of at
+// most maxScale. The returned data point has no attributes or timestamps set.
+//
+// All values of a bucket of b are assumed to be equal to the midpoint of that
+// bucket. The returned sum is an estimate based on this assumption.
+func (b Bucketed) ExponentialHistogram(maxSize, maxScale int32) metricdata.ExponentialHistogramDataPoint[float64] {
+	p := newExpoHistogramDataPoint[float64](attribute.Set{}, int(maxSize), maxScale, false, false)
+	if b.valid() {
+		for i, n := range b.Counts {
+			if n > 0 {
+				p.recordN(b.value(i), n)
+			}
+		}
+	}
+
+	dPt := metricdata.ExponentialHistogramDataPoint[float64]{
+		Count:     p.count,
+		Sum:       p.sum,
+		Scale:     p.scale,
+		ZeroCount: p.zeroCount,
+		PositiveBucket: metricdata.ExponentialBucket{
+			Offset: p.posBuckets.startBin,
+			Counts: p.posBuckets.counts,
+		},
+		NegativeBucket: metricdata.ExponentialBucket{
+			Offset: p.negBuckets.startBin,
+			Counts: p.negBuckets.counts,
+		},
+	}
+	if p.count > 0 {
+		dPt.Min = metricdata.NewExtrema(p.min)
+		dPt.Max = metricdata.NewExtrema(p.max)
+	}
+	return dPt
+}
+
+// recordN adds n measurements of v to the histogram.
+func (p *expoHistogramDataPoint[N]) recordN(v N, n uint64) {
+	if n == 0 || math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+		return
+	}
+	// Record once to resolve any rescaling, and then add the remaining
+	// measurements to the same bin.
+	p.record(v)
+	if n == 1 {
+		return
+	}
+	extra := n - 1
+	p.count += extra
+	if !p.noSum {
+		p.sum += v * N(extra)
+	}
+
+	absV := math.Abs(float64(v))
+	if absV == 0 {
+		p.zeroCount += extra
+		return
+	}
+	bucket := &p.posBuckets
+	if v < 0 {
+		bucket = &p.negBuckets
+	}
+	idx := int(p.getBin(absV) - bucket.startBin)
+	if idx < 0 || idx >= len(bucket.counts) {
+		// The single record was dropped due to a scale underflow.
+		return
+	}
+	bucket.counts[idx] += extra
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+var bucketed = Bucketed{
+	Buckets: []float64{math.Inf(-1), 0, 1, 2, 4, math.Inf(1)},
+	Counts:  []uint64{0, 2, 1, 3, 1},
+}
+
+func TestBucketedBounds(t *testing.T) {
+	assert.Equal(t, []float64{0, 1, 2, 4}, bucketed.Bounds())
+}
+
+func TestBucketedExplicitBucketHistogram(t *testing.T) {
+	t.Run("NativeBounds", func(t *testing.T) {
+		got := bucketed.ExplicitBucketHistogram(bucketed.Bounds())
+		metricdatatest.AssertEqual(t, metricdata.HistogramDataPoint[float64]{
+			Bounds:       []float64{0, 1, 2, 4},
+			BucketCounts: []uint64{0, 2, 1, 3, 1},
+			Count:        7,
+			Sum:          2*0.5 + 1.5 + 3*3 + math.Nextafter(4, math.Inf(1)),
+			Min:          metricdata.NewExtrema(0.5),
+			Max:          metricdata.NewExtrema(math.Nextafter(4, math.Inf(1))),
+		}, got)
+	})
+
+	t.Run("OtherBounds", func(t *testing.T) {
+		got := bucketed.ExplicitBucketHistogram([]float64{1, 10})
+		assert.Equal(t, []uint64{2, 5, 0}, got.BucketCounts)
+		assert.Equal(t, uint64(7), got.Count)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		got := Bucketed{Buckets: []float64{0}, Counts: []uint64{1}}.ExplicitBucketHistogram([]float64{1})
+		assert.Equal(t, []uint64{0, 0}, got.BucketCounts)
+		assert.Zero(t, got.Count)
+	})
+}
+
+func TestBucketedExponentialHistogram(t *testing.T) {
+	t.Run("MaxScale", func(t *testing.T) {
+		got := bucketed.ExponentialHistogram(160, 0)
+		// At scale 0, bucket i holds (2^i, 2^(i+1)]: 0.5 is in -2, 1.5 in 0,
+		// 3 in 1, and the value just above 4 in 2.
+		assert.Equal(t, int32(0), got.Scale)
+		assert.Equal(t, uint64(7), got.Count)
+		assert.Equal(t, metricdata.ExponentialBucket{
+			Offset: -2,
+			Counts: []uint64{2, 0, 1, 3, 1},
+		}, got.PositiveBucket)
+		assert.Empty(t, got.NegativeBucket.Counts)
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		got := bucketed.ExponentialHistogram(2, 20)
+		assert.Equal(t, uint64(7), got.Count)
+		assert.LessOrEqual(t, len(got.PositiveBucket.Counts), 2)
+
+		var n uint64
+		for _, c := range got.PositiveBucket.Counts {
+			n += c
+		}
+		assert.Equal(t, got.Count, n+got.ZeroCount)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		got := Bucketed{
+			Buckets: []float64{math.Inf(-1), math.Inf(1)},
+			Counts:  []uint64{4},
+		}.ExponentialHistogram(160, 20)
+		assert.Equal(t, uint64(4), got.Count)
+		assert.Equal(t, uint64(4), got.ZeroCount)
+	})
+}
@@ -0,0 +1,318 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// sketch is a DDSketch quantile sketch of non-negative values.
+//
+// Values are mapped into logarithmically sized buckets so that any quantile
+// estimated from the sketch is within relErr of the true value (relative to
+// that value). Sketches with the same relative error are mergeable by adding
+// their bucket counts.
+//
+// See https://arxiv.org/abs/1908.10693 for a description of the algorithm.
+type sketch struct {
+	// gamma is the base of the logarithmic bucket mapping. A bucket with index
+	// i covers the values (gamma^(i-1), gamma^i].
+	gamma float64
+	// logGamma is the cached value of math.Log(gamma).
+	logGamma float64
+	// maxSize is the maximum number of buckets held. When the number of
+	// buckets would exceed this, the lowest buckets are collapsed together.
+	maxSize int
+
+	startBin  int32
+	counts    []uint64
+	zeroCount uint64
+	count     uint64
+	min, max  float64
+}
+
+// newSketch returns a sketch with a relative accuracy of relErr that uses at
+// most maxSize buckets.
+func newSketch(relErr float64, maxSize int) *sketch {
+	gamma := (1 + relErr) / (1 - relErr)
+	return &sketch{
+		gamma:    gamma,
+		logGamma: math.Log(gamma),
+		maxSize:  maxSize,
+	}
+}
+
+// minIndexableValue is the smallest value that is mapped into a bucket.
+// Smaller values are counted in the zero bucket.
+const minIndexableValue = smallestNonZeroNormalFloat64
+
+// record adds v to the sketch. Values less than or equal to zero are counted
+// in the zero bucket. NaN values are ignored, they would otherwise poison the
+// min and max used to clamp the quantiles.
+func (s *sketch) record(v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+
+	if s.count == 0 {
+		s.min, s.max = v, v
+	} else if v < s.min {
+		s.min = v
+	} else if v > s.max {
+		s.max = v
+	}
+	s.count++
+
+	if v < minIndexableValue {
+		s.zeroCount++
+		return
+	}
+
+	s.add(s.index(v), 1)
+}
+
+// index returns the bucket index for v. The value of v must be greater than
+// or equal to minIndexableValue.
+func (s *sketch) index(v float64) int32 {
+	i := math.Ceil(math.Log(v) / s.logGamma)
+	switch {
+	case i > math.MaxInt32:
+		return math.MaxInt32
+	case i < math.MinInt32:
+		return math.MinInt32
+	}
+	return int32(i)
+}
+
+// value returns the representative value of the bucket with index i. This
+// value is within the relative error of every value in that bucket.
+func (s *sketch) value(i int32) float64 {
+	return 2 * math.Pow(s.gamma, float64(i)) / (1 + s.gamma)
+}
+
+// add adds n to the count of the bucket with index i, growing the buckets if
+// needed.
+func (s *sketch) add(i int32, n uint64) {
+	if len(s.counts) == 0 {
+		s.counts = append(s.counts[:0], n)
+		s.startBin = i
+		return
+	}
+
+	endBin := s.startBin + int32(len(s.counts)) - 1 // nolint: gosec  // Bounded by maxSize.
+	switch {
+	case i < s.startBin:
+		if int64(endBin)-int64(i) >= int64(s.maxSize) {
+			// Collapse into the lowest bucket that can be held.
+			s.add(s.startBin, n)
+			return
+		}
+		shift := int(s.startBin - i)
+		s.counts = append(s.counts, make([]uint64, shift)...)
+		copy(s.counts[shift:], s.counts)
+		clear(s.counts[:shift])
+		s.counts[0] = n
+		s.startBin = i
+	case i > endBin:
+		// Collapse the lowest buckets first so that no more than maxSize
+		// buckets are ever allocated, however far i is from endBin.
+		if start := int64(i) - int64(s.maxSize) + 1; start > int64(s.startBin) {
+			s.collapse(int32(start))                       // nolint: gosec  // Bounded by i.
+			endBin = s.startBin + int32(len(s.counts)) - 1 // nolint: gosec  // Bounded by maxSize.
+		}
+		s.counts = append(s.counts, make([]uint64, int(i-endBin))...)
+		s.counts[len(s.counts)-1] += n
+	default:
+		s.counts[i-s.startBin] += n
+	}
+}
+
+// collapse merges all the buckets with an index lower than start into the
+// bucket with index start, which becomes the lowest bucket held.
+func (s *sketch) collapse(start int32) {
+	// Index of the bucket start in counts, or of the last bucket if start is
+	// past all of them.
+	k := int(min(int64(start)-int64(s.startBin), int64(len(s.counts)-1)))
+	var n uint64
+	for _, c := range s.counts[:k+1] {
+		n += c
+	}
+	s.counts = append(s.counts[:0], s.counts[k:]...)
+	s.counts[0] = n
+	s.startBin = start
+}
+
+// quantile returns the estimated value at quantile q, which must be in the
+// interval [0, 1]. If no values have been recorded, 0 is returned.
+//
+// The returned value is never negative. Negative values are recorded in the
+// zero bucket and are reported as 0.
+func (s *sketch) quantile(q float64) float64 {
+	if s.count == 0 {
+		return 0
+	}
+	// The extrema are exactly known.
+	if q <= 0 {
+		return math.Max(0, s.min)
+	}
+	if q >= 1 {
+		return math.Max(0, s.max)
+	}
+
+	rank := uint64(q * float64(s.count-1))
+	if rank < s.zeroCount {
+		return math.Max(0, s.min)
+	}
+	n := s.zeroCount
+	for i, c := range s.counts {
+		n += c
+		if n > rank {
+			v := s.value(s.startBin + int32(i)) // nolint: gosec  // Bounded by maxSize.
+			// Estimates are never outside of the recorded range.
+			return math.Min(math.Max(v, s.min), s.max)
+		}
+	}
+	return math.Max(0, s.max)
+}
+
+// summaryDataPoint is the aggregate of measurements for a single attribute
+// set.
+type summaryDataPoint[N int64 | float64] struct {
+	attrs  attribute.Set
+	sketch *sketch
+	sum    N
+}
+
+// summary summarizes a set of measurements as quantiles estimated from a
+// quantile sketch.
+type summary[N int64 | float64] struct {
+	quantiles []float64
+	relErr    float64
+	maxSize   int
+
+	limit    limiter[*summaryDataPoint[N]]
+	values   map[attribute.Distinct]*summaryDataPoint[N]
+	valuesMu sync.Mutex
+
+	start time.Time
+}
+
+// newSummary returns an aggregator that summarizes a set of measurements as
+// quantiles with a relative accuracy of relErr.
+func newSummary[N int64 | float64](quantiles []float64, relErr float64, maxSize, limit int) *summary[N] {
+	q := slices.Clone(quantiles)
+	slices.Sort(q)
+	return &summary[N]{
+		quantiles: q,
+		relErr:    relErr,
+		maxSize:   maxSize,
+		limit:     newLimiter[*summaryDataPoint[N]](limit),
+		values:    make(map[attribute.Distinct]*summaryDataPoint[N]),
+		start:     now(),
+	}
+}
+
+func (s *summary[N]) measure(_ context.Context, value N, fltrAttr attribute.Set, _ []attribute.KeyValue) {
+	// Ignore NaN and infinity.
+	if math.IsInf(float64(value), 0) || math.IsNaN(float64(value)) {
+		return
+	}
+
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	attr := s.limit.Attributes(fltrAttr, s.values)
+	v, ok := s.values[attr.Equivalent()]
+	if !ok {
+		v = &summaryDataPoint[N]{
+			attrs:  attr,
+			sketch: newSketch(s.relErr, s.maxSize),
+		}
+		s.values[attr.Equivalent()] = v
+	}
+	v.sketch.record(float64(value))
+	v.sum += value
+}
+
+func (s *summary[N]) delta(dest *metricdata.Aggregation) int {
+	t := now()
+
+	// If *dest is not a metricdata.Summary, memory reuse is missed. In that
+	// case, use the zero-value sData and hope for better alignment next cycle.
+	sData, _ := (*dest).(metricdata.Summary)
+
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	n := len(s.values)
+	dPts := reset(sData.DataPoints, n, n)
+
+	var i int
+	for _, val := range s.values {
+		s.dataPoint(&dPts[i], val, t)
+		i++
+	}
+	// Unused attribute sets do not report.
+	clear(s.values)
+	// The delta collection cycle resets.
+	s.start = t
+
+	sData.DataPoints = dPts
+	*dest = sData
+
+	return n
+}
+
+func (s *summary[N]) cumulative(dest *metricdata.Aggregation) int {
+	t := now()
+
+	// If *dest is not a metricdata.Summary, memory reuse is missed. In that
+	// case, use the zero-value sData and hope for better alignment next cycle.
+	sData, _ := (*dest).(metricdata.Summary)
+
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	n := len(s.values)
+	dPts := reset(sData.DataPoints, n, n)
+
+	var i int
+	for _, val := range s.values {
+		s.dataPoint(&dPts[i], val, t)
+		i++
+		// TODO (#3006): This will use an unbounded amount of memory if there
+		// are unbounded number of attribute sets being aggregated. Attribute
+		// sets that become "stale" need to be forgotten so this will not
+		// overload the system.
+	}
+
+	sData.DataPoints = dPts
+	*dest = sData
+
+	return n
+}
+
+// dataPoint sets the fields of dPt to the current state of val.
+func (s *summary[N]) dataPoint(dPt *metricdata.SummaryDataPoint, val *summaryDataPoint[N], t time.Time) {
+	dPt.Attributes = val.attrs
+	dPt.StartTime = s.start
+	dPt.Time = t
+	dPt.Count = val.sketch.count
+	dPt.Sum = float64(val.sum)
+
+	dPt.QuantileValues = reset(dPt.QuantileValues, len(s.quantiles), len(s.quantiles))
+	for j, q := range s.quantiles {
+		dPt.QuantileValues[j] = metricdata.QuantileValue{
+			Quantile: q,
+			Value:    val.sketch.quantile(q),
+		}
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var (
+	summaryQuantiles = []float64{0, 0.5, 1}
+	summaryRelErr    = 0.01
+	summaryMaxSize   = 2048
+)
+
+func TestSummary(t *testing.T) {
+	c := new(clock)
+	t.Cleanup(c.Register())
+
+	t.Run("Int64/Delta", testDeltaSummary[int64]())
+	c.Reset()
+	t.Run("Float64/Delta", testDeltaSummary[float64]())
+	c.Reset()
+	t.Run("Int64/Cumulative", testCumulativeSummary[int64]())
+	c.Reset()
+	t.Run("Float64/Cumulative", testCumulativeSummary[float64]())
+}
+
+func testDeltaSummary[N int64 | float64]() func(t *testing.T) {
+	in, out := Builder[N]{
+		Temporality:      metricdata.DeltaTemporality,
+		Filter:           attrFltr,
+		AggregationLimit: 3,
+	}.Summary(summaryQuantiles, summaryRelErr, summaryMaxSize)
+	ctx := context.Background()
+	return test[N](in, out, []teststep[N]{
+		{
+			input: []arg[N]{},
+			expect: output{
+				n:   0,
+				agg: metricdata.Summary{DataPoints: []metricdata.SummaryDataPoint{}},
+			},
+		},
+		{
+			input: []arg[N]{
+				{ctx, 2, alice},
+				{ctx, 10, bob},
+				{ctx, 2, alice},
+				{ctx, 2, alice},
+				{ctx, 10, bob},
+			},
+			expect: output{
+				n: 2,
+				agg: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{
+						sPoint(fltrAlice, 2, 3, y2kPlus(1), y2kPlus(2)),
+						sPoint(fltrBob, 10, 2, y2kPlus(1), y2kPlus(2)),
+					},
+				},
+			},
+		},
+		{
+			input: []arg[N]{
+				{ctx, 10, alice},
+				{ctx, 3, bob},
+			},
+			expect: output{
+				n: 2,
+				agg: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{
+						sPoint(fltrAlice, 10, 1, y2kPlus(2), y2kPlus(3)),
+						sPoint(fltrBob, 3, 1, y2kPlus(2), y2kPlus(3)),
+					},
+				},
+			},
+		},
+		{
+			input: []arg[N]{},
+			// Delta summaries are expected to reset.
+			expect: output{
+				n:   0,
+				agg: metricdata.Summary{DataPoints: []metricdata.SummaryDataPoint{}},
+			},
+		},
+		{
+			input: []arg[N]{
+				{ctx, 1, alice},
+				{ctx, 1, bob},
+				// These will exceed cardinality limit.
+				{ctx, 1, carol},
+				{ctx, 1, dave},
+			},
+			expect: output{
+				n: 3,
+				agg: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{
+						sPoint(fltrAlice, 1, 1, y2kPlus(4), y2kPlus(5)),
+						sPoint(fltrBob, 1, 1, y2kPlus(4), y2kPlus(5)),
+						sPoint(overflowSet, 1, 2, y2kPlus(4), y2kPlus(5)),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testCumulativeSummary[N int64 | float64]() func(t *testing.T) {
+	in, out := Builder[N]{
+		Temporality:      metricdata.CumulativeTemporality,
+		Filter:           attrFltr,
+		AggregationLimit: 3,
+	}.Summary(summaryQuantiles, summaryRelErr, summaryMaxSize)
+	ctx := context.Background()
+	return test[N](in, out, []teststep[N]{
+		{
+			input: []arg[N]{},
+			expect: output{
+				n:   0,
+				agg: metricdata.Summary{DataPoints: []metricdata.SummaryDataPoint{}},
+			},
+		},
+		{
+			input: []arg[N]{
+				{ctx, 2, alice},
+				{ctx, 10, bob},
+				{ctx, 2, alice},
+				{ctx, 2, alice},
+				{ctx, 10, bob},
+			},
+			expect: output{
+				n: 2,
+				agg: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{
+						sPoint(fltrAlice, 2, 3, y2kPlus(0), y2kPlus(2)),
+						sPoint(fltrBob, 10, 2, y2kPlus(0), y2kPlus(2)),
+					},
+				},
+			},
+		},
+		{
+			input: []arg[N]{},
+			expect: output{
+				n: 2,
+				agg: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{
+						sPoint(fltrAlice, 2, 3, y2kPlus(0), y2kPlus(3)),
+						sPoint(fltrBob, 10, 2, y2kPlus(0), y2kPlus(3)),
+					},
+				},
+			},
+		},
+		{
+			input: []arg[N]{
+				{ctx, 2, alice},
+				{ctx, 10, bob},
+				// These will exceed cardinality limit.
+				{ctx, 1, carol},
+				{ctx, 1, dave},
+			},
+			expect: output{
+				n: 3,
+				agg: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{
+						sPoint(fltrAlice, 2, 4, y2kPlus(0), y2kPlus(4)),
+						sPoint(fltrBob, 10, 3, y2kPlus(0), y2kPlus(4)),
+						sPoint(overflowSet, 1, 2, y2kPlus(0), y2kPlus(4)),
+					},
+				},
+			},
+		},
+	})
+}
+
+// sPoint returns a SummaryDataPoint of multi measurements of v.
+func sPoint(a attribute.Set, v float64, multi uint64, start, t time.Time) metricdata.SummaryDataPoint {
+	qVals := make([]metricdata.QuantileValue, len(summaryQuantiles))
+	for i, q := range summaryQuantiles {
+		qVals[i] = metricdata.QuantileValue{Quantile: q, Value: v}
+	}
+	return metricdata.SummaryDataPoint{
+		Attributes:     a,
+		StartTime:      start,
+		Time:           t,
+		Count:          multi,
+		Sum:            v * float64(multi),
+		QuantileValues: qVals,
+	}
+}
+
+func TestSketchRelativeError(t *testing.T) {
+	const relErr = 0.01
+
+	r := rand.New(rand.NewSource(1)) // nolint: gosec  // Deterministic test data.
+	values := make([]float64, 10000)
+	s := newSketch(relErr, summaryMaxSize)
+	for i := range values {
+		// Log-normally distributed values spanning several magnitudes.
+		values[i] = math.Exp(r.NormFloat64() * 3)
+		s.record(values[i])
+	}
+	sort.Float64s(values)
+
+	for _, q := range []float64{0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999} {
+		want := values[int(q*float64(len(values)-1))]
+		got := s.quantile(q)
+		assert.InEpsilonf(t, want, got, relErr, "quantile %v", q)
+	}
+
+	assert.Equal(t, values[0], s.quantile(0), "min")
+	assert.Equal(t, values[len(values)-1], s.quantile(1), "max")
+}
+
+func TestSketchZeroAndNegative(t *testing.T) {
+	s := newSketch(summaryRelErr, summaryMaxSize)
+	assert.Equal(t, 0.0, s.quantile(0.5), "empty sketch")
+
+	s.record(-5)
+	s.record(0)
+	s.record(0)
+	s.record(10)
+	assert.Equal(t, uint64(3), s.zeroCount)
+	assert.Equal(t, uint64(4), s.count)
+
+	assert.Equal(t, 0.0, s.quantile(0), "negative min reported")
+	assert.Equal(t, 0.0, s.quantile(0.5))
+	assert.Equal(t, 10.0, s.quantile(1))
+}
+
+func TestSketchNaN(t *testing.T) {
+	s := newSketch(summaryRelErr, summaryMaxSize)
+	s.record(math.NaN())
+	s.record(2)
+	s.record(math.NaN())
+	s.record(8)
+	assert.Equal(t, uint64(2), s.count, "NaN counted")
+	assert.Equal(t, uint64(0), s.zeroCount, "NaN counted as zero")
+
+	assert.Equal(t, 2.0, s.quantile(0))
+	assert.InEpsilon(t, 2.0, s.quantile(0.5), summaryRelErr)
+	assert.Equal(t, 8.0, s.quantile(1))
+}
+
+func TestSummaryMeasureNaN(t *testing.T) {
+	in, out := Builder[float64]{
+		Temporality: metricdata.CumulativeTemporality,
+	}.Summary(summaryQuantiles, summaryRelErr, summaryMaxSize)
+	ctx := context.Background()
+	in(ctx, math.NaN(), alice)
+	in(ctx, 2, alice)
+
+	var got metricdata.Aggregation = metricdata.Summary{}
+	assert.Equal(t, 1, out(&got))
+	dPts := got.(metricdata.Summary).DataPoints
+	if assert.Len(t, dPts, 1) {
+		assert.Equal(t, uint64(1), dPts[0].Count)
+		assert.Equal(t, 2.0, dPts[0].Sum)
+		for _, qv := range dPts[0].QuantileValues {
+			assert.Equal(t, 2.0, qv.Value, "quantile %v", qv.Quantile)
+		}
+	}
+}
+
+func TestSketchCollapse(t *testing.T) {
+	const maxSize = 4
+	s := newSketch(summaryRelErr, maxSize)
+
+	// Values in consecutive buckets, recorded in both increasing and
+	// decreasing order.
+	v := func(i int) float64 { return math.Pow(s.gamma, float64(i)) }
+	for i := 5; i < 10; i++ {
+		s.record(v(i))
+	}
+	for i := 4; i >= 0; i-- {
+		s.record(v(i))
+	}
+	assert.Len(t, s.counts, maxSize, "buckets not collapsed")
+
+	var n uint64
+	for _, c := range s.counts {
+		n += c
+	}
+	assert.Equal(t, s.count, n+s.zeroCount, "counts lost when collapsing")
+
+	// High quantiles are unaffected by collapsing.
+	assert.InEpsilon(t, v(8), s.quantile(0.9), summaryRelErr)
+	assert.InEpsilon(t, v(7), s.quantile(0.8), summaryRelErr)
+	assert.Equal(t, v(9), s.quantile(1))
+}
+
+func TestSummaryMeasureInf(t *testing.T) {
+	in, out := Builder[float64]{
+		Temporality: metricdata.CumulativeTemporality,
+	}.Summary(summaryQuantiles, summaryRelErr, summaryMaxSize)
+	ctx := context.Background()
+	in(ctx, math.Inf(1), alice)
+	in(ctx, math.Inf(-1), alice)
+	in(ctx, 2, alice)
+
+	var got metricdata.Aggregation = metricdata.Summary{}
+	assert.Equal(t, 1, out(&got))
+	dPts := got.(metricdata.Summary).DataPoints
+	if assert.Len(t, dPts, 1) {
+		assert.Equal(t, uint64(1), dPts[0].Count)
+		assert.Equal(t, 2.0, dPts[0].Sum)
+		for _, qv := range dPts[0].QuantileValues {
+			assert.Equal(t, 2.0, qv.Value, "quantile %v", qv.Quantile)
+		}
+	}
+}
+
+func TestSketchExtremeValues(t *testing.T) {
+	// A small relative error maps the extreme values to the most distant
+	// bucket indexes.
+	for _, relErr := range []float64{summaryRelErr, 1e-12} {
+		s := newSketch(relErr, summaryMaxSize)
+		values := []float64{
+			1,
+			math.MaxFloat64,
+			minIndexableValue,
+			math.Inf(1),
+			math.SmallestNonzeroFloat64,
+			math.MaxFloat64,
+			minIndexableValue,
+		}
+		for _, v := range values {
+			s.record(v)
+		}
+		assert.LessOrEqual(t, len(s.counts), summaryMaxSize, "relErr %v", relErr)
+
+		var n uint64
+		for _, c := range s.counts {
+			n += c
+		}
+		assert.Equal(t, uint64(len(values)), n+s.zeroCount, "relErr %v: counts lost", relErr)
+		assert.Equal(t, math.SmallestNonzeroFloat64, s.quantile(0), "relErr %v", relErr)
+		assert.Equal(t, math.Inf(1), s.quantile(1), "relErr %v", relErr)
+	}
+}
+
+func BenchmarkSummary(b *testing.B) {
+	b.Run("Int64/Cumulative", benchmarkAggregate(func() (Measure[int64], ComputeAggregation) {
+		return Builder[int64]{}.Summary(summaryQuantiles, summaryRelErr, summaryMaxSize)
+	}))
+	b.Run("Float64/Delta", benchmarkAggregate(func() (Measure[float64], ComputeAggregation) {
+		return Builder[float64]{
+			Temporality: metricdata.DeltaTemporality,
+		}.Summary(summaryQuantiles, summaryRelErr, summaryMaxSize)
+	}))
+}
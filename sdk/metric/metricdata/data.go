@@ -249,8 +249,9 @@ type Exemplar[N int64 | float64] struct {
 // data type.
 //
 // These data points cannot always be merged in a meaningful way. The Summary
-// type is only used by bridges from other metrics libraries, and cannot be
-// produced using OpenTelemetry instrumentation.
+// type is used by bridges from other metrics libraries, and is produced by
+// OpenTelemetry instrumentation only when an AggregationSummary is
+// configured.
 type Summary struct {
 	// DataPoints are the individual aggregated measurements with unique
 	// attributes.
@@ -498,6 +498,8 @@ func (i *inserter[N]) aggregateFunc(b aggregate.Builder[N], agg Aggregation, kin
 			noSum = true
 		}
 		meas, comp = b.ExponentialBucketHistogram(a.MaxSize, a.MaxScale, a.NoMinMax, noSum)
+	case AggregationSummary:
+		meas, comp = b.Summary(a.params())
 
 	default:
 		err = errUnknownAggregation
@@ -509,15 +511,15 @@ func (i *inserter[N]) aggregateFunc(b aggregate.Builder[N], agg Aggregation, kin
 // isAggregatorCompatible checks if the aggregation can be used by the instrument.
 // Current compatibility:
 //
-// | Instrument Kind          | Drop | LastValue | Sum | Histogram | Exponential Histogram |
-// |--------------------------|------|-----------|-----|-----------|-----------------------|
-// | Counter                  | ✓    |           | ✓   | ✓         | ✓                     |
-// | UpDownCounter            | ✓    |           | ✓   | ✓         | ✓                     |
-// | Histogram                | ✓    |           | ✓   | ✓         | ✓                     |
-// | Gauge                    | ✓    | ✓         |     | ✓         | ✓                     |
-// | Observable Counter       | ✓    |           | ✓   | ✓         | ✓                     |
-// | Observable UpDownCounter | ✓    |           | ✓   | ✓         | ✓                     |
-// | Observable Gauge         | ✓    | ✓         |     | ✓         | ✓                     |.
+// | Instrument Kind          | Drop | LastValue | Sum | Histogram | Exponential Histogram | Summary |
+// |--------------------------|------|-----------|-----|-----------|-----------------------|---------|
+// | Counter                  | ✓    |           | ✓   | ✓         | ✓                     | ✓       |
+// | UpDownCounter            | ✓    |           | ✓   | ✓         | ✓                     |         |
+// | Histogram                | ✓    |           | ✓   | ✓         | ✓                     | ✓       |
+// | Gauge                    | ✓    | ✓         |     | ✓         | ✓                     |         |
+// | Observable Counter       | ✓    |           | ✓   | ✓         | ✓                     |         |
+// | Observable UpDownCounter | ✓    |           | ✓   | ✓         | ✓                     |         |
+// | Observable Gauge         | ✓    | ✓         |     | ✓         | ✓                     |         |.
 func isAggregatorCompatible(kind InstrumentKind, agg Aggregation) error {
 	switch agg.(type) {
 	case AggregationDefault:
@@ -535,6 +537,15 @@ func isAggregatorCompatible(kind InstrumentKind, agg Aggregation) error {
 		default:
 			return errIncompatibleAggregation
 		}
+	case AggregationSummary:
+		switch kind {
+		case InstrumentKindCounter, InstrumentKindHistogram:
+			// Summary quantiles cannot be negative. Only summarize
+			// measurements of non-negative increments.
+			return nil
+		default:
+			return errIncompatibleAggregation
+		}
 	case AggregationSum:
 		switch kind {
 		case InstrumentKindObservableCounter, InstrumentKindObservableUpDownCounter, InstrumentKindCounter, InstrumentKindHistogram, InstrumentKindUpDownCounter:
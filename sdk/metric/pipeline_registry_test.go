@@ -847,6 +847,34 @@ func TestIsAggregatorCompatible(t *testing.T) {
 			agg:  AggregationBase2ExponentialHistogram{},
 			want: errIncompatibleAggregation,
 		},
+		{
+			name: "Counter and Summary",
+			kind: InstrumentKindCounter,
+			agg:  AggregationSummary{},
+		},
+		{
+			name: "Histogram and Summary",
+			kind: InstrumentKindHistogram,
+			agg:  AggregationSummary{},
+		},
+		{
+			name: "UpDownCounter and Summary",
+			kind: InstrumentKindUpDownCounter,
+			agg:  AggregationSummary{},
+			want: errIncompatibleAggregation,
+		},
+		{
+			name: "Gauge and Summary",
+			kind: InstrumentKindGauge,
+			agg:  AggregationSummary{},
+			want: errIncompatibleAggregation,
+		},
+		{
+			name: "ObservableCounter and Summary",
+			kind: InstrumentKindObservableCounter,
+			agg:  AggregationSummary{},
+			want: errIncompatibleAggregation,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/otel/sdk/metric/runtime"
+
+import "go.opentelemetry.io/otel/sdk/metric"
+
+// config contains configuration options for a Producer.
+type config struct {
+	histogramAggregation metric.Aggregation
+}
+
+// newConfig returns a config configured with options.
+func newConfig(options []Option) config {
+	conf := config{
+		histogramAggregation: metric.AggregationBase2ExponentialHistogram{
+			MaxSize:  160,
+			MaxScale: 20,
+		},
+	}
+	for _, o := range options {
+		conf = o.apply(conf)
+	}
+	return conf
+}
+
+// Option applies a configuration option value to a Producer.
+type Option interface {
+	apply(config) config
+}
+
+// optionFunc applies a set of options to a config.
+type optionFunc func(config) config
+
+// apply returns a config with option(s) applied.
+func (o optionFunc) apply(conf config) config {
+	return o(conf)
+}
+
+// WithHistogramAggregation sets the aggregation used to produce the
+// distributions read from the Go runtime (e.g. go.schedule.duration).
+//
+// Supported aggregations are:
+//
+//   - [metric.AggregationBase2ExponentialHistogram]: the distribution is
+//     converted to an exponential histogram using the configured maximum size
+//     and scale.
+//   - [metric.AggregationExplicitBucketHistogram]: the distribution is
+//     converted to a histogram with the configured boundaries. If no
+//     boundaries are configured, the boundaries used by the Go runtime are
+//     used.
+//   - [metric.AggregationDrop]: distributions are not produced.
+//
+// By default, or if an unsupported or invalid aggregation is passed, an
+// exponential histogram with a maximum size of 160 and a maximum scale of 20
+// is used.
+func WithHistogramAggregation(agg metric.Aggregation) Option {
+	return optionFunc(func(conf config) config {
+		switch a := agg.(type) {
+		case metric.AggregationBase2ExponentialHistogram:
+			if a.MaxSize > 0 && a.MaxScale <= 20 && a.MaxScale >= -10 {
+				conf.histogramAggregation = a
+			}
+		case metric.AggregationExplicitBucketHistogram:
+			if increasing(a.Boundaries) {
+				conf.histogramAggregation = a
+			}
+		case metric.AggregationDrop:
+			conf.histogramAggregation = a
+		}
+		return conf
+	})
+}
+
+// increasing returns if bounds are strictly increasing.
+func increasing(bounds []float64) bool {
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i-1] >= bounds[i] {
+			return false
+		}
+	}
+	return true
+}
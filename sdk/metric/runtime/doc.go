@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package runtime provides a [go.opentelemetry.io/otel/sdk/metric.Producer]
+// of Go runtime metrics.
+//
+// The Producer reads [runtime/metrics] when data is collected and converts
+// it to metric data following the OpenTelemetry semantic conventions for Go
+// runtime metrics. Register it with any reader using
+// [go.opentelemetry.io/otel/sdk/metric.WithProducer]:
+//
+//	reader := metric.NewPeriodicReader(exporter, metric.WithProducer(runtime.NewProducer()))
+//
+// The following metrics are produced when supported by the Go runtime in use:
+//
+//   - go.memory.used
+//   - go.memory.limit
+//   - go.memory.allocated
+//   - go.memory.allocations
+//   - go.memory.gc.goal
+//   - go.goroutine.count
+//   - go.processor.limit
+//   - go.config.gogc
+//   - go.schedule.duration
+//   - go.gc.pause.duration
+package runtime // import "go.opentelemetry.io/otel/sdk/metric/runtime"
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/otel/sdk/metric/runtime"
+
+import (
+	"context"
+	"math"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+const scopeName = "go.opentelemetry.io/otel/sdk/metric/runtime"
+
+// Names of the runtime/metrics samples read.
+const (
+	memoryTotal      = "/memory/classes/total:bytes"
+	memoryReleased   = "/memory/classes/heap/released:bytes"
+	memoryHeapStacks = "/memory/classes/heap/stacks:bytes"
+	memoryOSStacks   = "/memory/classes/os-stacks:bytes"
+	memoryLimit      = "/gc/gomemlimit:bytes"
+	heapAllocs       = "/gc/heap/allocs:bytes"
+	heapAllocObjects = "/gc/heap/allocs:objects"
+	heapGoal         = "/gc/heap/goal:bytes"
+	goroutines       = "/sched/goroutines:goroutines"
+	gomaxprocs       = "/sched/gomaxprocs:threads"
+	gogc             = "/gc/gogc:percent"
+	schedLatencies   = "/sched/latencies:seconds"
+	gcPauses         = "/sched/pauses/total/gc:seconds"
+)
+
+// The GC pause duration is not defined by the semantic conventions.
+const (
+	gcPauseDurationName        = "go.gc.pause.duration"
+	gcPauseDurationUnit        = "s"
+	gcPauseDurationDescription = "The time the world was stopped for garbage collection."
+)
+
+var (
+	memoryTypeStack = attribute.NewSet(semconv.GoMemoryTypeStack)
+	memoryTypeOther = attribute.NewSet(semconv.GoMemoryTypeOther)
+)
+
+// Producer implements the [go.opentelemetry.io/otel/sdk/metric.Producer] to
+// provide Go runtime metrics to the OpenTelemetry SDK.
+type Producer struct {
+	histAgg metric.Aggregation
+	start   time.Time
+
+	mu      sync.Mutex
+	samples []metrics.Sample
+	index   map[string]int
+}
+
+var _ metric.Producer = (*Producer)(nil)
+
+// NewProducer returns a Producer that reads metrics from the Go runtime.
+func NewProducer(opts ...Option) *Producer {
+	cfg := newConfig(opts)
+
+	supported := make(map[string]bool)
+	for _, d := range metrics.All() {
+		supported[d.Name] = true
+	}
+
+	p := &Producer{
+		histAgg: cfg.histogramAggregation,
+		start:   time.Now(),
+		index:   make(map[string]int),
+	}
+	for _, name := range []string{
+		memoryTotal, memoryReleased, memoryHeapStacks, memoryOSStacks,
+		memoryLimit, heapAllocs, heapAllocObjects, heapGoal, goroutines,
+		gomaxprocs, gogc, schedLatencies, gcPauses,
+	} {
+		if !supported[name] {
+			continue
+		}
+		p.index[name] = len(p.samples)
+		p.samples = append(p.samples, metrics.Sample{Name: name})
+	}
+	return p
+}
+
+// Produce reads the metrics from the Go runtime, translates them to
+// OpenTelemetry's data model, and returns them.
+func (p *Producer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	metrics.Read(p.samples)
+	now := time.Now()
+
+	var m []metricdata.Metrics
+	if total, ok := p.uint64(memoryTotal); ok {
+		released, _ := p.uint64(memoryReleased)
+		heapStacks, _ := p.uint64(memoryHeapStacks)
+		osStacks, _ := p.uint64(memoryOSStacks)
+		stack := heapStacks + osStacks
+		other := total - released - stack
+		m = append(m, metricdata.Metrics{
+			Name:        semconv.GoMemoryUsedName,
+			Description: semconv.GoMemoryUsedDescription,
+			Unit:        semconv.GoMemoryUsedUnit,
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					p.dataPoint(memoryTypeStack, stack, now),
+					p.dataPoint(memoryTypeOther, other, now),
+				},
+			},
+		})
+	}
+	// A limit of math.MaxInt64 means no limit has been set.
+	if v, ok := p.uint64(memoryLimit); ok && v != math.MaxInt64 {
+		m = append(m, p.sum(semconv.GoMemoryLimitName, semconv.GoMemoryLimitDescription, semconv.GoMemoryLimitUnit, false, v, now))
+	}
+	if v, ok := p.uint64(heapAllocs); ok {
+		m = append(m, p.sum(semconv.GoMemoryAllocatedName, semconv.GoMemoryAllocatedDescription, semconv.GoMemoryAllocatedUnit, true, v, now))
+	}
+	if v, ok := p.uint64(heapAllocObjects); ok {
+		m = append(m, p.sum(semconv.GoMemoryAllocationsName, semconv.GoMemoryAllocationsDescription, semconv.GoMemoryAllocationsUnit, true, v, now))
+	}
+	if v, ok := p.uint64(heapGoal); ok {
+		m = append(m, p.sum(semconv.GoMemoryGcGoalName, semconv.GoMemoryGcGoalDescription, semconv.GoMemoryGcGoalUnit, false, v, now))
+	}
+	if v, ok := p.uint64(goroutines); ok {
+		m = append(m, p.sum(semconv.GoGoroutineCountName, semconv.GoGoroutineCountDescription, semconv.GoGoroutineCountUnit, false, v, now))
+	}
+	if v, ok := p.uint64(gomaxprocs); ok {
+		m = append(m, p.sum(semconv.GoProcessorLimitName, semconv.GoProcessorLimitDescription, semconv.GoProcessorLimitUnit, false, v, now))
+	}
+	if v, ok := p.uint64(gogc); ok {
+		m = append(m, p.sum(semconv.GoConfigGogcName, semconv.GoConfigGogcDescription, semconv.GoConfigGogcUnit, false, v, now))
+	}
+	if h, ok := p.histogram(schedLatencies, now); ok {
+		m = append(m, metricdata.Metrics{
+			Name:        semconv.GoScheduleDurationName,
+			Description: semconv.GoScheduleDurationDescription,
+			Unit:        semconv.GoScheduleDurationUnit,
+			Data:        h,
+		})
+	}
+	if h, ok := p.histogram(gcPauses, now); ok {
+		m = append(m, metricdata.Metrics{
+			Name:        gcPauseDurationName,
+			Description: gcPauseDurationDescription,
+			Unit:        gcPauseDurationUnit,
+			Data:        h,
+		})
+	}
+
+	if len(m) == 0 {
+		return nil, nil
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope: instrumentation.Scope{
+			Name:      scopeName,
+			Version:   sdk.Version(),
+			SchemaURL: semconv.SchemaURL,
+		},
+		Metrics: m,
+	}}, nil
+}
+
+// uint64 returns the value of the sample name if it was read.
+func (p *Producer) uint64(name string) (uint64, bool) {
+	i, ok := p.index[name]
+	if !ok || p.samples[i].Value.Kind() != metrics.KindUint64 {
+		return 0, false
+	}
+	return p.samples[i].Value.Uint64(), true
+}
+
+// dataPoint returns a cumulative data point for v.
+func (p *Producer) dataPoint(attrs attribute.Set, v uint64, t time.Time) metricdata.DataPoint[int64] {
+	if v > math.MaxInt64 {
+		v = math.MaxInt64
+	}
+	return metricdata.DataPoint[int64]{
+		Attributes: attrs,
+		StartTime:  p.start,
+		Time:       t,
+		Value:      int64(v), // nolint: gosec  // Overflow checked above.
+	}
+}
+
+// sum returns a metric with a single cumulative sum data point for v.
+func (p *Producer) sum(name, desc, unit string, monotonic bool, v uint64, t time.Time) metricdata.Metrics {
+	return metricdata.Metrics{
+		Name:        name,
+		Description: desc,
+		Unit:        unit,
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: monotonic,
+			DataPoints:  []metricdata.DataPoint[int64]{p.dataPoint(attribute.Set{}, v, t)},
+		},
+	}
+}
+
+// histogram returns the sample name, if it was read, converted to the
+// configured histogram aggregation.
+func (p *Producer) histogram(name string, t time.Time) (metricdata.Aggregation, bool) {
+	i, ok := p.index[name]
+	if !ok || p.samples[i].Value.Kind() != metrics.KindFloat64Histogram {
+		return nil, false
+	}
+	rh := p.samples[i].Value.Float64Histogram()
+	b := aggregate.Bucketed{Buckets: rh.Buckets, Counts: rh.Counts}
+
+	switch a := p.histAgg.(type) {
+	case metric.AggregationExplicitBucketHistogram:
+		bounds := a.Boundaries
+		if len(bounds) == 0 {
+			bounds = b.Bounds()
+		}
+		dPt := b.ExplicitBucketHistogram(bounds)
+		dPt.StartTime, dPt.Time = p.start, t
+		if a.NoMinMax {
+			dPt.Min, dPt.Max = metricdata.Extrema[float64]{}, metricdata.Extrema[float64]{}
+		}
+		return metricdata.Histogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints:  []metricdata.HistogramDataPoint[float64]{dPt},
+		}, true
+	case metric.AggregationBase2ExponentialHistogram:
+		dPt := b.ExponentialHistogram(a.MaxSize, a.MaxScale)
+		dPt.StartTime, dPt.Time = p.start, t
+		if a.NoMinMax {
+			dPt.Min, dPt.Max = metricdata.Extrema[float64]{}, metricdata.Extrema[float64]{}
+		}
+		return metricdata.ExponentialHistogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints:  []metricdata.ExponentialHistogramDataPoint[float64]{dPt},
+		}, true
+	}
+	// AggregationDrop.
+	return nil, false
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/otel/sdk/metric/runtime"
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+func produce(t *testing.T, opts ...Option) map[string]metricdata.Metrics {
+	t.Helper()
+
+	// Ensure a GC has happened so the GC pause histogram is populated.
+	runtime.GC()
+
+	sm, err := NewProducer(opts...).Produce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sm, 1)
+	assert.Equal(t, instrumentation.Scope{
+		Name:      scopeName,
+		Version:   sdk.Version(),
+		SchemaURL: semconv.SchemaURL,
+	}, sm[0].Scope)
+
+	got := make(map[string]metricdata.Metrics, len(sm[0].Metrics))
+	for _, m := range sm[0].Metrics {
+		got[m.Name] = m
+	}
+	return got
+}
+
+func TestProducerSums(t *testing.T) {
+	got := produce(t)
+
+	tests := []struct {
+		name      string
+		monotonic bool
+	}{
+		{semconv.GoMemoryAllocatedName, true},
+		{semconv.GoMemoryAllocationsName, true},
+		{semconv.GoMemoryGcGoalName, false},
+		{semconv.GoGoroutineCountName, false},
+		{semconv.GoProcessorLimitName, false},
+		{semconv.GoConfigGogcName, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Contains(t, got, tt.name)
+			sum, ok := got[tt.name].Data.(metricdata.Sum[int64])
+			require.Truef(t, ok, "unexpected data type: %T", got[tt.name].Data)
+			assert.Equal(t, metricdata.CumulativeTemporality, sum.Temporality)
+			assert.Equal(t, tt.monotonic, sum.IsMonotonic)
+			require.Len(t, sum.DataPoints, 1)
+			assert.Equal(t, 0, sum.DataPoints[0].Attributes.Len())
+			assert.False(t, sum.DataPoints[0].Time.Before(sum.DataPoints[0].StartTime))
+		})
+	}
+
+	gmp := got[semconv.GoProcessorLimitName].Data.(metricdata.Sum[int64])
+	assert.Equal(t, int64(runtime.GOMAXPROCS(0)), gmp.DataPoints[0].Value)
+}
+
+func TestProducerMemoryUsed(t *testing.T) {
+	got := produce(t)
+
+	require.Contains(t, got, semconv.GoMemoryUsedName)
+	sum, ok := got[semconv.GoMemoryUsedName].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.False(t, sum.IsMonotonic)
+	require.Len(t, sum.DataPoints, 2)
+
+	types := make(map[string]int64)
+	for _, dPt := range sum.DataPoints {
+		v, ok := dPt.Attributes.Value(semconv.GoMemoryTypeKey)
+		require.True(t, ok)
+		types[v.AsString()] = dPt.Value
+	}
+	assert.Positive(t, types[semconv.GoMemoryTypeStack.Value.AsString()])
+	assert.Positive(t, types[semconv.GoMemoryTypeOther.Value.AsString()])
+}
+
+func TestProducerMemoryLimit(t *testing.T) {
+	// No limit is set by default and the metric is not produced.
+	got := produce(t)
+	assert.NotContains(t, got, semconv.GoMemoryLimitName)
+}
+
+func TestProducerHistograms(t *testing.T) {
+	names := []string{semconv.GoScheduleDurationName, gcPauseDurationName}
+
+	t.Run("Default", func(t *testing.T) {
+		got := produce(t)
+		for _, name := range names {
+			require.Contains(t, got, name)
+			h, ok := got[name].Data.(metricdata.ExponentialHistogram[float64])
+			require.Truef(t, ok, "%s: unexpected data type: %T", name, got[name].Data)
+			assert.Equal(t, metricdata.CumulativeTemporality, h.Temporality)
+			require.Len(t, h.DataPoints, 1)
+			assert.LessOrEqual(t, h.DataPoints[0].Scale, int32(20))
+			assert.LessOrEqual(t, len(h.DataPoints[0].PositiveBucket.Counts), 160)
+		}
+		assert.Positive(t, got[gcPauseDurationName].Data.(metricdata.ExponentialHistogram[float64]).DataPoints[0].Count)
+	})
+
+	t.Run("Exponential", func(t *testing.T) {
+		got := produce(t, WithHistogramAggregation(metric.AggregationBase2ExponentialHistogram{
+			MaxSize:  4,
+			MaxScale: 2,
+			NoMinMax: true,
+		}))
+		for _, name := range names {
+			require.Contains(t, got, name)
+			h, ok := got[name].Data.(metricdata.ExponentialHistogram[float64])
+			require.True(t, ok)
+			require.Len(t, h.DataPoints, 1)
+			dPt := h.DataPoints[0]
+			assert.LessOrEqual(t, dPt.Scale, int32(2))
+			assert.LessOrEqual(t, len(dPt.PositiveBucket.Counts), 4)
+			_, ok = dPt.Min.Value()
+			assert.False(t, ok)
+			_, ok = dPt.Max.Value()
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("Explicit", func(t *testing.T) {
+		bounds := []float64{0.001, 0.01, 0.1}
+		got := produce(t, WithHistogramAggregation(metric.AggregationExplicitBucketHistogram{
+			Boundaries: bounds,
+		}))
+		for _, name := range names {
+			require.Contains(t, got, name)
+			h, ok := got[name].Data.(metricdata.Histogram[float64])
+			require.Truef(t, ok, "%s: unexpected data type: %T", name, got[name].Data)
+			require.Len(t, h.DataPoints, 1)
+			dPt := h.DataPoints[0]
+			assert.Equal(t, bounds, dPt.Bounds)
+			assert.Len(t, dPt.BucketCounts, len(bounds)+1)
+
+			var n uint64
+			for _, c := range dPt.BucketCounts {
+				n += c
+			}
+			assert.Equal(t, dPt.Count, n)
+		}
+	})
+
+	t.Run("ExplicitRuntimeBounds", func(t *testing.T) {
+		got := produce(t, WithHistogramAggregation(metric.AggregationExplicitBucketHistogram{}))
+		for _, name := range names {
+			require.Contains(t, got, name)
+			h, ok := got[name].Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, h.DataPoints, 1)
+			assert.NotEmpty(t, h.DataPoints[0].Bounds)
+		}
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		got := produce(t, WithHistogramAggregation(metric.AggregationDrop{}))
+		for _, name := range names {
+			assert.NotContains(t, got, name)
+		}
+	})
+}
+
+func TestWithHistogramAggregationInvalid(t *testing.T) {
+	def := newConfig(nil).histogramAggregation
+	for _, agg := range []metric.Aggregation{
+		nil,
+		metric.AggregationSum{},
+		metric.AggregationBase2ExponentialHistogram{MaxSize: 0, MaxScale: 20},
+		metric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 21},
+		metric.AggregationExplicitBucketHistogram{Boundaries: []float64{1, 0}},
+	} {
+		conf := newConfig([]Option{WithHistogramAggregation(agg)})
+		assert.Equal(t, def, conf.histogramAggregation, agg)
+	}
+}
+
+func TestProducerWithMeterProvider(t *testing.T) {
+	rdr := metric.NewManualReader(metric.WithProducer(NewProducer()))
+	_ = metric.NewMeterProvider(metric.WithReader(rdr))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, rdr.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+	assert.NotEmpty(t, rm.ScopeMetrics[0].Metrics)
+}
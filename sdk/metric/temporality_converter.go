@@ -0,0 +1,652 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// defaultStaleStreamTimeout is the default duration after which a stream
+// that has not been exported is forgotten by a temporality converter.
+const defaultStaleStreamTimeout = 5 * time.Minute
+
+// temporalityConverterConfig contains configuration options for a
+// temporality converter.
+type temporalityConverterConfig struct {
+	temporalitySelector TemporalitySelector
+	staleTimeout        time.Duration
+}
+
+// newTemporalityConverterConfig returns a temporalityConverterConfig
+// configured with options.
+func newTemporalityConverterConfig(options []TemporalityConverterOption) temporalityConverterConfig {
+	cfg := temporalityConverterConfig{
+		temporalitySelector: DefaultTemporalitySelector,
+		staleTimeout:        defaultStaleStreamTimeout,
+	}
+	for _, o := range options {
+		cfg = o.applyTemporalityConverter(cfg)
+	}
+	return cfg
+}
+
+// TemporalityConverterOption applies a configuration option value to an
+// Exporter returned from NewTemporalityConverter.
+type TemporalityConverterOption interface {
+	applyTemporalityConverter(temporalityConverterConfig) temporalityConverterConfig
+}
+
+// temporalityConverterOptionFunc applies a set of options to a
+// temporalityConverterConfig.
+type temporalityConverterOptionFunc func(temporalityConverterConfig) temporalityConverterConfig
+
+// applyTemporalityConverter returns a temporalityConverterConfig with
+// option(s) applied.
+func (o temporalityConverterOptionFunc) applyTemporalityConverter(conf temporalityConverterConfig) temporalityConverterConfig {
+	return o(conf)
+}
+
+// WithInputTemporalitySelector sets the TemporalitySelector used to determine
+// the Temporality of the data a reader collects and passes to the converter.
+// If this option is not used, the DefaultTemporalitySelector is used.
+func WithInputTemporalitySelector(selector TemporalitySelector) TemporalityConverterOption {
+	return temporalityConverterOptionFunc(func(conf temporalityConverterConfig) temporalityConverterConfig {
+		if selector != nil {
+			conf.temporalitySelector = selector
+		}
+		return conf
+	})
+}
+
+// WithStaleStreamTimeout sets the duration after which the state held for a
+// stream that has not been exported is dropped. Staleness is determined using
+// the timestamps of exported data points. If this option is not used, a
+// timeout of 5 minutes is used.
+//
+// If d is less than or equal to zero, state is never dropped.
+func WithStaleStreamTimeout(d time.Duration) TemporalityConverterOption {
+	return temporalityConverterOptionFunc(func(conf temporalityConverterConfig) temporalityConverterConfig {
+		conf.staleTimeout = d
+		return conf
+	})
+}
+
+// NewTemporalityConverter returns an Exporter that converts the temporality of
+// Sum, Histogram, and ExponentialHistogram data to the Temporality selected
+// by exporter before passing the data to it. The returned Exporter requests
+// data from the reader it is registered with using the TemporalitySelector
+// set with WithInputTemporalitySelector.
+//
+// This allows the same reader data to be exported to destinations that
+// require different temporalities. For example, cumulative data collected for
+// a Prometheus exporter can be fanned out to an exporter preferring delta
+// temporality.
+//
+// The temporality of each metric is requested from exporter by assuming a
+// monotonic Sum was produced by an InstrumentKindCounter, a non-monotonic Sum
+// by an InstrumentKindUpDownCounter, and any histogram by an
+// InstrumentKindHistogram.
+//
+// Converting data requires the previous state of each stream to be held in
+// memory. The state of a stream is dropped when it has not been exported for
+// the duration set with WithStaleStreamTimeout. Until then, a stream
+// converted to cumulative temporality is exported with its last value when
+// no data point of it is collected. The first data point of a stream
+// converted to delta temporality holds the full cumulative value since its
+// start time. Converted delta data does not contain a minimum or maximum
+// value. Gauge and Summary data are passed through unchanged.
+func NewTemporalityConverter(exporter Exporter, opts ...TemporalityConverterOption) Exporter {
+	cfg := newTemporalityConverterConfig(opts)
+	return &temporalityConverter{
+		exporter:     exporter,
+		selector:     cfg.temporalitySelector,
+		staleTimeout: cfg.staleTimeout,
+		streams:      make(map[streamID]*streamState),
+	}
+}
+
+// streamID uniquely identifies a stream of data points.
+type streamID struct {
+	scope instrumentation.Scope
+	name  string
+	attrs attribute.Distinct
+}
+
+// metricID uniquely identifies a metric.
+type metricID struct {
+	scope instrumentation.Scope
+	name  string
+}
+
+// streamState is the last cumulative data point of a stream.
+type streamState struct {
+	point any
+	seen  time.Time
+	// collection is the number of the last collection the stream was part
+	// of.
+	collection uint64
+	// metric is the metric, without data points, the point is exported with
+	// when the stream is not part of a collection. It is nil if the stream
+	// is not converted to cumulative temporality.
+	metric *metricdata.Metrics
+}
+
+// temporalityConverter is an Exporter that converts the temporality of data
+// before exporting it.
+type temporalityConverter struct {
+	exporter     Exporter
+	selector     TemporalitySelector
+	staleTimeout time.Duration
+
+	mu      sync.Mutex
+	streams map[streamID]*streamState
+	latest  time.Time
+	// collection is the number of the collection being converted.
+	collection uint64
+	// collected is the latest time of the data points of the collection
+	// being converted.
+	collected time.Time
+}
+
+var _ Exporter = (*temporalityConverter)(nil)
+
+// Temporality returns the Temporality the data passed to Export is expected
+// to have.
+func (c *temporalityConverter) Temporality(kind InstrumentKind) metricdata.Temporality {
+	return c.selector(kind)
+}
+
+// Aggregation returns the Aggregation of the wrapped exporter.
+func (c *temporalityConverter) Aggregation(kind InstrumentKind) Aggregation {
+	return c.exporter.Aggregation(kind)
+}
+
+// Export converts the temporality of rm and exports it with the wrapped
+// exporter.
+func (c *temporalityConverter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	out := &metricdata.ResourceMetrics{
+		Resource:     rm.Resource,
+		ScopeMetrics: make([]metricdata.ScopeMetrics, len(rm.ScopeMetrics)),
+	}
+
+	c.mu.Lock()
+	c.collection++
+	c.collected = time.Time{}
+	for i, sm := range rm.ScopeMetrics {
+		out.ScopeMetrics[i] = metricdata.ScopeMetrics{
+			Scope:   sm.Scope,
+			Metrics: make([]metricdata.Metrics, len(sm.Metrics)),
+		}
+		for j, m := range sm.Metrics {
+			out.ScopeMetrics[i].Metrics[j] = metricdata.Metrics{
+				Name:        m.Name,
+				Description: m.Description,
+				Unit:        m.Unit,
+				Data:        c.convert(sm.Scope, m),
+			}
+		}
+	}
+	t := c.collected
+	if t.IsZero() {
+		t = time.Now()
+		c.latest = latest(c.latest, t)
+	}
+	c.expire()
+	c.repeat(out, t)
+	c.mu.Unlock()
+
+	return c.exporter.Export(ctx, out)
+}
+
+// ForceFlush flushes the wrapped exporter.
+func (c *temporalityConverter) ForceFlush(ctx context.Context) error {
+	return c.exporter.ForceFlush(ctx)
+}
+
+// Shutdown drops all held state and shuts down the wrapped exporter.
+func (c *temporalityConverter) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	clear(c.streams)
+	c.mu.Unlock()
+	return c.exporter.Shutdown(ctx)
+}
+
+// convert returns the data of m converted to the temporality selected by the
+// wrapped exporter.
+func (c *temporalityConverter) convert(scope instrumentation.Scope, m metricdata.Metrics) metricdata.Aggregation {
+	switch a := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		return convertSum(c, scope, m, a)
+	case metricdata.Sum[float64]:
+		return convertSum(c, scope, m, a)
+	case metricdata.Histogram[int64]:
+		return convertHistogram(c, scope, m, a)
+	case metricdata.Histogram[float64]:
+		return convertHistogram(c, scope, m, a)
+	case metricdata.ExponentialHistogram[int64]:
+		return convertExponentialHistogram(c, scope, m, a)
+	case metricdata.ExponentialHistogram[float64]:
+		return convertExponentialHistogram(c, scope, m, a)
+	default:
+		return m.Data
+	}
+}
+
+// previous returns the held state for id and records the stream as seen at
+// t, in the current collection. The state is exported with metric when the
+// stream is not part of a collection, unless metric is nil.
+func (c *temporalityConverter) previous(id streamID, t time.Time, metric *metricdata.Metrics) *streamState {
+	c.latest = latest(c.latest, t)
+	c.collected = latest(c.collected, t)
+	s, ok := c.streams[id]
+	if !ok {
+		s = &streamState{}
+		c.streams[id] = s
+	}
+	s.seen = t
+	s.collection = c.collection
+	s.metric = metric
+	return s
+}
+
+// latest returns the later of a and b.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// expire drops the state of all streams not seen within the stale timeout of
+// the latest exported data point.
+func (c *temporalityConverter) expire() {
+	if c.staleTimeout <= 0 {
+		return
+	}
+	cutoff := c.latest.Add(-c.staleTimeout)
+	for id, s := range c.streams {
+		if s.seen.Before(cutoff) {
+			delete(c.streams, id)
+		}
+	}
+}
+
+// repeat adds to out the held data point of every stream converted to
+// cumulative temporality that is not part of the current collection, so
+// that its cumulative value is exported at t.
+func (c *temporalityConverter) repeat(out *metricdata.ResourceMetrics, t time.Time) {
+	scopes := make(map[instrumentation.Scope]int, len(out.ScopeMetrics))
+	metrics := make(map[metricID]int)
+	for i, sm := range out.ScopeMetrics {
+		scopes[sm.Scope] = i
+		for j, m := range sm.Metrics {
+			metrics[metricID{scope: sm.Scope, name: m.Name}] = j
+		}
+	}
+
+	for id, s := range c.streams {
+		if s.metric == nil || s.collection == c.collection {
+			continue
+		}
+		i, ok := scopes[id.scope]
+		if !ok {
+			i = len(out.ScopeMetrics)
+			out.ScopeMetrics = append(out.ScopeMetrics, metricdata.ScopeMetrics{Scope: id.scope})
+			scopes[id.scope] = i
+		}
+		sm := &out.ScopeMetrics[i]
+		mID := metricID{scope: id.scope, name: id.name}
+		j, ok := metrics[mID]
+		if !ok {
+			j = len(sm.Metrics)
+			sm.Metrics = append(sm.Metrics, *s.metric)
+			metrics[mID] = j
+		}
+		sm.Metrics[j].Data = appendPoint(sm.Metrics[j].Data, s.point, t)
+	}
+}
+
+// appendPoint returns agg with a copy of the data point p, with its time set
+// to t, added. The returned aggregation does not share its data points with
+// agg. If p is not a data point of agg, agg is returned unchanged.
+func appendPoint(agg metricdata.Aggregation, p any, t time.Time) metricdata.Aggregation {
+	switch p := p.(type) {
+	case metricdata.DataPoint[int64]:
+		return appendSumPoint(agg, p, t)
+	case metricdata.DataPoint[float64]:
+		return appendSumPoint(agg, p, t)
+	case metricdata.HistogramDataPoint[int64]:
+		return appendHistogramPoint(agg, p, t)
+	case metricdata.HistogramDataPoint[float64]:
+		return appendHistogramPoint(agg, p, t)
+	case metricdata.ExponentialHistogramDataPoint[int64]:
+		return appendExponentialHistogramPoint(agg, p, t)
+	case metricdata.ExponentialHistogramDataPoint[float64]:
+		return appendExponentialHistogramPoint(agg, p, t)
+	default:
+		return agg
+	}
+}
+
+func appendSumPoint[N int64 | float64](agg metricdata.Aggregation, p metricdata.DataPoint[N], t time.Time) metricdata.Aggregation {
+	s, ok := agg.(metricdata.Sum[N])
+	if !ok {
+		return agg
+	}
+	p.Time = t
+	s.DataPoints = append(slices.Clip(s.DataPoints), p)
+	return s
+}
+
+func appendHistogramPoint[N int64 | float64](agg metricdata.Aggregation, p metricdata.HistogramDataPoint[N], t time.Time) metricdata.Aggregation {
+	h, ok := agg.(metricdata.Histogram[N])
+	if !ok {
+		return agg
+	}
+	p.Time = t
+	p.Bounds = slices.Clone(p.Bounds)
+	p.BucketCounts = slices.Clone(p.BucketCounts)
+	h.DataPoints = append(slices.Clip(h.DataPoints), p)
+	return h
+}
+
+func appendExponentialHistogramPoint[N int64 | float64](agg metricdata.Aggregation, p metricdata.ExponentialHistogramDataPoint[N], t time.Time) metricdata.Aggregation {
+	h, ok := agg.(metricdata.ExponentialHistogram[N])
+	if !ok {
+		return agg
+	}
+	p.Time = t
+	p.PositiveBucket.Counts = slices.Clone(p.PositiveBucket.Counts)
+	p.NegativeBucket.Counts = slices.Clone(p.NegativeBucket.Counts)
+	h.DataPoints = append(slices.Clip(h.DataPoints), p)
+	return h
+}
+
+// heldMetric returns m with the aggregation agg, which holds no data points.
+// The held state of the streams of m is exported with it when they are not
+// collected. It returns nil if target is not cumulative temporality.
+func heldMetric(m metricdata.Metrics, target metricdata.Temporality, agg metricdata.Aggregation) *metricdata.Metrics {
+	if target != metricdata.CumulativeTemporality {
+		return nil
+	}
+	return &metricdata.Metrics{
+		Name:        m.Name,
+		Description: m.Description,
+		Unit:        m.Unit,
+		Data:        agg,
+	}
+}
+
+func convertSum[N int64 | float64](c *temporalityConverter, scope instrumentation.Scope, m metricdata.Metrics, s metricdata.Sum[N]) metricdata.Sum[N] {
+	kind := InstrumentKindUpDownCounter
+	if s.IsMonotonic {
+		kind = InstrumentKindCounter
+	}
+	target := c.exporter.Temporality(kind)
+	if s.Temporality == target {
+		return s
+	}
+
+	out := metricdata.Sum[N]{
+		Temporality: target,
+		IsMonotonic: s.IsMonotonic,
+		DataPoints:  make([]metricdata.DataPoint[N], len(s.DataPoints)),
+	}
+	held := heldMetric(m, target, metricdata.Sum[N]{Temporality: target, IsMonotonic: s.IsMonotonic})
+	for i, dp := range s.DataPoints {
+		id := streamID{scope: scope, name: m.Name, attrs: dp.Attributes.Equivalent()}
+		state := c.previous(id, dp.Time, held)
+		prev, ok := state.point.(metricdata.DataPoint[N])
+
+		cur := dp
+		cur.Exemplars = nil
+		switch target {
+		case metricdata.CumulativeTemporality:
+			if ok {
+				cur.StartTime = prev.StartTime
+				cur.Value += prev.Value
+			}
+			out.DataPoints[i] = cur
+		case metricdata.DeltaTemporality:
+			out.DataPoints[i] = cur
+			reset := !ok || !prev.StartTime.Equal(dp.StartTime) || (s.IsMonotonic && dp.Value < prev.Value)
+			if !reset {
+				out.DataPoints[i].StartTime = prev.Time
+				out.DataPoints[i].Value -= prev.Value
+			}
+		}
+		out.DataPoints[i].Exemplars = dp.Exemplars
+		state.point = cur
+	}
+	return out
+}
+
+func convertHistogram[N int64 | float64](c *temporalityConverter, scope instrumentation.Scope, m metricdata.Metrics, h metricdata.Histogram[N]) metricdata.Histogram[N] {
+	target := c.exporter.Temporality(InstrumentKindHistogram)
+	if h.Temporality == target {
+		return h
+	}
+
+	out := metricdata.Histogram[N]{
+		Temporality: target,
+		DataPoints:  make([]metricdata.HistogramDataPoint[N], len(h.DataPoints)),
+	}
+	held := heldMetric(m, target, metricdata.Histogram[N]{Temporality: target})
+	for i, dp := range h.DataPoints {
+		id := streamID{scope: scope, name: m.Name, attrs: dp.Attributes.Equivalent()}
+		state := c.previous(id, dp.Time, held)
+		prev, ok := state.point.(metricdata.HistogramDataPoint[N])
+		ok = ok && slices.Equal(prev.Bounds, dp.Bounds)
+
+		// Copy all slices, the passed data may be reused after the export.
+		cur := dp
+		cur.Bounds = slices.Clone(dp.Bounds)
+		cur.BucketCounts = slices.Clone(dp.BucketCounts)
+		cur.Exemplars = nil
+		switch target {
+		case metricdata.CumulativeTemporality:
+			if ok {
+				cur.StartTime = prev.StartTime
+				cur.Count += prev.Count
+				cur.Sum += prev.Sum
+				cur.Min = minExtrema(prev.Min, cur.Min)
+				cur.Max = maxExtrema(prev.Max, cur.Max)
+				for j, n := range prev.BucketCounts {
+					cur.BucketCounts[j] += n
+				}
+			}
+			out.DataPoints[i] = cur
+			out.DataPoints[i].BucketCounts = slices.Clone(cur.BucketCounts)
+		case metricdata.DeltaTemporality:
+			out.DataPoints[i] = cur
+			out.DataPoints[i].BucketCounts = slices.Clone(cur.BucketCounts)
+			if ok && !histogramReset(prev, cur) {
+				d := &out.DataPoints[i]
+				d.StartTime = prev.Time
+				d.Count -= prev.Count
+				d.Sum -= prev.Sum
+				d.Min, d.Max = metricdata.Extrema[N]{}, metricdata.Extrema[N]{}
+				for j, n := range prev.BucketCounts {
+					d.BucketCounts[j] -= n
+				}
+			}
+		}
+		out.DataPoints[i].Exemplars = dp.Exemplars
+		state.point = cur
+	}
+	return out
+}
+
+// histogramReset returns if cur is not a continuation of the cumulative
+// histogram prev.
+func histogramReset[N int64 | float64](prev, cur metricdata.HistogramDataPoint[N]) bool {
+	if !prev.StartTime.Equal(cur.StartTime) || cur.Count < prev.Count {
+		return true
+	}
+	for i, n := range prev.BucketCounts {
+		if cur.BucketCounts[i] < n {
+			return true
+		}
+	}
+	return false
+}
+
+func convertExponentialHistogram[N int64 | float64](c *temporalityConverter, scope instrumentation.Scope, m metricdata.Metrics, h metricdata.ExponentialHistogram[N]) metricdata.ExponentialHistogram[N] {
+	target := c.exporter.Temporality(InstrumentKindHistogram)
+	if h.Temporality == target {
+		return h
+	}
+
+	out := metricdata.ExponentialHistogram[N]{
+		Temporality: target,
+		DataPoints:  make([]metricdata.ExponentialHistogramDataPoint[N], len(h.DataPoints)),
+	}
+	held := heldMetric(m, target, metricdata.ExponentialHistogram[N]{Temporality: target})
+	for i, dp := range h.DataPoints {
+		id := streamID{scope: scope, name: m.Name, attrs: dp.Attributes.Equivalent()}
+		state := c.previous(id, dp.Time, held)
+		prev, ok := state.point.(metricdata.ExponentialHistogramDataPoint[N])
+
+		// Copy all slices, the passed data may be reused after the export.
+		cur := dp
+		cur.PositiveBucket.Counts = slices.Clone(dp.PositiveBucket.Counts)
+		cur.NegativeBucket.Counts = slices.Clone(dp.NegativeBucket.Counts)
+		cur.Exemplars = nil
+		switch target {
+		case metricdata.CumulativeTemporality:
+			if ok {
+				scale := min(prev.Scale, cur.Scale)
+				cur.StartTime = prev.StartTime
+				cur.Scale = scale
+				cur.Count += prev.Count
+				cur.Sum += prev.Sum
+				cur.ZeroCount += prev.ZeroCount
+				cur.ZeroThreshold = max(prev.ZeroThreshold, cur.ZeroThreshold)
+				cur.Min = minExtrema(prev.Min, cur.Min)
+				cur.Max = maxExtrema(prev.Max, cur.Max)
+				cur.PositiveBucket = addExpoBuckets(
+					downscaleExpoBucket(prev.PositiveBucket, prev.Scale-scale),
+					downscaleExpoBucket(cur.PositiveBucket, dp.Scale-scale),
+				)
+				cur.NegativeBucket = addExpoBuckets(
+					downscaleExpoBucket(prev.NegativeBucket, prev.Scale-scale),
+					downscaleExpoBucket(cur.NegativeBucket, dp.Scale-scale),
+				)
+			}
+			out.DataPoints[i] = cur
+			out.DataPoints[i].PositiveBucket.Counts = slices.Clone(cur.PositiveBucket.Counts)
+			out.DataPoints[i].NegativeBucket.Counts = slices.Clone(cur.NegativeBucket.Counts)
+		case metricdata.DeltaTemporality:
+			out.DataPoints[i] = cur
+			out.DataPoints[i].PositiveBucket.Counts = slices.Clone(cur.PositiveBucket.Counts)
+			out.DataPoints[i].NegativeBucket.Counts = slices.Clone(cur.NegativeBucket.Counts)
+			if ok && !expoHistogramReset(prev, cur) {
+				pos, posOK := subExpoBuckets(cur.PositiveBucket, downscaleExpoBucket(prev.PositiveBucket, prev.Scale-cur.Scale))
+				neg, negOK := subExpoBuckets(cur.NegativeBucket, downscaleExpoBucket(prev.NegativeBucket, prev.Scale-cur.Scale))
+				if posOK && negOK {
+					d := &out.DataPoints[i]
+					d.StartTime = prev.Time
+					d.Count -= prev.Count
+					d.Sum -= prev.Sum
+					d.ZeroCount -= prev.ZeroCount
+					d.Min, d.Max = metricdata.Extrema[N]{}, metricdata.Extrema[N]{}
+					d.PositiveBucket, d.NegativeBucket = pos, neg
+				}
+			}
+		}
+		out.DataPoints[i].Exemplars = dp.Exemplars
+		state.point = cur
+	}
+	return out
+}
+
+// expoHistogramReset returns if cur is not a continuation of the cumulative
+// exponential histogram prev. The bucket counts are checked when they are
+// subtracted.
+func expoHistogramReset[N int64 | float64](prev, cur metricdata.ExponentialHistogramDataPoint[N]) bool {
+	return !prev.StartTime.Equal(cur.StartTime) ||
+		prev.Scale < cur.Scale ||
+		cur.Count < prev.Count ||
+		cur.ZeroCount < prev.ZeroCount
+}
+
+// downscaleExpoBucket returns a copy of b with its scale reduced by delta.
+func downscaleExpoBucket(b metricdata.ExponentialBucket, delta int32) metricdata.ExponentialBucket {
+	if delta <= 0 || len(b.Counts) == 0 {
+		return metricdata.ExponentialBucket{Offset: b.Offset, Counts: slices.Clone(b.Counts)}
+	}
+	offset := b.Offset >> delta
+	last := (b.Offset + int32(len(b.Counts)) - 1) >> delta // nolint: gosec  // Bucket counts are bounded by int32 indexes.
+	counts := make([]uint64, last-offset+1)
+	for i, n := range b.Counts {
+		idx := (b.Offset + int32(i)) >> delta // nolint: gosec  // Bucket counts are bounded by int32 indexes.
+		counts[idx-offset] += n
+	}
+	return metricdata.ExponentialBucket{Offset: offset, Counts: counts}
+}
+
+// addExpoBuckets returns the sum of a and b. Both need to have the same
+// scale.
+func addExpoBuckets(a, b metricdata.ExponentialBucket) metricdata.ExponentialBucket {
+	if len(a.Counts) == 0 {
+		return b
+	}
+	if len(b.Counts) == 0 {
+		return a
+	}
+	offset := min(a.Offset, b.Offset)
+	end := max(a.Offset+int32(len(a.Counts)), b.Offset+int32(len(b.Counts))) // nolint: gosec  // Bucket counts are bounded by int32 indexes.
+	counts := make([]uint64, end-offset)
+	for i, n := range a.Counts {
+		counts[a.Offset-offset+int32(i)] += n // nolint: gosec  // Bucket counts are bounded by int32 indexes.
+	}
+	for i, n := range b.Counts {
+		counts[b.Offset-offset+int32(i)] += n // nolint: gosec  // Bucket counts are bounded by int32 indexes.
+	}
+	return metricdata.ExponentialBucket{Offset: offset, Counts: counts}
+}
+
+// subExpoBuckets returns a minus b. Both need to have the same scale. If b
+// holds a count greater than a for any bucket, false is returned.
+func subExpoBuckets(a, b metricdata.ExponentialBucket) (metricdata.ExponentialBucket, bool) {
+	out := metricdata.ExponentialBucket{Offset: a.Offset, Counts: slices.Clone(a.Counts)}
+	for i, n := range b.Counts {
+		if n == 0 {
+			continue
+		}
+		idx := int(b.Offset-a.Offset) + i
+		if idx < 0 || idx >= len(out.Counts) || out.Counts[idx] < n {
+			return metricdata.ExponentialBucket{}, false
+		}
+		out.Counts[idx] -= n
+	}
+	return out, true
+}
+
+// minExtrema returns the lesser of the defined extrema a and b.
+func minExtrema[N int64 | float64](a, b metricdata.Extrema[N]) metricdata.Extrema[N] {
+	aV, aOK := a.Value()
+	bV, bOK := b.Value()
+	if !aOK || (bOK && bV < aV) {
+		return b
+	}
+	return a
+}
+
+// maxExtrema returns the greater of the defined extrema a and b.
+func maxExtrema[N int64 | float64](a, b metricdata.Extrema[N]) metricdata.Extrema[N] {
+	aV, aOK := a.Value()
+	bV, bOK := b.Value()
+	if !aOK || (bOK && bV > aV) {
+		return b
+	}
+	return a
+}
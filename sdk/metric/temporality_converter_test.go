@@ -0,0 +1,357 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+var (
+	convScope = instrumentation.Scope{Name: "converter"}
+	convAttrs = attribute.NewSet(attribute.String("user", "alice"))
+	convStart = time.Unix(946684800, 0)
+)
+
+// convTime returns the timestamp n seconds after convStart.
+func convTime(n int) time.Time {
+	return convStart.Add(time.Duration(n) * time.Second)
+}
+
+// convertOnce exports agg with the converter c and returns the aggregation
+// the wrapped exporter received.
+func convertOnce(t *testing.T, c Exporter, got *metricdata.Aggregation, agg metricdata.Aggregation) metricdata.Aggregation {
+	t.Helper()
+	rm := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope:   convScope,
+			Metrics: []metricdata.Metrics{{Name: "m", Data: agg}},
+		}},
+	}
+	require.NoError(t, c.Export(context.Background(), rm))
+	return *got
+}
+
+func newTestConverter(target metricdata.Temporality, opts ...TemporalityConverterOption) (Exporter, *metricdata.Aggregation) {
+	got := new(metricdata.Aggregation)
+	exp := &fnExporter{
+		temporalityFunc: func(InstrumentKind) metricdata.Temporality { return target },
+		exportFunc: func(_ context.Context, rm *metricdata.ResourceMetrics) error {
+			*got = rm.ScopeMetrics[0].Metrics[0].Data
+			return nil
+		},
+	}
+	return NewTemporalityConverter(exp, opts...), got
+}
+
+func sumPt(start, end int, v int64) metricdata.DataPoint[int64] {
+	return metricdata.DataPoint[int64]{
+		Attributes: convAttrs,
+		StartTime:  convTime(start),
+		Time:       convTime(end),
+		Value:      v,
+	}
+}
+
+func TestTemporalityConverterTemporality(t *testing.T) {
+	c := NewTemporalityConverter(&fnExporter{})
+	assert.Equal(t, metricdata.CumulativeTemporality, c.Temporality(InstrumentKindCounter))
+
+	c = NewTemporalityConverter(&fnExporter{}, WithInputTemporalitySelector(
+		func(InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality },
+	))
+	assert.Equal(t, metricdata.DeltaTemporality, c.Temporality(InstrumentKindCounter))
+}
+
+func TestTemporalityConverterSumDeltaToCumulative(t *testing.T) {
+	c, got := newTestConverter(metricdata.CumulativeTemporality)
+
+	delta := func(pts ...metricdata.DataPoint[int64]) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{Temporality: metricdata.DeltaTemporality, IsMonotonic: true, DataPoints: pts}
+	}
+	cumulative := func(pts ...metricdata.DataPoint[int64]) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{Temporality: metricdata.CumulativeTemporality, IsMonotonic: true, DataPoints: pts}
+	}
+
+	metricdatatest.AssertAggregationsEqual(t, cumulative(sumPt(0, 1, 2)), convertOnce(t, c, got, delta(sumPt(0, 1, 2))))
+	metricdatatest.AssertAggregationsEqual(t, cumulative(sumPt(0, 2, 5)), convertOnce(t, c, got, delta(sumPt(1, 2, 3))))
+	metricdatatest.AssertAggregationsEqual(t, cumulative(sumPt(0, 3, 5)), convertOnce(t, c, got, delta(sumPt(2, 3, 0))))
+}
+
+func TestTemporalityConverterSumCumulativeToDelta(t *testing.T) {
+	c, got := newTestConverter(metricdata.DeltaTemporality)
+
+	delta := func(pts ...metricdata.DataPoint[int64]) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{Temporality: metricdata.DeltaTemporality, IsMonotonic: true, DataPoints: pts}
+	}
+	cumulative := func(pts ...metricdata.DataPoint[int64]) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{Temporality: metricdata.CumulativeTemporality, IsMonotonic: true, DataPoints: pts}
+	}
+
+	metricdatatest.AssertAggregationsEqual(t, delta(sumPt(0, 1, 2)), convertOnce(t, c, got, cumulative(sumPt(0, 1, 2))))
+	metricdatatest.AssertAggregationsEqual(t, delta(sumPt(1, 2, 3)), convertOnce(t, c, got, cumulative(sumPt(0, 2, 5))))
+	// A decreasing monotonic sum is a reset.
+	metricdatatest.AssertAggregationsEqual(t, delta(sumPt(2, 3, 1)), convertOnce(t, c, got, cumulative(sumPt(2, 3, 1))))
+	metricdatatest.AssertAggregationsEqual(t, delta(sumPt(3, 4, 4)), convertOnce(t, c, got, cumulative(sumPt(2, 4, 5))))
+}
+
+func TestTemporalityConverterPassThrough(t *testing.T) {
+	c, got := newTestConverter(metricdata.CumulativeTemporality)
+
+	sum := metricdata.Sum[int64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints:  []metricdata.DataPoint[int64]{sumPt(0, 1, 2)},
+	}
+	metricdatatest.AssertAggregationsEqual(t, sum, convertOnce(t, c, got, sum))
+
+	gauge := metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{sumPt(0, 1, 2)}}
+	metricdatatest.AssertAggregationsEqual(t, gauge, convertOnce(t, c, got, gauge))
+}
+
+func TestTemporalityConverterHistogram(t *testing.T) {
+	hPt := func(start, end int, count uint64, sum float64, counts []uint64, minV, maxV float64) metricdata.HistogramDataPoint[float64] {
+		return metricdata.HistogramDataPoint[float64]{
+			Attributes:   convAttrs,
+			StartTime:    convTime(start),
+			Time:         convTime(end),
+			Count:        count,
+			Sum:          sum,
+			Bounds:       []float64{1, 5},
+			BucketCounts: counts,
+			Min:          metricdata.NewExtrema(minV),
+			Max:          metricdata.NewExtrema(maxV),
+		}
+	}
+	hist := func(temp metricdata.Temporality, pts ...metricdata.HistogramDataPoint[float64]) metricdata.Histogram[float64] {
+		return metricdata.Histogram[float64]{Temporality: temp, DataPoints: pts}
+	}
+
+	t.Run("DeltaToCumulative", func(t *testing.T) {
+		c, got := newTestConverter(metricdata.CumulativeTemporality)
+
+		in := hPt(0, 1, 2, 4, []uint64{1, 1, 0}, 1, 3)
+		metricdatatest.AssertAggregationsEqual(t,
+			hist(metricdata.CumulativeTemporality, in),
+			convertOnce(t, c, got, hist(metricdata.DeltaTemporality, in)),
+		)
+
+		// Modify the input to ensure the converter holds a copy.
+		in.BucketCounts[0] = 100
+
+		metricdatatest.AssertAggregationsEqual(t,
+			hist(metricdata.CumulativeTemporality, hPt(0, 2, 4, 14, []uint64{1, 2, 1}, 1, 7)),
+			convertOnce(t, c, got, hist(metricdata.DeltaTemporality, hPt(1, 2, 2, 10, []uint64{0, 1, 1}, 3, 7))),
+		)
+	})
+
+	t.Run("CumulativeToDelta", func(t *testing.T) {
+		c, got := newTestConverter(metricdata.DeltaTemporality)
+
+		in := hPt(0, 1, 2, 4, []uint64{1, 1, 0}, 1, 3)
+		metricdatatest.AssertAggregationsEqual(t,
+			hist(metricdata.DeltaTemporality, in),
+			convertOnce(t, c, got, hist(metricdata.CumulativeTemporality, in)),
+		)
+
+		want := hPt(1, 2, 2, 10, []uint64{0, 1, 1}, 0, 0)
+		want.Min, want.Max = metricdata.Extrema[float64]{}, metricdata.Extrema[float64]{}
+		metricdatatest.AssertAggregationsEqual(t,
+			hist(metricdata.DeltaTemporality, want),
+			convertOnce(t, c, got, hist(metricdata.CumulativeTemporality, hPt(0, 2, 4, 14, []uint64{1, 2, 1}, 1, 7))),
+		)
+	})
+}
+
+func TestTemporalityConverterExponentialHistogram(t *testing.T) {
+	ePt := func(start, end int, scale int32, count uint64, pos metricdata.ExponentialBucket) metricdata.ExponentialHistogramDataPoint[int64] {
+		return metricdata.ExponentialHistogramDataPoint[int64]{
+			Attributes:     convAttrs,
+			StartTime:      convTime(start),
+			Time:           convTime(end),
+			Count:          count,
+			Scale:          scale,
+			PositiveBucket: pos,
+		}
+	}
+	hist := func(temp metricdata.Temporality, pts ...metricdata.ExponentialHistogramDataPoint[int64]) metricdata.ExponentialHistogram[int64] {
+		return metricdata.ExponentialHistogram[int64]{Temporality: temp, DataPoints: pts}
+	}
+
+	t.Run("DeltaToCumulative", func(t *testing.T) {
+		c, got := newTestConverter(metricdata.CumulativeTemporality, WithInputTemporalitySelector(
+			func(InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality },
+		))
+
+		convertOnce(t, c, got, hist(metricdata.DeltaTemporality,
+			ePt(0, 1, 1, 3, metricdata.ExponentialBucket{Offset: 2, Counts: []uint64{1, 2}}),
+		))
+		// The second delta has a lower scale, the first needs to be
+		// downscaled: indexes 2, 3 at scale 1 are index 1 at scale 0.
+		metricdatatest.AssertAggregationsEqual(t,
+			hist(metricdata.CumulativeTemporality,
+				ePt(0, 2, 0, 5, metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{4, 0, 1}}),
+			),
+			convertOnce(t, c, got, hist(metricdata.DeltaTemporality,
+				ePt(1, 2, 0, 2, metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{1, 0, 1}}),
+			)),
+		)
+	})
+
+	t.Run("CumulativeToDelta", func(t *testing.T) {
+		c, got := newTestConverter(metricdata.DeltaTemporality)
+
+		convertOnce(t, c, got, hist(metricdata.CumulativeTemporality,
+			ePt(0, 1, 1, 3, metricdata.ExponentialBucket{Offset: 2, Counts: []uint64{1, 2}}),
+		))
+		metricdatatest.AssertAggregationsEqual(t,
+			hist(metricdata.DeltaTemporality,
+				ePt(1, 2, 0, 2, metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{1, 0, 1}}),
+			),
+			convertOnce(t, c, got, hist(metricdata.CumulativeTemporality,
+				ePt(0, 2, 0, 5, metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{4, 0, 1}}),
+			)),
+		)
+	})
+}
+
+func TestTemporalityConverterStaleStreams(t *testing.T) {
+	c, got := newTestConverter(metricdata.CumulativeTemporality, WithStaleStreamTimeout(time.Minute))
+
+	delta := func(attrs attribute.Set, start, end int, v int64) metricdata.Sum[int64] {
+		pt := sumPt(start, end, v)
+		pt.Attributes = attrs
+		return metricdata.Sum[int64]{Temporality: metricdata.DeltaTemporality, IsMonotonic: true, DataPoints: []metricdata.DataPoint[int64]{pt}}
+	}
+
+	bob := attribute.NewSet(attribute.String("user", "bob"))
+	convertOnce(t, c, got, delta(convAttrs, 0, 1, 1))
+	convertOnce(t, c, got, delta(bob, 0, 30, 1))
+
+	tc := c.(*temporalityConverter)
+	assert.Len(t, tc.streams, 2)
+
+	// Alice was last seen more than a minute before bob.
+	convertOnce(t, c, got, delta(bob, 30, 90, 1))
+	assert.Len(t, tc.streams, 1)
+
+	// Alice restarts accumulating, bob is not stale yet.
+	bobPt := sumPt(0, 90, 2)
+	bobPt.Attributes = bob
+	got2 := convertOnce(t, c, got, delta(convAttrs, 89, 90, 3))
+	metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints:  []metricdata.DataPoint[int64]{sumPt(89, 90, 3), bobPt},
+	}, got2)
+
+	require.NoError(t, c.Shutdown(context.Background()))
+	assert.Empty(t, tc.streams)
+}
+
+func TestTemporalityConverterHeldStreams(t *testing.T) {
+	var got *metricdata.ResourceMetrics
+	exp := &fnExporter{
+		temporalityFunc: func(InstrumentKind) metricdata.Temporality { return metricdata.CumulativeTemporality },
+		exportFunc: func(_ context.Context, rm *metricdata.ResourceMetrics) error {
+			got = rm
+			return nil
+		},
+	}
+	// The data point timestamps are far in the past, never drop the streams
+	// when no data point is collected.
+	c := NewTemporalityConverter(exp, WithStaleStreamTimeout(0), WithInputTemporalitySelector(
+		func(InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality },
+	))
+
+	bob := attribute.NewSet(attribute.String("user", "bob"))
+	bobPt := func(start, end int, v int64) metricdata.DataPoint[int64] {
+		pt := sumPt(start, end, v)
+		pt.Attributes = bob
+		return pt
+	}
+	sum := func(temp metricdata.Temporality, pts ...metricdata.DataPoint[int64]) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name:        "m",
+			Description: "desc",
+			Unit:        "1",
+			Data:        metricdata.Sum[int64]{Temporality: temp, IsMonotonic: true, DataPoints: pts},
+		}
+	}
+	export := func(metrics ...metricdata.Metrics) {
+		t.Helper()
+		rm := &metricdata.ResourceMetrics{}
+		if len(metrics) > 0 {
+			rm.ScopeMetrics = []metricdata.ScopeMetrics{{Scope: convScope, Metrics: metrics}}
+		}
+		require.NoError(t, c.Export(context.Background(), rm))
+	}
+	want := func(metrics ...metricdata.Metrics) metricdata.ResourceMetrics {
+		return metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{Scope: convScope, Metrics: metrics}},
+		}
+	}
+
+	export(sum(metricdata.DeltaTemporality, sumPt(0, 1, 2), bobPt(0, 1, 1)))
+	metricdatatest.AssertEqual(t, want(
+		sum(metricdata.CumulativeTemporality, sumPt(0, 1, 2), bobPt(0, 1, 1)),
+	), *got)
+
+	// Bob has no measurement, the cumulative value is repeated.
+	export(sum(metricdata.DeltaTemporality, sumPt(1, 2, 3)))
+	metricdatatest.AssertEqual(t, want(
+		sum(metricdata.CumulativeTemporality, sumPt(0, 2, 5), bobPt(0, 2, 1)),
+	), *got)
+
+	// Nothing is collected, the metric is exported with the held values.
+	export()
+	metricdatatest.AssertEqual(t, want(
+		sum(metricdata.CumulativeTemporality, sumPt(0, 2, 5), bobPt(0, 2, 1)),
+	), *got, metricdatatest.IgnoreTimestamp())
+}
+
+func TestTemporalityConverterWithReader(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := &fnExporter{
+		temporalityFunc: func(InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality },
+		exportFunc: func(_ context.Context, rm *metricdata.ResourceMetrics) error {
+			got = *rm
+			return nil
+		},
+	}
+	r := NewPeriodicReader(NewTemporalityConverter(exp), WithInterval(time.Hour))
+	mp := NewMeterProvider(WithReader(r))
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+
+	ctr, err := mp.Meter("test").Int64Counter("ctr")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	values := func() []int64 {
+		require.NoError(t, r.ForceFlush(ctx))
+		require.Len(t, got.ScopeMetrics, 1)
+		sum := got.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+		assert.Equal(t, metricdata.DeltaTemporality, sum.Temporality)
+		var out []int64
+		for _, dp := range sum.DataPoints {
+			out = append(out, dp.Value)
+		}
+		return out
+	}
+
+	ctr.Add(ctx, 3)
+	assert.Equal(t, []int64{3}, values())
+	ctr.Add(ctx, 4)
+	assert.Equal(t, []int64{4}, values())
+	assert.Equal(t, []int64{0}, values())
+}